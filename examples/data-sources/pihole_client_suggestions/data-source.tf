@@ -0,0 +1,7 @@
+# Devices seen on the network that are not configured as clients yet
+data "pihole_client_suggestions" "new" {}
+
+# Output the identifiers to configure them with
+output "unconfigured_clients" {
+  value = [for s in data.pihole_client_suggestions.new.suggestions : s.client]
+}
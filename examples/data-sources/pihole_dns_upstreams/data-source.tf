@@ -0,0 +1,9 @@
+# Retrieve the configured upstream DNS servers and check their health
+data "pihole_dns_upstreams" "current" {
+  probe = true
+}
+
+# Upstreams that have not answered any forwarded query
+output "unhealthy_upstreams" {
+  value = [for u in data.pihole_dns_upstreams.current.upstreams : u.upstream if u.healthy == false]
+}
@@ -0,0 +1,21 @@
+# Check that a domain is blocked for a client
+data "pihole_effective_policy" "kids_tiktok" {
+  domain = "www.tiktok.com"
+  client = "192.168.1.60"
+}
+
+check "kids_policy" {
+  assert {
+    condition     = data.pihole_effective_policy.kids_tiktok.blocked
+    error_message = "www.tiktok.com is not blocked for the kids' tablet (${data.pihole_effective_policy.kids_tiktok.reason})."
+  }
+}
+
+# Show why a domain is blocked for clients without their own entry
+data "pihole_effective_policy" "ads" {
+  domain = "ads.example.com"
+}
+
+output "ads_blocked_by" {
+  value = "${data.pihole_effective_policy.ads.reason}: ${data.pihole_effective_policy.ads.rule}"
+}
@@ -0,0 +1,17 @@
+data "pihole_ftl" "this" {}
+
+output "gravity_domains" {
+  value = data.pihole_ftl.this.gravity
+}
+
+# Fail the run when gravity is empty after the lists changed
+check "gravity_loaded" {
+  data "pihole_ftl" "after_apply" {
+    depends_on = [pihole_list.blocklist]
+  }
+
+  assert {
+    condition     = data.pihole_ftl.after_apply.gravity > 0
+    error_message = "Gravity is empty; check that the blocklists can be downloaded."
+  }
+}
@@ -0,0 +1,14 @@
+# Resolve group names to IDs
+data "pihole_group_ids" "client" {
+  names = ["Default", "kids", "iot"]
+}
+
+output "kids_group_id" {
+  value = data.pihole_group_ids.client.ids["kids"]
+}
+
+# Ignore groups that do not exist yet
+data "pihole_group_ids" "optional" {
+  names           = ["guests"]
+  fail_on_missing = false
+}
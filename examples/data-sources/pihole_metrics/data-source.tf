@@ -0,0 +1,24 @@
+data "pihole_metrics" "this" {}
+
+output "pihole_metrics" {
+  value = {
+    queries_total   = data.pihole_metrics.this.queries_total
+    queries_blocked = data.pihole_metrics.this.queries_blocked
+    percent_blocked = data.pihole_metrics.this.percent_blocked
+    cache_hit_ratio = data.pihole_metrics.this.cache_hit_ratio
+    gravity_domains = data.pihole_metrics.this.gravity_domains
+    uptime          = data.pihole_metrics.this.uptime
+  }
+}
+
+# Fail the canary rollout when Pi-hole restarted or lost gravity
+check "canary_healthy" {
+  data "pihole_metrics" "after_apply" {
+    depends_on = [pihole_config_dns.canary]
+  }
+
+  assert {
+    condition     = data.pihole_metrics.after_apply.gravity_domains > 0 && data.pihole_metrics.after_apply.uptime > 60
+    error_message = "Pi-hole has no gravity or restarted less than a minute ago."
+  }
+}
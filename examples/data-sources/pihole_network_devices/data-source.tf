@@ -0,0 +1,13 @@
+# Retrieve the devices Pi-hole has seen on the network
+data "pihole_network_devices" "all" {}
+
+# Label devices by MAC address using the vendor FTL detected
+resource "pihole_client" "labeled" {
+  for_each = {
+    for d in data.pihole_network_devices.all.devices : d.mac => d
+    if d.mac_vendor != null
+  }
+
+  client  = each.key
+  comment = "${each.value.mac_vendor} on ${each.value.interface}"
+}
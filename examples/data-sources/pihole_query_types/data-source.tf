@@ -0,0 +1,6 @@
+data "pihole_query_types" "current" {}
+
+# Share of HTTPS (type 65) queries, used by browsers to discover DoH endpoints
+output "https_query_share" {
+  value = lookup(data.pihole_query_types.current.types, "HTTPS", 0)
+}
@@ -0,0 +1,8 @@
+data "pihole_sessions" "all" {}
+
+# Addresses holding sessions, other than the provider's own
+output "session_addresses" {
+  value = distinct([
+    for s in data.pihole_sessions.all.sessions : s.remote_addr if !s.current
+  ])
+}
@@ -0,0 +1,16 @@
+# A new application password for the provider itself, activated by
+# pihole_password and stored in Vault without entering the state
+ephemeral "pihole_app_password" "terraform" {}
+
+resource "pihole_password" "terraform" {
+  type              = "app"
+  app_password_hash = ephemeral.pihole_app_password.terraform.hash
+  password_version  = 1
+}
+
+resource "vault_kv_secret_v2" "pihole" {
+  mount                = "secret"
+  name                 = "pihole"
+  data_json_wo         = jsonencode({ app_password = ephemeral.pihole_app_password.terraform.password })
+  data_json_wo_version = 1
+}
@@ -0,0 +1,13 @@
+variable "domains" {
+  type    = list(string)
+  default = ["ads.example.com", "not a domain", "tracker.example.net"]
+}
+
+# Only create entries for domains Pi-hole will accept
+resource "pihole_domain" "blocked" {
+  for_each = toset([for d in var.domains : d if provider::pihole::is_valid_domain(d)])
+
+  domain = each.value
+  type   = "deny"
+  kind   = "exact"
+}
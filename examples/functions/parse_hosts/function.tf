@@ -0,0 +1,11 @@
+# Migrate an existing hosts file into Pi-hole local DNS records
+locals {
+  hosts = provider::pihole::parse_hosts(file("${path.module}/hosts"))
+}
+
+resource "pihole_local_dns" "migrated" {
+  for_each = { for h in local.hosts : h.hostnames[0] => h.ip }
+
+  hostname = each.key
+  ip       = each.value
+}
@@ -0,0 +1,6 @@
+# Block a domain and all its subdomains with a regex rule
+resource "pihole_domain" "tracker" {
+  domain = "(\\.|^)${provider::pihole::regex_escape("tracker.example.com")}$"
+  type   = "deny"
+  kind   = "regex"
+}
@@ -0,0 +1,6 @@
+# Block a whole top-level domain with a regex rule
+resource "pihole_domain" "xyz" {
+  domain = provider::pihole::regex_template("tld", "xyz")
+  type   = "deny"
+  kind   = "regex"
+}
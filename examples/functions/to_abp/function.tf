@@ -0,0 +1,14 @@
+# Render an ABP-style blocklist that a pihole_list subscription can serve
+locals {
+  ad_domains = ["ads.example.com", "*.tracker.example.net"]
+}
+
+resource "local_file" "blocklist" {
+  filename = "/var/www/html/blocklist.txt"
+  content  = join("\n", [for d in local.ad_domains : provider::pihole::to_abp(d)])
+}
+
+resource "pihole_list" "local" {
+  address = "http://lists.lan/blocklist.txt"
+  type    = "block"
+}
@@ -21,4 +21,32 @@ provider "pihole" {
 
   # Optional: HTTP timeout in seconds (default: 30)
   # timeout = 60
+
+  # Optional: Seconds to wait for FTL to come back after a restart (default: 60)
+  # wait_for_restart_seconds = 120
+
+  # Optional: Log API request latencies and a per-endpoint summary (TF_LOG=INFO)
+  # enable_api_metrics = true
+
+  # Optional: Extra headers for a reverse proxy in front of Pi-hole
+  # extra_headers = {
+  #   "CF-Access-Client-Id"     = var.cf_access_client_id
+  #   "CF-Access-Client-Secret" = var.cf_access_client_secret
+  # }
+
+  # Optional: Mark comments of created domains, lists and clients with [tf:prod]
+  # Can also be set via PIHOLE_MANAGED_BY_TAG environment variable
+  # managed_by_tag = "prod"
+
+  # Optional: Start comments of created entries with [terraform]
+  # Can also be set via PIHOLE_COMMENT_PREFIX environment variable
+  # comment_prefix = "[terraform]"
+
+  # Optional: Append a JSON line for every change made through the API
+  # Can also be set via PIHOLE_AUDIT_LOG environment variable
+  # audit_log_path = "pihole-audit.jsonl"
+
+  # Optional: Refuse every change, e.g. for plans from untrusted pipelines
+  # Can also be set via PIHOLE_READ_ONLY environment variable
+  # read_only = true
 }
@@ -0,0 +1,2 @@
+# Import by type and value
+terraform import pihole_api_exclusion.monitoring client/192.168.1.5
@@ -0,0 +1,11 @@
+# Hide a monitoring host from the query log and top clients
+resource "pihole_api_exclusion" "monitoring" {
+  type  = "client"
+  value = "192.168.1.5"
+}
+
+# Values are regular expressions; escape literal names
+resource "pihole_api_exclusion" "ntp" {
+  type  = "domain"
+  value = "(\\.|^)${provider::pihole::regex_escape("pool.ntp.org")}$"
+}
@@ -0,0 +1,14 @@
+# Restrictive blocking for the kids' devices at night
+resource "pihole_group" "bedtime" {
+  name = "bedtime"
+
+  lifecycle {
+    ignore_changes = [enabled]
+  }
+}
+
+resource "pihole_blocking_schedule" "bedtime" {
+  group              = pihole_group.bedtime.name
+  enabled_expression = "sun-thu 21:00-07:00; fri,sat 23:00-09:00"
+  timezone           = "Europe/Berlin"
+}
@@ -0,0 +1,3 @@
+# Import by "domain,target", or by the domain alone
+terraform import pihole_cname_record.www www.example.local,server.example.local
+terraform import pihole_cname_record.www www.example.local
@@ -0,0 +1,2 @@
+# Import by "domain,server" or "domain,server#port"
+terraform import pihole_conditional_forward.consul consul,127.0.0.1#8600
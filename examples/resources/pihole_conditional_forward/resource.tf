@@ -0,0 +1,11 @@
+# Forward queries for internal domains
+resource "pihole_conditional_forward" "corp" {
+  domain = "corp.example.com"
+  server = "10.0.0.1"
+}
+
+resource "pihole_conditional_forward" "consul" {
+  domain = "consul"
+  server = "127.0.0.1"
+  port   = 8600
+}
@@ -0,0 +1,14 @@
+# Reset all debug flags, and again whenever incident_id changes
+resource "pihole_config_reset" "debug" {
+  section = "debug"
+
+  triggers = {
+    incident = var.incident_id
+  }
+}
+
+# Reset only some options of a section
+resource "pihole_config_reset" "dns_cache" {
+  section = "dns"
+  keys    = ["cache.size", "cache.optimizer"]
+}
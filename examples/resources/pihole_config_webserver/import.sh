@@ -0,0 +1,2 @@
+# Import by section name
+terraform import pihole_config_webserver.settings webserver
@@ -10,4 +10,7 @@ resource "pihole_config_webserver" "settings" {
   # Interface settings
   interface_boxed = true
   interface_theme = "default-auto"
+
+  # Paths of the web interface
+  paths_webhome = "/admin/"
 }
@@ -0,0 +1,2 @@
+# Import by "type/template/value" or "type/template/value/query_type"
+terraform import pihole_custom_regex.xyz deny/tld/xyz
@@ -0,0 +1,12 @@
+# Block a whole top-level domain
+resource "pihole_custom_regex" "xyz" {
+  template = "tld"
+  value    = "xyz"
+}
+
+# Block a tracker and all of its subdomains
+resource "pihole_custom_regex" "tracker" {
+  template = "domain"
+  value    = "tracker.example.com"
+  comment  = "Tracker and all of its subdomains"
+}
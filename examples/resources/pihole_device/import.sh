@@ -0,0 +1,2 @@
+# Import by the device's client identifiers, separated by commas
+terraform import pihole_device.laptop AA:BB:CC:DD:EE:01,AA:BB:CC:DD:EE:02
@@ -0,0 +1,6 @@
+# A laptop with a wired and a Wi-Fi network card
+resource "pihole_device" "laptop" {
+  identifiers = ["AA:BB:CC:DD:EE:01", "AA:BB:CC:DD:EE:02"]
+  groups      = [pihole_group.trusted.id]
+  comment     = "Work laptop"
+}
@@ -0,0 +1,2 @@
+# Import by the line without "dhcp-option="
+terraform import pihole_dhcp_option.kids_dns tag:kids,option:dns-server,192.168.1.53
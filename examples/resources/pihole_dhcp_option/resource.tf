@@ -0,0 +1,11 @@
+# Manage DHCP options
+resource "pihole_dhcp_option" "ntp" {
+  option = "ntp-server"
+  values = ["192.168.1.1"]
+}
+
+resource "pihole_dhcp_option" "kids_dns" {
+  tag    = "kids"
+  option = "dns-server"
+  values = ["192.168.1.53"]
+}
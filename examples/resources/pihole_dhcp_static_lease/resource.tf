@@ -10,3 +10,10 @@ resource "pihole_dhcp_static_lease" "nas" {
   ip       = "192.168.1.50"
   hostname = "nas"
 }
+
+# Fixed hostname with a dynamic address and a custom lease time
+resource "pihole_dhcp_static_lease" "laptop" {
+  mac        = "22:33:44:55:66:77"
+  hostname   = "laptop"
+  lease_time = "12h"
+}
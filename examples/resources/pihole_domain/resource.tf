@@ -25,3 +25,11 @@ resource "pihole_domain" "block_trackers" {
   groups  = [pihole_group.iot.id]
   comment = "Block tracking domains for IoT devices"
 }
+
+# Block a domain and its subdomains, except for two of them
+resource "pihole_domain" "block_example" {
+  domain     = "(\\.|^)example\\.com$"
+  type       = "deny"
+  kind       = "regex"
+  exceptions = ["docs.example.com", "status.example.com"]
+}
@@ -0,0 +1,2 @@
+# Import by "zone,server" or "zone,server#port"
+terraform import pihole_forward_zone.home 192.168.178.0/24,192.168.178.1
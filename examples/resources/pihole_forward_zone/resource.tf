@@ -0,0 +1,12 @@
+# Names and reverse lookups of the home network from the router
+resource "pihole_forward_zone" "home" {
+  zone         = "192.168.178.0/24"
+  server       = "192.168.178.1"
+  local_domain = "fritz.box"
+}
+
+# A company domain from the resolver behind the VPN
+resource "pihole_forward_zone" "corp" {
+  zone   = "corp.example"
+  server = "10.0.0.1"
+}
@@ -0,0 +1,11 @@
+# Switch a group created in the web interface on and off
+resource "pihole_group_state" "kids_bedtime" {
+  group   = "kids-bedtime"
+  enabled = var.bedtime
+}
+
+# Or refer to the group by ID
+resource "pihole_group_state" "guests" {
+  group_id = 3
+  enabled  = false
+}
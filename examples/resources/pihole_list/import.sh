@@ -1,2 +1,5 @@
 # Import format: type/address
 terraform import pihole_list.stevenblack block/https://raw.githubusercontent.com/StevenBlack/hosts/master/hosts
+
+# Import format: id:<id>
+terraform import pihole_list.stevenblack id:12
@@ -0,0 +1,2 @@
+# Import by type, group ID and list address
+terraform import pihole_list_group_association.kids_social block/3/https://example.com/social-media.txt
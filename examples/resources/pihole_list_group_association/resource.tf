@@ -0,0 +1,9 @@
+resource "pihole_group" "kids" {
+  name = "kids"
+}
+
+# Apply a list subscribed elsewhere to the kids group as well
+resource "pihole_list_group_association" "kids_social" {
+  address  = "https://example.com/social-media.txt"
+  group_id = pihole_group.kids.id
+}
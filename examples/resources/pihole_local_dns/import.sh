@@ -0,0 +1,3 @@
+# Import by "IP hostname", or by the hostname alone
+terraform import pihole_local_dns.server "192.168.1.100 server.lan"
+terraform import pihole_local_dns.server server.lan
@@ -0,0 +1,28 @@
+provider "pihole" {
+  managed_by_tag = "prod"
+}
+
+resource "pihole_domain" "blocked" {
+  for_each = toset(["ads.example.com", "tracker.example.com"])
+
+  domain = each.value
+  type   = "deny"
+  kind   = "exact"
+}
+
+# Delete domains tagged "prod" that are no longer in the configuration
+resource "pihole_managed_cleanup" "prod" {
+  keep_domain_ids = [for d in pihole_domain.blocked : d.id]
+}
+
+# Only report the domains and lists another workspace's tag would delete
+resource "pihole_managed_cleanup" "staging" {
+  tag             = "staging"
+  keep_domain_ids = []
+  keep_list_ids   = []
+  dry_run         = true
+}
+
+output "staging_orphans" {
+  value = pihole_managed_cleanup.staging.orphans
+}
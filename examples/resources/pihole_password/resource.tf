@@ -0,0 +1,27 @@
+# Rotate the web interface password
+variable "pihole_admin_password" {
+  type      = string
+  sensitive = true
+  ephemeral = true
+}
+
+resource "pihole_password" "admin" {
+  type     = "admin"
+  password = var.pihole_admin_password
+
+  # Change to set the password again
+  password_version = 1
+}
+
+# Activate an application password, e.g. for the provider itself. The
+# password is never stored in the state; hand it to a secret store with a
+# write-only attribute in the same run.
+ephemeral "pihole_app_password" "terraform" {}
+
+resource "pihole_password" "terraform" {
+  type              = "app"
+  app_password_hash = ephemeral.pihole_app_password.terraform.hash
+
+  # Change to activate a new application password
+  password_version = 2
+}
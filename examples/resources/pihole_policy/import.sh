@@ -0,0 +1,2 @@
+# Import by group name
+terraform import pihole_policy.kids kids
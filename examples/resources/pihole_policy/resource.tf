@@ -0,0 +1,10 @@
+# Parental controls for the kids' devices
+resource "pihole_policy" "kids" {
+  group   = "kids"
+  comment = "Parental controls"
+
+  deny_domains  = ["tiktok.com", "www.tiktok.com"]
+  deny_regexes  = ["(\\.|^)roblox\\.com$"]
+  allow_domains = ["khanacademy.org"]
+  clients       = ["AA:BB:CC:DD:EE:10", "192.168.1.60"]
+}
@@ -0,0 +1,2 @@
+# Import by "ip,hostname"
+terraform import pihole_ptr_record.gateway 192.168.1.1,gateway.lan
@@ -0,0 +1,10 @@
+# Manage reverse lookup (PTR) records
+resource "pihole_ptr_record" "gateway" {
+  ip       = "192.168.1.1"
+  hostname = "gateway.lan"
+}
+
+resource "pihole_ptr_record" "nas_v6" {
+  ip       = "fd00::10"
+  hostname = "nas.lan"
+}
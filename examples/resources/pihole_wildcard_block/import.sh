@@ -0,0 +1,2 @@
+# Import by domain
+terraform import pihole_wildcard_block.tracker tracker.example.com
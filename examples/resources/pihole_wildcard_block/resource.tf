@@ -0,0 +1,5 @@
+# Block a tracker and all of its subdomains
+resource "pihole_wildcard_block" "tracker" {
+  domain  = "tracker.example.com"
+  comment = "Tracker and all of its subdomains"
+}
@@ -0,0 +1,215 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+// Package importgen writes Terraform import blocks for the entries of a
+// Pi-hole, so an instance set up by hand can be brought under Terraform with
+// `terraform plan -generate-config-out`.
+package importgen
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/dklesev/terraform-provider-pihole/internal/provider/convert"
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+)
+
+// importBlock is the import of one Pi-hole entry into a resource.
+type importBlock struct {
+	resourceType string
+	name         string
+	id           string
+}
+
+// Generate reads the groups, domains, lists, clients, local DNS records,
+// CNAME records, upstream DNS servers and static DHCP leases of the Pi-hole
+// behind c and writes an import block for each of them to w. The built-in
+// Default group is left out.
+func Generate(ctx context.Context, c *pihole.Client, w io.Writer) error {
+	blocks, skipped, err := collect(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	names := newNamer()
+	fmt.Fprintf(w, "# Import blocks for the Pi-hole at %s, generated by terraform-provider-pihole generate-imports.\n", c.Host())
+	fmt.Fprintf(w, "# Run `terraform plan -generate-config-out=generated.tf` to write the matching resources.\n")
+	for _, s := range skipped {
+		fmt.Fprintf(w, "#\n# Skipped: %s\n", s)
+	}
+	for _, b := range blocks {
+		fmt.Fprintf(w, "\nimport {\n  to = %s.%s\n  id = %s\n}\n", b.resourceType, names.name(b.resourceType, b.name), hclString(b.id))
+	}
+	return nil
+}
+
+// collect reads the entries to import, in the order of the resource types
+// in the Generate documentation. skipped describes entries no resource can
+// import.
+func collect(ctx context.Context, c *pihole.Client) (blocks []importBlock, skipped []string, err error) {
+	groups, err := c.GetGroups(ctx, "")
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading groups: %w", err)
+	}
+	for _, g := range groups {
+		if !g.IsDefault() {
+			blocks = append(blocks, importBlock{"pihole_group", g.Name, g.Name})
+		}
+	}
+
+	domains, err := c.GetDomains(ctx, "", "", "")
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading domains: %w", err)
+	}
+	for _, d := range domains {
+		blocks = append(blocks, importBlock{"pihole_domain", d.Type + "_" + d.Kind + "_" + d.Domain, d.Type + "/" + d.Kind + "/" + d.Domain})
+	}
+
+	lists, err := c.GetLists(ctx, "", "")
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading lists: %w", err)
+	}
+	for _, l := range lists {
+		blocks = append(blocks, importBlock{"pihole_list", listName(l.Address), l.Type + "/" + l.Address})
+	}
+
+	clients, err := c.GetClients(ctx, "")
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading clients: %w", err)
+	}
+	for _, cl := range clients {
+		blocks = append(blocks, importBlock{"pihole_client", cl.Client, cl.Client})
+	}
+
+	config, err := c.GetConfig(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading configuration: %w", err)
+	}
+	if dns := config.DNS; dns != nil {
+		for _, line := range dns.Hosts {
+			ip, hostnames, ok := convert.ParseHostsLine(line)
+			if !ok {
+				continue
+			}
+			if len(hostnames) == 0 {
+				skipped = append(skipped, fmt.Sprintf("local DNS record %q, which is not an \"IP hostname\" line", line))
+				continue
+			}
+			for _, hostname := range hostnames {
+				blocks = append(blocks, importBlock{"pihole_local_dns", hostname, ip + " " + hostname})
+			}
+		}
+		for _, line := range dns.CNAMERecords {
+			domain, target, ok := convert.ParseCNAMERecord(line)
+			if !ok {
+				skipped = append(skipped, fmt.Sprintf("CNAME record %q, which is not a \"domain,target\" line", line))
+				continue
+			}
+			blocks = append(blocks, importBlock{"pihole_cname_record", domain, domain + "," + target})
+		}
+		for _, upstream := range dns.Upstreams {
+			blocks = append(blocks, importBlock{"pihole_dns_upstream", upstream, upstream})
+		}
+	}
+	if dhcp := config.DHCP; dhcp != nil {
+		for _, line := range dhcp.Hosts {
+			lease := convert.ParseDHCPHost(line)
+			if lease.MAC == "" || (lease.IP == "" && lease.Hostname == "") {
+				skipped = append(skipped, fmt.Sprintf("static DHCP lease %q, which pihole_dhcp_static_lease needs a MAC address and an IP or hostname for", line))
+				continue
+			}
+			name := lease.Hostname
+			if name == "" {
+				name = lease.IP
+			}
+			blocks = append(blocks, importBlock{"pihole_dhcp_static_lease", name, lease.String()})
+		}
+	}
+	return blocks, skipped, nil
+}
+
+// listName names a list after the file in its address, e.g. "hosts" for
+// ".../StevenBlack/hosts/master/hosts", or its host when there is none.
+func listName(address string) string {
+	rest := address
+	if _, after, found := strings.Cut(rest, "://"); found {
+		rest = after
+	}
+	rest = strings.TrimRight(rest, "/")
+	host, path, _ := strings.Cut(rest, "/")
+	if path == "" {
+		return host
+	}
+	file := path[strings.LastIndex(path, "/")+1:]
+	if ext := strings.LastIndex(file, "."); ext > 0 {
+		file = file[:ext]
+	}
+	return host + "_" + file
+}
+
+// nameSeparators are runs of characters replaced by a single "_" in
+// resource names.
+var nameSeparators = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// namer makes unique resource names.
+type namer struct {
+	used map[string]bool
+}
+
+func newNamer() *namer {
+	return &namer{used: make(map[string]bool)}
+}
+
+// name turns s into a resource name for resourceType that is not taken yet:
+// lowercase, with runs of other characters replaced by "_", starting with a
+// letter and with a number appended when needed.
+func (n *namer) name(resourceType, s string) string {
+	name := strings.Trim(nameSeparators.ReplaceAllString(strings.ToLower(s), "_"), "_-")
+	kind := strings.TrimPrefix(resourceType, "pihole_")
+	if name == "" {
+		name = kind
+	} else if name[0] >= '0' && name[0] <= '9' {
+		name = kind + "_" + name
+	}
+
+	unique := name
+	for i := 2; n.used[resourceType+"."+unique]; i++ {
+		unique = name + "_" + strconv.Itoa(i)
+	}
+	n.used[resourceType+"."+unique] = true
+	return unique
+}
+
+// hclString quotes s as an HCL string literal. Besides quotes, backslashes
+// and control characters, the template sequences "${" and "%{" are escaped
+// so regexes and comments are imported verbatim.
+func hclString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i, r := range s {
+		switch {
+		case r == '"' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case r < 0x20 || r == 0x7f:
+			fmt.Fprintf(&b, `\u%04x`, r)
+		case (r == '$' || r == '%') && strings.HasPrefix(s[i+1:], "{"):
+			b.WriteRune(r)
+			b.WriteRune(r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package importgen
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+)
+
+func TestGenerate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/auth":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"session": map[string]interface{}{"valid": true, "sid": "test-sid"},
+			})
+		case "/api/groups":
+			json.NewEncoder(w).Encode(pihole.GroupsResponse{Groups: []pihole.Group{
+				{ID: 0, Name: "Default"},
+				{ID: 1, Name: "IoT devices"},
+			}})
+		case "/api/domains":
+			json.NewEncoder(w).Encode(pihole.DomainsResponse{Domains: []pihole.Domain{
+				{ID: 1, Domain: "ads.example.com", Type: "deny", Kind: "exact"},
+				{ID: 2, Domain: `(^|\.)tracker\.${x}$`, Type: "deny", Kind: "regex"},
+				{ID: 3, Domain: "ads.example.com", Type: "allow", Kind: "exact"},
+			}})
+		case "/api/lists":
+			json.NewEncoder(w).Encode(pihole.ListsResponse{Lists: []pihole.List{
+				{ID: 1, Address: "https://raw.githubusercontent.com/StevenBlack/hosts/master/hosts", Type: "block"},
+			}})
+		case "/api/clients":
+			json.NewEncoder(w).Encode(pihole.ClientsResponse{Clients: []pihole.PiholeClient{
+				{ID: 1, Client: "192.168.1.100"},
+			}})
+		case "/api/config":
+			w.Write([]byte(`{"config":{
+				"dns":{
+					"hosts":["192.168.1.10 nas.lan nas","not-a-hosts-line"],
+					"cnameRecords":["www.lan,nas.lan"],
+					"upstreams":["1.1.1.1"]
+				},
+				"dhcp":{"hosts":["AA:BB:CC:DD:EE:FF,192.168.1.20,printer","192.168.1.30"]}
+			}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := pihole.New(pihole.Config{URL: server.URL, Password: "test"})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := Generate(context.Background(), client, &out); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	got := out.String()
+
+	for _, want := range []string{
+		"import {\n  to = pihole_group.iot_devices\n  id = \"IoT devices\"\n}\n",
+		"import {\n  to = pihole_domain.deny_exact_ads_example_com\n  id = \"deny/exact/ads.example.com\"\n}\n",
+		"import {\n  to = pihole_domain.deny_regex_tracker_x\n  id = \"deny/regex/(^|\\\\.)tracker\\\\.$${x}$\"\n}\n",
+		"import {\n  to = pihole_domain.allow_exact_ads_example_com\n  id = \"allow/exact/ads.example.com\"\n}\n",
+		"import {\n  to = pihole_list.raw_githubusercontent_com_hosts\n  id = \"block/https://raw.githubusercontent.com/StevenBlack/hosts/master/hosts\"\n}\n",
+		"import {\n  to = pihole_client.client_192_168_1_100\n  id = \"192.168.1.100\"\n}\n",
+		"import {\n  to = pihole_local_dns.nas_lan\n  id = \"192.168.1.10 nas.lan\"\n}\n",
+		"import {\n  to = pihole_local_dns.nas\n  id = \"192.168.1.10 nas\"\n}\n",
+		"import {\n  to = pihole_cname_record.www_lan\n  id = \"www.lan,nas.lan\"\n}\n",
+		"import {\n  to = pihole_dns_upstream.dns_upstream_1_1_1_1\n  id = \"1.1.1.1\"\n}\n",
+		"import {\n  to = pihole_dhcp_static_lease.printer\n  id = \"AA:BB:CC:DD:EE:FF,192.168.1.20,printer\"\n}\n",
+		`# Skipped: local DNS record "not-a-hosts-line"`,
+		`# Skipped: static DHCP lease "192.168.1.30"`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output does not contain %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "Default") {
+		t.Errorf("output imports the Default group:\n%s", got)
+	}
+}
+
+func TestNamer(t *testing.T) {
+	n := newNamer()
+	tests := []struct {
+		resourceType, s, want string
+	}{
+		{"pihole_domain", "Ads.Example.com", "ads_example_com"},
+		{"pihole_domain", "ads-example.com", "ads-example_com"},
+		{"pihole_domain", "ads.example.com", "ads_example_com_2"},
+		{"pihole_group", "ads.example.com", "ads_example_com"},
+		{"pihole_client", "10.0.0.1", "client_10_0_0_1"},
+		{"pihole_client", "***", "client"},
+		{"pihole_client", "", "client_2"},
+	}
+	for _, tt := range tests {
+		if got := n.name(tt.resourceType, tt.s); got != tt.want {
+			t.Errorf("name(%q, %q) = %q, want %q", tt.resourceType, tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestHCLString(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"plain", `"plain"`},
+		{`a"b\c`, `"a\"b\\c"`},
+		{"${var} %{if}", `"$${var} %%{if}"`},
+		{"$ % {", `"$ % {"`},
+		{"tab\tnl\nbell\a", `"tab\tnl\nbell\u0007"`},
+	}
+	for _, tt := range tests {
+		if got := hclString(tt.in); got != tt.want {
+			t.Errorf("hclString(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// Acceptance tests run against a pool of Pi-hole instances. Tests written
+// with resource.Test and testAccProtoV6ProviderFactories use the instance at
+// PIHOLE_URL. Tests written with testAccParallelTest borrow an instance from
+// the pool for their duration and run in parallel with each other, as many
+// at a time as there are instances. The pool is PIHOLE_ACC_URLS, a
+// comma-separated list of URLs of Pi-holes that all accept PIHOLE_PASSWORD,
+// or just PIHOLE_URL. With PIHOLE_ACC_DOCKER, PIHOLE_ACC_DOCKER_INSTANCES
+// containers are started for it.
+//
+// Go runs parallel tests after the sequential ones of the package, so the
+// instance at PIHOLE_URL can be in the pool, too.
+
+// testAccURLsEnv lists the URLs of the Pi-holes in the pool.
+const testAccURLsEnv = "PIHOLE_ACC_URLS"
+
+// testAccInstance is a Pi-hole that acceptance tests run against.
+type testAccInstance struct {
+	url      string
+	password string
+}
+
+// testAccPool holds the instances no test is using.
+var testAccPool chan *testAccInstance
+
+// testAccSetupPool fills testAccPool from PIHOLE_ACC_URLS or PIHOLE_URL.
+func testAccSetupPool() {
+	urls := os.Getenv(testAccURLsEnv)
+	if urls == "" {
+		urls = os.Getenv("PIHOLE_URL")
+	}
+	if urls == "" {
+		urls = "http://localhost:8080"
+	}
+
+	var instances []*testAccInstance
+	for _, url := range strings.Split(urls, ",") {
+		if url = strings.TrimSpace(url); url != "" {
+			instances = append(instances, &testAccInstance{url: url, password: testAccPassword()})
+		}
+	}
+	testAccPool = make(chan *testAccInstance, len(instances))
+	for _, inst := range instances {
+		testAccPool <- inst
+	}
+}
+
+// testAccParallelTest runs the test case that build returns for an instance
+// from the pool, in parallel with the other tests run this way. The case
+// must only talk to that instance, through providerFactories and
+// apiClient.
+func testAccParallelTest(t *testing.T, build func(inst *testAccInstance) resource.TestCase) {
+	t.Helper()
+	if os.Getenv(resource.EnvTfAcc) == "" {
+		t.Skipf("Acceptance tests skipped unless env '%s' set", resource.EnvTfAcc)
+	}
+
+	t.Parallel()
+	inst := <-testAccPool
+	t.Cleanup(func() { testAccPool <- inst })
+	t.Logf("Running against Pi-hole at %s", inst.url)
+
+	resource.Test(t, build(inst))
+}
+
+// providerFactories returns provider factories whose providers use the
+// instance in place of PIHOLE_URL and PIHOLE_PASSWORD.
+func (i *testAccInstance) providerFactories() map[string]func() (tfprotov6.ProviderServer, error) {
+	getenv := func(key string) string {
+		switch key {
+		case "PIHOLE_URL":
+			return i.url
+		case "PIHOLE_PASSWORD":
+			return i.password
+		}
+		return os.Getenv(key)
+	}
+	return map[string]func() (tfprotov6.ProviderServer, error){
+		"pihole": func() (tfprotov6.ProviderServer, error) {
+			return providerserver.NewProtocol6WithError(&PiholeProvider{version: "test", getenv: getenv})()
+		},
+	}
+}
+
+// apiClient returns an API client for the instance, for checks that talk to
+// Pi-hole directly.
+func (i *testAccInstance) apiClient() (*pihole.Client, error) {
+	return pihole.New(pihole.Config{
+		URL:      i.url,
+		Password: i.password,
+	})
+}
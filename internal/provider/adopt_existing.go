@@ -0,0 +1,35 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+)
+
+// adoptExistingAttribute is the adopt_existing attribute of resources whose
+// entry may already be in Pi-hole when Terraform creates it.
+func adoptExistingAttribute() schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Description: "Whether to take over an entry with the same identity that already exists in Pi-hole instead of failing to create it, e.g. to bring an existing Pi-hole under Terraform without importing every entry. " +
+			"The adopted entry is changed to match the configuration and deleted when the resource is destroyed. Default: false.",
+		Optional: true,
+	}
+}
+
+// addCreateError adds an error diagnostic for an entry that could not be
+// created. When it is already in Pi-hole, the detail tells how to manage it:
+// by importing it with importID or by setting adopt_existing.
+func addCreateError(diags *diag.Diagnostics, summary, what string, err error, resourceType, importID string) {
+	d := apiErrorDiagnostic(summary, "Could not create "+what, err)
+	detail := d.Detail()
+	if errors.Is(err, pihole.ErrExists) {
+		detail += fmt.Sprintf("\n\nThe entry already exists in Pi-hole. Import it with `terraform import %s.<name> %q`, or set adopt_existing = true to take it over.", resourceType, importID)
+	}
+	diags.AddError(d.Summary(), detail)
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// auditBodyLimit is how many bytes of a request body an audit entry keeps.
+const auditBodyLimit = 1024
+
+// auditLog appends one JSON line per mutating API request to a file, so
+// operators have a record of what an apply changed. Terraform may run several
+// provider processes at once, so every entry is a single append.
+type auditLog struct {
+	mu   sync.Mutex
+	path string
+}
+
+// auditEntry is one line of the audit log.
+type auditEntry struct {
+	Time       string `json:"time"`
+	Method     string `json:"method"`
+	Path       string `json:"path"`
+	Body       string `json:"body,omitempty"`
+	Status     int    `json:"status"`
+	Error      string `json:"error,omitempty"`
+	DurationMS int64  `json:"duration_ms"`
+}
+
+// newAuditLog checks that path can be appended to.
+func newAuditLog(path string) (*auditLog, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	if err := f.Close(); err != nil {
+		return nil, err
+	}
+	return &auditLog{path: path}, nil
+}
+
+// record writes an entry for m unless it is a read. Failures are logged and
+// do not fail the request.
+func (a *auditLog) record(ctx context.Context, m pihole.RequestMetric) {
+	if m.Method == http.MethodGet {
+		return
+	}
+
+	entry := auditEntry{
+		Time:       time.Now().UTC().Format(time.RFC3339),
+		Method:     m.Method,
+		Path:       m.Path,
+		Body:       auditBody(m.Body),
+		Status:     m.Status,
+		DurationMS: m.Duration.Milliseconds(),
+	}
+	if m.Err != nil {
+		entry.Error = m.Err.Error()
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		tflog.Warn(ctx, "Could not write audit log", map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	f, err := os.OpenFile(a.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		tflog.Warn(ctx, "Could not write audit log", map[string]interface{}{"path": a.path, "error": err.Error()})
+		return
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		tflog.Warn(ctx, "Could not write audit log", map[string]interface{}{"path": a.path, "error": err.Error()})
+	}
+}
+
+// auditBody returns a request body for the audit log, with passwords and
+// other secrets replaced and cut to auditBodyLimit bytes.
+func auditBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err == nil {
+		redactAuditSecrets(value)
+		if redacted, err := json.Marshal(value); err == nil {
+			body = redacted
+		}
+	}
+
+	if len(body) > auditBodyLimit {
+		return string(body[:auditBodyLimit]) + "...(truncated)"
+	}
+	return string(body)
+}
+
+// redactAuditSecrets replaces the values of password, hash and secret
+// fields in decoded JSON.
+func redactAuditSecrets(value interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			lower := strings.ToLower(key)
+			if strings.Contains(lower, "password") || strings.Contains(lower, "pwhash") ||
+				strings.Contains(lower, "secret") || strings.Contains(lower, "totp") {
+				v[key] = "(redacted)"
+				continue
+			}
+			redactAuditSecrets(child)
+		}
+	case []interface{}:
+		for _, child := range v {
+			redactAuditSecrets(child)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+)
+
+func TestAuditLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	audit, err := newAuditLog(path)
+	if err != nil {
+		t.Fatalf("newAuditLog() error = %v", err)
+	}
+
+	ctx := context.Background()
+	audit.record(ctx, pihole.RequestMetric{Method: "GET", Path: "groups", Status: 200})
+	audit.record(ctx, pihole.RequestMetric{
+		Method:   "PATCH",
+		Path:     "config",
+		Body:     []byte(`{"config":{"webserver":{"api":{"password":"hunter2","app_pwhash":"abc"}}}}`),
+		Status:   200,
+		Duration: 15 * time.Millisecond,
+	})
+	audit.record(ctx, pihole.RequestMetric{Method: "DELETE", Path: "groups/test", Status: 404, Err: errors.New("status 404")})
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 entries, got %d:\n%s", len(lines), data)
+	}
+
+	var entry auditEntry
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+		t.Fatal(err)
+	}
+	if entry.Method != "PATCH" || entry.Path != "config" || entry.Status != 200 || entry.DurationMS != 15 {
+		t.Errorf("Unexpected entry: %+v", entry)
+	}
+	if strings.Contains(entry.Body, "hunter2") || strings.Contains(entry.Body, `"abc"`) {
+		t.Errorf("Secrets not redacted: %s", entry.Body)
+	}
+
+	entry = auditEntry{}
+	if err := json.Unmarshal([]byte(lines[1]), &entry); err != nil {
+		t.Fatal(err)
+	}
+	if entry.Method != "DELETE" || entry.Error != "status 404" || entry.Body != "" {
+		t.Errorf("Unexpected entry: %+v", entry)
+	}
+}
+
+func TestAuditBody_truncated(t *testing.T) {
+	body := `{"domain":"` + strings.Repeat("a", 2*auditBodyLimit) + `"}`
+	got := auditBody([]byte(body))
+	if len(got) != auditBodyLimit+len("...(truncated)") || !strings.HasSuffix(got, "...(truncated)") {
+		t.Errorf("auditBody() returned %d bytes", len(got))
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// addAPIError adds an error diagnostic for err. See apiErrorDiagnostic.
+func addAPIError(diags *diag.Diagnostics, summary string, err error) {
+	diags.Append(apiErrorDiagnostic(summary, "", err))
+}
+
+// addAPIErrorf adds an error diagnostic for err, with what failed, e.g.
+// "Could not create group %s", leading the detail. See apiErrorDiagnostic.
+func addAPIErrorf(diags *diag.Diagnostics, summary string, err error, format string, args ...any) {
+	diags.Append(apiErrorDiagnostic(summary, fmt.Sprintf(format, args...), err))
+}
+
+// apiErrorDiagnostic describes err, which happened doing what. When Pi-hole
+// rejected the request, the summary is Pi-hole's message, and the detail
+// starts with what (or summary without it) followed by the error key, the
+// hint, the endpoint and the processing time Pi-hole reported. Any other
+// error keeps summary, with what and the error as the detail.
+func apiErrorDiagnostic(summary, what string, err error) diag.Diagnostic {
+	var apiErr *pihole.APIError
+	if !errors.As(err, &apiErr) || apiErr.Message == "" {
+		if what == "" {
+			return diag.NewErrorDiagnostic(summary, err.Error())
+		}
+		return diag.NewErrorDiagnostic(summary, what+": "+err.Error())
+	}
+
+	if what == "" {
+		what = summary
+	}
+	var b strings.Builder
+	b.WriteString(strings.TrimSuffix(what, ".") + ".\n")
+	fmt.Fprintf(&b, "\nKey:      %s", apiErr.Key)
+	if apiErr.Hint != "" {
+		fmt.Fprintf(&b, "\nHint:     %s", apiErr.Hint)
+	}
+	fmt.Fprintf(&b, "\nEndpoint: %s (HTTP %d)", apiErr.Endpoint(), apiErr.StatusCode)
+	if apiErr.Took > 0 {
+		fmt.Fprintf(&b, "\nTook:     %s", apiErr.Took)
+	}
+	var retryErr *pihole.RetryError
+	if errors.As(err, &retryErr) {
+		fmt.Fprintf(&b, "\nRetries:  %s", retryErr.Summary())
+	}
+	return diag.NewErrorDiagnostic(apiErr.Message, b.String())
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+)
+
+func TestAPIErrorDiagnostic(t *testing.T) {
+	apiErr := &pihole.APIError{
+		StatusCode: 400,
+		Key:        "bad_request",
+		Message:    "Invalid request",
+		Hint:       "Specify a group name",
+		Method:     "POST",
+		Path:       "/api/groups",
+		Took:       1500 * time.Microsecond,
+	}
+	tests := []struct {
+		name          string
+		summary, what string
+		err           error
+		wantSummary   string
+		wantDetail    string
+	}{
+		{
+			name:        "API error",
+			summary:     "Error creating group",
+			what:        "Could not create group test",
+			err:         apiErr,
+			wantSummary: "Invalid request",
+			wantDetail: "Could not create group test.\n" +
+				"\nKey:      bad_request" +
+				"\nHint:     Specify a group name" +
+				"\nEndpoint: POST /api/groups (HTTP 400)" +
+				"\nTook:     1.5ms",
+		},
+		{
+			name:        "retried API error without what",
+			summary:     "Error reading groups",
+			err:         &pihole.RetryError{Retries: 2, Elapsed: 3 * time.Second, LastStatus: 400, Err: &pihole.APIError{StatusCode: 400, Key: "bad_request", Message: "Invalid request", Method: "GET", Path: "/api/groups"}},
+			wantSummary: "Invalid request",
+			wantDetail: "Error reading groups.\n" +
+				"\nKey:      bad_request" +
+				"\nEndpoint: GET /api/groups (HTTP 400)" +
+				"\nRetries:  2 retries over 3s, last status 400",
+		},
+		{
+			name:        "other error",
+			summary:     "Error creating group",
+			what:        "Could not create group test",
+			err:         fmt.Errorf("request failed: %w", errors.New("connection refused")),
+			wantSummary: "Error creating group",
+			wantDetail:  "Could not create group test: request failed: connection refused",
+		},
+		{
+			name:        "API error without Pi-hole's error object",
+			summary:     "Error reading groups",
+			err:         &pihole.APIError{StatusCode: 502, Body: "Bad Gateway"},
+			wantSummary: "Error reading groups",
+			wantDetail:  "API request failed with status 502: Bad Gateway",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := apiErrorDiagnostic(tt.summary, tt.what, tt.err)
+			if d.Summary() != tt.wantSummary {
+				t.Errorf("Summary() = %q, want %q", d.Summary(), tt.wantSummary)
+			}
+			if d.Detail() != tt.wantDetail {
+				t.Errorf("Detail() = %q, want %q", d.Detail(), tt.wantDetail)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// observeAPIRequest logs a request's latency along with the running totals
+// of its endpoint. It is installed as the client's RequestObserver when
+// enable_api_metrics is set.
+//
+// Terraform runs a provider process per operation and stops it without a
+// shutdown hook the provider could log from, so the totals go out with each
+// request: the last line per endpoint carries its summary.
+func (p *PiholeProvider) observeAPIRequest(ctx context.Context, m pihole.RequestMetric) {
+	endpoint := p.metrics.Record(m)
+
+	fields := map[string]interface{}{
+		"method":      m.Method,
+		"path":        m.Path,
+		"endpoint":    m.Endpoint,
+		"status":      m.Status,
+		"duration_ms": m.Duration.Milliseconds(),
+		"took_ms":     float64(m.Took.Microseconds()) / 1000,
+	}
+	if m.Err != nil {
+		fields["error"] = m.Err.Error()
+	}
+	fields["endpoint_summary"] = endpoint.String()
+	tflog.Info(ctx, "Pi-hole API request", fields)
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// The management styles of dns.upstreams.
+const (
+	upstreamsOwnerConfigDNS = "the upstreams attribute of pihole_config_dns"
+	upstreamsOwnerResource  = "pihole_dns_upstream resources"
+)
+
+// Some Pi-hole config arrays can be managed either whole, through a list
+// attribute of a config resource, or item by item, through a resource per
+// entry. Using both for the same Pi-hole makes each undo the other on every
+// apply, so the resources claim the array they manage while planning and
+// the second style to claim it fails the plan.
+//
+// Claims are kept per client, which the provider creates once per Terraform
+// run and Pi-hole, so they last exactly as long as one plan or apply.
+var configClaims = struct {
+	sync.Mutex
+	owners map[*pihole.Client]map[string]string
+}{owners: make(map[*pihole.Client]map[string]string)}
+
+// claimConfigArray records owner as managing the config array on the Pi-hole
+// behind c. It returns the owner that claimed the array first and whether
+// that is owner itself.
+func claimConfigArray(c *pihole.Client, array, owner string) (string, bool) {
+	configClaims.Lock()
+	defer configClaims.Unlock()
+
+	owners, ok := configClaims.owners[c]
+	if !ok {
+		owners = make(map[string]string)
+		configClaims.owners[c] = owners
+	}
+	if first, ok := owners[array]; ok {
+		return first, first == owner
+	}
+	owners[array] = owner
+	return owner, true
+}
+
+// claimUpstreams claims dns.upstreams for owner and adds an error on attr
+// when the other management style already claimed it.
+func claimUpstreams(c *pihole.Client, owner string, attr path.Path, diags *diag.Diagnostics) {
+	if c == nil {
+		return
+	}
+	if first, ok := claimConfigArray(c, "dns.upstreams", owner); !ok {
+		diags.AddAttributeError(
+			attr,
+			"Conflicting DNS upstream management",
+			fmt.Sprintf("Pi-hole's DNS upstreams are managed by both %s and %s, so each apply would undo the other's changes. "+
+				"Manage them with one of the two only.", first, owner),
+		)
+	}
+}
@@ -0,0 +1,30 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+)
+
+func TestClaimConfigArray(t *testing.T) {
+	a, b := &pihole.Client{}, &pihole.Client{}
+
+	if first, ok := claimConfigArray(a, "dns.upstreams", "list"); !ok || first != "list" {
+		t.Fatalf("first claim = %q, %v", first, ok)
+	}
+	if _, ok := claimConfigArray(a, "dns.upstreams", "list"); !ok {
+		t.Error("repeated claim by the same owner failed")
+	}
+	if first, ok := claimConfigArray(a, "dns.upstreams", "items"); ok || first != "list" {
+		t.Errorf("conflicting claim = %q, %v, want \"list\", false", first, ok)
+	}
+	if _, ok := claimConfigArray(a, "dns.hosts", "items"); !ok {
+		t.Error("claim of another array failed")
+	}
+	if _, ok := claimConfigArray(b, "dns.upstreams", "items"); !ok {
+		t.Error("claim through another client failed")
+	}
+}
@@ -0,0 +1,198 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// A config section cannot be deleted, so by default destroying a config
+// resource only removes it from state. on_destroy lets it reset the options
+// it manages to Pi-hole's defaults, or restore the values they had before
+// Terraform took them over. Those values are kept in the resource's private
+// state.
+const (
+	onDestroyNoop            = "noop"
+	onDestroyResetToDefaults = "reset_to_defaults"
+	onDestroyRestoreSnapshot = "restore_snapshot"
+
+	configSnapshotKey = "snapshot"
+)
+
+// privateStateGetter and privateStateSetter are the parts of a resource's
+// private state the config resources use.
+type privateStateGetter interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+}
+
+type privateStateSetter interface {
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// onDestroyAttribute is the on_destroy attribute shared by the config
+// resources.
+func onDestroyAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Description: "What destroying the resource does to Pi-hole: `noop` leaves the configuration as it is, " +
+			"`reset_to_defaults` sets the options this resource manages back to Pi-hole's defaults, and " +
+			"`restore_snapshot` restores the values they had when the resource was created or imported. Default: `noop`.",
+		Optional: true,
+		Computed: true,
+		Default:  stringdefault.StaticString(onDestroyNoop),
+		Validators: []validator.String{
+			stringvalidator.OneOf(onDestroyNoop, onDestroyResetToDefaults, onDestroyRestoreSnapshot),
+		},
+	}
+}
+
+// snapshotConfig saves the current values of the options in values (a tree
+// as passed to configPayload) to private state. It only logs failures: the
+// snapshot is needed at destroy time, and only with restore_snapshot.
+func snapshotConfig(ctx context.Context, c *pihole.Client, section string, values map[string]interface{}, private privateStateSetter) {
+	options, err := c.GetConfigOptions(ctx, section)
+	if err != nil {
+		tflog.Warn(ctx, "Could not snapshot config", map[string]interface{}{"section": section, "error": err.Error()})
+		return
+	}
+
+	snapshot, err := json.Marshal(configSnapshotPayload(options, values))
+	if err != nil {
+		tflog.Warn(ctx, "Could not snapshot config", map[string]interface{}{"section": section, "error": err.Error()})
+		return
+	}
+	for _, d := range private.SetKey(ctx, configSnapshotKey, snapshot) {
+		tflog.Warn(ctx, "Could not snapshot config", map[string]interface{}{"section": section, "error": d.Detail()})
+	}
+}
+
+// destroyConfig applies a config resource's on_destroy setting to the
+// options in values (a tree as passed to configPayload).
+func destroyConfig(ctx context.Context, c *pihole.Client, section string, onDestroy types.String, values map[string]interface{}, private privateStateGetter, diags *diag.Diagnostics) {
+	var payload map[string]interface{}
+
+	switch onDestroy.ValueString() {
+	case onDestroyResetToDefaults:
+		resetConfig(ctx, c, section, values, diags)
+		return
+
+	case onDestroyRestoreSnapshot:
+		snapshot, d := private.GetKey(ctx, configSnapshotKey)
+		diags.Append(d...)
+		if diags.HasError() {
+			return
+		}
+		if snapshot == nil {
+			diags.AddWarning(
+				"No config snapshot",
+				fmt.Sprintf("No snapshot of the %s config was taken when the resource was created or imported, "+
+					"so the configuration is left as it is.", section),
+			)
+			return
+		}
+		if err := json.Unmarshal(snapshot, &payload); err != nil {
+			diags.AddError("Error restoring config", fmt.Sprintf("Could not decode the %s config snapshot: %s", section, err.Error()))
+			return
+		}
+
+	default:
+		tflog.Debug(ctx, "Removing config from state (config remains in Pi-hole)", map[string]interface{}{"section": section})
+		return
+	}
+
+	tflog.Info(ctx, "Restoring config snapshot on destroy", map[string]interface{}{"section": section})
+	if err := c.UpdateConfig(ctx, section, payload); err != nil {
+		addAPIErrorf(diags, "Error restoring config", err, "Could not update the %s config", section)
+	}
+}
+
+// resetConfig sets the options in values back to Pi-hole's defaults. Options
+// the instance does not know are left out, so resources written for a newer
+// Pi-hole can still be destroyed.
+func resetConfig(ctx context.Context, c *pihole.Client, section string, values map[string]interface{}, diags *diag.Diagnostics) {
+	defaults, err := c.GetConfigDefaults(ctx, section)
+	if err != nil {
+		addAPIErrorf(diags, "Error resetting config", err, "Could not read the defaults of the %s config", section)
+		return
+	}
+
+	var managed, keys []string
+	configLeafKeys(values, "", &managed)
+	for _, key := range managed {
+		if _, ok := defaults[key]; ok {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		// An empty list would reset the whole section.
+		return
+	}
+
+	reset, err := c.ResetConfigSection(ctx, section, keys)
+	if err != nil {
+		addAPIErrorf(diags, "Error resetting config", err, "Could not reset the %s config", section)
+		return
+	}
+	tflog.Info(ctx, "Reset config to defaults on destroy", map[string]interface{}{"section": section, "keys": reset})
+}
+
+// configSnapshotPayload builds a PATCH payload that sets every option in
+// values to its current value. Options Pi-hole does not describe, and options
+// set by environment variables, which the API cannot change, are left out.
+func configSnapshotPayload(options map[string]pihole.ConfigOption, values map[string]interface{}) map[string]interface{} {
+	var keys []string
+	configLeafKeys(values, "", &keys)
+	sort.Strings(keys)
+
+	payload := make(map[string]interface{})
+	for _, key := range keys {
+		option, ok := options[key]
+		if !ok || option.Flags.EnvVar || len(option.Value) == 0 {
+			continue
+		}
+
+		node := payload
+		parts := strings.Split(key, ".")
+		for _, part := range parts[:len(parts)-1] {
+			child, ok := node[part].(map[string]interface{})
+			if !ok {
+				child = make(map[string]interface{})
+				node[part] = child
+			}
+			node = child
+		}
+		node[parts[len(parts)-1]] = option.Value
+	}
+	return payload
+}
+
+// configLeafKeys collects the dotted paths of all leaves of a tree of
+// attribute values, whether they are known or not.
+func configLeafKeys(values map[string]interface{}, prefix string, keys *[]string) {
+	for key, value := range values {
+		name := key
+		if prefix != "" {
+			name = prefix + "." + key
+		}
+		switch v := value.(type) {
+		case map[string]interface{}:
+			configLeafKeys(v, name, keys)
+		case attr.Value:
+			*keys = append(*keys, name)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestConfigSnapshotPayload(t *testing.T) {
+	options := map[string]pihole.ConfigOption{
+		"port":       {Value: json.RawMessage(`5353`), Default: json.RawMessage(`53`)},
+		"cache.size": {Value: json.RawMessage(`20000`), Default: json.RawMessage(`10000`)},
+		"upstreams":  {Value: json.RawMessage(`["1.1.1.1"]`), Default: json.RawMessage(`[]`), Flags: pihole.ConfigOptionFlags{EnvVar: true}},
+		"domain":     {Value: json.RawMessage(`"lan"`), Default: json.RawMessage(`"lan"`)},
+	}
+	values := map[string]interface{}{
+		"port":      types.Int64Value(5353),
+		"upstreams": types.ListNull(types.StringType),
+		"cache": map[string]interface{}{
+			"size": types.Int64Unknown(),
+		},
+		"missing": types.StringValue("x"),
+	}
+
+	got, err := json.Marshal(configSnapshotPayload(options, values))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"cache":{"size":20000},"port":5353}`; string(got) != want {
+		t.Errorf("configSnapshotPayload() = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,24 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// checkConfigImportID adds an error unless the import ID id is the name of
+// the config section a config resource manages. The resources are
+// singletons, so the ID only guards against importing one section into the
+// resource of another, e.g. through a mixed-up import block.
+func checkConfigImportID(id, section string, diags *diag.Diagnostics) {
+	if id == section {
+		return
+	}
+	diags.AddError(
+		"Invalid import ID",
+		fmt.Sprintf("pihole_config_%s manages the %q config section and must be imported with the ID %q, got %q.", section, section, section, id),
+	)
+}
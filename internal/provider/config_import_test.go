@@ -0,0 +1,29 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+func TestCheckConfigImportID(t *testing.T) {
+	tests := []struct {
+		id      string
+		wantErr bool
+	}{
+		{"dns", false},
+		{"dhcp", true},
+		{"DNS", true},
+		{"", true},
+	}
+	for _, tt := range tests {
+		var diags diag.Diagnostics
+		checkConfigImportID(tt.id, "dns", &diags)
+		if diags.HasError() != tt.wantErr {
+			t.Errorf("checkConfigImportID(%q) errors = %v, want error %t", tt.id, diags, tt.wantErr)
+		}
+	}
+}
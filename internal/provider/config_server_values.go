@@ -0,0 +1,61 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// serverValuesAttribute is the read-only mirror of a config section that the
+// config resources expose, so plans and `terraform state show` reveal what
+// Pi-hole actually runs with.
+func serverValuesAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Description: "The section's configuration as Pi-hole reported it at the last read, as JSON. " +
+			"Password hashes are left out.",
+		Computed: true,
+	}
+}
+
+// serverValuesJSON encodes a config section read from Pi-hole for the
+// server_values_json attribute, without password hashes.
+func serverValuesJSON(section interface{}) types.String {
+	encoded, err := json.Marshal(section)
+	if err != nil {
+		return types.StringNull()
+	}
+
+	var values interface{}
+	if err := json.Unmarshal(encoded, &values); err != nil {
+		return types.StringNull()
+	}
+	redactSecrets(values)
+
+	// Maps are encoded with sorted keys, so the value only changes when the
+	// configuration does.
+	encoded, err = json.Marshal(values)
+	if err != nil {
+		return types.StringNull()
+	}
+	return types.StringValue(string(encoded))
+}
+
+// redactSecrets removes password hashes from decoded JSON.
+func redactSecrets(value interface{}) {
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		return
+	}
+	for key, v := range m {
+		if strings.HasSuffix(strings.ToLower(key), "pwhash") {
+			delete(m, key)
+			continue
+		}
+		redactSecrets(v)
+	}
+}
@@ -0,0 +1,31 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+)
+
+func TestServerValuesJSON(t *testing.T) {
+	got := serverValuesJSON(&pihole.WebserverConfig{
+		Port: "80o,443os",
+		API:  &pihole.WebserverAPIConfig{AppPwhash: "$BALLOON-SHA256$v=1$s=1024,t=32$secret"},
+	})
+	if got.IsNull() {
+		t.Fatal("serverValuesJSON() returned null")
+	}
+	if want := `"port":"80o,443os"`; !strings.Contains(got.ValueString(), want) {
+		t.Errorf("serverValuesJSON() = %s, want it to contain %s", got.ValueString(), want)
+	}
+	if strings.Contains(got.ValueString(), "pwhash") || strings.Contains(got.ValueString(), "secret") {
+		t.Errorf("serverValuesJSON() = %s, want password hashes left out", got.ValueString())
+	}
+
+	if got := serverValuesJSON((*pihole.MiscConfig)(nil)); got.ValueString() != "null" {
+		t.Errorf("serverValuesJSON(nil) = %s, want null", got.ValueString())
+	}
+}
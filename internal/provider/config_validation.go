@@ -0,0 +1,216 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// The config resources describe a section as a tree keyed by Pi-hole option
+// names whose leaves are the model's attribute values, e.g.
+//
+//	map[string]interface{}{
+//		"port":  data.Port,
+//		"cache": map[string]interface{}{"size": data.CacheSize},
+//	}
+//
+// configPayload turns such a tree into the body of a PATCH request,
+// configPatch does the same for only the values an update changes, and
+// validateConfigValues checks it against the options Pi-hole reports.
+
+// configPayload converts a tree of attribute values into plain Go values.
+// Null and unknown values are left out, so Pi-hole keeps its current value.
+func configPayload(values map[string]interface{}) map[string]interface{} {
+	payload := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			payload[key] = configPayload(v)
+		case attr.Value:
+			if v.IsNull() || v.IsUnknown() {
+				continue
+			}
+			payload[key] = configValue(v)
+		}
+	}
+	return payload
+}
+
+// configPatch is configPayload restricted to the values in plan that differ
+// from prior, the tree of the resource's state, so an update only sends the
+// options that changed and FTL does not restart subsystems needlessly. With
+// a nil prior it returns the full payload.
+func configPatch(plan, prior map[string]interface{}) map[string]interface{} {
+	payload := make(map[string]interface{})
+	for key, value := range plan {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			priorSection, _ := prior[key].(map[string]interface{})
+			if section := configPatch(v, priorSection); len(section) > 0 || prior == nil {
+				payload[key] = section
+			}
+		case attr.Value:
+			if v.IsNull() || v.IsUnknown() {
+				continue
+			}
+			if p, ok := prior[key].(attr.Value); ok && v.Equal(p) {
+				continue
+			}
+			payload[key] = configValue(v)
+		}
+	}
+	return payload
+}
+
+// configValue converts a known attribute value into the value sent to Pi-hole.
+func configValue(v attr.Value) interface{} {
+	switch v := v.(type) {
+	case types.Bool:
+		return v.ValueBool()
+	case types.Int64:
+		return v.ValueInt64()
+	case types.Float64:
+		return v.ValueFloat64()
+	case types.String:
+		return v.ValueString()
+	case types.List:
+		return stringElements(v.Elements())
+	case types.Set:
+		return stringElements(v.Elements())
+	}
+	return nil
+}
+
+func stringElements(elements []attr.Value) []string {
+	items := make([]string, 0, len(elements))
+	for _, e := range elements {
+		if s, ok := e.(types.String); ok {
+			items = append(items, s.ValueString())
+		}
+	}
+	return items
+}
+
+// flattenConfigPayload returns the leaves of a payload keyed by their dotted
+// path below the section, matching pihole.GetConfigOptions.
+func flattenConfigPayload(payload map[string]interface{}, prefix string, leaves map[string]interface{}) {
+	for key, value := range payload {
+		name := key
+		if prefix != "" {
+			name = prefix + "." + key
+		}
+		if nested, ok := value.(map[string]interface{}); ok {
+			flattenConfigPayload(nested, name, leaves)
+			continue
+		}
+		leaves[name] = value
+	}
+}
+
+// validateConfigValues checks planned values against the allowed values and
+// ranges Pi-hole reports for a config section, so invalid values fail the
+// plan instead of the apply. Values that are not known yet are skipped, and
+// so is the whole check when the instance cannot describe its options.
+func validateConfigValues(ctx context.Context, c *pihole.Client, section string, values map[string]interface{}, diags *diag.Diagnostics) {
+	if c == nil {
+		return
+	}
+
+	options, err := c.GetConfigOptions(ctx, section)
+	if err != nil {
+		tflog.Debug(ctx, "Skipping config validation", map[string]interface{}{"section": section, "error": err.Error()})
+		return
+	}
+
+	leaves := make(map[string]interface{})
+	flattenConfigPayload(configPayload(values), "", leaves)
+
+	keys := make([]string, 0, len(leaves))
+	for key := range leaves {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		option, ok := options[key]
+		if !ok {
+			continue
+		}
+		if reason := checkConfigOption(section+"."+key, option, leaves[key]); reason != "" {
+			diags.AddError(
+				"Invalid Pi-hole configuration value",
+				fmt.Sprintf("%s.%s: %s", section, key, reason),
+			)
+		}
+	}
+}
+
+// checkConfigOption returns why Pi-hole would reject value for option, or an
+// empty string when it would accept it. name is the option's full dotted path.
+func checkConfigOption(name string, option pihole.ConfigOption, value interface{}) string {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+
+	if option.Flags.EnvVar && !sameJSON(encoded, option.Value) {
+		return fmt.Sprintf("the option is set by the environment variable %s and cannot be changed through the API (current value %s)",
+			"FTLCONF_"+strings.ReplaceAll(name, ".", "_"), option.Value)
+	}
+
+	if choices := option.Choices(); len(choices) > 0 {
+		allowed := make([]string, len(choices))
+		for i, choice := range choices {
+			if sameJSON(encoded, choice.Item) {
+				return ""
+			}
+			allowed[i] = strings.Trim(string(choice.Item), `"`)
+		}
+		return fmt.Sprintf("%s is not one of the allowed values: %s", encoded, strings.Join(allowed, ", "))
+	}
+
+	n, ok := value.(int64)
+	if !ok {
+		return ""
+	}
+	var lo, hi int64
+	switch {
+	case strings.Contains(option.Type, "16 bit"):
+		lo, hi = 0, math.MaxUint16
+	case option.Type == "unsigned integer":
+		lo, hi = 0, math.MaxUint32
+	case strings.HasPrefix(option.Type, "unsigned"):
+		lo, hi = 0, math.MaxInt64
+	case option.Type == "integer":
+		lo, hi = math.MinInt32, math.MaxInt32
+	default:
+		return ""
+	}
+	if n < lo || n > hi {
+		return fmt.Sprintf("%d is out of range for %s (%d to %d)", n, option.Type, lo, hi)
+	}
+	return ""
+}
+
+// sameJSON reports whether two JSON documents encode the same value,
+// regardless of key order and string escaping.
+func sameJSON(a, b []byte) bool {
+	var x, y interface{}
+	if json.Unmarshal(a, &x) != nil || json.Unmarshal(b, &y) != nil {
+		return false
+	}
+	return reflect.DeepEqual(x, y)
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestConfigPayload(t *testing.T) {
+	values := map[string]interface{}{
+		"port":    types.Int64Value(53),
+		"dnssec":  types.BoolValue(true),
+		"unknown": types.StringUnknown(),
+		"lines":   types.ListValueMust(types.StringType, []attr.Value{types.StringValue("a"), types.StringValue("b")}),
+		"cache": map[string]interface{}{
+			"size": types.Int64Value(10000),
+			"null": types.Int64Null(),
+		},
+		"temp": map[string]interface{}{
+			"limit": types.Float64Value(62.5),
+		},
+	}
+
+	want := map[string]interface{}{
+		"port":   int64(53),
+		"dnssec": true,
+		"lines":  []string{"a", "b"},
+		"cache": map[string]interface{}{
+			"size": int64(10000),
+		},
+		"temp": map[string]interface{}{
+			"limit": 62.5,
+		},
+	}
+
+	if got := configPayload(values); !reflect.DeepEqual(got, want) {
+		t.Errorf("configPayload() = %#v, want %#v", got, want)
+	}
+}
+
+func TestConfigPatch(t *testing.T) {
+	plan := map[string]interface{}{
+		"port":   types.Int64Value(5353),
+		"dnssec": types.BoolValue(true),
+		"lines":  types.ListValueMust(types.StringType, []attr.Value{types.StringValue("a")}),
+		"cache": map[string]interface{}{
+			"size":      types.Int64Value(10000),
+			"optimizer": types.Int64Unknown(),
+		},
+		"interface": types.StringNull(),
+	}
+	prior := map[string]interface{}{
+		"port":   types.Int64Value(53),
+		"dnssec": types.BoolValue(true),
+		"lines":  types.ListValueMust(types.StringType, []attr.Value{types.StringValue("a")}),
+		"cache": map[string]interface{}{
+			"size":      types.Int64Value(10000),
+			"optimizer": types.Int64Value(3600),
+		},
+		"interface": types.StringValue("eth0"),
+	}
+
+	want := map[string]interface{}{
+		"port": int64(5353),
+	}
+	if got := configPatch(plan, prior); !reflect.DeepEqual(got, want) {
+		t.Errorf("configPatch() = %#v, want %#v", got, want)
+	}
+
+	if got := configPatch(plan, nil); !reflect.DeepEqual(got, configPayload(plan)) {
+		t.Errorf("configPatch() without prior = %#v, want the full payload", got)
+	}
+}
+
+func TestCheckConfigOption(t *testing.T) {
+	enum := pihole.ConfigOption{
+		Type:    "enum (string)",
+		Allowed: json.RawMessage(`[{"item":"NULL","description":""},{"item":"NXDOMAIN","description":""}]`),
+		Value:   json.RawMessage(`"NULL"`),
+	}
+	port := pihole.ConfigOption{
+		Type:    "unsigned integer (16 bit)",
+		Allowed: json.RawMessage(`"Any valid port"`),
+		Value:   json.RawMessage(`53`),
+	}
+	env := port
+	env.Flags.EnvVar = true
+
+	tests := []struct {
+		name   string
+		option pihole.ConfigOption
+		value  interface{}
+		reason string
+	}{
+		{"allowed enum value", enum, "NXDOMAIN", ""},
+		{"unknown enum value", enum, "DROP", "not one of the allowed values: NULL, NXDOMAIN"},
+		{"port in range", port, int64(5353), ""},
+		{"port out of range", port, int64(70000), "out of range"},
+		{"env var unchanged", env, int64(53), ""},
+		{"env var changed", env, int64(5353), "FTLCONF_dns_port"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason := checkConfigOption("dns.port", tt.option, tt.value)
+			if tt.reason == "" && reason != "" {
+				t.Errorf("checkConfigOption() = %q, want no error", reason)
+			}
+			if tt.reason != "" && !strings.Contains(reason, tt.reason) {
+				t.Errorf("checkConfigOption() = %q, want it to contain %q", reason, tt.reason)
+			}
+		})
+	}
+}
+
+func TestSameJSON(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{`{"a": 1, "b": [1, 2]}`, `{"b":[1,2],"a":1}`, true},
+		{`"a\/b"`, `"a/b"`, true},
+		{`"\u00e9"`, `"é"`, true},
+		{`1.0`, `1`, true},
+		{`[1, 2]`, `[2, 1]`, false},
+		{`"a"`, `"b"`, false},
+		{`{"a": 1}`, `not json`, false},
+	}
+	for _, tt := range tests {
+		if got := sameJSON([]byte(tt.a), []byte(tt.b)); got != tt.want {
+			t.Errorf("sameJSON(%s, %s) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
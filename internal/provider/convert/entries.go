@@ -0,0 +1,528 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Pi-hole stores local DNS records, CNAMEs, reverse servers, static leases
+// and extra dnsmasq options as plain strings in config arrays (dns.hosts,
+// dns.cnameRecords, dns.revServers, dhcp.hosts, misc.dnsmasq_lines). FTL
+// and users editing pihole.toml may change case, spacing or MAC notation, so
+// lookups compare parsed, canonical values and return the raw line as
+// stored. The raw line is what the config array endpoints expect on delete.
+
+// ParseHostsLine splits a hosts-file line into its IP and hostnames. It
+// returns false for blank and comment-only lines.
+func ParseHostsLine(line string) (string, []string, bool) {
+	if i := strings.IndexByte(line, '#'); i >= 0 {
+		line = line[:i]
+	}
+
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return "", nil, false
+	}
+
+	return fields[0], fields[1:], true
+}
+
+// FindHostsLine returns the dns.hosts line that maps hostname to ip. Lines
+// may carry several hostnames ("IP host1 host2").
+func FindHostsLine(hosts []string, ip, hostname string) (string, bool) {
+	for _, line := range hosts {
+		lineIP, hostnames, ok := ParseHostsLine(line)
+		if !ok || !SameIP(lineIP, ip) {
+			continue
+		}
+		for _, h := range hostnames {
+			if SameHostname(h, hostname) {
+				return line, true
+			}
+		}
+	}
+	return "", false
+}
+
+// RemoveHostname drops hostname from a hosts line, returning "" when no
+// other hostname is left.
+func RemoveHostname(line, hostname string) string {
+	ip, hostnames, ok := ParseHostsLine(line)
+	if !ok {
+		return ""
+	}
+
+	remaining := []string{ip}
+	for _, h := range hostnames {
+		if !SameHostname(h, hostname) {
+			remaining = append(remaining, h)
+		}
+	}
+	if len(remaining) == 1 {
+		return ""
+	}
+	return strings.Join(remaining, " ")
+}
+
+// ParseCNAMERecord splits a "domain,target[,ttl]" dns.cnameRecords entry.
+func ParseCNAMERecord(line string) (string, string, bool) {
+	fields := strings.Split(line, ",")
+	if len(fields) < 2 {
+		return "", "", false
+	}
+	return strings.TrimSpace(fields[0]), strings.TrimSpace(fields[1]), true
+}
+
+// FindCNAMERecord returns the dns.cnameRecords entry for domain -> target.
+func FindCNAMERecord(records []string, domain, target string) (string, bool) {
+	for _, line := range records {
+		d, t, ok := ParseCNAMERecord(line)
+		if ok && SameHostname(d, domain) && SameHostname(t, target) {
+			return line, true
+		}
+	}
+	return "", false
+}
+
+// ReverseName returns the in-addr.arpa or ip6.arpa name of an IP address.
+func ReverseName(ip string) (string, bool) {
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return "", false
+	}
+	if v4 := addr.To4(); v4 != nil {
+		return fmt.Sprintf("%d.%d.%d.%d.in-addr.arpa", v4[3], v4[2], v4[1], v4[0]), true
+	}
+
+	var b strings.Builder
+	for i := len(addr) - 1; i >= 0; i-- {
+		fmt.Fprintf(&b, "%x.%x.", addr[i]&0x0f, addr[i]>>4)
+	}
+	b.WriteString("ip6.arpa")
+	return b.String(), true
+}
+
+// ParseDnsmasqLine splits a dnsmasq "option=value" line into the lower-case
+// option name and its value.
+func ParseDnsmasqLine(line string) (string, string, bool) {
+	key, value, ok := strings.Cut(strings.TrimSpace(line), "=")
+	if !ok {
+		return "", "", false
+	}
+	return strings.ToLower(strings.TrimSpace(key)), strings.TrimSpace(value), true
+}
+
+// ParsePTRRecord splits a dnsmasq "ptr-record=name,target" line.
+func ParsePTRRecord(line string) (string, string, bool) {
+	key, value, ok := ParseDnsmasqLine(line)
+	if !ok || key != "ptr-record" {
+		return "", "", false
+	}
+	name, target, ok := strings.Cut(value, ",")
+	if !ok {
+		return "", "", false
+	}
+	return strings.TrimSpace(name), strings.TrimSpace(target), true
+}
+
+// FindPTRRecord returns the misc.dnsmasq_lines entry that points the reverse
+// name of ip at hostname.
+func FindPTRRecord(lines []string, ip, hostname string) (string, bool) {
+	reverse, ok := ReverseName(ip)
+	if !ok {
+		return "", false
+	}
+	for _, line := range lines {
+		name, target, ok := ParsePTRRecord(line)
+		if ok && SameHostname(name, reverse) && SameHostname(target, hostname) {
+			return line, true
+		}
+	}
+	return "", false
+}
+
+// DnsmasqServer holds the fields of a dnsmasq "server=/domain/ip[#port]"
+// line that forwards a single domain.
+type DnsmasqServer struct {
+	Domain string
+	Server string
+	Port   int64
+}
+
+// ParseServerLine parses a server= line. Lines for several domains or
+// without a domain are not reported.
+func ParseServerLine(line string) (DnsmasqServer, bool) {
+	key, value, ok := ParseDnsmasqLine(line)
+	if !ok || key != "server" || !strings.HasPrefix(value, "/") {
+		return DnsmasqServer{}, false
+	}
+	parts := strings.Split(value[1:], "/")
+	if len(parts) != 2 || parts[0] == "" {
+		return DnsmasqServer{}, false
+	}
+
+	server := DnsmasqServer{Domain: parts[0], Server: parts[1], Port: 53}
+	if host, port, ok := strings.Cut(parts[1], "#"); ok {
+		p, err := strconv.ParseInt(port, 10, 64)
+		if err != nil {
+			return DnsmasqServer{}, false
+		}
+		server.Server, server.Port = host, p
+	}
+	return server, true
+}
+
+// String formats the server= line, leaving out the default port.
+func (s DnsmasqServer) String() string {
+	line := fmt.Sprintf("server=/%s/%s", s.Domain, s.Server)
+	if s.Port != 53 {
+		line += fmt.Sprintf("#%d", s.Port)
+	}
+	return line
+}
+
+// FindServerLine returns the misc.dnsmasq_lines entry that forwards the same
+// domain to the same server and port as want.
+func FindServerLine(lines []string, want DnsmasqServer) (string, bool) {
+	for _, line := range lines {
+		s, ok := ParseServerLine(line)
+		if ok && SameHostname(s.Domain, want.Domain) && SameIP(s.Server, want.Server) && s.Port == want.Port {
+			return line, true
+		}
+	}
+	return "", false
+}
+
+// RevServer holds the fields of a dns.revServers entry,
+// "<enabled>,<network>,<server>[#<port>][,<domain>]". FTL forwards the
+// reverse lookups for the network, and the names in the domain, to the
+// server.
+type RevServer struct {
+	Enabled bool
+	Network string
+	Server  string
+	Port    int64
+	Domain  string
+}
+
+// ParseRevServer parses a dns.revServers entry.
+func ParseRevServer(entry string) (RevServer, bool) {
+	parts := strings.Split(entry, ",")
+	if len(parts) < 3 || len(parts) > 4 {
+		return RevServer{}, false
+	}
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+	enabled, err := strconv.ParseBool(parts[0])
+	if err != nil || parts[1] == "" || parts[2] == "" {
+		return RevServer{}, false
+	}
+
+	s := RevServer{Enabled: enabled, Network: parts[1], Server: parts[2], Port: 53}
+	if host, port, ok := strings.Cut(parts[2], "#"); ok {
+		p, err := strconv.ParseInt(port, 10, 64)
+		if err != nil || host == "" {
+			return RevServer{}, false
+		}
+		s.Server, s.Port = host, p
+	}
+	if len(parts) == 4 {
+		s.Domain = parts[3]
+	}
+	return s, true
+}
+
+// String formats the dns.revServers entry, leaving out the default port.
+func (s RevServer) String() string {
+	entry := fmt.Sprintf("%t,%s,%s", s.Enabled, s.Network, s.Server)
+	if s.Port != 53 {
+		entry += fmt.Sprintf("#%d", s.Port)
+	}
+	if s.Domain != "" {
+		entry += "," + s.Domain
+	}
+	return entry
+}
+
+// FindRevServer returns the enabled dns.revServers entry that forwards the
+// same network to the same server and port as want.
+func FindRevServer(entries []string, want RevServer) (string, bool) {
+	for _, entry := range entries {
+		s, ok := ParseRevServer(entry)
+		if ok && s.Enabled && SameNetwork(s.Network, want.Network) && SameIP(s.Server, want.Server) && s.Port == want.Port {
+			return entry, true
+		}
+	}
+	return "", false
+}
+
+// SameNetwork compares two CIDR networks, ignoring host bits and different
+// notations of the same IPv6 address. Unparsable values are compared
+// literally.
+func SameNetwork(a, b string) bool {
+	prefixA, errA := netip.ParsePrefix(a)
+	prefixB, errB := netip.ParsePrefix(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	return prefixA.Masked() == prefixB.Masked()
+}
+
+// ReverseZoneNetwork returns the network of a reverse zone given as a CIDR
+// network, e.g. "192.168.0.0/16", or as its in-addr.arpa or ip6.arpa name,
+// e.g. "168.192.in-addr.arpa". It returns false for other names.
+func ReverseZoneNetwork(zone string) (string, bool) {
+	if prefix, err := netip.ParsePrefix(zone); err == nil {
+		return prefix.Masked().String(), true
+	}
+
+	name := strings.ToLower(strings.TrimSuffix(zone, "."))
+	if rest, ok := strings.CutSuffix(name, ".in-addr.arpa"); ok {
+		labels := strings.Split(rest, ".")
+		if len(labels) > 4 {
+			return "", false
+		}
+		var ip [4]byte
+		for i, label := range labels {
+			n, err := strconv.ParseUint(label, 10, 8)
+			if err != nil || (len(label) > 1 && label[0] == '0') {
+				return "", false
+			}
+			ip[len(labels)-1-i] = byte(n)
+		}
+		return netip.PrefixFrom(netip.AddrFrom4(ip), 8*len(labels)).String(), true
+	}
+	if rest, ok := strings.CutSuffix(name, ".ip6.arpa"); ok {
+		nibbles := strings.Split(rest, ".")
+		if len(nibbles) > 32 {
+			return "", false
+		}
+		var ip [16]byte
+		for i, nibble := range nibbles {
+			n, err := strconv.ParseUint(nibble, 16, 4)
+			if err != nil || len(nibble) != 1 {
+				return "", false
+			}
+			pos := len(nibbles) - 1 - i
+			if pos%2 == 0 {
+				ip[pos/2] |= byte(n) << 4
+			} else {
+				ip[pos/2] |= byte(n)
+			}
+		}
+		return netip.PrefixFrom(netip.AddrFrom16(ip), 4*len(nibbles)).String(), true
+	}
+	return "", false
+}
+
+// DHCPOption holds the fields of a dnsmasq
+// "dhcp-option=[tag:<tag>,]<option>[,<value>...]" line.
+type DHCPOption struct {
+	Tag    string
+	Option string
+	Values []string
+}
+
+// ParseDHCPOptionLine parses a dhcp-option= line. Lines with more than one
+// tag are not reported.
+func ParseDHCPOptionLine(line string) (DHCPOption, bool) {
+	key, value, ok := ParseDnsmasqLine(line)
+	if !ok || key != "dhcp-option" {
+		return DHCPOption{}, false
+	}
+
+	var option DHCPOption
+	fields := strings.Split(value, ",")
+	for i, field := range fields {
+		field = strings.TrimSpace(field)
+		if strings.HasPrefix(field, "tag:") {
+			if option.Tag != "" {
+				return DHCPOption{}, false
+			}
+			option.Tag = strings.TrimPrefix(field, "tag:")
+			continue
+		}
+
+		option.Option = strings.TrimPrefix(field, "option:")
+		for _, v := range fields[i+1:] {
+			option.Values = append(option.Values, strings.TrimSpace(v))
+		}
+		return option, option.Option != ""
+	}
+	return DHCPOption{}, false
+}
+
+// String formats the dhcp-option= line. Option names get the option: prefix
+// dnsmasq requires for them; numbers and other prefixes are kept as they are.
+func (o DHCPOption) String() string {
+	var fields []string
+	if o.Tag != "" {
+		fields = append(fields, "tag:"+o.Tag)
+	}
+	option := o.Option
+	if _, err := strconv.Atoi(option); err != nil && !strings.Contains(option, ":") {
+		option = "option:" + option
+	}
+	fields = append(fields, option)
+	fields = append(fields, o.Values...)
+	return "dhcp-option=" + strings.Join(fields, ",")
+}
+
+// FindDHCPOptionLine returns the misc.dnsmasq_lines entry that sets the same
+// option to the same values for the same tag as want.
+func FindDHCPOptionLine(lines []string, want DHCPOption) (string, bool) {
+	for _, line := range lines {
+		o, ok := ParseDHCPOptionLine(line)
+		if ok && strings.EqualFold(o.Tag, want.Tag) && strings.EqualFold(o.Option, want.Option) &&
+			strings.Join(o.Values, ",") == strings.Join(want.Values, ",") {
+			return line, true
+		}
+	}
+	return "", false
+}
+
+// DHCPHost holds the parsed fields of a dnsmasq dhcp-host style entry.
+type DHCPHost struct {
+	MAC       string
+	IP        string
+	Hostname  string
+	LeaseTime string
+}
+
+// ParseDHCPHost parses a dhcp.hosts entry. dnsmasq accepts the fields in any
+// order, so each one is classified by its shape; tags are ignored.
+func ParseDHCPHost(line string) DHCPHost {
+	var host DHCPHost
+	for _, field := range strings.Split(line, ",") {
+		field = strings.TrimSpace(field)
+		switch {
+		case field == "":
+		case host.MAC == "" && IsMAC(field):
+			host.MAC = field
+		case host.IP == "" && net.ParseIP(strings.Trim(field, "[]")) != nil:
+			host.IP = strings.Trim(field, "[]")
+		case host.LeaseTime == "" && IsLeaseTime(field):
+			host.LeaseTime = field
+		case strings.Contains(field, ":"):
+			// tagged option (id:, set:, tag:)
+		case host.Hostname == "":
+			host.Hostname = field
+		}
+	}
+	return host
+}
+
+// String formats the lease as "MAC[,IP][,hostname][,lease_time]", leaving out
+// empty fields.
+func (h DHCPHost) String() string {
+	fields := []string{h.MAC}
+	for _, f := range []string{h.IP, h.Hostname, h.LeaseTime} {
+		if f != "" {
+			fields = append(fields, f)
+		}
+	}
+	return strings.Join(fields, ",")
+}
+
+// FindDHCPHost returns the dhcp.hosts entry for the given lease. Empty ip or
+// hostname only match entries without that field.
+func FindDHCPHost(hosts []string, mac, ip, hostname string) (string, bool) {
+	for _, line := range hosts {
+		h := ParseDHCPHost(line)
+		if SameMAC(h.MAC, mac) && SameIP(h.IP, ip) && SameHostname(h.Hostname, hostname) {
+			return line, true
+		}
+	}
+	return "", false
+}
+
+// SameIP compares two IP addresses, tolerating different notations of the
+// same IPv6 address. Unparsable values are compared literally.
+func SameIP(a, b string) bool {
+	ipA, ipB := net.ParseIP(a), net.ParseIP(b)
+	if ipA == nil || ipB == nil {
+		return a == b
+	}
+	return ipA.Equal(ipB)
+}
+
+// SameHostname compares DNS names case-insensitively, ignoring a trailing dot.
+func SameHostname(a, b string) bool {
+	return strings.EqualFold(strings.TrimSuffix(a, "."), strings.TrimSuffix(b, "."))
+}
+
+// SameMAC compares MAC addresses regardless of case and separator.
+func SameMAC(a, b string) bool {
+	return NormalizeMAC(a) == NormalizeMAC(b)
+}
+
+// NormalizeMAC returns the lowercase, colon-separated form of a MAC address,
+// or the input unchanged if it cannot be parsed.
+func NormalizeMAC(mac string) string {
+	hw, err := net.ParseMAC(mac)
+	if err != nil {
+		return mac
+	}
+	return hw.String()
+}
+
+// NormalizeListAddress returns the canonical form of a list address: scheme
+// and host in lowercase, without a default port and without trailing
+// slashes. Path and query keep their case, since servers may not ignore it.
+// Addresses that are not absolute URLs only lose their trailing slashes.
+func NormalizeListAddress(address string) string {
+	address = strings.TrimSpace(address)
+	u, err := url.Parse(address)
+	if err != nil || u.Scheme == "" || u.Opaque != "" {
+		return strings.TrimRight(address, "/")
+	}
+
+	u.Scheme = strings.ToLower(u.Scheme)
+	host, port := strings.ToLower(u.Hostname()), u.Port()
+	if (u.Scheme == "http" && port == "80") || (u.Scheme == "https" && port == "443") {
+		port = ""
+	}
+	if strings.Contains(host, ":") {
+		host = "[" + host + "]"
+	}
+	if port != "" {
+		host += ":" + port
+	}
+	u.Host = host
+	u.Path = strings.TrimRight(u.Path, "/")
+	u.RawPath = strings.TrimRight(u.RawPath, "/")
+	if u.RawQuery == "" && u.Fragment == "" {
+		u.ForceQuery = false
+	}
+	return u.String()
+}
+
+// SameListAddress compares list addresses by their canonical form.
+func SameListAddress(a, b string) bool {
+	return NormalizeListAddress(a) == NormalizeListAddress(b)
+}
+
+// IsMAC reports whether s is a MAC address in any notation net.ParseMAC
+// accepts.
+func IsMAC(s string) bool {
+	_, err := net.ParseMAC(s)
+	return err == nil
+}
+
+// LeaseTimeRegexp matches dnsmasq lease times such as "infinite", "3600" or
+// "12h".
+var LeaseTimeRegexp = regexp.MustCompile(`^(infinite|[0-9]+[smhdw]?)$`)
+
+// IsLeaseTime reports whether s is a dnsmasq lease time.
+func IsLeaseTime(s string) bool {
+	return LeaseTimeRegexp.MatchString(s)
+}
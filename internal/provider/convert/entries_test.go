@@ -0,0 +1,316 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import "testing"
+
+func TestFindHostsLine(t *testing.T) {
+	hosts := []string{
+		"192.168.1.10 NAS.lan nas",
+		"fd00::0:1  router.lan.",
+	}
+
+	tests := []struct {
+		name     string
+		ip       string
+		hostname string
+		want     string
+		found    bool
+	}{
+		{"exact", "192.168.1.10", "nas", hosts[0], true},
+		{"case", "192.168.1.10", "nas.lan", hosts[0], true},
+		{"ipv6 notation and trailing dot", "fd00::1", "router.lan", hosts[1], true},
+		{"wrong ip", "192.168.1.11", "nas", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := FindHostsLine(hosts, tt.ip, tt.hostname)
+			if got != tt.want || found != tt.found {
+				t.Errorf("FindHostsLine() = %q, %v; want %q, %v", got, found, tt.want, tt.found)
+			}
+		})
+	}
+}
+
+func TestRemoveHostname(t *testing.T) {
+	if got := RemoveHostname("192.168.1.10 NAS.lan nas", "nas.lan"); got != "192.168.1.10 nas" {
+		t.Errorf("RemoveHostname() = %q", got)
+	}
+	if got := RemoveHostname("192.168.1.10 nas", "NAS"); got != "" {
+		t.Errorf("RemoveHostname() = %q, want empty", got)
+	}
+}
+
+func TestFindCNAMERecord(t *testing.T) {
+	records := []string{"Alias.lan, target.lan", "www.lan,web.lan,300"}
+
+	if got, found := FindCNAMERecord(records, "alias.lan", "TARGET.lan"); !found || got != records[0] {
+		t.Errorf("FindCNAMERecord() = %q, %v", got, found)
+	}
+	if got, found := FindCNAMERecord(records, "www.lan", "web.lan"); !found || got != records[1] {
+		t.Errorf("FindCNAMERecord() with ttl = %q, %v", got, found)
+	}
+	if _, found := FindCNAMERecord(records, "alias.lan", "other.lan"); found {
+		t.Error("FindCNAMERecord() matched a different target")
+	}
+}
+
+func TestReverseName(t *testing.T) {
+	tests := map[string]string{
+		"192.168.1.10": "10.1.168.192.in-addr.arpa",
+		"2001:db8::1":  "1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa",
+	}
+	for ip, want := range tests {
+		if got, ok := ReverseName(ip); !ok || got != want {
+			t.Errorf("ReverseName(%q) = %q, %v, want %q", ip, got, ok, want)
+		}
+	}
+	if _, ok := ReverseName("nas.lan"); ok {
+		t.Error("ReverseName() accepted a hostname")
+	}
+}
+
+func TestFindPTRRecord(t *testing.T) {
+	lines := []string{
+		"server=/corp.lan/10.0.0.1",
+		"PTR-Record = 10.1.168.192.in-addr.arpa., NAS.lan",
+		"ptr-record=1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa,router.lan",
+	}
+
+	if got, found := FindPTRRecord(lines, "192.168.1.10", "nas.lan"); !found || got != lines[1] {
+		t.Errorf("FindPTRRecord() = %q, %v", got, found)
+	}
+	if got, found := FindPTRRecord(lines, "2001:db8:0::1", "router.lan"); !found || got != lines[2] {
+		t.Errorf("FindPTRRecord() ipv6 = %q, %v", got, found)
+	}
+	if _, found := FindPTRRecord(lines, "192.168.1.10", "other.lan"); found {
+		t.Error("FindPTRRecord() matched a different hostname")
+	}
+	if _, found := FindPTRRecord(lines, "10.0.0.1", "corp.lan"); found {
+		t.Error("FindPTRRecord() matched a non-PTR line")
+	}
+}
+
+func TestFindServerLine(t *testing.T) {
+	lines := []string{
+		"address=/ads.lan/0.0.0.0",
+		"Server = /Corp.Example.com/10.0.0.1",
+		"server=/consul/127.0.0.1#8600",
+		"server=/a.lan/b.lan/10.0.0.2",
+	}
+
+	tests := []struct {
+		name  string
+		want  DnsmasqServer
+		line  string
+		found bool
+	}{
+		{"case and spacing", DnsmasqServer{"corp.example.com", "10.0.0.1", 53}, lines[1], true},
+		{"port", DnsmasqServer{"consul", "127.0.0.1", 8600}, lines[2], true},
+		{"different port", DnsmasqServer{"consul", "127.0.0.1", 53}, "", false},
+		{"several domains", DnsmasqServer{"a.lan", "10.0.0.2", 53}, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			line, found := FindServerLine(lines, tt.want)
+			if line != tt.line || found != tt.found {
+				t.Errorf("FindServerLine() = %q, %v, want %q, %v", line, found, tt.line, tt.found)
+			}
+		})
+	}
+
+	if got := (DnsmasqServer{"consul", "127.0.0.1", 8600}).String(); got != lines[2] {
+		t.Errorf("String() = %q", got)
+	}
+	if got := (DnsmasqServer{"corp.lan", "10.0.0.1", 53}).String(); got != "server=/corp.lan/10.0.0.1" {
+		t.Errorf("String() with default port = %q", got)
+	}
+}
+
+func TestFindRevServer(t *testing.T) {
+	entries := []string{
+		"true,192.168.0.0/16,192.168.0.1,fritz.box",
+		"false,10.0.0.0/8,10.0.0.1",
+		"true, 10.8.0.0/24 , 10.8.0.1#5353",
+		"garbage",
+	}
+
+	tests := []struct {
+		name  string
+		want  RevServer
+		entry string
+		found bool
+	}{
+		{"with domain", RevServer{Network: "192.168.0.0/16", Server: "192.168.0.1", Port: 53}, entries[0], true},
+		{"host bits", RevServer{Network: "192.168.1.0/16", Server: "192.168.0.1", Port: 53}, entries[0], true},
+		{"disabled", RevServer{Network: "10.0.0.0/8", Server: "10.0.0.1", Port: 53}, "", false},
+		{"port and spacing", RevServer{Network: "10.8.0.0/24", Server: "10.8.0.1", Port: 5353}, entries[2], true},
+		{"different port", RevServer{Network: "10.8.0.0/24", Server: "10.8.0.1", Port: 53}, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry, found := FindRevServer(entries, tt.want)
+			if entry != tt.entry || found != tt.found {
+				t.Errorf("FindRevServer() = %q, %v, want %q, %v", entry, found, tt.entry, tt.found)
+			}
+		})
+	}
+
+	s, ok := ParseRevServer(entries[0])
+	if !ok || s != (RevServer{true, "192.168.0.0/16", "192.168.0.1", 53, "fritz.box"}) {
+		t.Errorf("ParseRevServer() = %+v, %v", s, ok)
+	}
+	if got := s.String(); got != entries[0] {
+		t.Errorf("String() = %q", got)
+	}
+	if got := (RevServer{true, "10.8.0.0/24", "10.8.0.1", 5353, ""}).String(); got != "true,10.8.0.0/24,10.8.0.1#5353" {
+		t.Errorf("String() with port = %q", got)
+	}
+}
+
+func TestReverseZoneNetwork(t *testing.T) {
+	tests := map[string]string{
+		"192.168.0.0/16":           "192.168.0.0/16",
+		"192.168.1.7/24":           "192.168.1.0/24",
+		"168.192.in-addr.arpa":     "192.168.0.0/16",
+		"10.IN-ADDR.ARPA.":         "10.0.0.0/8",
+		"1.168.192.in-addr.arpa":   "192.168.1.0/24",
+		"8.b.d.0.1.0.0.2.ip6.arpa": "2001:db8::/32",
+		"fd00::/8":                 "fd00::/8",
+	}
+	for zone, want := range tests {
+		if got, ok := ReverseZoneNetwork(zone); !ok || got != want {
+			t.Errorf("ReverseZoneNetwork(%q) = %q, %v, want %q", zone, got, ok, want)
+		}
+	}
+
+	for _, zone := range []string{"corp.example", "10.0.0.1", "256.in-addr.arpa", "1.2.3.4.5.in-addr.arpa", "01.in-addr.arpa", "g.ip6.arpa", "in-addr.arpa"} {
+		if got, ok := ReverseZoneNetwork(zone); ok {
+			t.Errorf("ReverseZoneNetwork(%q) = %q, want no reverse zone", zone, got)
+		}
+	}
+}
+
+func TestFindDHCPOptionLine(t *testing.T) {
+	lines := []string{
+		"dhcp-option=option:ntp-server,192.168.1.1",
+		"dhcp-option = tag:kids, option:dns-server, 192.168.1.53",
+		"dhcp-option=119,lan,home.arpa",
+		"dhcp-option=tag:a,tag:b,3,10.0.0.1",
+	}
+
+	tests := []struct {
+		name  string
+		want  DHCPOption
+		line  string
+		found bool
+	}{
+		{"name", DHCPOption{Option: "ntp-server", Values: []string{"192.168.1.1"}}, lines[0], true},
+		{"tag and spacing", DHCPOption{Tag: "kids", Option: "dns-server", Values: []string{"192.168.1.53"}}, lines[1], true},
+		{"number", DHCPOption{Option: "119", Values: []string{"lan", "home.arpa"}}, lines[2], true},
+		{"missing tag", DHCPOption{Option: "dns-server", Values: []string{"192.168.1.53"}}, "", false},
+		{"different values", DHCPOption{Option: "119", Values: []string{"lan"}}, "", false},
+		{"several tags", DHCPOption{Tag: "a", Option: "3", Values: []string{"10.0.0.1"}}, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			line, found := FindDHCPOptionLine(lines, tt.want)
+			if line != tt.line || found != tt.found {
+				t.Errorf("FindDHCPOptionLine() = %q, %v, want %q, %v", line, found, tt.line, tt.found)
+			}
+		})
+	}
+
+	if got := (DHCPOption{Tag: "kids", Option: "dns-server", Values: []string{"192.168.1.53"}}).String(); got != "dhcp-option=tag:kids,option:dns-server,192.168.1.53" {
+		t.Errorf("String() = %q", got)
+	}
+	if got := (DHCPOption{Option: "252"}).String(); got != "dhcp-option=252" {
+		t.Errorf("String() without values = %q", got)
+	}
+}
+
+func TestFindDHCPHost(t *testing.T) {
+	hosts := []string{
+		"AA-BB-CC-DD-EE-FF, 192.168.1.50, Printer",
+		"192.168.1.60,11:22:33:44:55:66,laptop,infinite",
+	}
+
+	tests := []struct {
+		name     string
+		mac      string
+		ip       string
+		hostname string
+		want     string
+		found    bool
+	}{
+		{"mac notation and case", "aa:bb:cc:dd:ee:ff", "192.168.1.50", "printer", hosts[0], true},
+		{"field order and lease time", "11:22:33:44:55:66", "192.168.1.60", "laptop", hosts[1], true},
+		{"different ip", "aa:bb:cc:dd:ee:ff", "192.168.1.51", "printer", "", false},
+		{"missing ip", "11:22:33:44:55:66", "", "laptop", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := FindDHCPHost(hosts, tt.mac, tt.ip, tt.hostname)
+			if got != tt.want || found != tt.found {
+				t.Errorf("FindDHCPHost() = %q, %v; want %q, %v", got, found, tt.want, tt.found)
+			}
+		})
+	}
+}
+
+func TestNormalizeMAC(t *testing.T) {
+	tests := map[string]string{
+		"AA:BB:CC:DD:EE:FF": "aa:bb:cc:dd:ee:ff",
+		"aa-bb-cc-dd-ee-ff": "aa:bb:cc:dd:ee:ff",
+		"not-a-mac":         "not-a-mac",
+	}
+	for in, want := range tests {
+		if got := NormalizeMAC(in); got != want {
+			t.Errorf("NormalizeMAC(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestNormalizeListAddress(t *testing.T) {
+	tests := map[string]string{
+		"https://big.oisd.nl":                   "https://big.oisd.nl",
+		"https://big.oisd.nl/":                  "https://big.oisd.nl",
+		"HTTPS://Example.COM/Lists/Ads.txt":     "https://example.com/Lists/Ads.txt",
+		"https://example.com:443/ads.txt":       "https://example.com/ads.txt",
+		"http://example.com:8080/ads.txt//":     "http://example.com:8080/ads.txt",
+		"https://example.com/ads.txt?Token=AbC": "https://example.com/ads.txt?Token=AbC",
+		"http://[FD00::53]:80/hosts":            "http://[fd00::53]/hosts",
+		"file:///etc/pihole/local.list":         "file:///etc/pihole/local.list",
+		"  https://example.com/a%2Fb/  ":        "https://example.com/a%2Fb",
+		"not a url/":                            "not a url",
+	}
+	for in, want := range tests {
+		if got := NormalizeListAddress(in); got != want {
+			t.Errorf("NormalizeListAddress(%q) = %q, want %q", in, got, want)
+		}
+	}
+
+	if !SameListAddress("https://Example.com/ads.txt/", "https://example.com/ads.txt") {
+		t.Error("SameListAddress() = false for addresses differing in host case and trailing slash")
+	}
+	if SameListAddress("https://example.com/Ads.txt", "https://example.com/ads.txt") {
+		t.Error("SameListAddress() = true for addresses differing in path case")
+	}
+}
+
+func TestDHCPHostString(t *testing.T) {
+	tests := map[string]string{
+		"AA:BB:CC:DD:EE:FF,192.168.1.50,printer":  "AA:BB:CC:DD:EE:FF,192.168.1.50,printer",
+		"AA:BB:CC:DD:EE:FF,192.168.1.50":          "AA:BB:CC:DD:EE:FF,192.168.1.50",
+		"AA:BB:CC:DD:EE:FF, laptop, 12h":          "AA:BB:CC:DD:EE:FF,laptop,12h",
+		"infinite,192.168.1.50,AA:BB:CC:DD:EE:FF": "AA:BB:CC:DD:EE:FF,192.168.1.50,infinite",
+	}
+	for in, want := range tests {
+		if got := ParseDHCPHost(in).String(); got != want {
+			t.Errorf("ParseDHCPHost(%q).String() = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// The fuzz tests check that every line a parser accepts formats back to a
+// line that parses to the same fields, so an update never writes an entry
+// the next read cannot find.
+
+func FuzzParseHostsLine(f *testing.F) {
+	for _, seed := range []string{
+		"192.168.1.10 nas.lan nas",
+		"fd00::1\trouter.lan. # gateway",
+		"# comment",
+		"",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, line string) {
+		ip, hostnames, ok := ParseHostsLine(line)
+		if !ok {
+			return
+		}
+
+		formatted := strings.Join(append([]string{ip}, hostnames...), " ")
+		ip2, hostnames2, ok := ParseHostsLine(formatted)
+		if !ok || ip2 != ip || !reflect.DeepEqual(hostnames2, hostnames) {
+			t.Fatalf("%q formatted as %q parses to %q %q", line, formatted, ip2, hostnames2)
+		}
+
+		for _, h := range hostnames {
+			if rest := RemoveHostname(line, h); rest != "" {
+				if _, found := FindHostsLine([]string{rest}, ip, h); found {
+					t.Fatalf("RemoveHostname(%q, %q) = %q still maps the hostname", line, h, rest)
+				}
+			}
+		}
+	})
+}
+
+func FuzzParseCNAMERecord(f *testing.F) {
+	for _, seed := range []string{
+		"www.lan,nas.lan",
+		"WWW.lan , nas.lan. ,300",
+		"nocomma",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, line string) {
+		domain, target, ok := ParseCNAMERecord(line)
+		if !ok {
+			return
+		}
+
+		formatted := domain + "," + target
+		domain2, target2, ok := ParseCNAMERecord(formatted)
+		if !ok || domain2 != domain || target2 != target {
+			t.Fatalf("%q formatted as %q parses to %q, %q", line, formatted, domain2, target2)
+		}
+		if got, found := FindCNAMERecord([]string{line}, domain, target); !found || got != line {
+			t.Fatalf("FindCNAMERecord() does not find %q", line)
+		}
+	})
+}
+
+func FuzzParseDHCPHost(f *testing.F) {
+	for _, seed := range []string{
+		"AA-BB-CC-DD-EE-FF, 192.168.1.50, Printer",
+		"192.168.1.60,11:22:33:44:55:66,laptop,infinite",
+		"aa:bb:cc:dd:ee:ff,[fd00::50],set:kids,12h",
+		"",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, line string) {
+		host := ParseDHCPHost(line)
+		if host.MAC == "" || IsLeaseTime(host.Hostname) {
+			// Without a MAC the resource cannot own the entry; a hostname
+			// that looks like a lease time is ambiguous to dnsmasq, too.
+			return
+		}
+
+		formatted := host.String()
+		if got := ParseDHCPHost(formatted); got != host {
+			t.Fatalf("%q formatted as %q parses to %+v, want %+v", line, formatted, got, host)
+		}
+		if _, found := FindDHCPHost([]string{line}, host.MAC, host.IP, host.Hostname); !found {
+			t.Fatalf("FindDHCPHost() does not find %q", line)
+		}
+	})
+}
+
+func FuzzParseServerLine(f *testing.F) {
+	for _, seed := range []string{
+		"server=/corp.example.com/10.0.0.1",
+		"Server = /consul/127.0.0.1#8600",
+		"server=/a/b/1.1.1.1",
+		"server=1.1.1.1",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, line string) {
+		server, ok := ParseServerLine(line)
+		if !ok {
+			return
+		}
+
+		formatted := server.String()
+		if got, ok := ParseServerLine(formatted); !ok || got != server {
+			t.Fatalf("%q formatted as %q parses to %+v, want %+v", line, formatted, got, server)
+		}
+	})
+}
+
+func FuzzParseDHCPOptionLine(f *testing.F) {
+	for _, seed := range []string{
+		"dhcp-option=option:ntp-server,192.168.1.1",
+		"dhcp-option = tag:kids, 6, 192.168.1.53",
+		"dhcp-option=tag:a,tag:b,3",
+		"dhcp-option=option6:dns-server,[fd00::1]",
+		"dhcp-option=252",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, line string) {
+		option, ok := ParseDHCPOptionLine(line)
+		if !ok || strings.HasPrefix(option.Option, "option:") {
+			// dnsmasq itself rejects a doubled option: prefix.
+			return
+		}
+
+		formatted := option.String()
+		got, ok := ParseDHCPOptionLine(formatted)
+		if !ok || !reflect.DeepEqual(got, option) {
+			t.Fatalf("%q formatted as %q parses to %+v, want %+v", line, formatted, got, option)
+		}
+	})
+}
+
+func FuzzParseRevServer(f *testing.F) {
+	for _, seed := range []string{
+		"true,192.168.0.0/16,192.168.0.1,fritz.box",
+		"false, 10.0.0.0/8 ,10.0.0.1#5353",
+		"true,fd00::/8,fd00::1",
+		"",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, entry string) {
+		s, ok := ParseRevServer(entry)
+		if !ok {
+			return
+		}
+
+		formatted := s.String()
+		s2, ok := ParseRevServer(formatted)
+		if !ok || s2 != s {
+			t.Fatalf("%q formatted as %q parses to %+v", entry, formatted, s2)
+		}
+	})
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+// Package convert maps between the values the Pi-hole API uses and the
+// Terraform framework values of the provider's models. The functions are
+// pure, so the mapping can be tested without a Pi-hole.
+package convert
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// OptionalString maps an empty API field to null.
+func OptionalString(s string) types.String {
+	if s == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(s)
+}
+
+// GroupList maps the group IDs of a Pi-hole entry to a list, null when the
+// entry belongs to no group.
+func GroupList(ctx context.Context, groups []int64) (types.List, diag.Diagnostics) {
+	if len(groups) == 0 {
+		return types.ListNull(types.Int64Type), nil
+	}
+	return types.ListValueFrom(ctx, types.Int64Type, groups)
+}
+
+// GroupSet is GroupList for attributes that hold the group IDs as a set.
+func GroupSet(ctx context.Context, groups []int64) (types.Set, diag.Diagnostics) {
+	if len(groups) == 0 {
+		return types.SetNull(types.Int64Type), nil
+	}
+	return types.SetValueFrom(ctx, types.Int64Type, groups)
+}
+
+// Collection is implemented by types.List and types.Set.
+type Collection interface {
+	IsNull() bool
+	IsUnknown() bool
+	ElementsAs(ctx context.Context, target interface{}, allowUnhandled bool) diag.Diagnostics
+}
+
+// Int64s returns the numbers in a list or set, such as group IDs, and nil
+// when it is null or unknown.
+func Int64s(ctx context.Context, values Collection) ([]int64, diag.Diagnostics) {
+	if values.IsNull() || values.IsUnknown() {
+		return nil, nil
+	}
+	var numbers []int64
+	diags := values.ElementsAs(ctx, &numbers, false)
+	return numbers, diags
+}
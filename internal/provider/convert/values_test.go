@@ -0,0 +1,97 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestOptionalString(t *testing.T) {
+	if got := OptionalString(""); !got.IsNull() {
+		t.Errorf("OptionalString(\"\") = %v, want null", got)
+	}
+	if got := OptionalString("kids"); got.ValueString() != "kids" {
+		t.Errorf("OptionalString(\"kids\") = %v", got)
+	}
+}
+
+func TestGroupList(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name   string
+		groups []int64
+		want   types.List
+	}{
+		{"no groups", nil, types.ListNull(types.Int64Type)},
+		{"empty", []int64{}, types.ListNull(types.Int64Type)},
+		{"groups", []int64{0, 3}, types.ListValueMust(types.Int64Type, []attr.Value{types.Int64Value(0), types.Int64Value(3)})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, diags := GroupList(ctx, tt.groups)
+			if diags.HasError() {
+				t.Fatalf("GroupList() diags = %v", diags)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("GroupList() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGroupSet(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name   string
+		groups []int64
+		want   types.Set
+	}{
+		{"no groups", nil, types.SetNull(types.Int64Type)},
+		{"groups", []int64{3, 0}, types.SetValueMust(types.Int64Type, []attr.Value{types.Int64Value(0), types.Int64Value(3)})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, diags := GroupSet(ctx, tt.groups)
+			if diags.HasError() {
+				t.Fatalf("GroupSet() diags = %v", diags)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("GroupSet() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInt64s(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name   string
+		values Collection
+		want   []int64
+	}{
+		{"null list", types.ListNull(types.Int64Type), nil},
+		{"unknown set", types.SetUnknown(types.Int64Type), nil},
+		{"list", types.ListValueMust(types.Int64Type, []attr.Value{types.Int64Value(2), types.Int64Value(1)}), []int64{2, 1}},
+		{"set", types.SetValueMust(types.Int64Type, []attr.Value{types.Int64Value(5)}), []int64{5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, diags := Int64s(ctx, tt.values)
+			if diags.HasError() {
+				t.Fatalf("Int64s() diags = %v", diags)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Int64s() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,240 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/dklesev/terraform-provider-pihole/internal/provider/convert"
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &ClientSuggestionsDataSource{}
+
+func NewClientSuggestionsDataSource() datasource.DataSource {
+	return &ClientSuggestionsDataSource{}
+}
+
+type ClientSuggestionsDataSource struct {
+	client *pihole.Client
+}
+
+type ClientSuggestionsDataSourceModel struct {
+	ExcludeConfigured types.Bool                        `tfsdk:"exclude_configured"`
+	Suggestions       []ClientSuggestionDataSourceModel `tfsdk:"suggestions"`
+}
+
+type ClientSuggestionDataSourceModel struct {
+	Client    types.String `tfsdk:"client"`
+	MAC       types.String `tfsdk:"mac"`
+	MACVendor types.String `tfsdk:"mac_vendor"`
+	LastQuery types.Int64  `tfsdk:"last_query"`
+	Addresses types.List   `tfsdk:"addresses"`
+	Names     types.List   `tfsdk:"names"`
+}
+
+func (d *ClientSuggestionsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_client_suggestions"
+}
+
+func (d *ClientSuggestionsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Fetches the devices Pi-hole suggests configuring as clients.",
+		MarkdownDescription: `
+Fetches the devices Pi-hole suggests configuring as clients: the entries of
+its network table with the vendor, addresses and host names FTL knows for
+them. Devices that are already configured as a client, by their MAC address,
+one of their addresses or host names, or a subnet containing one of their
+addresses, are left out unless ` + "`exclude_configured`" + ` is ` + "`false`" + `.
+
+Each suggestion's ` + "`client`" + ` is the identifier to give a ` + "`pihole_client`" + `
+for it: the MAC address, or the address for devices seen without one.
+
+Creating clients from this data source adds every newly seen device to the
+configuration on the next apply. Because configured devices are no longer
+suggested, use ` + "`exclude_configured = false`" + ` when the suggestions drive
+` + "`for_each`" + `, or the clients would be destroyed on the apply after.
+
+## Example Usage
+
+` + "```hcl" + `
+data "pihole_client_suggestions" "all" {
+  exclude_configured = false
+}
+
+# Put every device seen on the network in the untrusted group
+resource "pihole_client" "seen" {
+  for_each = {
+    for s in data.pihole_client_suggestions.all.suggestions : s.client => s
+  }
+
+  client  = each.key
+  comment = join(", ", each.value.names)
+  groups  = [pihole_group.untrusted.id]
+}
+` + "```" + `
+`,
+		Attributes: map[string]schema.Attribute{
+			"exclude_configured": schema.BoolAttribute{
+				Description: "Leave out devices that are already configured as a client. Defaults to `true`.",
+				Optional:    true,
+			},
+			"suggestions": schema.ListNestedAttribute{
+				Description: "List of suggested devices.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"client": schema.StringAttribute{
+							Description: "The client identifier to configure the device with: its MAC address, or its address when it was seen without one.",
+							Computed:    true,
+						},
+						"mac": schema.StringAttribute{
+							Description: "The hardware address of the device. Devices seen without one use a pseudo address of the form `ip-<address>`.",
+							Computed:    true,
+						},
+						"mac_vendor": schema.StringAttribute{
+							Description: "The vendor derived from the MAC address.",
+							Computed:    true,
+						},
+						"last_query": schema.Int64Attribute{
+							Description: "Unix timestamp of the last query from the device.",
+							Computed:    true,
+						},
+						"addresses": schema.ListAttribute{
+							Description: "Addresses seen for the device.",
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+						"names": schema.ListAttribute{
+							Description: "Host names seen for the device.",
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ClientSuggestionsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.client
+}
+
+func (d *ClientSuggestionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ClientSuggestionsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	suggestions, err := d.client.GetClientSuggestions(ctx)
+	if err != nil {
+		addAPIErrorf(&resp.Diagnostics, "Error reading client suggestions", err, "Could not read client suggestions")
+		return
+	}
+
+	var configured []pihole.PiholeClient
+	if data.ExcludeConfigured.IsNull() || data.ExcludeConfigured.ValueBool() {
+		configured, err = d.client.GetClients(ctx, "")
+		if err != nil {
+			addAPIErrorf(&resp.Diagnostics, "Error reading clients", err, "Could not read clients")
+			return
+		}
+	}
+
+	data.Suggestions = make([]ClientSuggestionDataSourceModel, 0, len(suggestions))
+	for _, s := range suggestions {
+		if isConfiguredClient(s, configured) {
+			continue
+		}
+		model, diags := mapClientSuggestionToDataSourceModel(ctx, s)
+		resp.Diagnostics.Append(diags...)
+		data.Suggestions = append(data.Suggestions, model)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// suggestedClient returns the identifier to configure the device of s with:
+// its MAC address, or its first address for the pseudo addresses FTL gives
+// devices seen without one.
+func suggestedClient(s pihole.ClientSuggestion) string {
+	if strings.HasPrefix(s.HWAddr, "ip-") {
+		if addresses := s.AddressList(); len(addresses) > 0 {
+			return addresses[0]
+		}
+		return strings.TrimPrefix(s.HWAddr, "ip-")
+	}
+	return s.HWAddr
+}
+
+// isConfiguredClient reports whether one of clients matches the device of s
+// by its MAC address, one of its addresses or host names, or a subnet
+// containing one of its addresses.
+func isConfiguredClient(s pihole.ClientSuggestion, clients []pihole.PiholeClient) bool {
+	for _, c := range clients {
+		if convert.SameMAC(c.Client, s.HWAddr) {
+			return true
+		}
+		prefix, prefixErr := netip.ParsePrefix(c.Client)
+		for _, a := range s.AddressList() {
+			if convert.SameIP(c.Client, a) {
+				return true
+			}
+			if addr, err := netip.ParseAddr(a); prefixErr == nil && err == nil && prefix.Contains(addr) {
+				return true
+			}
+		}
+		for _, n := range s.NameList() {
+			if convert.SameHostname(c.Client, n) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// mapClientSuggestionToDataSourceModel maps a pihole.ClientSuggestion to the data source model.
+func mapClientSuggestionToDataSourceModel(ctx context.Context, s pihole.ClientSuggestion) (ClientSuggestionDataSourceModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	model := ClientSuggestionDataSourceModel{
+		Client:    types.StringValue(suggestedClient(s)),
+		MAC:       types.StringValue(s.HWAddr),
+		MACVendor: convert.OptionalString(s.MACVendor),
+		LastQuery: types.Int64Value(s.LastQuery),
+	}
+
+	addresses, d := types.ListValueFrom(ctx, types.StringType, s.AddressList())
+	diags.Append(d...)
+	model.Addresses = addresses
+
+	names, d := types.ListValueFrom(ctx, types.StringType, s.NameList())
+	diags.Append(d...)
+	model.Names = names
+
+	return model, diags
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDataSourceClientSuggestions_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "pihole_client_suggestions" "test" {}
+
+data "pihole_client_suggestions" "all" {
+  exclude_configured = false
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.pihole_client_suggestions.test", "suggestions.#"),
+					resource.TestCheckResourceAttrSet("data.pihole_client_suggestions.all", "suggestions.#"),
+				),
+			},
+		},
+	})
+}
+
+func TestSuggestedClient(t *testing.T) {
+	tests := []struct {
+		name string
+		s    pihole.ClientSuggestion
+		want string
+	}{
+		{"MAC address", pihole.ClientSuggestion{HWAddr: "aa:bb:cc:dd:ee:ff", Addresses: "192.168.1.10"}, "aa:bb:cc:dd:ee:ff"},
+		{"pseudo address", pihole.ClientSuggestion{HWAddr: "ip-192.168.1.20", Addresses: "192.168.1.20"}, "192.168.1.20"},
+		{"pseudo address without addresses", pihole.ClientSuggestion{HWAddr: "ip-192.168.1.30"}, "192.168.1.30"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := suggestedClient(tt.s); got != tt.want {
+				t.Errorf("suggestedClient() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsConfiguredClient(t *testing.T) {
+	s := pihole.ClientSuggestion{
+		HWAddr:    "aa:bb:cc:dd:ee:ff",
+		Addresses: "192.168.1.10,fe80::1",
+		Names:     "pi.lan",
+	}
+	tests := []struct {
+		client string
+		want   bool
+	}{
+		{"AA-BB-CC-DD-EE-FF", true},
+		{"192.168.1.10", true},
+		{"fe80:0:0::1", true},
+		{"PI.lan", true},
+		{"192.168.1.0/24", true},
+		{"192.168.2.0/24", false},
+		{"192.168.1.11", false},
+		{":eth0", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.client, func(t *testing.T) {
+			if got := isConfiguredClient(s, []pihole.PiholeClient{{Client: tt.client}}); got != tt.want {
+				t.Errorf("isConfiguredClient(%q) = %v, want %v", tt.client, got, tt.want)
+			}
+		})
+	}
+}
@@ -7,7 +7,8 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/dklesev/terraform-provider-pihole/internal/client"
+	"github.com/dklesev/terraform-provider-pihole/internal/provider/convert"
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -21,11 +22,12 @@ func NewClientsDataSource() datasource.DataSource {
 }
 
 type ClientsDataSource struct {
-	client *client.Client
+	client *pihole.Client
 }
 
 type ClientsDataSourceModel struct {
-	Clients []ClientDataSourceModel `tfsdk:"clients"`
+	ManagedByTag types.String            `tfsdk:"managed_by_tag"`
+	Clients      []ClientDataSourceModel `tfsdk:"clients"`
 }
 
 type ClientDataSourceModel struct {
@@ -57,6 +59,10 @@ output "client_count" {
 ` + "```" + `
 `,
 		Attributes: map[string]schema.Attribute{
+			"managed_by_tag": schema.StringAttribute{
+				Description: "Only return entries whose comment carries the `[tf:<tag>]` marker of this `managed_by_tag`.",
+				Optional:    true,
+			},
 			"clients": schema.ListNestedAttribute{
 				Description: "List of all client configurations.",
 				Computed:    true,
@@ -95,42 +101,47 @@ func (d *ClientsDataSource) Configure(ctx context.Context, req datasource.Config
 		return
 	}
 
-	c, ok := req.ProviderData.(*client.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData),
 		)
 		return
 	}
 
-	d.client = c
+	d.client = data.client
 }
 
 func (d *ClientsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var data ClientsDataSourceModel
 
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	clients, err := d.client.GetClients(ctx, "")
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error reading clients",
-			fmt.Sprintf("Could not read clients: %s", err.Error()),
-		)
+		addAPIErrorf(&resp.Diagnostics, "Error reading clients", err, "Could not read clients")
 		return
 	}
 
-	data.Clients = make([]ClientDataSourceModel, len(clients))
-	for i, c := range clients {
+	data.Clients = make([]ClientDataSourceModel, 0, len(clients))
+	for _, c := range clients {
+		if !data.ManagedByTag.IsNull() && !hasManagedTag(c.Comment, data.ManagedByTag.ValueString()) {
+			continue
+		}
 		model, diags := mapClientToDataSourceModel(ctx, &c)
 		resp.Diagnostics.Append(diags...)
-		data.Clients[i] = model
+		data.Clients = append(data.Clients, model)
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
-// mapClientToDataSourceModel maps a client.PiholeClient to the data source model.
-func mapClientToDataSourceModel(ctx context.Context, c *client.PiholeClient) (ClientDataSourceModel, diag.Diagnostics) {
+// mapClientToDataSourceModel maps a pihole.PiholeClient to the data source model.
+func mapClientToDataSourceModel(ctx context.Context, c *pihole.PiholeClient) (ClientDataSourceModel, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
 	model := ClientDataSourceModel{
@@ -139,19 +150,11 @@ func mapClientToDataSourceModel(ctx context.Context, c *client.PiholeClient) (Cl
 		DateAdded: types.Int64Value(c.DateAdded),
 	}
 
-	if c.Comment != "" {
-		model.Comment = types.StringValue(c.Comment)
-	} else {
-		model.Comment = types.StringNull()
-	}
+	model.Comment = convert.OptionalString(c.Comment)
 
-	if len(c.Groups) > 0 {
-		groupsList, d := types.ListValueFrom(ctx, types.Int64Type, c.Groups)
-		diags.Append(d...)
-		model.Groups = groupsList
-	} else {
-		model.Groups = types.ListNull(types.Int64Type)
-	}
+	groups, d := convert.GroupList(ctx, c.Groups)
+	diags.Append(d...)
+	model.Groups = groups
 
 	return model, diags
 }
@@ -0,0 +1,223 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dklesev/terraform-provider-pihole/internal/provider/convert"
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &DNSUpstreamsDataSource{}
+
+func NewDNSUpstreamsDataSource() datasource.DataSource {
+	return &DNSUpstreamsDataSource{}
+}
+
+type DNSUpstreamsDataSource struct {
+	client *pihole.Client
+}
+
+type DNSUpstreamsDataSourceModel struct {
+	Probe     types.Bool                   `tfsdk:"probe"`
+	Upstreams []DNSUpstreamDataSourceModel `tfsdk:"upstreams"`
+}
+
+type DNSUpstreamDataSourceModel struct {
+	Upstream     types.String  `tfsdk:"upstream"`
+	Address      types.String  `tfsdk:"address"`
+	Port         types.Int64   `tfsdk:"port"`
+	Name         types.String  `tfsdk:"name"`
+	Queries      types.Int64   `tfsdk:"queries"`
+	ResponseTime types.Float64 `tfsdk:"response_time"`
+	Healthy      types.Bool    `tfsdk:"healthy"`
+}
+
+func (d *DNSUpstreamsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dns_upstreams"
+}
+
+func (d *DNSUpstreamsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Fetches the configured Pi-hole DNS upstream servers, optionally with their health.",
+		MarkdownDescription: `
+Fetches the configured Pi-hole DNS upstream servers (` + "`dns.upstreams`" + `).
+
+With ` + "`probe = true`" + ` the data source also reads FTL's upstream statistics
+for the last 24 hours. An upstream is reported as unhealthy when Pi-hole has
+forwarded queries to it but it has not answered any of them, and a warning is
+shown during plan so unreachable upstreams are noticed before they are relied
+on. An upstream without any queries, like a fallback Pi-hole has not needed,
+has no health (` + "`null`" + `) rather than being unhealthy.
+
+## Example Usage
+
+` + "```hcl" + `
+data "pihole_dns_upstreams" "current" {
+  probe = true
+}
+
+output "unhealthy_upstreams" {
+  value = [for u in data.pihole_dns_upstreams.current.upstreams : u.upstream if u.healthy == false]
+}
+` + "```" + `
+`,
+		Attributes: map[string]schema.Attribute{
+			"probe": schema.BoolAttribute{
+				Description: "Check the upstream statistics and report the health of each upstream. Defaults to false.",
+				Optional:    true,
+			},
+			"upstreams": schema.ListNestedAttribute{
+				Description: "List of configured upstream servers.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"upstream": schema.StringAttribute{
+							Description: "The upstream as configured, e.g. `1.1.1.1` or `127.0.0.1#5335`.",
+							Computed:    true,
+						},
+						"address": schema.StringAttribute{
+							Description: "The address of the upstream.",
+							Computed:    true,
+						},
+						"port": schema.Int64Attribute{
+							Description: "The port of the upstream.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "The hostname FTL resolved for the upstream. Only set when probing.",
+							Computed:    true,
+						},
+						"queries": schema.Int64Attribute{
+							Description: "Queries forwarded to the upstream in the last 24 hours. Only set when probing.",
+							Computed:    true,
+						},
+						"response_time": schema.Float64Attribute{
+							Description: "Average response time of the upstream in seconds. Only set when probing.",
+							Computed:    true,
+						},
+						"healthy": schema.BoolAttribute{
+							Description: "Whether the upstream is answering queries. Only set when probing and the upstream had queries.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *DNSUpstreamsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.client
+}
+
+func (d *DNSUpstreamsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DNSUpstreamsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, err := d.client.GetDNSConfig(ctx)
+	if err != nil {
+		addAPIErrorf(&resp.Diagnostics, "Error reading DNS upstreams", err, "Could not read DNS config")
+		return
+	}
+
+	var stats *pihole.UpstreamStatsResponse
+	if data.Probe.ValueBool() {
+		stats, err = d.client.GetUpstreamStats(ctx)
+		if err != nil {
+			addAPIErrorf(&resp.Diagnostics, "Error probing DNS upstreams", err, "Could not read upstream statistics")
+			return
+		}
+	}
+
+	data.Upstreams = make([]DNSUpstreamDataSourceModel, len(config.Upstreams))
+	for i, upstream := range config.Upstreams {
+		address, port := splitUpstream(upstream)
+		model := DNSUpstreamDataSourceModel{
+			Upstream:     types.StringValue(upstream),
+			Address:      types.StringValue(address),
+			Port:         types.Int64Value(int64(port)),
+			Name:         types.StringNull(),
+			Queries:      types.Int64Null(),
+			ResponseTime: types.Float64Null(),
+			Healthy:      types.BoolNull(),
+		}
+
+		if stats != nil {
+			var queries int64
+			var response float64
+			for _, s := range stats.Upstreams {
+				if s.Port == port && convert.SameIP(s.IP, address) {
+					model.Name = convert.OptionalString(s.Name)
+					queries = s.Count
+					response = s.Statistics.Response
+					break
+				}
+			}
+
+			model.Queries = types.Int64Value(queries)
+			model.ResponseTime = types.Float64Value(response)
+			model.Healthy = upstreamHealth(queries, response)
+
+			if !model.Healthy.IsNull() && !model.Healthy.ValueBool() {
+				resp.Diagnostics.AddWarning(
+					"DNS upstream not answering",
+					fmt.Sprintf("Pi-hole forwarded %d queries to upstream %q in the last 24 hours, but it has not answered any of them. Check that it is reachable from Pi-hole.", queries, upstream),
+				)
+			}
+		}
+
+		data.Upstreams[i] = model
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// upstreamHealth judges an upstream by the queries Pi-hole forwarded to it
+// and its average response time. Without queries, as for a fallback upstream
+// Pi-hole has not needed, there is nothing to judge by and the health is
+// null.
+func upstreamHealth(queries int64, response float64) types.Bool {
+	if queries == 0 {
+		return types.BoolNull()
+	}
+	return types.BoolValue(response > 0)
+}
+
+// splitUpstream splits a dnsmasq upstream ("address[#port]") into its address
+// and port, defaulting to port 53.
+func splitUpstream(upstream string) (string, int) {
+	address, portStr, found := strings.Cut(upstream, "#")
+	if !found {
+		return upstream, 53
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return address, 53
+	}
+	return address, port
+}
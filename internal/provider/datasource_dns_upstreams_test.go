@@ -0,0 +1,91 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDataSourceDNSUpstreams_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "pihole_dns_upstream" "test" {
+  upstream = "127.0.0.1#5335"
+}
+
+data "pihole_dns_upstreams" "test" {
+  depends_on = [pihole_dns_upstream.test]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs("data.pihole_dns_upstreams.test", "upstreams.*", map[string]string{
+						"upstream": "127.0.0.1#5335",
+						"address":  "127.0.0.1",
+						"port":     "5335",
+					}),
+					resource.TestCheckNoResourceAttr("data.pihole_dns_upstreams.test", "upstreams.0.healthy"),
+				),
+			},
+			{
+				Config: `
+data "pihole_dns_upstreams" "test" {
+  probe = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.pihole_dns_upstreams.test", "upstreams.0.queries"),
+					resource.TestCheckResourceAttrSet("data.pihole_dns_upstreams.test", "upstreams.0.response_time"),
+				),
+			},
+		},
+	})
+}
+
+func TestUpstreamHealth(t *testing.T) {
+	tests := []struct {
+		name     string
+		queries  int64
+		response float64
+		want     types.Bool
+	}{
+		{"answering", 42, 0.012, types.BoolValue(true)},
+		{"not answering", 42, 0, types.BoolValue(false)},
+		// A fallback upstream Pi-hole has not needed is not unhealthy
+		{"no queries", 0, 0, types.BoolNull()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := upstreamHealth(tt.queries, tt.response); !got.Equal(tt.want) {
+				t.Errorf("upstreamHealth(%d, %v) = %v, want %v", tt.queries, tt.response, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitUpstream(t *testing.T) {
+	tests := []struct {
+		upstream string
+		address  string
+		port     int
+	}{
+		{"1.1.1.1", "1.1.1.1", 53},
+		{"127.0.0.1#5335", "127.0.0.1", 5335},
+		{"2606:4700:4700::1111", "2606:4700:4700::1111", 53},
+		{"2606:4700:4700::1111#853", "2606:4700:4700::1111", 853},
+	}
+
+	for _, tt := range tests {
+		address, port := splitUpstream(tt.upstream)
+		if address != tt.address || port != tt.port {
+			t.Errorf("splitUpstream(%q) = %q, %d; want %q, %d", tt.upstream, address, port, tt.address, tt.port)
+		}
+	}
+}
@@ -6,8 +6,11 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strings"
 
-	"github.com/dklesev/terraform-provider-pihole/internal/client"
+	"github.com/dklesev/terraform-provider-pihole/internal/provider/convert"
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
@@ -23,13 +26,17 @@ func NewDomainsDataSource() datasource.DataSource {
 }
 
 type DomainsDataSource struct {
-	client *client.Client
+	client *pihole.Client
 }
 
 type DomainsDataSourceModel struct {
-	Type    types.String            `tfsdk:"type"`
-	Kind    types.String            `tfsdk:"kind"`
-	Domains []DomainDataSourceModel `tfsdk:"domains"`
+	Type            types.String            `tfsdk:"type"`
+	Kind            types.String            `tfsdk:"kind"`
+	ManagedByTag    types.String            `tfsdk:"managed_by_tag"`
+	Enabled         types.Bool              `tfsdk:"enabled"`
+	CommentContains types.String            `tfsdk:"comment_contains"`
+	Limit           types.Int64             `tfsdk:"limit"`
+	Domains         []DomainDataSourceModel `tfsdk:"domains"`
 }
 
 type DomainDataSourceModel struct {
@@ -76,6 +83,16 @@ data "pihole_domains" "regex_rules" {
   kind = "regex"
 }
 ` + "```" + `
+
+### First Disabled Ad Rules
+
+` + "```hcl" + `
+data "pihole_domains" "disabled_ads" {
+  enabled          = false
+  comment_contains = "ads"
+  limit            = 100
+}
+` + "```" + `
 `,
 		Attributes: map[string]schema.Attribute{
 			"type": schema.StringAttribute{
@@ -92,6 +109,25 @@ data "pihole_domains" "regex_rules" {
 					stringvalidator.OneOf("exact", "regex"),
 				},
 			},
+			"managed_by_tag": schema.StringAttribute{
+				Description: "Only return entries whose comment carries the `[tf:<tag>]` marker of this `managed_by_tag`.",
+				Optional:    true,
+			},
+			"enabled": schema.BoolAttribute{
+				Description: "Only return enabled (true) or disabled (false) entries. Leave empty for all.",
+				Optional:    true,
+			},
+			"comment_contains": schema.StringAttribute{
+				Description: "Only return entries whose comment contains this text (case-insensitive).",
+				Optional:    true,
+			},
+			"limit": schema.Int64Attribute{
+				Description: "Return at most this many entries, in the order Pi-hole returns them. Leave empty for all.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
 			"domains": schema.ListNestedAttribute{
 				Description: "List of domains matching the filter.",
 				Computed:    true,
@@ -142,16 +178,16 @@ func (d *DomainsDataSource) Configure(ctx context.Context, req datasource.Config
 		return
 	}
 
-	c, ok := req.ProviderData.(*client.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData),
 		)
 		return
 	}
 
-	d.client = c
+	d.client = data.client
 }
 
 func (d *DomainsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -172,27 +208,35 @@ func (d *DomainsDataSource) Read(ctx context.Context, req datasource.ReadRequest
 		kind = data.Kind.ValueString()
 	}
 
-	domains, err := d.client.GetDomains(ctx, domainType, kind, "")
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error reading domains",
-			fmt.Sprintf("Could not read domains: %s", err.Error()),
-		)
-		return
-	}
-
-	data.Domains = make([]DomainDataSourceModel, len(domains))
-	for i, dom := range domains {
+	commentContains := strings.ToLower(data.CommentContains.ValueString())
+
+	// Filter while decoding so large instances never hold every entry as a Domain.
+	data.Domains = make([]DomainDataSourceModel, 0)
+	err := d.client.ForEachDomain(ctx, domainType, kind, "", func(dom pihole.Domain) bool {
+		if !data.ManagedByTag.IsNull() && !hasManagedTag(dom.Comment, data.ManagedByTag.ValueString()) {
+			return true
+		}
+		if !data.Enabled.IsNull() && dom.Enabled != data.Enabled.ValueBool() {
+			return true
+		}
+		if commentContains != "" && !strings.Contains(strings.ToLower(dom.Comment), commentContains) {
+			return true
+		}
 		model, diags := mapDomainToDataSourceModel(ctx, &dom)
 		resp.Diagnostics.Append(diags...)
-		data.Domains[i] = model
+		data.Domains = append(data.Domains, model)
+		return data.Limit.IsNull() || int64(len(data.Domains)) < data.Limit.ValueInt64()
+	})
+	if err != nil {
+		addAPIErrorf(&resp.Diagnostics, "Error reading domains", err, "Could not read domains")
+		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
-// mapDomainToDataSourceModel maps a client.Domain to the data source model.
-func mapDomainToDataSourceModel(ctx context.Context, dom *client.Domain) (DomainDataSourceModel, diag.Diagnostics) {
+// mapDomainToDataSourceModel maps a pihole.Domain to the data source model.
+func mapDomainToDataSourceModel(ctx context.Context, dom *pihole.Domain) (DomainDataSourceModel, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
 	model := DomainDataSourceModel{
@@ -204,19 +248,11 @@ func mapDomainToDataSourceModel(ctx context.Context, dom *client.Domain) (Domain
 		DateAdded: types.Int64Value(dom.DateAdded),
 	}
 
-	if dom.Comment != "" {
-		model.Comment = types.StringValue(dom.Comment)
-	} else {
-		model.Comment = types.StringNull()
-	}
+	model.Comment = convert.OptionalString(dom.Comment)
 
-	if len(dom.Groups) > 0 {
-		groupsList, d := types.ListValueFrom(ctx, types.Int64Type, dom.Groups)
-		diags.Append(d...)
-		model.Groups = groupsList
-	} else {
-		model.Groups = types.ListNull(types.Int64Type)
-	}
+	groups, d := convert.GroupList(ctx, dom.Groups)
+	diags.Append(d...)
+	model.Groups = groups
 
 	return model, diags
 }
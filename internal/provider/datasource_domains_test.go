@@ -54,10 +54,60 @@ func TestAccDataSourceDomains_filterByKind(t *testing.T) {
 	})
 }
 
+func TestAccDataSourceDomains_filterByEnabledCommentAndLimit(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "pihole_domain" "enabled" {
+  domain  = "tf-acc-ds-filter-enabled.example.com"
+  type    = "deny"
+  kind    = "exact"
+  enabled = true
+  comment = "ds-filter-marker enabled"
+}
+
+resource "pihole_domain" "disabled" {
+  domain  = "tf-acc-ds-filter-disabled.example.com"
+  type    = "deny"
+  kind    = "exact"
+  enabled = false
+  comment = "DS-FILTER-MARKER disabled"
+}
+
+data "pihole_domains" "marked" {
+  comment_contains = "ds-filter-marker"
+  depends_on       = [pihole_domain.enabled, pihole_domain.disabled]
+}
+
+data "pihole_domains" "marked_disabled" {
+  comment_contains = "ds-filter-marker"
+  enabled          = false
+  depends_on       = [pihole_domain.enabled, pihole_domain.disabled]
+}
+
+data "pihole_domains" "limited" {
+  limit      = 1
+  depends_on = [pihole_domain.enabled, pihole_domain.disabled]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.pihole_domains.marked", "domains.#", "2"),
+					resource.TestCheckResourceAttr("data.pihole_domains.marked_disabled", "domains.#", "1"),
+					resource.TestCheckResourceAttr("data.pihole_domains.marked_disabled", "domains.0.domain", "tf-acc-ds-filter-disabled.example.com"),
+					resource.TestCheckResourceAttr("data.pihole_domains.limited", "domains.#", "1"),
+				),
+			},
+		},
+	})
+}
+
 func testAccDataSourceDomainsConfig() string {
 	return `
 resource "pihole_domain" "test" {
-  domain  = "ds-test.example.com"
+  domain  = "tf-acc-ds-test.example.com"
   type    = "deny"
   kind    = "exact"
   enabled = true
@@ -72,7 +122,7 @@ data "pihole_domains" "test" {
 func testAccDataSourceDomainsFilterByTypeConfig() string {
 	return `
 resource "pihole_domain" "test" {
-  domain  = "ds-filter-type.example.com"
+  domain  = "tf-acc-ds-filter-type.example.com"
   type    = "deny"
   kind    = "exact"
   enabled = true
@@ -88,7 +138,7 @@ data "pihole_domains" "test" {
 func testAccDataSourceDomainsFilterByKindConfig() string {
 	return `
 resource "pihole_domain" "test" {
-  domain  = "ds-filter-kind.example.com"
+  domain  = "tf-acc-ds-filter-kind.example.com"
   type    = "deny"
   kind    = "exact"
   enabled = true
@@ -0,0 +1,270 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &EffectivePolicyDataSource{}
+
+func NewEffectivePolicyDataSource() datasource.DataSource {
+	return &EffectivePolicyDataSource{}
+}
+
+// EffectivePolicyDataSource tells whether Pi-hole blocks a domain for a
+// client, and by which rule.
+type EffectivePolicyDataSource struct {
+	client *pihole.Client
+}
+
+type EffectivePolicyDataSourceModel struct {
+	Domain  types.String `tfsdk:"domain"`
+	Client  types.String `tfsdk:"client"`
+	Blocked types.Bool   `tfsdk:"blocked"`
+	Reason  types.String `tfsdk:"reason"`
+	Rule    types.String `tfsdk:"rule"`
+	Groups  types.List   `tfsdk:"groups"`
+}
+
+// The reasons for a decision, in the order Pi-hole checks them.
+const (
+	policyReasonExactAllow = "exact_allow"
+	policyReasonRegexAllow = "regex_allow"
+	policyReasonExactDeny  = "exact_deny"
+	policyReasonGravity    = "gravity"
+	policyReasonRegexDeny  = "regex_deny"
+	policyReasonNone       = "none"
+)
+
+// policyDecision is the outcome of the rules that apply to a domain.
+type policyDecision struct {
+	blocked bool
+	reason  string
+	// rule is the domain or regex of the deciding entry, or the address
+	// of the deciding list.
+	rule string
+}
+
+func (d *EffectivePolicyDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_effective_policy"
+}
+
+func (d *EffectivePolicyDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Tells whether Pi-hole blocks a domain for a client, and by which rule.",
+		MarkdownDescription: `
+Tells whether Pi-hole blocks a domain for a client, and by which rule, e.g. to
+check a policy in CI with a ` + "`check`" + ` block or a test assertion.
+
+The data source searches the domain entries and gravity lists for the domain
+and evaluates the ones that apply to the client's enabled groups in the order
+Pi-hole does:
+
+1. ` + "`exact_allow`" + `: an exact allow entry
+2. ` + "`regex_allow`" + `: an allow regex
+3. ` + "`exact_deny`" + `: an exact deny entry
+4. ` + "`gravity`" + `: a blocklist, unless an allowlist applying to the client contains the domain as well
+5. ` + "`regex_deny`" + `: a deny regex
+
+The client is looked up among the configured clients by its exact identifier,
+or for IP addresses by the most specific subnet containing it; any other
+client is in the Default group only. Whether blocking is currently disabled,
+CNAME inspection and the query type of ` + "`;querytype=`" + ` regexes are not taken into
+account.
+
+## Example Usage
+
+` + "```hcl" + `
+data "pihole_effective_policy" "kids_tiktok" {
+  domain = "www.tiktok.com"
+  client = "192.168.1.60"
+}
+
+check "kids_policy" {
+  assert {
+    condition     = data.pihole_effective_policy.kids_tiktok.blocked
+    error_message = "www.tiktok.com is not blocked for the kids' tablet (${data.pihole_effective_policy.kids_tiktok.reason})."
+  }
+}
+` + "```" + `
+`,
+		Attributes: map[string]schema.Attribute{
+			"domain": schema.StringAttribute{
+				Description: "The domain to check.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"client": schema.StringAttribute{
+				Description: "The client to check the domain for: an IP address, MAC address, hostname, subnet or interface as configured in `pihole_client`. Default: a client without its own entry, which is in the Default group only.",
+				Optional:    true,
+			},
+			"blocked": schema.BoolAttribute{
+				Description: "Whether Pi-hole blocks the domain for the client.",
+				Computed:    true,
+			},
+			"reason": schema.StringAttribute{
+				Description: "What decides: `exact_allow`, `regex_allow`, `exact_deny`, `gravity`, `regex_deny`, or `none` when no rule applies.",
+				Computed:    true,
+			},
+			"rule": schema.StringAttribute{
+				Description: "The domain or regex of the deciding entry, or the address of the deciding list. Empty when no rule applies.",
+				Computed:    true,
+			},
+			"groups": schema.ListAttribute{
+				Description: "The IDs of the enabled groups the client is in, in ascending order.",
+				Computed:    true,
+				ElementType: types.Int64Type,
+			},
+		},
+	}
+}
+
+func (d *EffectivePolicyDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.client
+}
+
+func (d *EffectivePolicyDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data EffectivePolicyDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := d.client.Search(ctx, data.Domain.ValueString(), false)
+	if err != nil {
+		addAPIErrorf(&resp.Diagnostics, "Error reading effective policy", err, "Could not search for %s", data.Domain.ValueString())
+		return
+	}
+	clients, err := d.client.GetClients(ctx, "")
+	if err != nil {
+		addAPIErrorf(&resp.Diagnostics, "Error reading effective policy", err, "Could not read clients")
+		return
+	}
+	groups, err := d.client.GetGroups(ctx, "")
+	if err != nil {
+		addAPIErrorf(&resp.Diagnostics, "Error reading effective policy", err, "Could not read groups")
+		return
+	}
+
+	groupIDs := clientGroups(clients, groups, data.Client.ValueString())
+	decision := decidePolicy(result, groupIDs)
+
+	groupValues := make([]attr.Value, len(groupIDs))
+	for i, id := range groupIDs {
+		groupValues[i] = types.Int64Value(id)
+	}
+
+	data.Blocked = types.BoolValue(decision.blocked)
+	data.Reason = types.StringValue(decision.reason)
+	data.Rule = types.StringValue(decision.rule)
+	data.Groups = types.ListValueMust(types.Int64Type, groupValues)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// clientGroups returns the IDs of the enabled groups of a client, sorted.
+// The client entry is found by its exact identifier, ignoring case, or for
+// IP addresses by the most specific subnet containing it. Clients without
+// an entry are in the Default group.
+func clientGroups(clients []pihole.PiholeClient, groups []pihole.Group, identifier string) []int64 {
+	memberOf := []int64{pihole.DefaultGroupID}
+	if identifier != "" {
+		bits := -1
+		addr, addrErr := netip.ParseAddr(identifier)
+		for _, c := range clients {
+			if strings.EqualFold(c.Client, identifier) {
+				memberOf = c.Groups
+				break
+			}
+			if addrErr != nil {
+				continue
+			}
+			if prefix, err := netip.ParsePrefix(c.Client); err == nil && prefix.Contains(addr) && prefix.Bits() > bits {
+				memberOf, bits = c.Groups, prefix.Bits()
+			}
+		}
+	}
+
+	ids := []int64{}
+	for _, g := range groups {
+		if g.Enabled && slices.Contains(memberOf, g.ID) {
+			ids = append(ids, g.ID)
+		}
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// decidePolicy evaluates the search result for a client in the given groups
+// the way Pi-hole does: allow entries win over deny entries, then come
+// exact deny entries, blocklists not overridden by an allowlist, and deny
+// regexes. Only enabled entries sharing a group with the client apply.
+func decidePolicy(result *pihole.SearchResult, groups []int64) policyDecision {
+	applies := func(enabled bool, entryGroups []int64) bool {
+		return enabled && slices.ContainsFunc(entryGroups, func(id int64) bool { return slices.Contains(groups, id) })
+	}
+	domain := func(domainType, kind string) (string, bool) {
+		for _, d := range result.Domains {
+			if d.Type == domainType && d.Kind == kind && applies(d.Enabled, d.Groups) {
+				return d.Domain, true
+			}
+		}
+		return "", false
+	}
+	list := func(listType string) (string, bool) {
+		for _, l := range result.Gravity {
+			if l.Type == listType && applies(l.Enabled, l.Groups) {
+				return l.Address, true
+			}
+		}
+		return "", false
+	}
+
+	if rule, ok := domain("allow", "exact"); ok {
+		return policyDecision{reason: policyReasonExactAllow, rule: rule}
+	}
+	if rule, ok := domain("allow", "regex"); ok {
+		return policyDecision{reason: policyReasonRegexAllow, rule: rule}
+	}
+	if rule, ok := domain("deny", "exact"); ok {
+		return policyDecision{blocked: true, reason: policyReasonExactDeny, rule: rule}
+	}
+	if rule, ok := list("block"); ok {
+		if _, allowed := list("allow"); !allowed {
+			return policyDecision{blocked: true, reason: policyReasonGravity, rule: rule}
+		}
+	}
+	if rule, ok := domain("deny", "regex"); ok {
+		return policyDecision{blocked: true, reason: policyReasonRegexDeny, rule: rule}
+	}
+	return policyDecision{reason: policyReasonNone}
+}
@@ -0,0 +1,147 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestClientGroups(t *testing.T) {
+	groups := []pihole.Group{
+		{ID: 0, Name: "Default", Enabled: true},
+		{ID: 2, Name: "kids", Enabled: true},
+		{ID: 3, Name: "iot", Enabled: true},
+		{ID: 4, Name: "paused", Enabled: false},
+	}
+	clients := []pihole.PiholeClient{
+		{Client: "192.168.1.0/24", Groups: []int64{0, 3}},
+		{Client: "192.168.1.0/28", Groups: []int64{0, 2, 4}},
+		{Client: "AA:BB:CC:DD:EE:10", Groups: []int64{2}},
+	}
+
+	tests := []struct {
+		identifier string
+		want       []int64
+	}{
+		{"", []int64{0}},
+		{"aa:bb:cc:dd:ee:10", []int64{2}},
+		{"192.168.1.5", []int64{0, 2}},
+		{"192.168.1.100", []int64{0, 3}},
+		{"10.0.0.1", []int64{0}},
+		{"laptop.lan", []int64{0}},
+	}
+	for _, tt := range tests {
+		if got := clientGroups(clients, groups, tt.identifier); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("clientGroups(%q) = %v, want %v", tt.identifier, got, tt.want)
+		}
+	}
+}
+
+func TestDecidePolicy(t *testing.T) {
+	denyRegex := pihole.Domain{Domain: `(\.|^)example\.com$`, Type: "deny", Kind: "regex", Enabled: true, Groups: []int64{0}}
+	blocklist := pihole.GravityMatch{List: pihole.List{Address: "https://example.com/hosts.txt", Type: "block", Enabled: true, Groups: []int64{0}}}
+	allowlist := pihole.GravityMatch{List: pihole.List{Address: "https://example.com/allow.txt", Type: "allow", Enabled: true, Groups: []int64{2}}}
+	exactAllow := pihole.Domain{Domain: "ads.example.com", Type: "allow", Kind: "exact", Enabled: true, Groups: []int64{2}}
+	exactDeny := pihole.Domain{Domain: "ads.example.com", Type: "deny", Kind: "exact", Enabled: false, Groups: []int64{0}}
+
+	tests := []struct {
+		name   string
+		result pihole.SearchResult
+		groups []int64
+		want   policyDecision
+	}{
+		{
+			name: "no match",
+			want: policyDecision{reason: policyReasonNone},
+		},
+		{
+			name:   "gravity before regex",
+			result: pihole.SearchResult{Domains: []pihole.Domain{denyRegex}, Gravity: []pihole.GravityMatch{blocklist}},
+			groups: []int64{0},
+			want:   policyDecision{blocked: true, reason: policyReasonGravity, rule: blocklist.Address},
+		},
+		{
+			name:   "allowlist overrides gravity but not regex",
+			result: pihole.SearchResult{Domains: []pihole.Domain{denyRegex}, Gravity: []pihole.GravityMatch{blocklist, allowlist}},
+			groups: []int64{0, 2},
+			want:   policyDecision{blocked: true, reason: policyReasonRegexDeny, rule: denyRegex.Domain},
+		},
+		{
+			name:   "allow entry wins",
+			result: pihole.SearchResult{Domains: []pihole.Domain{denyRegex, exactAllow}, Gravity: []pihole.GravityMatch{blocklist}},
+			groups: []int64{0, 2},
+			want:   policyDecision{reason: policyReasonExactAllow, rule: exactAllow.Domain},
+		},
+		{
+			name:   "rules of other groups and disabled rules do not apply",
+			result: pihole.SearchResult{Domains: []pihole.Domain{exactAllow, exactDeny}},
+			groups: []int64{0},
+			want:   policyDecision{reason: policyReasonNone},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := decidePolicy(&tt.result, tt.groups); got != tt.want {
+				t.Errorf("decidePolicy() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAccDataSourceEffectivePolicy_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "pihole_group" "test" {
+  name = "tf-acc-effective-policy"
+}
+
+resource "pihole_domain" "deny" {
+  domain = "tf-acc-effective-policy.tf-acc-zone.example.com"
+  type   = "deny"
+  kind   = "exact"
+}
+
+resource "pihole_domain" "allow" {
+  domain = "tf-acc-effective-policy.tf-acc-zone.example.com"
+  type   = "allow"
+  kind   = "exact"
+  groups = [pihole_group.test.id]
+}
+
+resource "pihole_client" "test" {
+  client = "192.0.2.10"
+  groups = [0, pihole_group.test.id]
+}
+
+data "pihole_effective_policy" "default" {
+  domain     = pihole_domain.deny.domain
+  depends_on = [pihole_domain.allow, pihole_client.test]
+}
+
+data "pihole_effective_policy" "client" {
+  domain     = pihole_domain.deny.domain
+  client     = pihole_client.test.client
+  depends_on = [pihole_domain.allow]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.pihole_effective_policy.default", "blocked", "true"),
+					resource.TestCheckResourceAttr("data.pihole_effective_policy.default", "reason", "exact_deny"),
+					resource.TestCheckResourceAttr("data.pihole_effective_policy.default", "groups.#", "1"),
+					resource.TestCheckResourceAttr("data.pihole_effective_policy.client", "blocked", "false"),
+					resource.TestCheckResourceAttr("data.pihole_effective_policy.client", "reason", "exact_allow"),
+					resource.TestCheckResourceAttr("data.pihole_effective_policy.client", "groups.#", "2"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &FTLDataSource{}
+
+func NewFTLDataSource() datasource.DataSource {
+	return &FTLDataSource{}
+}
+
+type FTLDataSource struct {
+	client *pihole.Client
+}
+
+type FTLDataSourceModel struct {
+	Gravity    types.Int64 `tfsdk:"gravity"`
+	Groups     types.Int64 `tfsdk:"groups"`
+	Lists      types.Int64 `tfsdk:"lists"`
+	Clients    types.Int64 `tfsdk:"clients"`
+	AllowExact types.Int64 `tfsdk:"allow_exact"`
+	AllowRegex types.Int64 `tfsdk:"allow_regex"`
+	DenyExact  types.Int64 `tfsdk:"deny_exact"`
+	DenyRegex  types.Int64 `tfsdk:"deny_regex"`
+}
+
+func (d *FTLDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ftl"
+}
+
+func (d *FTLDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Fetches the gravity database counters FTL reports.",
+		MarkdownDescription: `
+Fetches the gravity database counters FTL reports: how many domains gravity
+blocks, and how many groups, lists, clients and allow/deny rules exist. Useful
+for guardrails, e.g. failing when gravity is empty after the lists changed.
+
+## Example Usage
+
+` + "```hcl" + `
+check "gravity_loaded" {
+  data "pihole_ftl" "this" {
+    depends_on = [pihole_list.blocklist]
+  }
+
+  assert {
+    condition     = data.pihole_ftl.this.gravity > 0
+    error_message = "Gravity is empty; check that the blocklists can be downloaded."
+  }
+}
+` + "```" + `
+`,
+		Attributes: map[string]schema.Attribute{
+			"gravity": schema.Int64Attribute{
+				Description: "Number of unique domains on enabled lists.",
+				Computed:    true,
+			},
+			"groups": schema.Int64Attribute{
+				Description: "Number of groups.",
+				Computed:    true,
+			},
+			"lists": schema.Int64Attribute{
+				Description: "Number of lists.",
+				Computed:    true,
+			},
+			"clients": schema.Int64Attribute{
+				Description: "Number of configured clients.",
+				Computed:    true,
+			},
+			"allow_exact": schema.Int64Attribute{
+				Description: "Number of exact allowed domains.",
+				Computed:    true,
+			},
+			"allow_regex": schema.Int64Attribute{
+				Description: "Number of allowing regular expressions.",
+				Computed:    true,
+			},
+			"deny_exact": schema.Int64Attribute{
+				Description: "Number of exact denied domains.",
+				Computed:    true,
+			},
+			"deny_regex": schema.Int64Attribute{
+				Description: "Number of denying regular expressions.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *FTLDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.client
+}
+
+func (d *FTLDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	info, err := d.client.GetFTLInfo(ctx)
+	if err != nil {
+		addAPIErrorf(&resp.Diagnostics, "Error reading FTL info", err, "Could not read FTL information")
+		return
+	}
+
+	db := info.FTL.Database
+	data := FTLDataSourceModel{
+		Gravity:    types.Int64Value(db.Gravity),
+		Groups:     types.Int64Value(db.Groups),
+		Lists:      types.Int64Value(db.Lists),
+		Clients:    types.Int64Value(db.Clients),
+		AllowExact: types.Int64Value(db.Domains.Allowed.Total),
+		AllowRegex: types.Int64Value(db.Regex.Allowed.Total),
+		DenyExact:  types.Int64Value(db.Domains.Denied.Total),
+		DenyRegex:  types.Int64Value(db.Regex.Denied.Total),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
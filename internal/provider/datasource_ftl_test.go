@@ -0,0 +1,27 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDataSourceFTL_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "pihole_ftl" "test" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.pihole_ftl.test", "gravity"),
+					resource.TestCheckResourceAttrSet("data.pihole_ftl.test", "groups"),
+					resource.TestCheckResourceAttrSet("data.pihole_ftl.test", "deny_regex"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,188 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &GroupIDsDataSource{}
+
+func NewGroupIDsDataSource() datasource.DataSource {
+	return &GroupIDsDataSource{}
+}
+
+// GroupIDsDataSource resolves group names to IDs with a single request.
+type GroupIDsDataSource struct {
+	client *pihole.Client
+}
+
+type GroupIDsDataSourceModel struct {
+	Names         types.Set  `tfsdk:"names"`
+	FailOnMissing types.Bool `tfsdk:"fail_on_missing"`
+	IDs           types.Map  `tfsdk:"ids"`
+	GroupIDs      types.List `tfsdk:"group_ids"`
+	Missing       types.List `tfsdk:"missing"`
+}
+
+func (d *GroupIDsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_group_ids"
+}
+
+func (d *GroupIDsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Maps a set of group names to their IDs.",
+		MarkdownDescription: `
+Maps a set of group names to their IDs with a single request, so modules can
+pass group names around and resolve them where a resource needs IDs, instead of
+looking every group up on its own.
+
+## Example Usage
+
+` + "```hcl" + `
+variable "client_groups" {
+  type    = set(string)
+  default = ["Default", "kids", "iot"]
+}
+
+data "pihole_group_ids" "client" {
+  names = var.client_groups
+}
+
+resource "pihole_client" "tablet" {
+  client = "192.168.1.50"
+  groups = data.pihole_group_ids.client.group_ids
+}
+` + "```" + `
+`,
+		Attributes: map[string]schema.Attribute{
+			"names": schema.SetAttribute{
+				Description: "The names of the groups to look up.",
+				Required:    true,
+				ElementType: types.StringType,
+			},
+			"fail_on_missing": schema.BoolAttribute{
+				Description: "Fail when a group does not exist. When false, missing groups are listed in `missing`. Default: true.",
+				Optional:    true,
+			},
+			"ids": schema.MapAttribute{
+				Description: "The ID of every group found, by name.",
+				Computed:    true,
+				ElementType: types.Int64Type,
+			},
+			"group_ids": schema.ListAttribute{
+				Description: "The IDs of the groups found, in ascending order.",
+				Computed:    true,
+				ElementType: types.Int64Type,
+			},
+			"missing": schema.ListAttribute{
+				Description: "The names that match no group, sorted.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (d *GroupIDsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.client
+}
+
+func (d *GroupIDsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data GroupIDsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var names []string
+	resp.Diagnostics.Append(data.Names.ElementsAs(ctx, &names, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	groups, err := d.client.GetGroups(ctx, "")
+	if err != nil {
+		addAPIErrorf(&resp.Diagnostics, "Error reading groups", err, "Could not read groups")
+		return
+	}
+
+	ids, missing := groupIDsByName(groups, names)
+	if len(missing) > 0 && (data.FailOnMissing.IsNull() || data.FailOnMissing.ValueBool()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("names"),
+			"Groups not found",
+			fmt.Sprintf("No group exists with the name %s. Set fail_on_missing = false to ignore missing groups.",
+				strings.Join(missing, ", ")),
+		)
+		return
+	}
+
+	idValues := make(map[string]attr.Value, len(ids))
+	groupIDs := make([]int64, 0, len(ids))
+	for name, id := range ids {
+		idValues[name] = types.Int64Value(id)
+		groupIDs = append(groupIDs, id)
+	}
+	sort.Slice(groupIDs, func(i, j int) bool { return groupIDs[i] < groupIDs[j] })
+
+	groupIDValues := make([]attr.Value, len(groupIDs))
+	for i, id := range groupIDs {
+		groupIDValues[i] = types.Int64Value(id)
+	}
+	missingValues := make([]attr.Value, len(missing))
+	for i, name := range missing {
+		missingValues[i] = types.StringValue(name)
+	}
+
+	data.IDs = types.MapValueMust(types.Int64Type, idValues)
+	data.GroupIDs = types.ListValueMust(types.Int64Type, groupIDValues)
+	data.Missing = types.ListValueMust(types.StringType, missingValues)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// groupIDsByName returns the IDs of the named groups, and the sorted names
+// that match no group. Group names are unique and matched exactly.
+func groupIDsByName(groups []pihole.Group, names []string) (map[string]int64, []string) {
+	byName := make(map[string]int64, len(groups))
+	for _, g := range groups {
+		byName[g.Name] = g.ID
+	}
+
+	ids := make(map[string]int64, len(names))
+	var missing []string
+	for _, name := range names {
+		if id, ok := byName[name]; ok {
+			ids[name] = id
+		} else {
+			missing = append(missing, name)
+		}
+	}
+	sort.Strings(missing)
+	return ids, missing
+}
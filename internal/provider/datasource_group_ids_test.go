@@ -0,0 +1,69 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestGroupIDsByName(t *testing.T) {
+	groups := []pihole.Group{
+		{ID: 0, Name: "Default"},
+		{ID: 3, Name: "kids"},
+		{ID: 5, Name: "iot"},
+	}
+
+	ids, missing := groupIDsByName(groups, []string{"kids", "Default", "guests", "Kids"})
+	if want := map[string]int64{"kids": 3, "Default": 0}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("ids = %v, want %v", ids, want)
+	}
+	if want := []string{"Kids", "guests"}; !reflect.DeepEqual(missing, want) {
+		t.Errorf("missing = %v, want %v", missing, want)
+	}
+}
+
+func TestAccDataSourceGroupIDs_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "pihole_group" "test" {
+  name = "tf-acc-group-ids"
+}
+
+data "pihole_group_ids" "test" {
+  names           = ["Default", pihole_group.test.name, "group-ids-missing"]
+  fail_on_missing = false
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.pihole_group_ids.test", "ids.Default", "0"),
+					resource.TestCheckResourceAttrPair("data.pihole_group_ids.test", "ids.tf-acc-group-ids", "pihole_group.test", "id"),
+					resource.TestCheckResourceAttr("data.pihole_group_ids.test", "group_ids.#", "2"),
+					resource.TestCheckResourceAttr("data.pihole_group_ids.test", "missing.0", "group-ids-missing"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceGroupIDs_failOnMissing(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      `data "pihole_group_ids" "test" { names = ["group-ids-missing"] }`,
+				ExpectError: regexp.MustCompile(`Groups not found`),
+			},
+		},
+	})
+}
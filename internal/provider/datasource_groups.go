@@ -7,7 +7,8 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/dklesev/terraform-provider-pihole/internal/client"
+	"github.com/dklesev/terraform-provider-pihole/internal/provider/convert"
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -20,7 +21,7 @@ func NewGroupsDataSource() datasource.DataSource {
 }
 
 type GroupsDataSource struct {
-	client *client.Client
+	client *pihole.Client
 }
 
 type GroupsDataSourceModel struct {
@@ -93,16 +94,16 @@ func (d *GroupsDataSource) Configure(ctx context.Context, req datasource.Configu
 		return
 	}
 
-	c, ok := req.ProviderData.(*client.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData),
 		)
 		return
 	}
 
-	d.client = c
+	d.client = data.client
 }
 
 func (d *GroupsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -110,10 +111,7 @@ func (d *GroupsDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 
 	groups, err := d.client.GetGroups(ctx, "")
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error reading groups",
-			fmt.Sprintf("Could not read groups: %s", err.Error()),
-		)
+		addAPIErrorf(&resp.Diagnostics, "Error reading groups", err, "Could not read groups")
 		return
 	}
 
@@ -125,8 +123,8 @@ func (d *GroupsDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
-// mapGroupToDataSourceModel maps a client.Group to the data source model.
-func mapGroupToDataSourceModel(g *client.Group) GroupDataSourceModel {
+// mapGroupToDataSourceModel maps a pihole.Group to the data source model.
+func mapGroupToDataSourceModel(g *pihole.Group) GroupDataSourceModel {
 	model := GroupDataSourceModel{
 		ID:        types.Int64Value(g.ID),
 		Name:      types.StringValue(g.Name),
@@ -134,11 +132,7 @@ func mapGroupToDataSourceModel(g *client.Group) GroupDataSourceModel {
 		DateAdded: types.Int64Value(g.DateAdded),
 	}
 
-	if g.Description != "" {
-		model.Description = types.StringValue(g.Description)
-	} else {
-		model.Description = types.StringNull()
-	}
+	model.Description = convert.OptionalString(g.Description)
 
 	return model
 }
@@ -50,7 +50,7 @@ data "pihole_groups" "test" {}
 func testAccDataSourceGroupsWithResourcesConfig() string {
 	return `
 resource "pihole_group" "test" {
-  name        = "datasource-test-group"
+  name        = "tf-acc-ds-groups"
   description = "Created for datasource test"
 }
 
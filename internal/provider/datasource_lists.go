@@ -6,8 +6,10 @@ package provider
 import (
 	"context"
 	"fmt"
+	"slices"
 
-	"github.com/dklesev/terraform-provider-pihole/internal/client"
+	"github.com/dklesev/terraform-provider-pihole/internal/provider/convert"
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
@@ -23,24 +25,31 @@ func NewListsDataSource() datasource.DataSource {
 }
 
 type ListsDataSource struct {
-	client *client.Client
+	client *pihole.Client
 }
 
 type ListsDataSourceModel struct {
-	Type  types.String          `tfsdk:"type"`
-	Lists []ListDataSourceModel `tfsdk:"lists"`
+	Type         types.String          `tfsdk:"type"`
+	ManagedByTag types.String          `tfsdk:"managed_by_tag"`
+	GroupID      types.Int64           `tfsdk:"group_id"`
+	Enabled      types.Bool            `tfsdk:"enabled"`
+	Lists        []ListDataSourceModel `tfsdk:"lists"`
 }
 
 type ListDataSourceModel struct {
-	ID        types.Int64  `tfsdk:"id"`
-	Address   types.String `tfsdk:"address"`
-	Type      types.String `tfsdk:"type"`
-	Enabled   types.Bool   `tfsdk:"enabled"`
-	Comment   types.String `tfsdk:"comment"`
-	Groups    types.List   `tfsdk:"groups"`
-	DateAdded types.Int64  `tfsdk:"date_added"`
-	Number    types.Int64  `tfsdk:"number"`
-	Status    types.Int64  `tfsdk:"status"`
+	ID             types.Int64  `tfsdk:"id"`
+	Address        types.String `tfsdk:"address"`
+	Type           types.String `tfsdk:"type"`
+	Enabled        types.Bool   `tfsdk:"enabled"`
+	Comment        types.String `tfsdk:"comment"`
+	Groups         types.List   `tfsdk:"groups"`
+	DateAdded      types.Int64  `tfsdk:"date_added"`
+	DateModified   types.Int64  `tfsdk:"date_modified"`
+	DateUpdated    types.Int64  `tfsdk:"date_updated"`
+	Number         types.Int64  `tfsdk:"number"`
+	InvalidDomains types.Int64  `tfsdk:"invalid_domains"`
+	ABPEntries     types.Int64  `tfsdk:"abp_entries"`
+	Status         types.Int64  `tfsdk:"status"`
 }
 
 func (d *ListsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -68,6 +77,34 @@ data "pihole_lists" "blocklists" {
   type = "block"
 }
 ` + "```" + `
+
+### Enabled Blocklists of a Group
+
+` + "```hcl" + `
+data "pihole_lists" "kids" {
+  type     = "block"
+  group_id = pihole_group.kids.id
+  enabled  = true
+}
+` + "```" + `
+
+### Alert on Stale Lists
+
+` + "```hcl" + `
+data "pihole_lists" "enabled" {
+  enabled = true
+}
+
+# now_unix is passed in, e.g. -var "now_unix=$(date +%s)"
+check "lists_fresh" {
+  assert {
+    condition = alltrue([
+      for l in data.pihole_lists.enabled.lists : l.date_updated > var.now_unix - 7 * 86400 && l.status <= 2
+    ])
+    error_message = "A list could not be downloaded or has not been updated for a week."
+  }
+}
+` + "```" + `
 `,
 		Attributes: map[string]schema.Attribute{
 			"type": schema.StringAttribute{
@@ -77,6 +114,18 @@ data "pihole_lists" "blocklists" {
 					stringvalidator.OneOf("block", "allow"),
 				},
 			},
+			"managed_by_tag": schema.StringAttribute{
+				Description: "Only return entries whose comment carries the `[tf:<tag>]` marker of this `managed_by_tag`.",
+				Optional:    true,
+			},
+			"group_id": schema.Int64Attribute{
+				Description: "Only return lists assigned to this group.",
+				Optional:    true,
+			},
+			"enabled": schema.BoolAttribute{
+				Description: "Only return enabled (true) or disabled (false) lists. Leave empty for all.",
+				Optional:    true,
+			},
 			"lists": schema.ListNestedAttribute{
 				Description: "List of list subscriptions matching the filter.",
 				Computed:    true,
@@ -111,12 +160,28 @@ data "pihole_lists" "blocklists" {
 							Description: "Unix timestamp when the list was added.",
 							Computed:    true,
 						},
+						"date_modified": schema.Int64Attribute{
+							Description: "Unix timestamp when the list was last modified.",
+							Computed:    true,
+						},
+						"date_updated": schema.Int64Attribute{
+							Description: "Unix timestamp when gravity last downloaded the list. 0 if it never has.",
+							Computed:    true,
+						},
 						"number": schema.Int64Attribute{
 							Description: "Number of domains in the list.",
 							Computed:    true,
 						},
+						"invalid_domains": schema.Int64Attribute{
+							Description: "Number of lines gravity could not parse as domains at the last download.",
+							Computed:    true,
+						},
+						"abp_entries": schema.Int64Attribute{
+							Description: "Number of Adblock Plus style entries in the list.",
+							Computed:    true,
+						},
 						"status": schema.Int64Attribute{
-							Description: "Download status of the list.",
+							Description: "Download status of the list: 1 = updated, 2 = unchanged, 3 = unavailable (cached copy used), 4 = unavailable (no copy).",
 							Computed:    true,
 						},
 					},
@@ -131,16 +196,16 @@ func (d *ListsDataSource) Configure(ctx context.Context, req datasource.Configur
 		return
 	}
 
-	c, ok := req.ProviderData.(*client.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData),
 		)
 		return
 	}
 
-	d.client = c
+	d.client = data.client
 }
 
 func (d *ListsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -158,50 +223,52 @@ func (d *ListsDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 
 	lists, err := d.client.GetLists(ctx, listType, "")
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error reading lists",
-			fmt.Sprintf("Could not read lists: %s", err.Error()),
-		)
+		addAPIErrorf(&resp.Diagnostics, "Error reading lists", err, "Could not read lists")
 		return
 	}
 
-	data.Lists = make([]ListDataSourceModel, len(lists))
-	for i, l := range lists {
+	data.Lists = make([]ListDataSourceModel, 0, len(lists))
+	for _, l := range lists {
+		if !data.ManagedByTag.IsNull() && !hasManagedTag(l.Comment, data.ManagedByTag.ValueString()) {
+			continue
+		}
+		if !data.GroupID.IsNull() && !slices.Contains(l.Groups, data.GroupID.ValueInt64()) {
+			continue
+		}
+		if !data.Enabled.IsNull() && l.Enabled != data.Enabled.ValueBool() {
+			continue
+		}
 		model, diags := mapListToDataSourceModel(ctx, &l)
 		resp.Diagnostics.Append(diags...)
-		data.Lists[i] = model
+		data.Lists = append(data.Lists, model)
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
-// mapListToDataSourceModel maps a client.List to the data source model.
-func mapListToDataSourceModel(ctx context.Context, l *client.List) (ListDataSourceModel, diag.Diagnostics) {
+// mapListToDataSourceModel maps a pihole.List to the data source model.
+func mapListToDataSourceModel(ctx context.Context, l *pihole.List) (ListDataSourceModel, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
 	model := ListDataSourceModel{
-		ID:        types.Int64Value(l.ID),
-		Address:   types.StringValue(l.Address),
-		Type:      types.StringValue(l.Type),
-		Enabled:   types.BoolValue(l.Enabled),
-		DateAdded: types.Int64Value(l.DateAdded),
-		Number:    types.Int64Value(l.Number),
-		Status:    types.Int64Value(int64(l.Status)),
+		ID:             types.Int64Value(l.ID),
+		Address:        types.StringValue(l.Address),
+		Type:           types.StringValue(l.Type),
+		Enabled:        types.BoolValue(l.Enabled),
+		DateAdded:      types.Int64Value(l.DateAdded),
+		DateModified:   types.Int64Value(l.DateModified),
+		DateUpdated:    types.Int64Value(l.DateUpdated),
+		Number:         types.Int64Value(l.Number),
+		InvalidDomains: types.Int64Value(l.InvalidDomains),
+		ABPEntries:     types.Int64Value(l.ABPEntries),
+		Status:         types.Int64Value(int64(l.Status)),
 	}
 
-	if l.Comment != "" {
-		model.Comment = types.StringValue(l.Comment)
-	} else {
-		model.Comment = types.StringNull()
-	}
+	model.Comment = convert.OptionalString(l.Comment)
 
-	if len(l.Groups) > 0 {
-		groupsList, d := types.ListValueFrom(ctx, types.Int64Type, l.Groups)
-		diags.Append(d...)
-		model.Groups = groupsList
-	} else {
-		model.Groups = types.ListNull(types.Int64Type)
-	}
+	groups, d := convert.GroupList(ctx, l.Groups)
+	diags.Append(d...)
+	model.Groups = groups
 
 	return model, diags
 }
@@ -39,10 +39,56 @@ func TestAccDataSourceLists_filterByType(t *testing.T) {
 	})
 }
 
+func TestAccDataSourceLists_filterByGroupAndEnabled(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "pihole_group" "test" {
+  name = "tf-acc-ds-lists"
+}
+
+resource "pihole_list" "enabled" {
+  address = "https://example.com/tf-acc-ds-group-enabled.txt"
+  type    = "block"
+  enabled = true
+  groups  = [pihole_group.test.id]
+}
+
+resource "pihole_list" "disabled" {
+  address = "https://example.com/tf-acc-ds-group-disabled.txt"
+  type    = "block"
+  enabled = false
+  groups  = [pihole_group.test.id]
+}
+
+data "pihole_lists" "group" {
+  group_id   = pihole_group.test.id
+  depends_on = [pihole_list.enabled, pihole_list.disabled]
+}
+
+data "pihole_lists" "group_enabled" {
+  group_id   = pihole_group.test.id
+  enabled    = true
+  depends_on = [pihole_list.enabled, pihole_list.disabled]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.pihole_lists.group", "lists.#", "2"),
+					resource.TestCheckResourceAttr("data.pihole_lists.group_enabled", "lists.#", "1"),
+					resource.TestCheckResourceAttr("data.pihole_lists.group_enabled", "lists.0.address", "https://example.com/tf-acc-ds-group-enabled.txt"),
+				),
+			},
+		},
+	})
+}
+
 func testAccDataSourceListsConfig() string {
 	return `
 resource "pihole_list" "test" {
-  address = "https://example.com/ds-test-list.txt"
+  address = "https://example.com/tf-acc-ds-test-list.txt"
   type    = "block"
   enabled = true
 }
@@ -56,7 +102,7 @@ data "pihole_lists" "test" {
 func testAccDataSourceListsFilterConfig() string {
 	return `
 resource "pihole_list" "test" {
-  address = "https://example.com/ds-filter-list.txt"
+  address = "https://example.com/tf-acc-ds-filter-list.txt"
   type    = "block"
   enabled = true
 }
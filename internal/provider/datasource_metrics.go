@@ -0,0 +1,200 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &MetricsDataSource{}
+
+func NewMetricsDataSource() datasource.DataSource {
+	return &MetricsDataSource{}
+}
+
+type MetricsDataSource struct {
+	client *pihole.Client
+}
+
+type MetricsDataSourceModel struct {
+	QueriesTotal      types.Int64   `tfsdk:"queries_total"`
+	QueriesBlocked    types.Int64   `tfsdk:"queries_blocked"`
+	PercentBlocked    types.Float64 `tfsdk:"percent_blocked"`
+	QueriesCached     types.Int64   `tfsdk:"queries_cached"`
+	QueriesForwarded  types.Int64   `tfsdk:"queries_forwarded"`
+	CacheHitRatio     types.Float64 `tfsdk:"cache_hit_ratio"`
+	UniqueDomains     types.Int64   `tfsdk:"unique_domains"`
+	QueriesPerSecond  types.Float64 `tfsdk:"queries_per_second"`
+	ClientsActive     types.Int64   `tfsdk:"clients_active"`
+	ClientsTotal      types.Int64   `tfsdk:"clients_total"`
+	GravityDomains    types.Int64   `tfsdk:"gravity_domains"`
+	GravityLastUpdate types.Int64   `tfsdk:"gravity_last_update"`
+	Uptime            types.Int64   `tfsdk:"uptime"`
+}
+
+func (d *MetricsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_metrics"
+}
+
+func (d *MetricsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Fetches Pi-hole's key statistics as flat numeric attributes.",
+		MarkdownDescription: `
+Fetches Pi-hole's key statistics as flat numeric attributes, one number each,
+like the gauges of a Prometheus exporter: queries and blocked queries over the
+last 24 hours, cache hits, gravity size and FTL's uptime. Useful to hand the
+numbers to external monitoring through outputs, or to gate a canary rollout on
+a healthy instance.
+
+In anonymous mode, privacy level 3, FTL keeps no query history, so the query
+counters only cover queries since FTL last started; the data source warns when
+that applies.
+
+## Example Usage
+
+` + "```hcl" + `
+data "pihole_metrics" "this" {}
+
+output "pihole_metrics" {
+  value = {
+    queries_total   = data.pihole_metrics.this.queries_total
+    percent_blocked = data.pihole_metrics.this.percent_blocked
+    cache_hit_ratio = data.pihole_metrics.this.cache_hit_ratio
+  }
+}
+
+# Fail the canary rollout when Pi-hole restarted or lost gravity
+check "canary_healthy" {
+  data "pihole_metrics" "after_apply" {
+    depends_on = [pihole_config_dns.canary]
+  }
+
+  assert {
+    condition     = data.pihole_metrics.after_apply.gravity_domains > 0 && data.pihole_metrics.after_apply.uptime > 60
+    error_message = "Pi-hole has no gravity or restarted less than a minute ago."
+  }
+}
+` + "```" + `
+`,
+		Attributes: map[string]schema.Attribute{
+			"queries_total": schema.Int64Attribute{
+				Description: "Number of queries over the last 24 hours.",
+				Computed:    true,
+			},
+			"queries_blocked": schema.Int64Attribute{
+				Description: "Number of blocked queries over the last 24 hours.",
+				Computed:    true,
+			},
+			"percent_blocked": schema.Float64Attribute{
+				Description: "Percentage (0-100) of queries blocked.",
+				Computed:    true,
+			},
+			"queries_cached": schema.Int64Attribute{
+				Description: "Number of queries answered from the cache (cache hits).",
+				Computed:    true,
+			},
+			"queries_forwarded": schema.Int64Attribute{
+				Description: "Number of queries forwarded to an upstream server.",
+				Computed:    true,
+			},
+			"cache_hit_ratio": schema.Float64Attribute{
+				Description: "Share (0-1) of the cached and forwarded queries that were answered from the cache, 0 without such queries.",
+				Computed:    true,
+			},
+			"unique_domains": schema.Int64Attribute{
+				Description: "Number of unique domains queried.",
+				Computed:    true,
+			},
+			"queries_per_second": schema.Float64Attribute{
+				Description: "Average number of queries per second.",
+				Computed:    true,
+			},
+			"clients_active": schema.Int64Attribute{
+				Description: "Number of clients that sent queries over the last 24 hours.",
+				Computed:    true,
+			},
+			"clients_total": schema.Int64Attribute{
+				Description: "Number of clients FTL has seen.",
+				Computed:    true,
+			},
+			"gravity_domains": schema.Int64Attribute{
+				Description: "Number of domains gravity blocks.",
+				Computed:    true,
+			},
+			"gravity_last_update": schema.Int64Attribute{
+				Description: "Unix time of the last gravity update.",
+				Computed:    true,
+			},
+			"uptime": schema.Int64Attribute{
+				Description: "Seconds since FTL started.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *MetricsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.client
+}
+
+func (d *MetricsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	summary, err := d.client.GetStatsSummary(ctx)
+	if err != nil {
+		addAPIErrorf(&resp.Diagnostics, "Error reading metrics", err, "Could not read the statistics summary")
+		return
+	}
+	info, err := d.client.GetFTLInfo(ctx)
+	if err != nil {
+		addAPIErrorf(&resp.Diagnostics, "Error reading metrics", err, "Could not read FTL information")
+		return
+	}
+
+	warnPrivacyLevel(ctx, d.client, 3, "the query counters only cover queries since FTL last started", &resp.Diagnostics)
+
+	data := metricsModel(summary, info)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// metricsModel flattens the statistics summary and FTL's information.
+func metricsModel(summary *pihole.StatsSummaryResponse, info *pihole.FTLInfoResponse) MetricsDataSourceModel {
+	q := summary.Queries
+	ratio := 0.0
+	if answered := q.Cached + q.Forwarded; answered > 0 {
+		ratio = float64(q.Cached) / float64(answered)
+	}
+	return MetricsDataSourceModel{
+		QueriesTotal:      types.Int64Value(q.Total),
+		QueriesBlocked:    types.Int64Value(q.Blocked),
+		PercentBlocked:    types.Float64Value(q.PercentBlocked),
+		QueriesCached:     types.Int64Value(q.Cached),
+		QueriesForwarded:  types.Int64Value(q.Forwarded),
+		CacheHitRatio:     types.Float64Value(ratio),
+		UniqueDomains:     types.Int64Value(q.UniqueDomains),
+		QueriesPerSecond:  types.Float64Value(q.Frequency),
+		ClientsActive:     types.Int64Value(summary.Clients.Active),
+		ClientsTotal:      types.Int64Value(summary.Clients.Total),
+		GravityDomains:    types.Int64Value(summary.Gravity.DomainsBeingBlocked),
+		GravityLastUpdate: types.Int64Value(summary.Gravity.LastUpdate),
+		Uptime:            types.Int64Value(info.FTL.Uptime / 1000),
+	}
+}
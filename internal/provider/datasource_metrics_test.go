@@ -0,0 +1,56 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestMetricsModel(t *testing.T) {
+	var summary pihole.StatsSummaryResponse
+	summary.Queries.Total = 100
+	summary.Queries.Blocked = 40
+	summary.Queries.Cached = 15
+	summary.Queries.Forwarded = 45
+	summary.Gravity.DomainsBeingBlocked = 123456
+	info := &pihole.FTLInfoResponse{FTL: pihole.FTLInfo{Uptime: 3600999}}
+
+	data := metricsModel(&summary, info)
+	if got := data.CacheHitRatio.ValueFloat64(); got != 0.25 {
+		t.Errorf("cache_hit_ratio = %v, want 0.25", got)
+	}
+	if got := data.Uptime.ValueInt64(); got != 3600 {
+		t.Errorf("uptime = %d, want 3600", got)
+	}
+	if got := data.GravityDomains.ValueInt64(); got != 123456 {
+		t.Errorf("gravity_domains = %d, want 123456", got)
+	}
+
+	// No cached or forwarded queries yet
+	data = metricsModel(&pihole.StatsSummaryResponse{}, info)
+	if got := data.CacheHitRatio.ValueFloat64(); got != 0 {
+		t.Errorf("cache_hit_ratio = %v, want 0", got)
+	}
+}
+
+func TestAccDataSourceMetrics_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "pihole_metrics" "test" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.pihole_metrics.test", "queries_total"),
+					resource.TestCheckResourceAttrSet("data.pihole_metrics.test", "cache_hit_ratio"),
+					resource.TestCheckResourceAttrSet("data.pihole_metrics.test", "gravity_domains"),
+					resource.TestCheckResourceAttrSet("data.pihole_metrics.test", "uptime"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,212 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dklesev/terraform-provider-pihole/internal/provider/convert"
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &NetworkDevicesDataSource{}
+
+func NewNetworkDevicesDataSource() datasource.DataSource {
+	return &NetworkDevicesDataSource{}
+}
+
+type NetworkDevicesDataSource struct {
+	client *pihole.Client
+}
+
+type NetworkDevicesDataSourceModel struct {
+	Devices []NetworkDeviceDataSourceModel `tfsdk:"devices"`
+}
+
+type NetworkDeviceDataSourceModel struct {
+	ID         types.Int64  `tfsdk:"id"`
+	MAC        types.String `tfsdk:"mac"`
+	Interface  types.String `tfsdk:"interface"`
+	MACVendor  types.String `tfsdk:"mac_vendor"`
+	FirstSeen  types.Int64  `tfsdk:"first_seen"`
+	LastQuery  types.Int64  `tfsdk:"last_query"`
+	NumQueries types.Int64  `tfsdk:"num_queries"`
+	IPs        types.List   `tfsdk:"ips"`
+}
+
+// networkDeviceIPAttrTypes describes the objects in the ips list.
+var networkDeviceIPAttrTypes = map[string]attr.Type{
+	"ip":        types.StringType,
+	"name":      types.StringType,
+	"last_seen": types.Int64Type,
+}
+
+type networkDeviceIPModel struct {
+	IP       types.String `tfsdk:"ip"`
+	Name     types.String `tfsdk:"name"`
+	LastSeen types.Int64  `tfsdk:"last_seen"`
+}
+
+func (d *NetworkDevicesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_network_devices"
+}
+
+func (d *NetworkDevicesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Fetches the devices in Pi-hole's network table.",
+		MarkdownDescription: `
+Fetches the devices in Pi-hole's network table, including the vendor FTL
+derives from each MAC address and the addresses and names seen for it.
+
+The v6 API does not allow renaming network table entries. To label a device,
+manage a ` + "`pihole_client`" + ` keyed by its MAC address: the client comment is
+shown next to the device in the web interface and the same resource assigns
+its groups.
+
+## Example Usage
+
+` + "```hcl" + `
+data "pihole_network_devices" "all" {}
+
+# Label every Raspberry Pi on the network and put it in the IoT group
+resource "pihole_client" "pi" {
+  for_each = {
+    for d in data.pihole_network_devices.all.devices : d.mac => d
+    if d.mac_vendor == "Raspberry Pi Trading Ltd"
+  }
+
+  client  = each.key
+  comment = "Raspberry Pi (${each.value.interface})"
+  groups  = [pihole_group.iot.id]
+}
+` + "```" + `
+`,
+		Attributes: map[string]schema.Attribute{
+			"devices": schema.ListNestedAttribute{
+				Description: "List of devices in the network table.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Description: "The network table ID of the device.",
+							Computed:    true,
+						},
+						"mac": schema.StringAttribute{
+							Description: "The hardware address of the device. Devices seen without one use a pseudo address of the form `ip-<address>`.",
+							Computed:    true,
+						},
+						"interface": schema.StringAttribute{
+							Description: "The interface the device was seen on.",
+							Computed:    true,
+						},
+						"mac_vendor": schema.StringAttribute{
+							Description: "The vendor derived from the MAC address.",
+							Computed:    true,
+						},
+						"first_seen": schema.Int64Attribute{
+							Description: "Unix timestamp when the device was first seen.",
+							Computed:    true,
+						},
+						"last_query": schema.Int64Attribute{
+							Description: "Unix timestamp of the last query from the device.",
+							Computed:    true,
+						},
+						"num_queries": schema.Int64Attribute{
+							Description: "Number of queries made by the device.",
+							Computed:    true,
+						},
+						"ips": schema.ListNestedAttribute{
+							Description: "Addresses seen for the device.",
+							Computed:    true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"ip": schema.StringAttribute{
+										Description: "The IP address.",
+										Computed:    true,
+									},
+									"name": schema.StringAttribute{
+										Description: "The hostname resolved for the address.",
+										Computed:    true,
+									},
+									"last_seen": schema.Int64Attribute{
+										Description: "Unix timestamp when the address was last seen.",
+										Computed:    true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *NetworkDevicesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.client
+}
+
+func (d *NetworkDevicesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data NetworkDevicesDataSourceModel
+
+	devices, err := d.client.GetNetworkDevices(ctx)
+	if err != nil {
+		addAPIErrorf(&resp.Diagnostics, "Error reading network devices", err, "Could not read network devices")
+		return
+	}
+
+	data.Devices = make([]NetworkDeviceDataSourceModel, len(devices))
+	for i, device := range devices {
+		model, diags := mapNetworkDeviceToDataSourceModel(ctx, &device)
+		resp.Diagnostics.Append(diags...)
+		data.Devices[i] = model
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// mapNetworkDeviceToDataSourceModel maps a pihole.NetworkDevice to the data source model.
+func mapNetworkDeviceToDataSourceModel(ctx context.Context, device *pihole.NetworkDevice) (NetworkDeviceDataSourceModel, diag.Diagnostics) {
+	model := NetworkDeviceDataSourceModel{
+		ID:         types.Int64Value(device.ID),
+		MAC:        types.StringValue(device.HWAddr),
+		Interface:  types.StringValue(device.Interface),
+		MACVendor:  convert.OptionalString(device.MACVendor),
+		FirstSeen:  types.Int64Value(device.FirstSeen),
+		LastQuery:  types.Int64Value(device.LastQuery),
+		NumQueries: types.Int64Value(device.NumQueries),
+	}
+
+	ips := make([]networkDeviceIPModel, len(device.IPs))
+	for i, ip := range device.IPs {
+		ips[i] = networkDeviceIPModel{
+			IP:       types.StringValue(ip.IP),
+			Name:     convert.OptionalString(ip.Name),
+			LastSeen: types.Int64Value(ip.LastSeen),
+		}
+	}
+
+	var diags diag.Diagnostics
+	model.IPs, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: networkDeviceIPAttrTypes}, ips)
+	return model, diags
+}
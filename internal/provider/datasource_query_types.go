@@ -0,0 +1,130 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &QueryTypesDataSource{}
+
+func NewQueryTypesDataSource() datasource.DataSource {
+	return &QueryTypesDataSource{}
+}
+
+type QueryTypesDataSource struct {
+	client *pihole.Client
+}
+
+type QueryTypesDataSourceModel struct {
+	Types  types.Map   `tfsdk:"types"`
+	Counts types.Map   `tfsdk:"counts"`
+	Total  types.Int64 `tfsdk:"total"`
+}
+
+func (d *QueryTypesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_query_types"
+}
+
+func (d *QueryTypesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Fetches the share of DNS queries per record type over the last 24 hours.",
+		MarkdownDescription: `
+Fetches the share of DNS queries per record type (` + "`A`" + `, ` + "`AAAA`" + `, ` + "`HTTPS`" + `, ...)
+over the last 24 hours, as FTL reports it. Useful for audits, e.g. checking how
+many HTTPS (type 65) queries clients send, which browsers use to discover DNS
+over HTTPS endpoints that bypass Pi-hole.
+
+In anonymous mode, privacy level 3, FTL keeps no query history, so the counts
+only cover queries since FTL last started; the data source warns when that applies.
+
+## Example Usage
+
+` + "```hcl" + `
+data "pihole_query_types" "current" {}
+
+check "doh_discovery" {
+  assert {
+    condition     = lookup(data.pihole_query_types.current.types, "HTTPS", 0) < 10
+    error_message = "More than 10% of queries are HTTPS records; check for DoH bypass."
+  }
+}
+` + "```" + `
+`,
+		Attributes: map[string]schema.Attribute{
+			"types": schema.MapAttribute{
+				Description: "Percentage (0-100) of all queries per record type.",
+				Computed:    true,
+				ElementType: types.Float64Type,
+			},
+			"counts": schema.MapAttribute{
+				Description: "Number of queries per record type.",
+				Computed:    true,
+				ElementType: types.Int64Type,
+			},
+			"total": schema.Int64Attribute{
+				Description: "Total number of queries.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *QueryTypesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.client
+}
+
+func (d *QueryTypesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data QueryTypesDataSourceModel
+
+	stats, err := d.client.GetQueryTypes(ctx)
+	if err != nil {
+		addAPIErrorf(&resp.Diagnostics, "Error reading query types", err, "Could not read query type statistics")
+		return
+	}
+
+	var total int64
+	for _, count := range stats.Types {
+		total += count
+	}
+
+	shares := make(map[string]attr.Value, len(stats.Types))
+	counts := make(map[string]attr.Value, len(stats.Types))
+	for name, count := range stats.Types {
+		share := 0.0
+		if total > 0 {
+			share = float64(count) * 100 / float64(total)
+		}
+		shares[name] = types.Float64Value(share)
+		counts[name] = types.Int64Value(count)
+	}
+
+	warnPrivacyLevel(ctx, d.client, 3, "the counts only cover queries since FTL last started", &resp.Diagnostics)
+
+	data.Types = types.MapValueMust(types.Float64Type, shares)
+	data.Counts = types.MapValueMust(types.Int64Type, counts)
+	data.Total = types.Int64Value(total)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
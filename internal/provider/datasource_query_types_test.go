@@ -0,0 +1,60 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDataSourceQueryTypes_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "pihole_query_types" "test" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.pihole_query_types.test", "total"),
+					resource.TestCheckResourceAttrSet("data.pihole_query_types.test", "counts.A"),
+					resource.TestCheckResourceAttrSet("data.pihole_query_types.test", "types.HTTPS"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceQueryTypes_anonymousMode(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The privacy level is read at apply time, so the data source
+			// depends on the config resource and still returns counts
+			{
+				Config: `
+resource "pihole_config_misc" "test" {
+  privacy_level = 3
+}
+
+data "pihole_query_types" "test" {
+  depends_on = [pihole_config_misc.test]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_config_misc.test", "privacy_level", "3"),
+					resource.TestCheckResourceAttrSet("data.pihole_query_types.test", "total"),
+				),
+			},
+			{
+				Config: `
+resource "pihole_config_misc" "test" {
+  privacy_level = 0
+}
+`,
+			},
+		},
+	})
+}
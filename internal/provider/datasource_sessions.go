@@ -0,0 +1,193 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dklesev/terraform-provider-pihole/internal/provider/convert"
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &SessionsDataSource{}
+
+func NewSessionsDataSource() datasource.DataSource {
+	return &SessionsDataSource{}
+}
+
+type SessionsDataSource struct {
+	client *pihole.Client
+}
+
+type SessionsDataSourceModel struct {
+	Sessions []SessionDataSourceModel `tfsdk:"sessions"`
+}
+
+type SessionDataSourceModel struct {
+	ID            types.Int64  `tfsdk:"id"`
+	Current       types.Bool   `tfsdk:"current"`
+	Valid         types.Bool   `tfsdk:"valid"`
+	App           types.Bool   `tfsdk:"app"`
+	CLI           types.Bool   `tfsdk:"cli"`
+	TLS           types.Bool   `tfsdk:"tls"`
+	RemoteAddr    types.String `tfsdk:"remote_addr"`
+	XForwardedFor types.String `tfsdk:"x_forwarded_for"`
+	UserAgent     types.String `tfsdk:"user_agent"`
+	LoginAt       types.Int64  `tfsdk:"login_at"`
+	LastActive    types.Int64  `tfsdk:"last_active"`
+	ValidUntil    types.Int64  `tfsdk:"valid_until"`
+}
+
+func (d *SessionsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_sessions"
+}
+
+func (d *SessionsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Fetches the login sessions Pi-hole's API currently holds.",
+		MarkdownDescription: `
+Fetches the login sessions Pi-hole's API currently holds, with the address and
+user agent each was opened from. A scheduled plan can review them, e.g. fail
+when an address outside the admin network holds a session. The provider's own
+session is included and marked ` + "`current`" + `.
+
+## Example Usage
+
+` + "```hcl" + `
+locals {
+  admin_addresses = ["192.168.1.10", "192.168.1.11"]
+}
+
+data "pihole_sessions" "all" {
+  lifecycle {
+    postcondition {
+      condition = alltrue([
+        for s in self.sessions : s.current || contains(local.admin_addresses, s.remote_addr)
+      ])
+      error_message = "Pi-hole has sessions from unknown addresses."
+    }
+  }
+}
+` + "```" + `
+`,
+		Attributes: map[string]schema.Attribute{
+			"sessions": schema.ListNestedAttribute{
+				Description: "List of sessions.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Description: "The session ID, usable to revoke the session through the API.",
+							Computed:    true,
+						},
+						"current": schema.BoolAttribute{
+							Description: "Whether this is the provider's own session.",
+							Computed:    true,
+						},
+						"valid": schema.BoolAttribute{
+							Description: "Whether the session is still valid.",
+							Computed:    true,
+						},
+						"app": schema.BoolAttribute{
+							Description: "Whether the session logged in with an application password.",
+							Computed:    true,
+						},
+						"cli": schema.BoolAttribute{
+							Description: "Whether the session logged in with the CLI password of the Pi-hole host.",
+							Computed:    true,
+						},
+						"tls": schema.BoolAttribute{
+							Description: "Whether the login was made over TLS.",
+							Computed:    true,
+						},
+						"remote_addr": schema.StringAttribute{
+							Description: "The address the session was opened from.",
+							Computed:    true,
+						},
+						"x_forwarded_for": schema.StringAttribute{
+							Description: "The X-Forwarded-For header of the login, when it came through a proxy.",
+							Computed:    true,
+						},
+						"user_agent": schema.StringAttribute{
+							Description: "The user agent of the login.",
+							Computed:    true,
+						},
+						"login_at": schema.Int64Attribute{
+							Description: "Unix timestamp of the login.",
+							Computed:    true,
+						},
+						"last_active": schema.Int64Attribute{
+							Description: "Unix timestamp of the session's last request.",
+							Computed:    true,
+						},
+						"valid_until": schema.Int64Attribute{
+							Description: "Unix timestamp when the session expires.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *SessionsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.client
+}
+
+func (d *SessionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data SessionsDataSourceModel
+
+	sessions, err := d.client.GetSessions(ctx)
+	if err != nil {
+		addAPIErrorf(&resp.Diagnostics, "Error reading sessions", err, "Could not read sessions")
+		return
+	}
+
+	data.Sessions = make([]SessionDataSourceModel, len(sessions))
+	for i, session := range sessions {
+		data.Sessions[i] = mapSessionToDataSourceModel(&session)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// mapSessionToDataSourceModel maps a pihole.Session to the data source model.
+func mapSessionToDataSourceModel(session *pihole.Session) SessionDataSourceModel {
+	model := SessionDataSourceModel{
+		ID:            types.Int64Value(session.ID),
+		Current:       types.BoolValue(session.CurrentSession),
+		Valid:         types.BoolValue(session.Valid),
+		App:           types.BoolValue(session.App),
+		CLI:           types.BoolValue(session.CLI),
+		TLS:           types.BoolValue(session.TLS.Login),
+		RemoteAddr:    types.StringValue(session.RemoteAddr),
+		XForwardedFor: types.StringNull(),
+		UserAgent:     convert.OptionalString(session.UserAgent),
+		LoginAt:       types.Int64Value(session.LoginAt),
+		LastActive:    types.Int64Value(session.LastActive),
+		ValidUntil:    types.Int64Value(session.ValidUntil),
+	}
+	if session.XForwardedFor != nil {
+		model.XForwardedFor = convert.OptionalString(*session.XForwardedFor)
+	}
+	return model
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// deletionProtectionAttribute is the deletion_protection attribute of
+// resources for entries that are costly to lose.
+func deletionProtectionAttribute() schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Description: "Whether Terraform refuses to destroy or replace the entry. Set it to false and apply before removing the resource or changing an attribute that forces replacement. Default: false.",
+		Optional:    true,
+	}
+}
+
+// checkDeletionProtection adds an error when the entry in state has
+// deletion_protection set. The error names the entry by its kind, e.g.
+// "domain", and the value of the attribute nameAttribute.
+func checkDeletionProtection(ctx context.Context, state tfsdk.State, kind, nameAttribute string, diags *diag.Diagnostics) {
+	var protected types.Bool
+	var name types.String
+	diags.Append(state.GetAttribute(ctx, path.Root("deletion_protection"), &protected)...)
+	diags.Append(state.GetAttribute(ctx, path.Root(nameAttribute), &name)...)
+	if !protected.ValueBool() {
+		return
+	}
+
+	diags.AddError(
+		"Deletion protection enabled",
+		fmt.Sprintf("The %s %s has deletion_protection = true and cannot be destroyed or replaced. Set deletion_protection = false and apply first.", kind, name.ValueString()),
+	)
+}
+
+// planDeletionProtection fails a plan that destroys or replaces a protected
+// entry, so the apply does not stop halfway. Call it from ModifyPlan, in
+// addition to checkDeletionProtection in Delete.
+func planDeletionProtection(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, kind, nameAttribute string) {
+	if req.State.Raw.IsNull() {
+		return
+	}
+	if !req.Plan.Raw.IsNull() && len(resp.RequiresReplace) == 0 {
+		return
+	}
+	checkDeletionProtection(ctx, req.State, kind, nameAttribute, &resp.Diagnostics)
+}
@@ -0,0 +1,27 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// warnDestructiveDisabled adds a plan warning when the resource is about to be
+// destroyed or replaced while Pi-hole refuses destructive API actions. Call it
+// from ModifyPlan of resources whose Delete issues a DELETE request.
+func warnDestructiveDisabled(c *pihole.Client, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if c == nil || c.AllowDestructive() || req.State.Raw.IsNull() {
+		return
+	}
+	if !req.Plan.Raw.IsNull() && len(resp.RequiresReplace) == 0 {
+		return
+	}
+
+	resp.Diagnostics.AddWarning(
+		"Destructive API actions are disabled",
+		"This plan deletes Pi-hole entries, but webserver.api.allow_destructive is false on this instance, "+
+			"so the DELETE requests may be rejected during apply. "+pihole.DestructiveRemediation,
+	)
+}
@@ -0,0 +1,170 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/dklesev/terraform-provider-pihole/internal/provider/convert"
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Pi-hole accepts local DNS records into its configuration before dnsmasq
+// has reloaded them, so a record can be saved and still not resolve. The
+// verify block of the local record resources queries Pi-hole's DNS server
+// after create and update until the expected answer shows up.
+
+const (
+	defaultDNSVerifyPort    = 53
+	defaultDNSVerifyTimeout = 10 * time.Second
+	dnsVerifyInterval       = 500 * time.Millisecond
+)
+
+// dnsVerifyModel is the verify block.
+type dnsVerifyModel struct {
+	Resolver       types.String `tfsdk:"resolver"`
+	Port           types.Int64  `tfsdk:"port"`
+	TimeoutSeconds types.Int64  `tfsdk:"timeout_seconds"`
+}
+
+// dnsVerifyBlock is the verify block shared by the local record resources.
+func dnsVerifyBlock() schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		Description: "Query Pi-hole's DNS server after create and update, and fail unless the record resolves as configured.",
+		Attributes: map[string]schema.Attribute{
+			"resolver": schema.StringAttribute{
+				Description: "Address of the DNS server to query. Default: the host of the provider's `url`.",
+				Optional:    true,
+			},
+			"port": schema.Int64Attribute{
+				Description: "Port of the DNS server. Default: 53.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 65535),
+				},
+			},
+			"timeout_seconds": schema.Int64Attribute{
+				Description: "How long to wait for the expected answer. Default: 10.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+// dnsVerifyCheck queries r and reports whether the answer is the expected
+// one, describing what it got otherwise.
+type dnsVerifyCheck func(ctx context.Context, r *net.Resolver) (bool, string)
+
+// verifyDNS runs check against the resolver the verify block names until it
+// succeeds or the timeout passes. It does nothing when the block is absent.
+func verifyDNS(ctx context.Context, c *pihole.Client, v *dnsVerifyModel, name string, check dnsVerifyCheck, diags *diag.Diagnostics) {
+	if v == nil {
+		return
+	}
+
+	host := v.Resolver.ValueString()
+	if host == "" {
+		host = c.Host()
+	}
+	port := int64(defaultDNSVerifyPort)
+	if !v.Port.IsNull() {
+		port = v.Port.ValueInt64()
+	}
+	timeout := defaultDNSVerifyTimeout
+	if !v.TimeoutSeconds.IsNull() {
+		timeout = time.Duration(v.TimeoutSeconds.ValueInt64()) * time.Second
+	}
+
+	address := net.JoinHostPort(host, strconv.FormatInt(port, 10))
+	resolver := &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, network, address)
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	tflog.Debug(ctx, "Verifying DNS record", map[string]interface{}{"name": name, "resolver": address})
+
+	var got string
+	for {
+		var ok bool
+		if ok, got = check(ctx, resolver); ok {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			diags.AddError(
+				"DNS verification failed",
+				fmt.Sprintf("%s did not resolve as configured on %s within %s: %s. "+
+					"Pi-hole saved the record, but its DNS server may not have reloaded it.", name, address, timeout, got),
+			)
+			return
+		case <-time.After(dnsVerifyInterval):
+		}
+	}
+}
+
+// verifyAddress checks that hostname resolves to ip.
+func verifyAddress(hostname, ip string) dnsVerifyCheck {
+	return func(ctx context.Context, r *net.Resolver) (bool, string) {
+		addrs, err := r.LookupHost(ctx, hostname)
+		if err != nil {
+			return false, err.Error()
+		}
+		for _, addr := range addrs {
+			if convert.SameIP(addr, ip) {
+				return true, ""
+			}
+		}
+		return false, fmt.Sprintf("got %v", addrs)
+	}
+}
+
+// verifyCNAME checks that domain is an alias of target.
+func verifyCNAME(domain, target string) dnsVerifyCheck {
+	return func(ctx context.Context, r *net.Resolver) (bool, string) {
+		cname, err := r.LookupCNAME(ctx, domain)
+		if err != nil {
+			return false, err.Error()
+		}
+		if convert.SameHostname(cname, target) {
+			return true, ""
+		}
+		return false, fmt.Sprintf("got canonical name %s", cname)
+	}
+}
+
+// verifyPTR checks that ip resolves back to hostname.
+func verifyPTR(ip, hostname string) dnsVerifyCheck {
+	return func(ctx context.Context, r *net.Resolver) (bool, string) {
+		names, err := r.LookupAddr(ctx, ip)
+		if err != nil {
+			return false, err.Error()
+		}
+		for _, name := range names {
+			if convert.SameHostname(name, hostname) {
+				return true, ""
+			}
+		}
+		return false, fmt.Sprintf("got %v", names)
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// serveDNS answers A queries for name with ip on a local UDP port and
+// returns the port. Other queries get an empty answer.
+func serveDNS(t *testing.T, name string, ip net.IP) int64 {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		buf := make([]byte, 512)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			query := buf[:n]
+
+			// The question follows the 12 byte header: labels, type, class.
+			var labels []string
+			i := 12
+			for i < n && query[i] != 0 {
+				labels = append(labels, string(query[i+1:i+1+int(query[i])]))
+				i += 1 + int(query[i])
+			}
+			end := i + 5
+			if end > n {
+				continue
+			}
+			qtype := binary.BigEndian.Uint16(query[i+1:])
+
+			resp := append([]byte{}, query[:end]...)
+			resp[2], resp[3] = 0x81, 0x80 // response, recursion available
+			binary.BigEndian.PutUint16(resp[6:], 0)
+			if qtype == 1 && strings.EqualFold(strings.Join(labels, "."), name) {
+				binary.BigEndian.PutUint16(resp[6:], 1)
+				resp = append(resp, 0xc0, 12, 0, 1, 0, 1, 0, 0, 0, 60, 0, 4)
+				resp = append(resp, ip.To4()...)
+			}
+			conn.WriteTo(resp, addr)
+		}
+	}()
+
+	return int64(conn.LocalAddr().(*net.UDPAddr).Port)
+}
+
+func TestVerifyDNS(t *testing.T) {
+	port := serveDNS(t, "nas.lan", net.ParseIP("192.168.1.20"))
+	verify := &dnsVerifyModel{
+		Resolver:       types.StringValue("127.0.0.1"),
+		Port:           types.Int64Value(port),
+		TimeoutSeconds: types.Int64Value(1),
+	}
+
+	var diags diag.Diagnostics
+	verifyDNS(context.Background(), nil, verify, "nas.lan", verifyAddress("nas.lan", "192.168.1.20"), &diags)
+	if diags.HasError() {
+		t.Errorf("verifyDNS() = %v, want no error", diags)
+	}
+
+	diags = nil
+	verifyDNS(context.Background(), nil, verify, "nas.lan", verifyAddress("nas.lan", "192.168.1.21"), &diags)
+	if !diags.HasError() || !strings.Contains(diags[0].Detail(), "192.168.1.20") {
+		t.Errorf("verifyDNS() = %v, want an error naming the answer", diags)
+	}
+
+	// Without a verify block nothing is queried.
+	diags = nil
+	verifyDNS(context.Background(), nil, nil, "nas.lan", verifyAddress("nas.lan", "192.168.1.21"), &diags)
+	if diags.HasError() {
+		t.Errorf("verifyDNS(nil) = %v, want no error", diags)
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ ephemeral.EphemeralResource              = &AppPasswordEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &AppPasswordEphemeralResource{}
+)
+
+func NewAppPasswordEphemeralResource() ephemeral.EphemeralResource {
+	return &AppPasswordEphemeralResource{}
+}
+
+type AppPasswordEphemeralResource struct {
+	client *pihole.Client
+}
+
+type AppPasswordEphemeralResourceModel struct {
+	Password types.String `tfsdk:"password"`
+	Hash     types.String `tfsdk:"hash"`
+}
+
+func (r *AppPasswordEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_app_password"
+}
+
+func (r *AppPasswordEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Generates a new Pi-hole application password without storing it in the state.",
+		MarkdownDescription: `
+Generates a new Pi-hole application password without storing it in the plan or
+state (Terraform 1.10+). The password is not active until its hash is passed to
+a ` + "`pihole_password`" + ` resource of type ` + "`app`" + `.
+
+A new password is generated on every run, so pass the password to a write-only
+attribute of the same resources that activate it, and bump their version
+attributes together when rotating.
+
+## Example Usage
+
+` + "```hcl" + `
+ephemeral "pihole_app_password" "terraform" {}
+
+resource "pihole_password" "terraform" {
+  type              = "app"
+  app_password_hash = ephemeral.pihole_app_password.terraform.hash
+  password_version  = 1
+}
+
+resource "vault_kv_secret_v2" "pihole" {
+  mount                = "secret"
+  name                 = "pihole"
+  data_json_wo         = jsonencode({ app_password = ephemeral.pihole_app_password.terraform.password })
+  data_json_wo_version = 1
+}
+` + "```" + `
+`,
+		Attributes: map[string]schema.Attribute{
+			"password": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The generated application password.",
+			},
+			"hash": schema.StringAttribute{
+				Computed:    true,
+				Description: "The hash Pi-hole stores for the password; pass it to `pihole_password.app_password_hash` to activate it.",
+			},
+		},
+	}
+}
+
+func (r *AppPasswordEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.client
+}
+
+func (r *AppPasswordEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	tflog.Debug(ctx, "Generating application password")
+	app, err := r.client.GenerateAppPassword(ctx)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error generating application password", err)
+		return
+	}
+
+	data := AppPasswordEphemeralResourceModel{
+		Password: types.StringValue(app.Password),
+		Hash:     types.StringValue(app.Hash),
+	}
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
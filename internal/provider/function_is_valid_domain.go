@@ -0,0 +1,92 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &IsValidDomainFunction{}
+
+func NewIsValidDomainFunction() function.Function {
+	return &IsValidDomainFunction{}
+}
+
+// IsValidDomainFunction reports whether a string is a domain Pi-hole accepts
+// as an exact domain entry.
+type IsValidDomainFunction struct{}
+
+func (f *IsValidDomainFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "is_valid_domain"
+}
+
+func (f *IsValidDomainFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Checks whether a string is a valid domain name.",
+		MarkdownDescription: `
+Returns ` + "`true`" + ` if the given string is a valid domain name for an exact
+` + "`pihole_domain`" + ` entry, ` + "`false`" + ` otherwise.
+
+Labels may contain letters, digits, hyphens and underscores, must be 1-63
+characters long and may not start or end with a hyphen. The whole name may not
+exceed 253 characters. A single trailing dot is accepted.
+
+## Example Usage
+
+` + "```hcl" + `
+locals {
+  valid = [for d in var.domains : d if provider::pihole::is_valid_domain(d)]
+}
+` + "```" + `
+`,
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "domain",
+				Description: "The domain name to validate.",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *IsValidDomainFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var domain string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &domain))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, isValidDomain(domain)))
+}
+
+// isValidDomain implements the hostname rules Pi-hole applies to exact
+// domain entries, additionally allowing underscores as used in SRV/TXT names.
+func isValidDomain(domain string) bool {
+	domain = strings.TrimSuffix(domain, ".")
+	if domain == "" || len(domain) > 253 {
+		return false
+	}
+
+	for _, label := range strings.Split(domain, ".") {
+		if len(label) == 0 || len(label) > 63 {
+			return false
+		}
+		if label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, c := range label {
+			switch {
+			case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '-', c == '_':
+			default:
+				return false
+			}
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestIsValidDomainFunction(t *testing.T) {
+	tests := map[string]string{
+		"ads.example.com":    "true",
+		"Example.COM.":       "true",
+		"_dmarc.example.com": "true",
+		"localhost":          "true",
+		"":                   "false",
+		"-bad.example.com":   "false",
+		"bad-.example.com":   "false",
+		"two..dots.com":      "false",
+		"^ads\\.example$":    "false",
+		"space in.name":      "false",
+	}
+
+	for domain, want := range tests {
+		t.Run(domain, func(t *testing.T) {
+			resource.UnitTest(t, resource.TestCase{
+				TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+					tfversion.SkipBelow(tfversion.Version1_8_0),
+				},
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: fmt.Sprintf(`
+output "test" {
+  value = provider::pihole::is_valid_domain(%q)
+}
+`, domain),
+						Check: resource.TestCheckOutput("test", want),
+					},
+				},
+			})
+		})
+	}
+}
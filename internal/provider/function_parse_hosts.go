@@ -0,0 +1,116 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/dklesev/terraform-provider-pihole/internal/provider/convert"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &ParseHostsFunction{}
+
+func NewParseHostsFunction() function.Function {
+	return &ParseHostsFunction{}
+}
+
+// ParseHostsFunction parses hosts-file formatted text into IP/hostname
+// objects.
+type ParseHostsFunction struct{}
+
+// hostsEntryAttrTypes describes the objects returned by parse_hosts.
+var hostsEntryAttrTypes = map[string]attr.Type{
+	"ip":        types.StringType,
+	"hostnames": types.ListType{ElemType: types.StringType},
+}
+
+type hostsEntryModel struct {
+	IP        types.String `tfsdk:"ip"`
+	Hostnames types.List   `tfsdk:"hostnames"`
+}
+
+func (f *ParseHostsFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_hosts"
+}
+
+func (f *ParseHostsFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Parses hosts-file formatted text into IP and hostname entries.",
+		MarkdownDescription: `
+Parses text in ` + "`/etc/hosts`" + ` format (` + "`IP hostname [hostname...]`" + `) and returns
+a list of objects with ` + "`ip`" + ` and ` + "`hostnames`" + ` attributes. Blank lines and
+comments starting with ` + "`#`" + ` are ignored. Lines with an invalid IP address or
+without a hostname produce an error.
+
+## Example Usage
+
+` + "```hcl" + `
+locals {
+  hosts = provider::pihole::parse_hosts(file("${path.module}/hosts"))
+}
+
+resource "pihole_local_dns" "migrated" {
+  for_each = { for h in local.hosts : h.hostnames[0] => h.ip }
+
+  hostname = each.key
+  ip       = each.value
+}
+` + "```" + `
+`,
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "lines",
+				Description: "Hosts-file formatted text, one entry per line.",
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: types.ObjectType{AttrTypes: hostsEntryAttrTypes},
+		},
+	}
+}
+
+func (f *ParseHostsFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var lines string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &lines))
+	if resp.Error != nil {
+		return
+	}
+
+	entries := []hostsEntryModel{}
+	for i, line := range strings.Split(lines, "\n") {
+		ip, hostnames, ok := convert.ParseHostsLine(line)
+		if !ok {
+			continue
+		}
+
+		if net.ParseIP(ip) == nil {
+			resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("line %d: %q is not a valid IP address", i+1, ip))
+			return
+		}
+		if len(hostnames) == 0 {
+			resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("line %d: no hostname for %s", i+1, ip))
+			return
+		}
+
+		names, diags := types.ListValueFrom(ctx, types.StringType, hostnames)
+		if diags.HasError() {
+			resp.Error = function.FuncErrorFromDiags(ctx, diags)
+			return
+		}
+
+		entries = append(entries, hostsEntryModel{
+			IP:        types.StringValue(ip),
+			Hostnames: names,
+		})
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, entries))
+}
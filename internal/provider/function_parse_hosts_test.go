@@ -0,0 +1,75 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestParseHostsFunction(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+locals {
+  hosts = provider::pihole::parse_hosts(<<-EOT
+    # Local servers
+    192.168.1.10  nas.lan nas   # storage
+
+    192.168.1.20	printer.lan
+    fd00::1 router.lan
+  EOT
+  )
+}
+
+output "count" {
+  value = length(local.hosts)
+}
+
+output "first_ip" {
+  value = local.hosts[0].ip
+}
+
+output "first_names" {
+  value = join(",", local.hosts[0].hostnames)
+}
+
+output "ipv6_name" {
+  value = local.hosts[2].hostnames[0]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("count", "3"),
+					resource.TestCheckOutput("first_ip", "192.168.1.10"),
+					resource.TestCheckOutput("first_names", "nas.lan,nas"),
+					resource.TestCheckOutput("ipv6_name", "router.lan"),
+				),
+			},
+			{
+				Config: `
+output "invalid" {
+  value = provider::pihole::parse_hosts("999.1.1.1 bad.lan")
+}
+`,
+				ExpectError: regexp.MustCompile("not a valid IP address"),
+			},
+			{
+				Config: `
+output "missing" {
+  value = provider::pihole::parse_hosts("192.168.1.1")
+}
+`,
+				ExpectError: regexp.MustCompile("no hostname"),
+			},
+		},
+	})
+}
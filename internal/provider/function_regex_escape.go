@@ -0,0 +1,63 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &RegexEscapeFunction{}
+
+func NewRegexEscapeFunction() function.Function {
+	return &RegexEscapeFunction{}
+}
+
+// RegexEscapeFunction escapes regular expression metacharacters so a literal
+// string can be embedded in a regex domain rule.
+type RegexEscapeFunction struct{}
+
+func (f *RegexEscapeFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "regex_escape"
+}
+
+func (f *RegexEscapeFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Escapes regular expression metacharacters in a string.",
+		MarkdownDescription: `
+Escapes all regular expression metacharacters in the given string, so it matches
+literally when used inside a regex ` + "`pihole_domain`" + ` rule.
+
+## Example Usage
+
+` + "```hcl" + `
+resource "pihole_domain" "tracker_and_subdomains" {
+  domain = "(\\.|^)${provider::pihole::regex_escape("tracker.example.com")}$"
+  type   = "deny"
+  kind   = "regex"
+}
+` + "```" + `
+`,
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "value",
+				Description: "The string to escape.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *RegexEscapeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &value))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, regexp.QuoteMeta(value)))
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &RegexTemplateFunction{}
+
+func NewRegexTemplateFunction() function.Function {
+	return &RegexTemplateFunction{}
+}
+
+// RegexTemplateFunction returns the regex rule of one of the regexTemplates,
+// for rules managed through pihole_domain or pihole_policy.
+type RegexTemplateFunction struct{}
+
+func (f *RegexTemplateFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "regex_template"
+}
+
+func (f *RegexTemplateFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Builds a regex domain rule from a template.",
+		MarkdownDescription: `
+Builds the regex rule of a ` + "`pihole_custom_regex`" + ` template, for use where a
+regex string is needed, e.g. in ` + "`pihole_domain`" + ` or ` + "`pihole_policy`" + `. The
+templates are ` + "`tld`" + `, ` + "`domain`" + `, ` + "`subdomains`" + ` and ` + "`keyword`" + `; invalid values
+produce an error.
+
+## Example Usage
+
+` + "```hcl" + `
+resource "pihole_domain" "xyz" {
+  domain = provider::pihole::regex_template("tld", "xyz") # "(\\.|^)xyz$"
+  type   = "deny"
+  kind   = "regex"
+}
+` + "```" + `
+`,
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "template",
+				Description: "The template: " + regexTemplateDescription() + ".",
+			},
+			function.StringParameter{
+				Name:        "value",
+				Description: "The top-level domain, domain or keyword the template is applied to.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *RegexTemplateFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var template, value string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &template, &value))
+	if resp.Error != nil {
+		return
+	}
+
+	rule, err := buildRegexRule(template, value, "")
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, rule))
+}
@@ -0,0 +1,39 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestRegexTemplateFunction(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "test" {
+  value = provider::pihole::regex_template("subdomains", "example.com")
+}
+`,
+				Check: resource.TestCheckOutput("test", `\.example\.com$`),
+			},
+			{
+				Config: `
+output "test" {
+  value = provider::pihole::regex_template("tld", "example.com")
+}
+`,
+				ExpectError: regexp.MustCompile(`not a top-level domain`),
+			},
+		},
+	})
+}
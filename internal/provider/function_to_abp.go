@@ -0,0 +1,69 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &ToABPFunction{}
+
+func NewToABPFunction() function.Function {
+	return &ToABPFunction{}
+}
+
+// ToABPFunction converts a domain into Adblock Plus filter syntax.
+type ToABPFunction struct{}
+
+func (f *ToABPFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "to_abp"
+}
+
+func (f *ToABPFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Converts a domain into an Adblock Plus style filter.",
+		MarkdownDescription: `
+Converts a domain into the Adblock Plus filter ` + "`||domain^`" + `. When served
+in a list subscribed via ` + "`pihole_list`" + `, Pi-hole blocks the domain and all of
+its subdomains. The domain is lowercased and a leading ` + "`*.`" + ` or trailing dot
+is removed. Invalid domains produce an error.
+
+## Example Usage
+
+` + "```hcl" + `
+output "filter" {
+  value = provider::pihole::to_abp("Ads.Example.com") # "||ads.example.com^"
+}
+` + "```" + `
+`,
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "domain",
+				Description: "The domain to convert.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *ToABPFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var domain string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &domain))
+	if resp.Error != nil {
+		return
+	}
+
+	domain = strings.ToLower(strings.TrimSuffix(strings.TrimPrefix(domain, "*."), "."))
+	if !isValidDomain(domain) {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("%q is not a valid domain", domain))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, "||"+domain+"^"))
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestToABPFunction(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "plain" {
+  value = provider::pihole::to_abp("ads.example.com")
+}
+
+output "normalized" {
+  value = provider::pihole::to_abp("*.Tracker.Example.com.")
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("plain", "||ads.example.com^"),
+					resource.TestCheckOutput("normalized", "||tracker.example.com^"),
+				),
+			},
+			{
+				Config: `
+output "invalid" {
+  value = provider::pihole::to_abp("not a domain")
+}
+`,
+				ExpectError: regexp.MustCompile("is not a valid domain"),
+			},
+		},
+	})
+}
@@ -0,0 +1,152 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// gravitySettleDelay is how long the last list or domain change of an apply
+// waits for further changes, and for the provider to stop sending requests,
+// before it updates gravity. Terraform starts the resources that depend on a
+// finished one within milliseconds.
+var gravitySettleDelay = 2 * time.Second
+
+// With auto_update_gravity, pihole_list and pihole_domain changes are
+// followed by one gravity update per apply instead of leaving the new lists
+// undownloaded until the next scheduled `pihole -g`. Terraform has no hook
+// that runs once an apply is done, so the provider counts the changes in
+// flight instead: the change that finds none left and no new one starting
+// within gravitySettleDelay runs the update before it returns. It only does
+// so once the client has no request in flight either, so the update does not
+// start while other resources of the apply are still writing.
+//
+// Trackers are kept per client, which the provider creates once per
+// Terraform run and Pi-hole, so they last exactly as long as one apply.
+var gravityTrackers = struct {
+	sync.Mutex
+	trackers map[*pihole.Client]*gravityTracker
+}{trackers: make(map[*pihole.Client]*gravityTracker)}
+
+// gravityTracker counts the list and domain changes of one apply.
+type gravityTracker struct {
+	update func(context.Context) (string, error)
+	// busy reports whether the provider still has requests in flight.
+	busy   func() bool
+	settle time.Duration
+
+	mu sync.Mutex
+	// inFlight is the number of changes currently being applied.
+	inFlight int
+	// generation counts the changes started, so a change waiting to
+	// update gravity can tell that another one began meanwhile.
+	generation int
+	// changed is set once a change succeeded and cleared when gravity is
+	// updated for it.
+	changed bool
+
+	// running serializes updates, since Pi-hole runs one at a time. It is a
+	// channel rather than a mutex so that waiting for an update of another
+	// change ends when the context is canceled.
+	running chan struct{}
+}
+
+func newGravityTracker(update func(context.Context) (string, error), busy func() bool, settle time.Duration) *gravityTracker {
+	return &gravityTracker{update: update, busy: busy, settle: settle, running: make(chan struct{}, 1)}
+}
+
+// enableGravityUpdates makes list and domain changes through c update
+// gravity once they are done.
+func enableGravityUpdates(c *pihole.Client) {
+	gravityTrackers.Lock()
+	defer gravityTrackers.Unlock()
+	gravityTrackers.trackers[c] = newGravityTracker(c.UpdateGravity, c.Busy, gravitySettleDelay)
+}
+
+// trackGravityChange records the start of a list or domain change through c
+// and returns the function that records its end, to be deferred. Once the
+// last change in flight succeeded, the end function updates gravity and adds
+// a warning to diags if that fails. It does nothing unless
+// auto_update_gravity is set.
+func trackGravityChange(ctx context.Context, c *pihole.Client, diags *diag.Diagnostics) func() {
+	gravityTrackers.Lock()
+	t := gravityTrackers.trackers[c]
+	gravityTrackers.Unlock()
+	if t == nil {
+		return func() {}
+	}
+
+	t.begin()
+	return func() { t.end(ctx, diags) }
+}
+
+func (t *gravityTracker) begin() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.inFlight++
+	t.generation++
+}
+
+func (t *gravityTracker) end(ctx context.Context, diags *diag.Diagnostics) {
+	t.mu.Lock()
+	t.inFlight--
+	if !diags.HasError() {
+		t.changed = true
+	}
+	if t.inFlight > 0 || !t.changed {
+		t.mu.Unlock()
+		return
+	}
+	generation := t.generation
+	t.mu.Unlock()
+
+	for {
+		select {
+		case <-time.After(t.settle):
+		case <-ctx.Done():
+			return
+		}
+
+		t.mu.Lock()
+		if t.inFlight > 0 || t.generation != generation || !t.changed {
+			// A later change updates gravity when it is done.
+			t.mu.Unlock()
+			return
+		}
+		if !t.busy() {
+			t.changed = false
+			t.mu.Unlock()
+			break
+		}
+		// Other resources are still writing; the apply is not done.
+		t.mu.Unlock()
+	}
+
+	select {
+	case t.running <- struct{}{}:
+		defer func() { <-t.running }()
+	case <-ctx.Done():
+		return
+	}
+
+	tflog.Info(ctx, "Updating gravity after list and domain changes")
+	output, err := t.update(ctx)
+	if err != nil {
+		diags.AddWarning(
+			"Gravity update failed",
+			fmt.Sprintf("The list and domain changes were saved, but updating gravity afterwards failed: %s. "+
+				"Run `pihole -g` or update gravity in the web interface for new lists to take effect.", err),
+		)
+		return
+	}
+	tflog.Debug(ctx, "Gravity updated", map[string]interface{}{"output": strings.TrimSpace(output)})
+}
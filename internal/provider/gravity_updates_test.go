@@ -0,0 +1,144 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+func TestGravityTracker(t *testing.T) {
+	ctx := context.Background()
+	var updates atomic.Int32
+	tracker := newGravityTracker(func(context.Context) (string, error) {
+		updates.Add(1)
+		return "", nil
+	}, func() bool { return false }, 50*time.Millisecond)
+
+	// Parallel changes, and one that starts as the first of them ends,
+	// update gravity once.
+	var wg sync.WaitGroup
+	change := func(delay time.Duration) {
+		defer wg.Done()
+		time.Sleep(delay)
+		var diags diag.Diagnostics
+		tracker.end(ctx, &diags)
+	}
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		tracker.begin()
+		go change(time.Duration(i) * 5 * time.Millisecond)
+	}
+	wg.Add(1)
+	time.Sleep(time.Millisecond)
+	tracker.begin()
+	go change(40 * time.Millisecond)
+	wg.Wait()
+	if n := updates.Load(); n != 1 {
+		t.Errorf("parallel changes updated gravity %d times, want 1", n)
+	}
+
+	// A failed change alone does not update gravity.
+	var failed diag.Diagnostics
+	failed.AddError("Error creating list", "boom")
+	tracker.begin()
+	tracker.end(ctx, &failed)
+	if n := updates.Load(); n != 1 {
+		t.Errorf("failed change updated gravity, %d updates", n)
+	}
+
+	// A failed update is a warning.
+	tracker.update = func(context.Context) (string, error) {
+		return "", errors.New("connection refused")
+	}
+	var diags diag.Diagnostics
+	tracker.begin()
+	tracker.end(ctx, &diags)
+	if diags.HasError() || diags.WarningsCount() != 1 {
+		t.Errorf("failed update diagnostics = %v, want one warning", diags)
+	}
+}
+
+func TestGravityTracker_canceled(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{})
+	tracker := newGravityTracker(func(ctx context.Context) (string, error) {
+		close(started)
+		<-release
+		return "", nil
+	}, func() bool { return false }, 0)
+
+	// One change runs a long update; another change whose context is
+	// canceled meanwhile stops waiting for it.
+	var diags diag.Diagnostics
+	tracker.begin()
+	go tracker.end(context.Background(), &diags)
+	<-started
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		var diags diag.Diagnostics
+		tracker.begin()
+		tracker.end(ctx, &diags)
+	}()
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Error("canceled change kept waiting for the running gravity update")
+	}
+	close(release)
+}
+
+func TestGravityTracker_busy(t *testing.T) {
+	var updates atomic.Int32
+	var busy atomic.Bool
+	busy.Store(true)
+	tracker := newGravityTracker(func(context.Context) (string, error) {
+		updates.Add(1)
+		return "", nil
+	}, busy.Load, 10*time.Millisecond)
+
+	// The last change waits while other resources still send requests.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		var diags diag.Diagnostics
+		tracker.begin()
+		tracker.end(context.Background(), &diags)
+	}()
+	time.Sleep(50 * time.Millisecond)
+	if n := updates.Load(); n != 0 {
+		t.Fatalf("gravity updated %d times while requests were in flight", n)
+	}
+
+	busy.Store(false)
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("change kept waiting after the requests ended")
+	}
+	if n := updates.Load(); n != 1 {
+		t.Errorf("gravity updated %d times, want 1", n)
+	}
+}
+
+func TestTrackGravityChange_disabled(t *testing.T) {
+	var diags diag.Diagnostics
+	trackGravityChange(context.Background(), &pihole.Client{}, &diags)()
+	if len(diags) != 0 {
+		t.Errorf("disabled tracking added diagnostics: %v", diags)
+	}
+}
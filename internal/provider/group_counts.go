@@ -0,0 +1,57 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+)
+
+// groupCounts is how many clients, domain rules and lists are assigned to a
+// group.
+type groupCounts struct {
+	Clients int
+	Domains int
+	Lists   int
+}
+
+// countGroupReferences counts the clients, domain rules and lists assigned
+// to the group with the given ID. Pi-hole reports the groups of each entry,
+// not the entries of a group, so it reads all of them.
+func countGroupReferences(ctx context.Context, c *pihole.Client, id int64) (groupCounts, error) {
+	var counts groupCounts
+
+	clients, err := c.GetClients(ctx, "")
+	if err != nil {
+		return counts, fmt.Errorf("listing clients: %w", err)
+	}
+	for _, cl := range clients {
+		if hasGroup(cl.Groups, id) {
+			counts.Clients++
+		}
+	}
+
+	domains, err := c.GetDomains(ctx, "", "", "")
+	if err != nil {
+		return counts, fmt.Errorf("listing domains: %w", err)
+	}
+	for _, d := range domains {
+		if hasGroup(d.Groups, id) {
+			counts.Domains++
+		}
+	}
+
+	lists, err := c.GetLists(ctx, "", "")
+	if err != nil {
+		return counts, fmt.Errorf("listing lists: %w", err)
+	}
+	for _, l := range lists {
+		if hasGroup(l.Groups, id) {
+			counts.Lists++
+		}
+	}
+	return counts, nil
+}
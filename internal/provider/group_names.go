@@ -0,0 +1,207 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/dklesev/terraform-provider-pihole/internal/provider/convert"
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Domains, lists and clients are assigned to groups by ID, which changes
+// when a group is recreated. Their group_names attribute names the groups
+// instead; the names are resolved to the IDs in groups during plan, through
+// the group cache of the API client, and again during apply.
+//
+// A group that is created or replaced in the same run gets a new ID, while
+// during plan its name may still resolve to the group it replaces. Terraform
+// plans a group before the entries that reference it, so pihole_group and
+// pihole_policy record the names they plan to create in plannedGroups, and
+// groups stays unknown for entries that name one of them.
+
+// plannedGroups holds the names of the groups planned to be created or
+// replaced. Its methods accept a nil receiver, for resources that were not
+// configured.
+type plannedGroups struct {
+	mu    sync.Mutex
+	names map[string]bool
+}
+
+// record adds the group named by the attribute at name when the plan creates
+// the resource. Terraform plans a replacement as a create, with null state.
+func (p *plannedGroups) record(ctx context.Context, req resource.ModifyPlanRequest, name string) {
+	if p == nil || req.Plan.Raw.IsNull() || !req.State.Raw.IsNull() {
+		return
+	}
+	var group types.String
+	if req.Plan.GetAttribute(ctx, path.Root(name), &group).HasError() || group.IsUnknown() || group.IsNull() {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.names == nil {
+		p.names = make(map[string]bool)
+	}
+	p.names[group.ValueString()] = true
+}
+
+// contains reports whether any of names is planned to be created.
+func (p *plannedGroups) contains(names []string) bool {
+	if p == nil {
+		return false
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, name := range names {
+		if p.names[name] {
+			return true
+		}
+	}
+	return false
+}
+
+// groupNamesAttribute is the group_names attribute of resources with groups.
+func groupNamesAttribute() schema.SetAttribute {
+	return schema.SetAttribute{
+		Description: "Names of the groups this entry belongs to, as an alternative to `groups`. The names are resolved to " +
+			"the group IDs in `groups` during plan, or during apply for groups created or replaced in the same run. Conflicts with `groups`.",
+		Optional:    true,
+		ElementType: types.StringType,
+		Validators: []validator.Set{
+			setvalidator.ConflictsWith(path.MatchRoot("groups")),
+			setvalidator.SizeAtLeast(1),
+			setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+		},
+	}
+}
+
+// planGroupNames plans groups as the IDs of the groups in group_names when
+// it is set. While a name is unknown, no group has it yet or its group is
+// planned to be created or replaced in the same apply, groups is planned
+// unknown; resolveGroups reports names that are still missing during apply.
+// list selects the list type of the groups of pihole_client over a set.
+func planGroupNames(ctx context.Context, c *pihole.Client, planned *plannedGroups, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, list bool) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var names types.Set
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("group_names"), &names)...)
+	if resp.Diagnostics.HasError() || names.IsNull() {
+		return
+	}
+
+	var groups attr.Value = types.SetUnknown(types.Int64Type)
+	if list {
+		groups = types.ListUnknown(types.Int64Type)
+	}
+	if values, ok := knownStrings(names); ok && planned.contains(values) {
+		tflog.Debug(ctx, "Groups are planned to be created, resolving them during apply", map[string]interface{}{"group_names": values})
+	} else if ok && c != nil {
+		ids, missing, err := c.GroupIDsByName(ctx, values)
+		if err != nil {
+			addAPIErrorf(&resp.Diagnostics, "Error resolving group names", err, "Could not read groups")
+			return
+		}
+		if len(missing) > 0 {
+			tflog.Debug(ctx, "Groups not found during plan, resolving them during apply", map[string]interface{}{"group_names": missing})
+		} else {
+			var d diag.Diagnostics
+			slices.Sort(ids)
+			if list {
+				groups, d = convert.GroupList(ctx, ids)
+			} else {
+				groups, d = convert.GroupSet(ctx, ids)
+			}
+			resp.Diagnostics.Append(d...)
+		}
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("groups"), groups)...)
+}
+
+// resolveGroups returns the group IDs to assign an entry to: those of the
+// groups in names when it is set, else the IDs in groups. Names no group has
+// are reported with the group names that look like a typo of them.
+func resolveGroups(ctx context.Context, c *pihole.Client, groups convert.Collection, names types.Set) ([]int64, diag.Diagnostics) {
+	if names.IsNull() {
+		return convert.Int64s(ctx, groups)
+	}
+
+	var diags diag.Diagnostics
+	var values []string
+	diags.Append(names.ElementsAs(ctx, &values, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	ids, missing, err := c.GroupIDsByName(ctx, values)
+	if err != nil {
+		addAPIErrorf(&diags, "Error resolving group names", err, "Could not read groups")
+		return nil, diags
+	}
+	if len(missing) > 0 {
+		diags.AddAttributeError(path.Root("group_names"), "Unknown group", unknownGroupsDetail(ctx, c, missing))
+		return nil, diags
+	}
+	slices.Sort(ids)
+	return ids, diags
+}
+
+// unknownGroupsDetail describes group names no group has, with the group
+// names that look like a typo of them.
+func unknownGroupsDetail(ctx context.Context, c *pihole.Client, missing []string) string {
+	detail := fmt.Sprintf("No group is named %s in Pi-hole. Create the group first, e.g. with a pihole_group resource that this entry references.", quoteList(missing))
+
+	groups, err := c.GetGroups(ctx, "")
+	if err != nil {
+		tflog.Debug(ctx, "Could not list groups for suggestions", map[string]interface{}{"error": err.Error()})
+		return detail
+	}
+	existing := make([]string, len(groups))
+	for i, g := range groups {
+		existing[i] = g.Name
+	}
+	var matches []string
+	for _, name := range missing {
+		for _, m := range closeMatches(name, existing) {
+			if !slices.Contains(matches, m) {
+				matches = append(matches, m)
+			}
+		}
+	}
+	if len(matches) > 0 {
+		detail += fmt.Sprintf("\n\nDid you mean %s?", quoteList(matches))
+	}
+	return detail
+}
+
+// knownStrings returns the strings in set, and false when the set or one of
+// them is unknown.
+func knownStrings(set types.Set) ([]string, bool) {
+	if set.IsUnknown() {
+		return nil, false
+	}
+	values := make([]string, 0, len(set.Elements()))
+	for _, v := range set.Elements() {
+		s, ok := v.(types.String)
+		if !ok || s.IsUnknown() {
+			return nil, false
+		}
+		values = append(values, s.ValueString())
+	}
+	return values, true
+}
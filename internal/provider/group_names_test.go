@@ -0,0 +1,54 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestPlannedGroups(t *testing.T) {
+	ctx := context.Background()
+	s := schema.Schema{Attributes: map[string]schema.Attribute{
+		"name": schema.StringAttribute{Required: true},
+	}}
+	objectType := s.Type().TerraformType(ctx)
+	object := func(name interface{}) tftypes.Value {
+		if name == nil {
+			return tftypes.NewValue(objectType, nil)
+		}
+		return tftypes.NewValue(objectType, map[string]tftypes.Value{"name": tftypes.NewValue(tftypes.String, name)})
+	}
+	request := func(state, plan interface{}) resource.ModifyPlanRequest {
+		return resource.ModifyPlanRequest{
+			State: tfsdk.State{Schema: s, Raw: object(state)},
+			Plan:  tfsdk.Plan{Schema: s, Raw: object(plan)},
+		}
+	}
+
+	var planned plannedGroups
+	planned.record(ctx, request("kept", "kept"), "name")            // update
+	planned.record(ctx, request("deleted", nil), "name")            // destroy
+	planned.record(ctx, request(nil, tftypes.UnknownValue), "name") // unknown name
+	planned.record(ctx, request(nil, "created"), "name")            // create or replace
+
+	if planned.contains([]string{"kept", "deleted"}) {
+		t.Errorf("contains(kept, deleted) = true, want only planned creates")
+	}
+	if !planned.contains([]string{"kept", "created"}) {
+		t.Errorf("contains(kept, created) = false, want true")
+	}
+
+	// Resources that were not configured have no plannedGroups
+	var unconfigured *plannedGroups
+	unconfigured.record(ctx, request(nil, "created"), "name")
+	if unconfigured.contains([]string{"created"}) {
+		t.Errorf("nil contains(created) = true, want false")
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Deleting a group that clients, domains or lists are still assigned to
+// either fails with a foreign-key error from the gravity database or
+// silently moves them out of the group. The preconditions block of
+// pihole_group checks the assignments before the delete instead and names
+// what still uses the group.
+
+// maxGroupReferenceNames is how many names of each kind a failed check lists.
+const maxGroupReferenceNames = 5
+
+// groupPreconditionsModel is the preconditions block.
+type groupPreconditionsModel struct {
+	NoClients types.Bool `tfsdk:"no_clients"`
+	NoDomains types.Bool `tfsdk:"no_domains"`
+	NoLists   types.Bool `tfsdk:"no_lists"`
+}
+
+// groupPreconditionsBlock is the preconditions block of pihole_group.
+func groupPreconditionsBlock() schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		Description: "Check before deleting the group that nothing is assigned to it any more, and fail the destroy with " +
+			"what still is. Resources in the same configuration that use the group are destroyed before it and do not count.",
+		Attributes: map[string]schema.Attribute{
+			"no_clients": schema.BoolAttribute{
+				Description: "Fail while clients are assigned to the group. Default: true.",
+				Optional:    true,
+			},
+			"no_domains": schema.BoolAttribute{
+				Description: "Fail while domain rules are assigned to the group. Default: true.",
+				Optional:    true,
+			},
+			"no_lists": schema.BoolAttribute{
+				Description: "Fail while subscribed lists are assigned to the group. Default: true.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+// checkGroupPreconditions adds an error when entities the preconditions
+// block checks are still assigned to the group. It does nothing when the
+// block is absent.
+func checkGroupPreconditions(ctx context.Context, c *pihole.Client, p *groupPreconditionsModel, id int64, name string, diags *diag.Diagnostics) {
+	if p == nil {
+		return
+	}
+
+	var references []string
+	if p.NoClients.IsNull() || p.NoClients.ValueBool() {
+		clients, err := c.GetClients(ctx, "")
+		if err != nil {
+			addAPIErrorf(diags, "Error checking group preconditions", err, "Could not list clients")
+			return
+		}
+		var names []string
+		for _, cl := range clients {
+			if hasGroup(cl.Groups, id) {
+				names = append(names, cl.Client)
+			}
+		}
+		references = appendGroupReferences(references, "client", names)
+	}
+	if p.NoDomains.IsNull() || p.NoDomains.ValueBool() {
+		domains, err := c.GetDomains(ctx, "", "", "")
+		if err != nil {
+			addAPIErrorf(diags, "Error checking group preconditions", err, "Could not list domains")
+			return
+		}
+		var names []string
+		for _, d := range domains {
+			if hasGroup(d.Groups, id) {
+				names = append(names, d.Domain)
+			}
+		}
+		references = appendGroupReferences(references, "domain", names)
+	}
+	if p.NoLists.IsNull() || p.NoLists.ValueBool() {
+		lists, err := c.GetLists(ctx, "", "")
+		if err != nil {
+			addAPIErrorf(diags, "Error checking group preconditions", err, "Could not list lists")
+			return
+		}
+		var names []string
+		for _, l := range lists {
+			if hasGroup(l.Groups, id) {
+				names = append(names, l.Address)
+			}
+		}
+		references = appendGroupReferences(references, "list", names)
+	}
+
+	if len(references) > 0 {
+		diags.AddError(
+			"Group still in use",
+			fmt.Sprintf("Group %s (ID %d) is still assigned to %s. Remove them from the group first, "+
+				"or relax the preconditions block.", name, id, strings.Join(references, "; ")),
+		)
+	}
+}
+
+func hasGroup(groups []int64, id int64) bool {
+	for _, g := range groups {
+		if g == id {
+			return true
+		}
+	}
+	return false
+}
+
+// appendGroupReferences describes names, e.g. "2 clients (nas, laptop)",
+// and appends it to references when there are any.
+func appendGroupReferences(references []string, kind string, names []string) []string {
+	if len(names) == 0 {
+		return references
+	}
+	if len(names) != 1 {
+		kind += "s"
+	}
+	shown := names
+	more := ""
+	if len(names) > maxGroupReferenceNames {
+		shown = names[:maxGroupReferenceNames]
+		more = fmt.Sprintf(" and %d more", len(names)-maxGroupReferenceNames)
+	}
+	return append(references, fmt.Sprintf("%d %s (%s%s)", len(names), kind, strings.Join(shown, ", "), more))
+}
@@ -0,0 +1,24 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAppendGroupReferences(t *testing.T) {
+	var references []string
+	references = appendGroupReferences(references, "client", nil)
+	references = appendGroupReferences(references, "client", []string{"nas"})
+	references = appendGroupReferences(references, "domain", []string{"a", "b", "c", "d", "e", "f", "g"})
+
+	want := []string{
+		"1 client (nas)",
+		"7 domains (a, b, c, d, e and 2 more)",
+	}
+	if !reflect.DeepEqual(references, want) {
+		t.Errorf("appendGroupReferences() = %q, want %q", references, want)
+	}
+}
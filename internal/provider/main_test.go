@@ -0,0 +1,192 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+const (
+	// testAccDockerEnv enables the dockerized Pi-hole harness when set to a
+	// non-empty value alongside TF_ACC.
+	testAccDockerEnv = "PIHOLE_ACC_DOCKER"
+
+	// testAccDockerKeepEnv leaves the container running after the tests so it
+	// can be inspected or reused by the next run.
+	testAccDockerKeepEnv = "PIHOLE_ACC_DOCKER_KEEP"
+
+	// testAccDockerInstancesEnv is the number of containers to start, one per
+	// instance of the pool that tests written with testAccParallelTest share.
+	// Default: 1.
+	testAccDockerInstancesEnv = "PIHOLE_ACC_DOCKER_INSTANCES"
+
+	// testAccDockerPort is the HTTP port of the first container; the others
+	// use the ports after it. HTTPS ports start at testAccDockerTLSPort.
+	testAccDockerPort    = 8080
+	testAccDockerTLSPort = 8443
+
+	// testAccDockerReadyTimeout is how long we wait for Pi-hole to answer.
+	testAccDockerReadyTimeout = 3 * time.Minute
+)
+
+// testAccDockerComposeFile is the compose file that pins the Pi-hole image
+// used for acceptance tests, relative to this package.
+var testAccDockerComposeFile = filepath.Join("..", "..", "docker-compose.yml")
+
+func TestMain(m *testing.M) {
+	teardown, err := testAccStartDocker()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to start Pi-hole test container: %s\n", err)
+		os.Exit(1)
+	}
+	testAccSetupPool()
+
+	// resource.TestMain handles the -sweep flags and calls os.Exit, so the
+	// teardown has to run from inside Run.
+	resource.TestMain(testAccMain{m: m, teardown: teardown})
+}
+
+// testAccMain wraps testing.M so the container is torn down after the run.
+type testAccMain struct {
+	m        *testing.M
+	teardown func()
+}
+
+func (t testAccMain) Run() int {
+	defer t.teardown()
+	return t.m.Run()
+}
+
+// testAccStartDocker brings up the Pi-hole containers from docker-compose.yml
+// when both TF_ACC and PIHOLE_ACC_DOCKER are set, as one Compose project per
+// instance so they run side by side. The first one serves PIHOLE_URL; all of
+// them make up the pool, unless PIHOLE_ACC_URLS is set. The returned
+// teardown function is always safe to call.
+func testAccStartDocker() (func(), error) {
+	noop := func() {}
+	if os.Getenv(resource.EnvTfAcc) == "" || os.Getenv(testAccDockerEnv) == "" {
+		return noop, nil
+	}
+
+	instances := 1
+	if v := os.Getenv(testAccDockerInstancesEnv); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return noop, fmt.Errorf("%s must be a positive number, got %q", testAccDockerInstancesEnv, v)
+		}
+		instances = n
+	}
+
+	// Start the containers concurrently; each waits for its health check.
+	errs := make([]error, instances)
+	var wg sync.WaitGroup
+	for i := range instances {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = testAccDockerCompose(i, "up", "-d", "--wait")
+		}()
+	}
+	wg.Wait()
+
+	teardown := func() {
+		if os.Getenv(testAccDockerKeepEnv) != "" {
+			return
+		}
+		for i := range instances {
+			if err := testAccDockerCompose(i, "down", "-v"); err != nil {
+				fmt.Fprintf(os.Stderr, "failed to stop Pi-hole test container: %s\n", err)
+			}
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		teardown()
+		return noop, err
+	}
+
+	// Point the tests at the containers unless the caller overrides it.
+	if os.Getenv("PIHOLE_URL") == "" {
+		os.Setenv("PIHOLE_URL", testAccDockerURL(0))
+	}
+	if os.Getenv("PIHOLE_PASSWORD") == "" {
+		os.Setenv("PIHOLE_PASSWORD", "test123")
+	}
+	if os.Getenv(testAccURLsEnv) == "" {
+		urls := make([]string, instances)
+		for i := range urls {
+			urls[i] = testAccDockerURL(i)
+		}
+		os.Setenv(testAccURLsEnv, strings.Join(urls, ","))
+	}
+
+	for _, url := range strings.Split(os.Getenv(testAccURLsEnv), ",") {
+		if err := testAccWaitForPihole(url); err != nil {
+			teardown()
+			return noop, err
+		}
+	}
+
+	return teardown, nil
+}
+
+// testAccDockerURL is the URL of the container of instance i.
+func testAccDockerURL(i int) string {
+	return fmt.Sprintf("http://localhost:%d", testAccDockerPort+i)
+}
+
+// testAccDockerCompose runs docker compose for the project of instance i.
+func testAccDockerCompose(i int, args ...string) error {
+	project := fmt.Sprintf("pihole-acc-provider-%d", i)
+	cmd := exec.Command("docker", append([]string{"compose", "-f", testAccDockerComposeFile, "-p", project}, args...)...)
+	cmd.Env = append(os.Environ(),
+		fmt.Sprintf("PIHOLE_HTTP_PORT=%d", testAccDockerPort+i),
+		fmt.Sprintf("PIHOLE_HTTPS_PORT=%d", testAccDockerTLSPort+i),
+	)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("docker compose -p %s %v: %w", project, args, err)
+	}
+	return nil
+}
+
+// testAccWaitForPihole polls the unauthenticated version endpoint until FTL
+// is serving the API.
+func testAccWaitForPihole(url string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), testAccDockerReadyTimeout)
+	defer cancel()
+
+	for {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url+"/api/info/version", nil)
+		if err != nil {
+			return err
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode < 500 {
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("Pi-hole at %s not ready after %s", url, testAccDockerReadyTimeout)
+		case <-time.After(2 * time.Second):
+		}
+	}
+}
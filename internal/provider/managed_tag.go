@@ -0,0 +1,137 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"regexp"
+	"strings"
+)
+
+// The provider's managed_by_tag is stored as a "[tf:<tag>]" marker at the end
+// of the comments of domains, lists and clients, so several Terraform
+// configurations can share one Pi-hole and find their own entries. Resources
+// strip the marker again on read, so it never shows up as a diff. The
+// provider's comment_prefix is handled the same way at the start of the
+// comment.
+//
+// Entries that belong to a resource other than the one of their kind, like
+// the clients of a pihole_device, name that resource in the marker, e.g.
+// "[tf:prod/pihole_device]", so pihole_managed_cleanup leaves them alone.
+
+// managedTagRegexp restricts tags to characters that cannot end the marker.
+var managedTagRegexp = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+// managedTagMarker returns the comment marker for tag and, when set, the
+// type name of the resource that owns the entry.
+func managedTagMarker(tag, owner string) string {
+	if owner == "" {
+		return "[tf:" + tag + "]"
+	}
+	return "[tf:" + tag + "/" + owner + "]"
+}
+
+// tagComment appends the marker for tag and owner to comment. An empty tag
+// leaves the comment untouched.
+func tagComment(comment, tag, owner string) string {
+	if tag == "" {
+		return comment
+	}
+	if comment == "" {
+		return managedTagMarker(tag, owner)
+	}
+	return comment + " " + managedTagMarker(tag, owner)
+}
+
+// splitManagedTag splits the marker for tag, with or without an owner, from
+// the end of comment. It returns the comment before the marker and the
+// owner, and false when comment does not end with such a marker.
+func splitManagedTag(comment, tag string) (string, string, bool) {
+	if tag == "" || !strings.HasSuffix(comment, "]") {
+		return comment, "", false
+	}
+	i := strings.LastIndex(comment, "[tf:"+tag)
+	if i < 0 {
+		return comment, "", false
+	}
+	owner := comment[i+len("[tf:"+tag) : len(comment)-1]
+	if owner != "" {
+		var ok bool
+		owner, ok = strings.CutPrefix(owner, "/")
+		if !ok || owner == "" || strings.ContainsAny(owner, "[]") {
+			return comment, "", false
+		}
+	}
+	return strings.TrimSpace(comment[:i]), owner, true
+}
+
+// untagComment removes the marker for tag, with or without an owner, from
+// the end of comment.
+func untagComment(comment, tag string) string {
+	if tag == "" {
+		return comment
+	}
+	rest, _, ok := splitManagedTag(comment, tag)
+	if !ok {
+		return strings.TrimSpace(comment)
+	}
+	return rest
+}
+
+// hasManagedTag reports whether comment carries the marker for tag, with
+// or without an owner.
+func hasManagedTag(comment, tag string) bool {
+	_, _, ok := splitManagedTag(comment, tag)
+	return ok
+}
+
+// managedTagOwner returns the owner in the marker for tag at the end of
+// comment, empty when the marker names none or there is no marker.
+func managedTagOwner(comment, tag string) string {
+	_, owner, _ := splitManagedTag(comment, tag)
+	return owner
+}
+
+// prefixComment puts prefix and a space in front of comment. An empty
+// prefix leaves the comment untouched.
+func prefixComment(comment, prefix string) string {
+	if prefix == "" {
+		return comment
+	}
+	if comment == "" {
+		return prefix
+	}
+	return prefix + " " + comment
+}
+
+// unprefixComment removes prefix from the start of comment.
+func unprefixComment(comment, prefix string) string {
+	if prefix == "" || !strings.HasPrefix(comment, prefix) {
+		return comment
+	}
+	return strings.TrimSpace(strings.TrimPrefix(comment, prefix))
+}
+
+// commentSettings are the provider's managed_by_tag and comment_prefix,
+// which mark the comments of the entries it creates.
+type commentSettings struct {
+	managedByTag string
+	prefix       string
+}
+
+// managed returns the comment Pi-hole stores for an entry the provider
+// creates: comment with the comment prefix and managed-by marker.
+func (s commentSettings) managed(comment string) string {
+	return s.managedBy("", comment)
+}
+
+// managedBy is managed for an entry owned by the resource named owner, like
+// the clients of pihole_device, rather than by the resource of its kind.
+func (s commentSettings) managedBy(owner, comment string) string {
+	return tagComment(prefixComment(comment, s.prefix), s.managedByTag, owner)
+}
+
+// unmanaged reverses managed, returning the comment as configured.
+func (s commentSettings) unmanaged(comment string) string {
+	return unprefixComment(untagComment(comment, s.managedByTag), s.prefix)
+}
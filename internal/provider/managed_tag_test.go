@@ -0,0 +1,95 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import "testing"
+
+func TestManagedTagComment(t *testing.T) {
+	tests := []struct {
+		comment string
+		tag     string
+		tagged  string
+	}{
+		{"", "", ""},
+		{"Ads", "", "Ads"},
+		{"", "prod", "[tf:prod]"},
+		{"Ads", "prod", "Ads [tf:prod]"},
+	}
+
+	for _, tt := range tests {
+		got := tagComment(tt.comment, tt.tag, "")
+		if got != tt.tagged {
+			t.Errorf("tagComment(%q, %q) = %q, want %q", tt.comment, tt.tag, got, tt.tagged)
+		}
+		if back := untagComment(got, tt.tag); back != tt.comment {
+			t.Errorf("untagComment(%q, %q) = %q, want %q", got, tt.tag, back, tt.comment)
+		}
+		if tt.tag != "" && !hasManagedTag(got, tt.tag) {
+			t.Errorf("hasManagedTag(%q, %q) = false", got, tt.tag)
+		}
+	}
+
+	// Entries owned by another resource name it in the marker
+	owned := tagComment("Laptop", "prod", "pihole_device")
+	if owned != "Laptop [tf:prod/pihole_device]" {
+		t.Errorf("tagComment with owner = %q", owned)
+	}
+	if !hasManagedTag(owned, "prod") || managedTagOwner(owned, "prod") != "pihole_device" {
+		t.Errorf("marker of %q not recognized", owned)
+	}
+	if got := untagComment(owned, "prod"); got != "Laptop" {
+		t.Errorf("untagComment(%q) = %q", owned, got)
+	}
+	if hasManagedTag("Ads [tf:production]", "prod") || hasManagedTag("Ads [tf:prod/]", "prod") {
+		t.Error("hasManagedTag matched a longer tag or an empty owner")
+	}
+
+	if hasManagedTag("Ads [tf:prod]", "staging") {
+		t.Error("hasManagedTag matched a different tag")
+	}
+	if got := untagComment("Ads [tf:staging]", "prod"); got != "Ads [tf:staging]" {
+		t.Errorf("untagComment removed a different tag: %q", got)
+	}
+}
+
+func TestPrefixComment(t *testing.T) {
+	tests := []struct {
+		comment  string
+		prefix   string
+		prefixed string
+	}{
+		{"", "", ""},
+		{"Ads", "", "Ads"},
+		{"", "[terraform]", "[terraform]"},
+		{"Ads", "[terraform]", "[terraform] Ads"},
+	}
+
+	for _, tt := range tests {
+		got := prefixComment(tt.comment, tt.prefix)
+		if got != tt.prefixed {
+			t.Errorf("prefixComment(%q, %q) = %q, want %q", tt.comment, tt.prefix, got, tt.prefixed)
+		}
+		if back := unprefixComment(got, tt.prefix); back != tt.comment {
+			t.Errorf("unprefixComment(%q, %q) = %q, want %q", got, tt.prefix, back, tt.comment)
+		}
+	}
+
+	// Comments edited in the web interface keep what no longer matches
+	if got := unprefixComment("Ads [terraform]", "[terraform]"); got != "Ads [terraform]" {
+		t.Errorf("unprefixComment removed text that is not a prefix: %q", got)
+	}
+}
+
+func TestCommentSettings(t *testing.T) {
+	s := commentSettings{managedByTag: "prod", prefix: "[terraform]"}
+	if got := s.managed("Ads"); got != "[terraform] Ads [tf:prod]" {
+		t.Errorf("managed() = %q", got)
+	}
+	if got := s.unmanaged("[terraform] Ads [tf:prod]"); got != "Ads" {
+		t.Errorf("unmanaged() = %q", got)
+	}
+	if got := (commentSettings{}).managed("Ads"); got != "Ads" {
+		t.Errorf("managed() without settings = %q", got)
+	}
+}
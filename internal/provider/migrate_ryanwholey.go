@@ -0,0 +1,40 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// ryanwholeyProvider is the address suffix of the community provider for
+// Pi-hole v5, whose resources moved blocks can turn into this provider's.
+const ryanwholeyProvider = "/ryanwholey/pihole"
+
+// ryanwholeyState decodes the state of a ryanwholey/pihole resource of type
+// typeName into v. It returns false without diagnostics when the moved
+// resource is of another provider or type, so the framework tries the
+// resource's other movers.
+func ryanwholeyState(req resource.MoveStateRequest, typeName string, v interface{}, diags *diag.Diagnostics) bool {
+	if !strings.HasSuffix(req.SourceProviderAddress, ryanwholeyProvider) || req.SourceTypeName != typeName {
+		return false
+	}
+	if req.SourceRawState == nil {
+		diags.AddError("Unable to move resource state", fmt.Sprintf("The state of the %s resource is empty.", typeName))
+		return false
+	}
+
+	if err := json.Unmarshal(req.SourceRawState.JSON, v); err != nil {
+		diags.AddError(
+			"Unable to move resource state",
+			fmt.Sprintf("The state of the %s resource could not be read: %s", typeName, err),
+		)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// moveState runs the movers of r on a resource of another provider and
+// returns the response of the one that moved it.
+func moveState(t *testing.T, r resource.ResourceWithMoveState, providerAddress, typeName, state string) resource.MoveStateResponse {
+	t.Helper()
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	req := resource.MoveStateRequest{
+		SourceProviderAddress: providerAddress,
+		SourceTypeName:        typeName,
+		SourceRawState:        &tfprotov6.RawState{JSON: []byte(state)},
+	}
+
+	var resp resource.MoveStateResponse
+	for _, mover := range r.MoveState(ctx) {
+		resp = resource.MoveStateResponse{
+			TargetState: tfsdk.State{
+				Schema: schemaResp.Schema,
+				Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+			},
+		}
+		mover.StateMover(ctx, req, &resp)
+		if resp.Diagnostics.HasError() || !resp.TargetState.Raw.IsNull() {
+			break
+		}
+	}
+	return resp
+}
+
+func TestMoveState_ryanwholey(t *testing.T) {
+	ctx := context.Background()
+	const source = "registry.terraform.io/ryanwholey/pihole"
+
+	resp := moveState(t, &LocalDNSResource{}, source, "pihole_dns_record", `{"id": "nas.lan", "domain": "nas.lan", "ip": "192.168.1.10"}`)
+	var record LocalDNSResourceModel
+	resp.Diagnostics.Append(resp.TargetState.Get(ctx, &record)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("moving pihole_dns_record: %v", resp.Diagnostics)
+	}
+	if record.ID.ValueString() != "192.168.1.10 nas.lan" || record.Hostname.ValueString() != "nas.lan" || record.IP.ValueString() != "192.168.1.10" {
+		t.Errorf("moved pihole_dns_record = %+v", record)
+	}
+
+	resp = moveState(t, &CNAMERecordResource{}, source, "pihole_cname_record", `{"id": "www.lan", "domain": "www.lan", "target": "nas.lan"}`)
+	var cname CNAMERecordResourceModel
+	resp.Diagnostics.Append(resp.TargetState.Get(ctx, &cname)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("moving pihole_cname_record: %v", resp.Diagnostics)
+	}
+	if cname.ID.ValueString() != "www.lan,nas.lan" {
+		t.Errorf("moved pihole_cname_record = %+v", cname)
+	}
+
+	resp = moveState(t, &GroupResource{}, source, "pihole_group", `{"id": "3", "name": "kids", "enabled": true, "description": ""}`)
+	var group GroupResourceModel
+	resp.Diagnostics.Append(resp.TargetState.Get(ctx, &group)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("moving pihole_group: %v", resp.Diagnostics)
+	}
+	if group.Name.ValueString() != "kids" || !group.Enabled.ValueBool() || !group.Description.IsNull() {
+		t.Errorf("moved pihole_group = %+v", group)
+	}
+
+	// Resources of other providers and types are left to other movers.
+	for _, tt := range []struct{ provider, typeName string }{
+		{"registry.terraform.io/example/pihole", "pihole_dns_record"},
+		{source, "pihole_ad_blocker_status"},
+	} {
+		resp := moveState(t, &LocalDNSResource{}, tt.provider, tt.typeName, `{}`)
+		if resp.Diagnostics.HasError() || !resp.TargetState.Raw.IsNull() {
+			t.Errorf("moving %s of %s: state %v, diagnostics %v", tt.typeName, tt.provider, resp.TargetState.Raw, resp.Diagnostics)
+		}
+	}
+
+	// Unreadable state is an error.
+	if resp := moveState(t, &LocalDNSResource{}, source, "pihole_dns_record", `[]`); !resp.Diagnostics.HasError() {
+		t.Error("expected error for unreadable state")
+	}
+}
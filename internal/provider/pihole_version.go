@@ -0,0 +1,88 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// checkPiholeVersion adds an error when the FTL version of the Pi-hole
+// behind c is outside the min_pihole_version and max_pihole_version bounds,
+// both inclusive. Development builds, whose versions are branch names,
+// only get a warning.
+func checkPiholeVersion(ctx context.Context, c *pihole.Client, minVersion, maxVersion types.String, diags *diag.Diagnostics) {
+	if minVersion.IsNull() && maxVersion.IsNull() {
+		return
+	}
+
+	info, err := c.GetVersion(ctx)
+	if err != nil {
+		addAPIErrorf(diags, "Unable to check Pi-hole version", err, "The provider configuration constrains the Pi-hole version, but it could not be read")
+		return
+	}
+
+	raw := info.FTL.Local.Version
+	current, err := version.NewVersion(raw)
+	if err != nil {
+		diags.AddWarning(
+			"Unknown Pi-hole version",
+			fmt.Sprintf("The FTL version %q of the Pi-hole is not a release version, e.g. a development build, "+
+				"so min_pihole_version and max_pihole_version are not checked.", raw),
+		)
+		return
+	}
+
+	if !minVersion.IsNull() {
+		if bound, err := version.NewVersion(minVersion.ValueString()); err == nil && current.LessThan(bound) {
+			diags.AddAttributeError(
+				path.Root("min_pihole_version"),
+				"Pi-hole version too old",
+				fmt.Sprintf("This configuration requires Pi-hole FTL %s or newer, but %s runs FTL %s. Update Pi-hole first.",
+					minVersion.ValueString(), c.Host(), raw),
+			)
+		}
+	}
+	if !maxVersion.IsNull() {
+		if bound, err := version.NewVersion(maxVersion.ValueString()); err == nil && current.GreaterThan(bound) {
+			diags.AddAttributeError(
+				path.Root("max_pihole_version"),
+				"Pi-hole version too new",
+				fmt.Sprintf("This configuration supports Pi-hole FTL up to %s, but %s runs FTL %s. Check the configuration "+
+					"against the newer version and raise max_pihole_version.", maxVersion.ValueString(), c.Host(), raw),
+			)
+		}
+	}
+}
+
+// piholeVersionValidator checks that a version bound is a version number.
+type piholeVersionValidator struct{}
+
+func (v piholeVersionValidator) Description(ctx context.Context) string {
+	return "value must be a version number, e.g. 6.1 or v6.0.4"
+}
+
+func (v piholeVersionValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v piholeVersionValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if _, err := version.NewVersion(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Pi-hole version",
+			fmt.Sprintf("The version %q is invalid: %s.", req.ConfigValue.ValueString(), err),
+		)
+	}
+}
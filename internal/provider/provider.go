@@ -5,21 +5,32 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"strconv"
 	"time"
 
-	"github.com/dklesev/terraform-provider-pihole/internal/client"
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure PiholeProvider satisfies various provider interfaces.
-var _ provider.Provider = &PiholeProvider{}
+var (
+	_ provider.Provider                       = &PiholeProvider{}
+	_ provider.ProviderWithFunctions          = &PiholeProvider{}
+	_ provider.ProviderWithEphemeralResources = &PiholeProvider{}
+)
 
 // PiholeProvider defines the provider implementation.
 type PiholeProvider struct {
@@ -27,14 +38,45 @@ type PiholeProvider struct {
 	// provider is built and ran locally, and "test" when running acceptance
 	// testing.
 	version string
+
+	// metrics aggregates API request latencies when enable_api_metrics is set.
+	metrics *pihole.Metrics
+
+	// getenv looks up the PIHOLE_* environment variables; os.Getenv when nil.
+	// Acceptance tests set it to point providers at different Pi-holes.
+	getenv func(string) string
+}
+
+// env returns the value of the environment variable key.
+func (p *PiholeProvider) env(key string) string {
+	if p.getenv != nil {
+		return p.getenv(key)
+	}
+	return os.Getenv(key)
 }
 
+// defaultWaitForRestart is how long requests wait for FTL to come back after
+// a restart unless wait_for_restart_seconds says otherwise.
+const defaultWaitForRestart = 60 * time.Second
+
 // PiholeProviderModel describes the provider data model.
 type PiholeProviderModel struct {
 	URL                   types.String `tfsdk:"url"`
 	Password              types.String `tfsdk:"password"`
 	TLSInsecureSkipVerify types.Bool   `tfsdk:"tls_insecure_skip_verify"`
 	Timeout               types.Int64  `tfsdk:"timeout"`
+	ManagedByTag          types.String `tfsdk:"managed_by_tag"`
+	CommentPrefix         types.String `tfsdk:"comment_prefix"`
+	WaitForRestartSeconds types.Int64  `tfsdk:"wait_for_restart_seconds"`
+	EnableAPIMetrics      types.Bool   `tfsdk:"enable_api_metrics"`
+	ExtraHeaders          types.Map    `tfsdk:"extra_headers"`
+	ReadOnly              types.Bool   `tfsdk:"read_only"`
+	AuditLogPath          types.String `tfsdk:"audit_log_path"`
+	AutoUpdateGravity     types.Bool   `tfsdk:"auto_update_gravity"`
+	MinPiholeVersion      types.String `tfsdk:"min_pihole_version"`
+	MaxPiholeVersion      types.String `tfsdk:"max_pihole_version"`
+	MaxConcurrentRequests types.Int64  `tfsdk:"max_concurrent_requests"`
+	APIVersion            types.String `tfsdk:"api_version"`
 }
 
 func (p *PiholeProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -64,22 +106,201 @@ provider "pihole" {
 The provider supports password-based authentication.
 
 > [!WARNING]
-> While you can configure the password in the ` + "`provider`" + ` block, this will result in the password being stored in plain text in the Terraform state file.
+> Provider arguments are never written to the Terraform state, but values passed in through regular
+> input variables are recorded in saved plan files.
 > 
-> **Strongly Recommended**: Do not set the ` + "`password`" + ` field in the configuration. Instead, set the ` + "`PIHOLE_PASSWORD`" + ` environment variable. This prevents the secret from being persisted in the state.
+> **Strongly Recommended**: Do not hard-code the ` + "`password`" + ` field in the configuration. Set the
+> ` + "`PIHOLE_PASSWORD`" + ` environment variable, or pass it through an ephemeral input variable.
 
 Configuration options:
 
 1. Environment variables (Recommended): ` + "`PIHOLE_URL`" + `, ` + "`PIHOLE_PASSWORD`" + `
-2. Provider configuration block (Not Recommended for secrets)
+2. Ephemeral input variable (Terraform 1.10+): the value never lands in plan or state artifacts
+3. Provider configuration block with a literal value (Not Recommended for secrets)
+
+` + "```hcl" + `
+variable "pihole_password" {
+  type      = string
+  sensitive = true
+  ephemeral = true
+}
+
+provider "pihole" {
+  url      = "http://pi.hole"
+  password = var.pihole_password
+}
+` + "```" + `
+
+Terraform 1.11 write-only arguments only exist on resources; provider arguments accept
+ephemeral values directly, which gives the same guarantee.
+
+## Version Constraints
+
+Modules that rely on behavior of newer FTL releases can set ` + "`min_pihole_version`" + `;
+` + "`max_pihole_version`" + ` guards against releases they were not checked against. Both bounds are
+inclusive and compared with the FTL version from ` + "`/api/info/version`" + ` when the provider
+is configured, so a plan against an unsuitable Pi-hole fails right away with the version
+it found instead of with errors from individual resources.
+
+` + "```hcl" + `
+provider "pihole" {
+  url                = "http://pi.hole"
+  min_pihole_version = "6.1"
+}
+` + "```" + `
+
+## Destructive API Actions
+
+Pi-hole can refuse destructive API calls (` + "`webserver.api.allow_destructive = false`" + `).
+The provider reads this setting when it is configured and warns during plan before
+destroying or replacing entries, and failed deletes explain how to enable it again.
+
+## Read-Only Mode
+
+Set ` + "`read_only = true`" + ` (or ` + "`PIHOLE_READ_ONLY=true`" + `) to make sure a configuration never
+changes the Pi-hole, e.g. for plans run from untrusted pipelines against production.
+Reads, refreshes and data sources work as usual, but every request that would change
+something fails before it is sent, so an accidental apply errors out instead of
+writing.
+
+## Gravity Updates
+
+Pi-hole only downloads the lists of ` + "`pihole_list`" + ` resources when gravity is updated, which
+happens weekly by default. Set ` + "`auto_update_gravity = true`" + ` (or
+` + "`PIHOLE_AUTO_UPDATE_GRAVITY=true`" + `) to update it once at the end of every apply that
+creates, changes or deletes ` + "`pihole_list`" + ` or ` + "`pihole_domain`" + ` resources, instead of
+running ` + "`pihole -g`" + ` by hand.
+
+The update runs when the last of those changes is done, no other starts within two
+seconds and no other resource is still sending requests to Pi-hole, and that change
+only returns once gravity is updated, which can take minutes. The update takes one of
+the ` + "`max_concurrent_requests`" + ` slots while it runs.
+A change that only starts after a slow unrelated resource, e.g. through ` + "`depends_on`" + `,
+can cause a second update. A failed update is reported as a warning; the changes
+themselves are saved.
+
+## Audit Log
+
+Set ` + "`audit_log_path`" + ` (or ` + "`PIHOLE_AUDIT_LOG`" + `) to keep a record of what the provider
+changed. Every create, update and delete request is appended to the file as a JSON line:
+
+` + "```json" + `
+{"time":"2025-06-01T12:00:00Z","method":"POST","path":"domains/deny/exact","body":"{\"domain\":[\"ads.example.com\"]}","status":201,"duration_ms":12}
+` + "```" + `
+
+Passwords and other secrets in request bodies are redacted.
+
+## Sharing a Pi-hole Between Configurations
+
+Set ` + "`managed_by_tag`" + ` to mark the comments of domains, lists and clients a configuration
+creates with ` + "`[tf:<tag>]`" + `. Resources hide the marker, and the ` + "`pihole_domains`" + `,
+` + "`pihole_lists`" + ` and ` + "`pihole_clients`" + ` data sources can filter on it, so each
+workspace can find and clean up only its own entries. Entries that belong to a
+` + "`pihole_device`" + `, ` + "`pihole_policy`" + `, ` + "`pihole_wildcard_block`" + ` or ` + "`pihole_custom_regex`" + `
+also name that resource, as in ` + "`[tf:<tag>/pihole_device]`" + `, so
+` + "`pihole_managed_cleanup`" + ` leaves them alone.
+
+Set ` + "`comment_prefix`" + ` to put a text such as ` + "`[terraform]`" + ` in front of the same
+comments, so people using the web interface see which entries not to edit there.
+Resources hide the prefix as well.
+
+## Older Pi-hole Versions
+
+Some API endpoints were only added in later v6 releases. When Pi-hole answers a request
+with "not found" for an endpoint it does not have, the provider checks the endpoint once
+and reports it as not supported by the Pi-hole version, along with the FTL version
+from ` + "`/api/info/version`" + `, instead of a generic 404. Resources are not removed from the
+state because of it.
+
+## Migrating from ryanwholey/pihole
+
+Resources of the ` + "`ryanwholey/pihole`" + ` provider for Pi-hole v5 can be taken over without
+recreating them. With Terraform 1.8 or later, ` + "`moved`" + ` blocks move their state to this
+provider's resources:
+
+| ryanwholey/pihole | This provider |
+|-------------------|---------------|
+| ` + "`pihole_dns_record`" + ` | ` + "`pihole_local_dns`" + ` (` + "`domain`" + ` becomes ` + "`hostname`" + `) |
+| ` + "`pihole_cname_record`" + ` | ` + "`pihole_cname_record`" + ` |
+| ` + "`pihole_group`" + ` | ` + "`pihole_group`" + ` |
+
+` + "```hcl" + `
+moved {
+  from = pihole_dns_record.nas
+  to   = pihole_local_dns.nas
+}
+` + "```" + `
+
+Terraform requires the two addresses of a ` + "`moved`" + ` block to differ, so resources whose
+type keeps its name are moved to a new name, or removed from the state with a ` + "`removed`" + `
+block and imported again. ` + "`pihole_local_dns`" + ` and ` + "`pihole_cname_record`" + ` also accept the
+import IDs of that provider, the hostname or domain alone.
+
+## Pi-hole v5
+
+Fleets that are still being migrated can manage their Pi-hole v5 instances with the same
+configuration code by setting ` + "`api_version = \"5\"`" + ` (or ` + "`PIHOLE_API_VERSION=5`" + `) in a
+separate provider block. The provider then uses the PHP API of the v5 web interface,
+` + "`<url>/admin/api.php`" + `, which only covers:
+
+- ` + "`pihole_domain`" + `, ` + "`pihole_custom_regex`" + `, ` + "`pihole_wildcard_block`" + ` and the ` + "`pihole_domains`" + ` data source
+- ` + "`pihole_dns_blocking`" + `
+
+` + "`password`" + ` is the web interface password or the API token from Settings > API, and is sent
+as a query parameter, so use HTTPS outside trusted networks. Entries are always created
+enabled and in the Default group, changing one replaces it with a new ID, and the time
+left of a blocking timer is not reported. Everything else fails with an error saying it is
+not supported.
+
+` + "```hcl" + `
+provider "pihole" {
+  alias       = "legacy"
+  url         = "http://pihole-old.lan"
+  api_version = "5"
+}
+` + "```" + `
+
+## Parallelism
+
+Terraform refreshes, reads and applies up to 10 resources and data sources at once
+(` + "`-parallelism`" + `). The provider sends at most ` + "`max_concurrent_requests`" + ` API requests at
+a time and queues the rest, so configurations with many data sources are read in
+parallel without overloading FTL's web server on small devices. The requests share one
+login session, and a session FTL dropped is replaced once, not once per request.
+
+Raising ` + "`-parallelism`" + ` beyond ` + "`max_concurrent_requests`" + ` only queues more requests in the
+provider; raise both to speed up large configurations against a Pi-hole that keeps up.
+
+Resources that are one item of a configuration array, such as ` + "`pihole_local_dns`" + `,
+` + "`pihole_cname_record`" + `, ` + "`pihole_dns_upstream`" + ` and ` + "`pihole_dhcp_static_lease`" + `, read the
+array back after adding or removing their item. FTL rewrites the whole array for every
+change, so a concurrent apply against the same Pi-hole can undo the change; it is then
+written once more, and the apply fails if it is undone again.
+
+## FTL Restarts
+
+Some configuration changes, such as DNS settings, make FTL restart. The provider waits up
+to ` + "`wait_for_restart_seconds`" + ` for the API to answer again, retrying requests that find it
+down and logging in again if the restart ended the session, so the rest of the apply
+carries on.
+
+## Config Resources
+
+The ` + "`pihole_config_*`" + ` resources with an ` + "`on_destroy`" + ` attribute each manage options of
+one section of Pi-hole's configuration and are imported by the section name, e.g.
+` + "`dns`" + `. Creating or importing one records the current values of its options, which
+` + "`on_destroy = \"restore_snapshot\"`" + ` restores when the resource is destroyed.
 `,
 		Attributes: map[string]schema.Attribute{
 			"url": schema.StringAttribute{
-				Description: "The URL of the Pi-hole instance (e.g., 'http://pi.hole'). Can also be set via the PIHOLE_URL environment variable.",
+				Description: "The URL of the Pi-hole instance (e.g., 'http://pi.hole'). A path is kept as a prefix for Pi-holes served below a subpath by a reverse proxy (e.g., 'https://example.com/pihole/'); the API is expected under '<url>/api'. IPv6 addresses go in brackets (e.g., 'http://[fd00::53]:8080'). Can also be set via the PIHOLE_URL environment variable.",
 				Optional:    true,
+				Validators: []validator.String{
+					piholeURLValidator{},
+				},
 			},
 			"password": schema.StringAttribute{
-				Description: "The password for the Pi-hole web interface. Can also be set via the PIHOLE_PASSWORD environment variable.",
+				Description: "The password for the Pi-hole web interface. Can also be set via the PIHOLE_PASSWORD environment variable. Accepts ephemeral values, so it never needs to be persisted in plan or state artifacts.",
 				Optional:    true,
 				Sensitive:   true,
 			},
@@ -91,6 +312,88 @@ Configuration options:
 				Description: "HTTP timeout in seconds. Default: 30.",
 				Optional:    true,
 			},
+			"wait_for_restart_seconds": schema.Int64Attribute{
+				Description: "How long to wait for Pi-hole to come back when FTL restarts during an apply, e.g. after a DNS configuration change. " +
+					"Requests that find the API down are retried once it answers again, and configuration changes wait for it before returning. " +
+					"Set to 0 to disable. Default: 60.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				Description: "Maximum number of API requests sent at once; further requests wait. Default: 8.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"extra_headers": schema.MapAttribute{
+				Description: "Additional HTTP headers sent with every request, e.g. credentials for a reverse proxy in front of Pi-hole " +
+					"such as Cloudflare Access service tokens (`CF-Access-Client-Id`, `CF-Access-Client-Secret`).",
+				Optional:    true,
+				Sensitive:   true,
+				ElementType: types.StringType,
+			},
+			"enable_api_metrics": schema.BoolAttribute{
+				Description: "Log the latency of every API request, along with the processing time reported by Pi-hole, " +
+					"with running per-endpoint totals (requests, errors, average and maximum latency). Visible with TF_LOG=INFO. Default: false.",
+				Optional: true,
+			},
+			"audit_log_path": schema.StringAttribute{
+				Description: "File to append a JSON line to for every API request that changes the Pi-hole: method, path, request body " +
+					"(secrets redacted, truncated to 1 KiB), status and error. Reads are not logged. " +
+					"Can also be set via the PIHOLE_AUDIT_LOG environment variable.",
+				Optional: true,
+			},
+			"read_only": schema.BoolAttribute{
+				Description: "Refuse every API request that would change the Pi-hole, so creates, updates and deletes fail while reads keep working. " +
+					"Can also be set via the PIHOLE_READ_ONLY environment variable. Default: false.",
+				Optional: true,
+			},
+			"auto_update_gravity": schema.BoolAttribute{
+				Description: "Update gravity once at the end of an apply that changed `pihole_list` or `pihole_domain` resources, so new lists " +
+					"are downloaded right away. Can also be set via the PIHOLE_AUTO_UPDATE_GRAVITY environment variable. Default: false.",
+				Optional: true,
+			},
+			"min_pihole_version": schema.StringAttribute{
+				Description: "Oldest Pi-hole FTL version the configuration works with, e.g. `6.1`. The provider fails when configured against an older Pi-hole.",
+				Optional:    true,
+				Validators: []validator.String{
+					piholeVersionValidator{},
+				},
+			},
+			"max_pihole_version": schema.StringAttribute{
+				Description: "Newest Pi-hole FTL version the configuration works with, e.g. `6.2.3`. The provider fails when configured against a newer Pi-hole.",
+				Optional:    true,
+				Validators: []validator.String{
+					piholeVersionValidator{},
+				},
+			},
+			"api_version": schema.StringAttribute{
+				Description: "The Pi-hole API to use: `6` for the FTL API of Pi-hole v6, or `5` for the PHP API of Pi-hole v5, which only covers domains and blocking. " +
+					"Can also be set via the PIHOLE_API_VERSION environment variable. Default: `6`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("5", "6"),
+				},
+			},
+			"managed_by_tag": schema.StringAttribute{
+				Description: "Tag appended as a `[tf:<tag>]` marker to the comments of domains, lists and clients created by this provider, " +
+					"so several Terraform configurations can share one Pi-hole. Data sources can filter on it. Can also be set via the PIHOLE_MANAGED_BY_TAG environment variable.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(managedTagRegexp, "may only contain letters, digits, '.', '_' and '-'"),
+				},
+			},
+			"comment_prefix": schema.StringAttribute{
+				Description: "Text put in front of the comments of domains, lists and clients created by this provider, e.g. `[terraform]`, " +
+					"so the Pi-hole web interface shows which entries Terraform manages. Resources hide it, so it never shows up as a diff. Can also be set via the PIHOLE_COMMENT_PREFIX environment variable.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
 		},
 	}
 }
@@ -105,12 +408,12 @@ func (p *PiholeProvider) Configure(ctx context.Context, req provider.ConfigureRe
 	}
 
 	// Use environment variables as fallback
-	url := os.Getenv("PIHOLE_URL")
+	url := p.env("PIHOLE_URL")
 	if !config.URL.IsNull() {
 		url = config.URL.ValueString()
 	}
 
-	password := os.Getenv("PIHOLE_PASSWORD")
+	password := p.env("PIHOLE_PASSWORD")
 	if !config.Password.IsNull() {
 		password = config.Password.ValueString()
 	}
@@ -125,12 +428,22 @@ func (p *PiholeProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		)
 	}
 
+	if url != "" {
+		if _, err := pihole.ParseURL(url); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("url"),
+				"Invalid Pi-hole URL",
+				fmt.Sprintf("The Pi-hole URL %q is invalid: %s.", url, err),
+			)
+		}
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	// Build client configuration
-	cfg := client.Config{
+	cfg := pihole.Config{
 		URL:      url,
 		Password: password,
 	}
@@ -143,8 +456,130 @@ func (p *PiholeProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		cfg.Timeout = time.Duration(config.Timeout.ValueInt64()) * time.Second
 	}
 
+	cfg.WaitForRestart = defaultWaitForRestart
+	if !config.WaitForRestartSeconds.IsNull() {
+		cfg.WaitForRestart = time.Duration(config.WaitForRestartSeconds.ValueInt64()) * time.Second
+	}
+
+	if !config.MaxConcurrentRequests.IsNull() {
+		cfg.MaxConcurrentRequests = int(config.MaxConcurrentRequests.ValueInt64())
+	}
+
+	cfg.UserAgent = fmt.Sprintf("terraform-provider-pihole/%s (+https://registry.terraform.io/providers/dklesev/pihole) Terraform/%s",
+		p.version, req.TerraformVersion)
+
+	if !config.ExtraHeaders.IsNull() && !config.ExtraHeaders.IsUnknown() {
+		resp.Diagnostics.Append(config.ExtraHeaders.ElementsAs(ctx, &cfg.Headers, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	var observers []func(context.Context, pihole.RequestMetric)
+	if config.EnableAPIMetrics.ValueBool() {
+		p.metrics = &pihole.Metrics{}
+		observers = append(observers, p.observeAPIRequest)
+	}
+
+	auditLogPath := p.env("PIHOLE_AUDIT_LOG")
+	if !config.AuditLogPath.IsNull() {
+		auditLogPath = config.AuditLogPath.ValueString()
+	}
+	if auditLogPath != "" {
+		audit, err := newAuditLog(auditLogPath)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("audit_log_path"),
+				"Cannot write audit log",
+				fmt.Sprintf("The audit log %s cannot be opened for writing: %s.", auditLogPath, err),
+			)
+			return
+		}
+		observers = append(observers, audit.record)
+	}
+
+	switch len(observers) {
+	case 0:
+	case 1:
+		cfg.RequestObserver = observers[0]
+	default:
+		cfg.RequestObserver = func(ctx context.Context, m pihole.RequestMetric) {
+			for _, observe := range observers {
+				observe(ctx, m)
+			}
+		}
+	}
+
+	if !config.ReadOnly.IsNull() {
+		cfg.ReadOnly = config.ReadOnly.ValueBool()
+	} else if v := p.env("PIHOLE_READ_ONLY"); v != "" {
+		readOnly, err := strconv.ParseBool(v)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("read_only"),
+				"Invalid PIHOLE_READ_ONLY",
+				fmt.Sprintf("The PIHOLE_READ_ONLY environment variable must be true or false, got %q.", v),
+			)
+			return
+		}
+		cfg.ReadOnly = readOnly
+	}
+
+	autoUpdateGravity := config.AutoUpdateGravity.ValueBool()
+	if config.AutoUpdateGravity.IsNull() {
+		if v := p.env("PIHOLE_AUTO_UPDATE_GRAVITY"); v != "" {
+			var err error
+			autoUpdateGravity, err = strconv.ParseBool(v)
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("auto_update_gravity"),
+					"Invalid PIHOLE_AUTO_UPDATE_GRAVITY",
+					fmt.Sprintf("The PIHOLE_AUTO_UPDATE_GRAVITY environment variable must be true or false, got %q.", v),
+				)
+				return
+			}
+		}
+	}
+
+	apiVersion := p.env("PIHOLE_API_VERSION")
+	if !config.APIVersion.IsNull() {
+		apiVersion = config.APIVersion.ValueString()
+	}
+	switch apiVersion {
+	case "", "6":
+		cfg.APIVersion = pihole.APIVersionFTL
+	case "5":
+		cfg.APIVersion = pihole.APIVersionLegacy
+	default:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("api_version"),
+			"Invalid PIHOLE_API_VERSION",
+			fmt.Sprintf("The PIHOLE_API_VERSION environment variable must be 5 or 6, got %q.", apiVersion),
+		)
+		return
+	}
+
+	var comments commentSettings
+	comments.managedByTag = p.env("PIHOLE_MANAGED_BY_TAG")
+	if !config.ManagedByTag.IsNull() {
+		comments.managedByTag = config.ManagedByTag.ValueString()
+	}
+	if comments.managedByTag != "" && !managedTagRegexp.MatchString(comments.managedByTag) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("managed_by_tag"),
+			"Invalid PIHOLE_MANAGED_BY_TAG",
+			fmt.Sprintf("The PIHOLE_MANAGED_BY_TAG environment variable may only contain letters, digits, '.', '_' and '-', got %q.", comments.managedByTag),
+		)
+		return
+	}
+
+	comments.prefix = p.env("PIHOLE_COMMENT_PREFIX")
+	if !config.CommentPrefix.IsNull() {
+		comments.prefix = config.CommentPrefix.ValueString()
+	}
+
 	// Create the API client
-	apiClient, err := client.New(cfg)
+	apiClient, err := pihole.New(cfg)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to create Pi-hole API client",
@@ -155,28 +590,57 @@ func (p *PiholeProvider) Configure(ctx context.Context, req provider.ConfigureRe
 
 	// Test authentication
 	if err := apiClient.Authenticate(ctx); err != nil {
-		resp.Diagnostics.AddError(
-			"Failed to authenticate with Pi-hole",
-			"The provider was unable to authenticate with the Pi-hole instance: "+err.Error(),
-		)
+		addAPIErrorf(&resp.Diagnostics, "Failed to authenticate with Pi-hole", err, "The provider was unable to authenticate with the Pi-hole instance")
 		return
 	}
 
+	checkPiholeVersion(ctx, apiClient, config.MinPiholeVersion, config.MaxPiholeVersion, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Remember whether DELETE requests will be refused, so destroys can be
+	// flagged during plan instead of failing halfway through an apply.
+	if webserver, err := apiClient.GetWebserverConfig(ctx); err != nil {
+		tflog.Debug(ctx, "Could not read webserver.api.allow_destructive", map[string]interface{}{"error": err.Error()})
+	} else if webserver.API != nil {
+		apiClient.SetAllowDestructive(webserver.API.AllowDestructive)
+	}
+
+	if autoUpdateGravity {
+		enableGravityUpdates(apiClient)
+	}
+
 	tflog.Info(ctx, "Pi-hole provider configured successfully", map[string]interface{}{
-		"url": url,
+		"url":         url,
+		"read_only":   cfg.ReadOnly,
+		"api_version": cfg.APIVersion,
 	})
 
 	// Make client available to resources and data sources
-	resp.DataSourceData = apiClient
-	resp.ResourceData = apiClient
+	data := &providerData{client: apiClient, comments: comments, plannedGroups: &plannedGroups{}}
+	resp.DataSourceData = data
+	resp.ResourceData = data
+	resp.EphemeralResourceData = data
+}
+
+// providerData is handed to resources and data sources: the API client,
+// and the provider settings that only concern how the provider uses it.
+type providerData struct {
+	client        *pihole.Client
+	comments      commentSettings
+	plannedGroups *plannedGroups
 }
 
 func (p *PiholeProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewGroupResource,
 		NewDomainResource,
+		NewCustomRegexResource,
+		NewWildcardBlockResource,
 		NewClientResource,
 		NewListResource,
+		NewListGroupAssociationResource,
 		NewDNSBlockingResource,
 		NewConfigMiscResource,
 		NewConfigDNSResource,
@@ -188,18 +652,55 @@ func (p *PiholeProvider) Resources(ctx context.Context) []func() resource.Resour
 		NewConfigWebserverResource,
 		NewConfigFilesResource,
 		NewDNSUpstreamResource,
+		NewAPIExclusionResource,
 		NewLocalDNSResource,
 		NewCNAMERecordResource,
+		NewPTRRecordResource,
+		NewConditionalForwardResource,
+		NewForwardZoneResource,
 		NewDHCPStaticLeaseResource,
+		NewDHCPOptionResource,
+		NewPasswordResource,
+		NewManagedCleanupResource,
+		NewDeviceResource,
+		NewPolicyResource,
+		NewBlockingScheduleResource,
+		NewGroupStateResource,
+		NewConfigResetResource,
 	}
 }
 
 func (p *PiholeProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewGroupsDataSource,
+		NewGroupIDsDataSource,
 		NewDomainsDataSource,
 		NewClientsDataSource,
+		NewClientSuggestionsDataSource,
 		NewListsDataSource,
+		NewEffectivePolicyDataSource,
+		NewNetworkDevicesDataSource,
+		NewDNSUpstreamsDataSource,
+		NewQueryTypesDataSource,
+		NewFTLDataSource,
+		NewMetricsDataSource,
+		NewSessionsDataSource,
+	}
+}
+
+func (p *PiholeProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewAppPasswordEphemeralResource,
+	}
+}
+
+func (p *PiholeProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewIsValidDomainFunction,
+		NewToABPFunction,
+		NewRegexEscapeFunction,
+		NewRegexTemplateFunction,
+		NewParseHostsFunction,
 	}
 }
 
@@ -211,3 +712,28 @@ func New(version string) func() provider.Provider {
 		}
 	}
 }
+
+// piholeURLValidator checks that the url attribute is an http(s) URL the
+// client can use, with IPv6 addresses in brackets.
+type piholeURLValidator struct{}
+
+func (v piholeURLValidator) Description(ctx context.Context) string {
+	return "value must be an http or https URL, with IPv6 addresses in brackets"
+}
+
+func (v piholeURLValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v piholeURLValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if _, err := pihole.ParseURL(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Pi-hole URL",
+			fmt.Sprintf("The Pi-hole URL %q is invalid: %s.", req.ConfigValue.ValueString(), err),
+		)
+	}
+}
@@ -5,10 +5,13 @@ package provider
 
 import (
 	"os"
+	"regexp"
 	"testing"
 
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
 // testAccProtoV6ProviderFactories are used to instantiate a provider during
@@ -30,3 +33,112 @@ func testAccPreCheck(t *testing.T) {
 		os.Setenv("PIHOLE_PASSWORD", "test123")
 	}
 }
+
+// testAccAPIClient returns a Pi-hole API client for the acceptance test
+// instance, for checks and sweepers that talk to Pi-hole directly.
+func testAccAPIClient() (*pihole.Client, error) {
+	url := os.Getenv("PIHOLE_URL")
+	if url == "" {
+		url = "http://localhost:8080"
+	}
+
+	return pihole.New(pihole.Config{
+		URL:      url,
+		Password: testAccPassword(),
+	})
+}
+
+// testAccPassword returns the admin password of the acceptance test instance.
+func testAccPassword() string {
+	if password := os.Getenv("PIHOLE_PASSWORD"); password != "" {
+		return password
+	}
+	return "test123"
+}
+
+func TestAccProvider_readOnly(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Reads keep working
+			{
+				Config: `
+provider "pihole" {
+  read_only = true
+}
+
+data "pihole_groups" "test" {}
+`,
+				Check: resource.TestCheckResourceAttrSet("data.pihole_groups.test", "groups.#"),
+			},
+			{
+				Config: `
+provider "pihole" {
+  read_only = true
+}
+
+resource "pihole_group" "test" {
+  name = "tf-acc-read-only"
+}
+`,
+				ExpectError: regexp.MustCompile(`read-only mode is enabled`),
+			},
+		},
+	})
+}
+
+func TestAccProvider_versionConstraints(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "pihole" {
+  min_pihole_version = "6.0"
+  max_pihole_version = "99.0"
+}
+
+data "pihole_groups" "test" {}
+`,
+				Check: resource.TestCheckResourceAttrSet("data.pihole_groups.test", "groups.#"),
+			},
+			{
+				Config: `
+provider "pihole" {
+  min_pihole_version = "99.0"
+}
+
+data "pihole_groups" "test" {}
+`,
+				ExpectError: regexp.MustCompile(`Pi-hole version too old`),
+			},
+			{
+				Config: `
+provider "pihole" {
+  max_pihole_version = "not-a-version"
+}
+
+data "pihole_groups" "test" {}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Pi-hole version`),
+			},
+		},
+	})
+}
+
+func TestAccProvider_managedByTagEnv(t *testing.T) {
+	t.Setenv("PIHOLE_MANAGED_BY_TAG", "team a]")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      `data "pihole_groups" "test" {}`,
+				ExpectError: regexp.MustCompile(`Invalid PIHOLE_MANAGED_BY_TAG`),
+			},
+		},
+	})
+}
@@ -0,0 +1,42 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// privacyLevels describes what FTL withholds at each misc.privacylevel.
+var privacyLevels = map[int]string{
+	1: "hides the domains of queries",
+	2: "hides the domains and clients of queries",
+	3: "is anonymous mode: FTL stores no query history and keeps no per-domain or per-client statistics",
+}
+
+// warnPrivacyLevel warns that Pi-hole's privacy level redacts data a data
+// source returns, when the level is at least minLevel. affected says which
+// fields are missing. The check is best effort and skipped when the
+// configuration cannot be read.
+func warnPrivacyLevel(ctx context.Context, c *pihole.Client, minLevel int, affected string, diags *diag.Diagnostics) {
+	misc, err := c.GetMiscConfig(ctx)
+	if err != nil || misc == nil {
+		tflog.Debug(ctx, "Skipping privacy level check", map[string]interface{}{"error": fmt.Sprint(err)})
+		return
+	}
+
+	level := misc.PrivacyLevel
+	if level < minLevel {
+		return
+	}
+	diags.AddWarning(
+		"Pi-hole privacy level redacts query data",
+		fmt.Sprintf("Pi-hole's privacy level is %d, which %s, so %s. "+
+			"The level is set by privacy_level of pihole_config_misc.", level, privacyLevels[level], affected),
+	)
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// regexTemplates describes the regex rules pihole_custom_regex and the
+// regex_template function build, by template name.
+var regexTemplates = map[string]string{
+	"tld":        "every domain in the top-level domain, e.g. `xyz`",
+	"domain":     "the domain and all of its subdomains",
+	"subdomains": "the subdomains of the domain, but not the domain itself",
+	"keyword":    "every domain that contains the value",
+}
+
+// queryTypeRegexp matches the value of Pi-hole's ;querytype= regex
+// extension, e.g. "AAAA", "A,AAAA" or "!A".
+var queryTypeRegexp = regexp.MustCompile(`^!?[A-Z0-9]+(,[A-Z0-9]+)*$`)
+
+// regexTemplateNames returns the template names, sorted.
+func regexTemplateNames() []string {
+	names := make([]string, 0, len(regexTemplates))
+	for name := range regexTemplates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// regexTemplateDescription lists the templates for schema descriptions.
+func regexTemplateDescription() string {
+	var parts []string
+	for _, name := range regexTemplateNames() {
+		parts = append(parts, fmt.Sprintf("`%s` matches %s", name, regexTemplates[name]))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// buildRegexRule returns the Pi-hole regex rule a template builds for value.
+// A non-empty queryType limits the rule to those query types.
+func buildRegexRule(template, value, queryType string) (string, error) {
+	value = strings.ToLower(strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(value, "*"), "."), "."))
+
+	var rule string
+	switch template {
+	case "tld":
+		if strings.Contains(value, ".") || !isValidDomain(value) {
+			return "", fmt.Errorf("%q is not a top-level domain", value)
+		}
+		rule = `(\.|^)` + regexp.QuoteMeta(value) + `$`
+	case "domain":
+		if !isValidDomain(value) {
+			return "", fmt.Errorf("%q is not a valid domain", value)
+		}
+		rule = `(\.|^)` + regexp.QuoteMeta(value) + `$`
+	case "subdomains":
+		if !isValidDomain(value) {
+			return "", fmt.Errorf("%q is not a valid domain", value)
+		}
+		rule = `\.` + regexp.QuoteMeta(value) + `$`
+	case "keyword":
+		if value == "" {
+			return "", fmt.Errorf("the keyword must not be empty")
+		}
+		rule = regexp.QuoteMeta(value)
+	default:
+		return "", fmt.Errorf("unknown template %q, expected one of: %s", template, strings.Join(regexTemplateNames(), ", "))
+	}
+
+	if queryType != "" {
+		if !queryTypeRegexp.MatchString(queryType) {
+			return "", fmt.Errorf("%q is not a query type list such as \"AAAA\", \"A,AAAA\" or \"!A\"", queryType)
+		}
+		rule += ";querytype=" + queryType
+	}
+	return rule, nil
+}
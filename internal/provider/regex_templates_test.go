@@ -0,0 +1,76 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestBuildRegexRule(t *testing.T) {
+	tests := []struct {
+		template  string
+		value     string
+		queryType string
+		want      string
+		wantErr   bool
+	}{
+		{template: "tld", value: "xyz", want: `(\.|^)xyz$`},
+		{template: "tld", value: ".XYZ", want: `(\.|^)xyz$`},
+		{template: "tld", value: "example.xyz", wantErr: true},
+		{template: "domain", value: "Tracker.example.com.", want: `(\.|^)tracker\.example\.com$`},
+		{template: "domain", value: "*.example.com", want: `(\.|^)example\.com$`},
+		{template: "domain", value: "bad domain", wantErr: true},
+		{template: "subdomains", value: "example.com", want: `\.example\.com$`},
+		{template: "keyword", value: "ads+", want: `ads\+`},
+		{template: "keyword", value: "", wantErr: true},
+		{template: "domain", value: "example.com", queryType: "AAAA", want: `(\.|^)example\.com$;querytype=AAAA`},
+		{template: "domain", value: "example.com", queryType: "aaaa", wantErr: true},
+		{template: "prefix", value: "ads", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.template+" "+tt.value, func(t *testing.T) {
+			got, err := buildRegexRule(tt.template, tt.value, tt.queryType)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("buildRegexRule() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("buildRegexRule() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildRegexRule_matches(t *testing.T) {
+	tests := []struct {
+		template string
+		value    string
+		match    []string
+		noMatch  []string
+	}{
+		{"tld", "xyz", []string{"xyz", "a.xyz", "a.b.xyz"}, []string{"xyz.com", "axyz"}},
+		{"domain", "example.com", []string{"example.com", "www.example.com"}, []string{"badexample.com", "example.com.evil"}},
+		{"subdomains", "example.com", []string{"www.example.com"}, []string{"example.com", "badexample.com"}},
+		{"keyword", "track", []string{"track.example.com", "mytracker.net"}, []string{"example.com"}},
+	}
+
+	for _, tt := range tests {
+		rule, err := buildRegexRule(tt.template, tt.value, "")
+		if err != nil {
+			t.Fatalf("buildRegexRule(%q, %q) error = %v", tt.template, tt.value, err)
+		}
+		re := regexp.MustCompile(rule)
+		for _, domain := range tt.match {
+			if !re.MatchString(domain) {
+				t.Errorf("%s %q: %s should match %q", tt.template, tt.value, rule, domain)
+			}
+		}
+		for _, domain := range tt.noMatch {
+			if re.MatchString(domain) {
+				t.Errorf("%s %q: %s should not match %q", tt.template, tt.value, rule, domain)
+			}
+		}
+	}
+}
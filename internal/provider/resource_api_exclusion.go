@@ -0,0 +1,238 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = &APIExclusionResource{}
+	_ resource.ResourceWithImportState = &APIExclusionResource{}
+	_ resource.ResourceWithModifyPlan  = &APIExclusionResource{}
+)
+
+// apiExclusionArrays are the webserver.api arrays by exclusion type.
+var apiExclusionArrays = map[string]string{
+	"client": "webserver/api/excludeClients",
+	"domain": "webserver/api/excludeDomains",
+}
+
+func NewAPIExclusionResource() resource.Resource {
+	return &APIExclusionResource{}
+}
+
+// APIExclusionResource manages an entry of webserver.api.excludeClients or
+// webserver.api.excludeDomains.
+type APIExclusionResource struct {
+	client *pihole.Client
+}
+
+type APIExclusionResourceModel struct {
+	ID    types.String `tfsdk:"id"`
+	Type  types.String `tfsdk:"type"`
+	Value types.String `tfsdk:"value"`
+}
+
+func (r *APIExclusionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_exclusion"
+}
+
+func (r *APIExclusionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Excludes a client or domain from the query log and top lists of Pi-hole's API and dashboard.",
+		MarkdownDescription: `
+Excludes a client or domain from the query log and the top client and domain
+lists of Pi-hole's API and dashboard. Each exclusion is an entry of the
+` + "`webserver.api.excludeClients`" + ` or ` + "`webserver.api.excludeDomains`" + ` config
+and an individual resource, so several modules can exclude their own entries.
+Queries are still answered, logged to the database and counted in the totals.
+
+Pi-hole matches the values as regular expressions; use
+` + "`provider::pihole::regex_escape`" + ` to exclude a literal name.
+
+## Example Usage
+
+` + "```hcl" + `
+resource "pihole_api_exclusion" "monitoring" {
+  type  = "client"
+  value = "192.168.1.5"
+}
+
+resource "pihole_api_exclusion" "ntp" {
+  type  = "domain"
+  value = "(\\.|^)pool\\.ntp\\.org$"
+}
+` + "```" + `
+`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Resource identifier in the format type/value.",
+			},
+			"type": schema.StringAttribute{
+				Required:    true,
+				Description: "What the value matches: 'client' (IP address or hostname) or 'domain'.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("client", "domain"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"value": schema.StringAttribute{
+				Required:    true,
+				Description: "Regular expression matching the clients or domains to exclude.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *APIExclusionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData))
+		return
+	}
+	r.client = data.client
+}
+
+func (r *APIExclusionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data APIExclusionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	exclusionType, value := data.Type.ValueString(), data.Value.ValueString()
+	tflog.Debug(ctx, "Creating API exclusion", map[string]interface{}{"type": exclusionType, "value": value})
+
+	if err := r.client.AddConfigArrayItemVerified(ctx, apiExclusionArrays[exclusionType], value, nil); err != nil {
+		addAPIError(&resp.Diagnostics, "Error adding API exclusion", err)
+		return
+	}
+
+	data.ID = types.StringValue(exclusionType + "/" + value)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *APIExclusionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data APIExclusionResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found, err := r.exists(ctx, data.Type.ValueString(), data.Value.ValueString())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error reading webserver config", err)
+		return
+	}
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.ID = types.StringValue(data.Type.ValueString() + "/" + data.Value.ValueString())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *APIExclusionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Both attributes require replace, so Update should not be called
+	resp.Diagnostics.AddError("Update not supported", "API exclusion changes require replacement")
+}
+
+func (r *APIExclusionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data APIExclusionResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	exclusionType, value := data.Type.ValueString(), data.Value.ValueString()
+	tflog.Debug(ctx, "Deleting API exclusion", map[string]interface{}{"type": exclusionType, "value": value})
+
+	if err := r.client.DeleteConfigArrayItemVerified(ctx, apiExclusionArrays[exclusionType], value, nil); err != nil && !errors.Is(err, pihole.ErrNotFound) {
+		addAPIError(&resp.Diagnostics, "Error deleting API exclusion", err)
+		return
+	}
+}
+
+// ModifyPlan warns before destroys that Pi-hole may refuse.
+func (r *APIExclusionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	warnDestructiveDisabled(r.client, req, resp)
+}
+
+func (r *APIExclusionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import format: type/value; the value may contain slashes itself
+	exclusionType, value, ok := strings.Cut(req.ID, "/")
+	if _, known := apiExclusionArrays[exclusionType]; !ok || !known || value == "" {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			"Import ID must be in the format: type/value, with type 'client' or 'domain' (e.g., client/192.168.1.5)",
+		)
+		return
+	}
+
+	found, err := r.exists(ctx, exclusionType, value)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error reading webserver config", err)
+		return
+	}
+	if !found {
+		resp.Diagnostics.AddError("API exclusion not found", fmt.Sprintf("%s %q is not excluded in Pi-hole", exclusionType, value))
+		return
+	}
+
+	data := APIExclusionResourceModel{
+		ID:    types.StringValue(req.ID),
+		Type:  types.StringValue(exclusionType),
+		Value: types.StringValue(value),
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// exists reports whether value is in the exclusion array of exclusionType.
+func (r *APIExclusionResource) exists(ctx context.Context, exclusionType, value string) (bool, error) {
+	config, err := r.client.GetWebserverConfig(ctx)
+	if err != nil {
+		return false, err
+	}
+	if config.API == nil {
+		return false, nil
+	}
+
+	values := config.API.ExcludeClients
+	if exclusionType == "domain" {
+		values = config.API.ExcludeDomains
+	}
+	for _, v := range values {
+		if v == value {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,308 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource               = &BlockingScheduleResource{}
+	_ resource.ResourceWithModifyPlan = &BlockingScheduleResource{}
+)
+
+func NewBlockingScheduleResource() resource.Resource {
+	return &BlockingScheduleResource{now: time.Now}
+}
+
+// BlockingScheduleResource enables a group while its schedule is active and
+// disables it otherwise. The schedule is evaluated whenever Terraform plans.
+type BlockingScheduleResource struct {
+	client *pihole.Client
+
+	// now returns the current time; tests replace it.
+	now func() time.Time
+}
+
+type BlockingScheduleResourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	Group             types.String `tfsdk:"group"`
+	EnabledExpression types.String `tfsdk:"enabled_expression"`
+	Timezone          types.String `tfsdk:"timezone"`
+	Active            types.Bool   `tfsdk:"active"`
+	EvaluatedAt       types.String `tfsdk:"evaluated_at"`
+}
+
+func (r *BlockingScheduleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_blocking_schedule"
+}
+
+func (r *BlockingScheduleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Enables a group during scheduled time windows and disables it otherwise.",
+		MarkdownDescription: `
+Enables a group during scheduled time windows and disables it otherwise, for
+example a group with a restrictive blocklist that applies to the kids' devices
+at night.
+
+Pi-hole has no scheduler of its own. The schedule is evaluated whenever
+Terraform plans: when the group's state no longer matches the schedule, the
+plan shows an update and applying it enables or disables the group. Run
+` + "`terraform apply`" + ` from cron (or a CI schedule) at least at every window
+boundary, e.g.
+
+` + "```sh" + `
+*/15 * * * * cd /srv/pihole && terraform apply -auto-approve -target=pihole_blocking_schedule.bedtime
+` + "```" + `
+
+The ` + "`enabled_expression`" + ` lists the windows in which the group is enabled,
+separated by semicolons. Each window is an optional day specification and a
+time range: days are three-letter names (` + "`mon`" + `), ranges (` + "`mon-fri`" + `, ` + "`fri-mon`" + `),
+comma-separated lists of those, or ` + "`daily`" + `, ` + "`weekdays`" + ` and ` + "`weekends`" + `. A
+range ending before it starts runs past midnight and belongs to the day it starts on.
+
+Destroying the resource leaves the group as it is. When the group is managed
+with ` + "`pihole_group`" + `, add ` + "`enabled`" + ` to its ` + "`ignore_changes`" + `.
+
+## Example Usage
+
+` + "```hcl" + `
+resource "pihole_group" "bedtime" {
+  name = "bedtime"
+
+  lifecycle {
+    ignore_changes = [enabled]
+  }
+}
+
+resource "pihole_blocking_schedule" "bedtime" {
+  group              = pihole_group.bedtime.name
+  enabled_expression = "sun-thu 21:00-07:00; fri,sat 23:00-09:00"
+  timezone           = "Europe/Berlin"
+}
+` + "```" + `
+`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The name of the scheduled group.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"group": schema.StringAttribute{
+				Description: "The name of the group to enable and disable.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"enabled_expression": schema.StringAttribute{
+				Description: "The windows in which the group is enabled, e.g. `mon-fri 21:00-07:00; sat,sun 22:30-08:00`.",
+				Required:    true,
+				Validators: []validator.String{
+					scheduleExpressionValidator{},
+				},
+			},
+			"timezone": schema.StringAttribute{
+				Description: "The IANA time zone the windows are in. Default: `UTC`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("UTC"),
+				Validators: []validator.String{
+					timeZoneValidator{},
+				},
+			},
+			"active": schema.BoolAttribute{
+				Description: "Whether the schedule is active, i.e. the group is enabled.",
+				Computed:    true,
+			},
+			"evaluated_at": schema.StringAttribute{
+				Description: "When the schedule was last applied to the group (RFC 3339, in `timezone`).",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *BlockingScheduleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.client
+}
+
+func (r *BlockingScheduleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data BlockingScheduleResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.apply(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BlockingScheduleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data BlockingScheduleResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	group, err := r.client.GetGroup(ctx, data.Group.ValueString())
+	if err != nil {
+		addAPIErrorf(&resp.Diagnostics, "Error reading blocking schedule", err, "Could not read group %s", data.Group.ValueString())
+		return
+	}
+
+	if group == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.Active = types.BoolValue(group.Enabled)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BlockingScheduleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data BlockingScheduleResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.apply(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BlockingScheduleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// The group keeps its current state.
+}
+
+// ModifyPlan evaluates the schedule so the plan shows an update whenever the
+// group's state no longer matches it.
+func (r *BlockingScheduleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan BlockingScheduleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	active, ok := r.evaluate(plan)
+	if !ok {
+		return
+	}
+	plan.Active = types.BoolValue(active)
+
+	if !req.State.Raw.IsNull() {
+		var state BlockingScheduleResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if state.Active.Equal(plan.Active) && state.EnabledExpression.Equal(plan.EnabledExpression) && state.Timezone.Equal(plan.Timezone) {
+			plan.EvaluatedAt = state.EvaluatedAt
+		} else {
+			plan.EvaluatedAt = types.StringUnknown()
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// evaluate reports whether the schedule of a model is active now. ok is false
+// when the expression or time zone is not known yet or invalid.
+func (r *BlockingScheduleResource) evaluate(data BlockingScheduleResourceModel) (active, ok bool) {
+	if data.EnabledExpression.IsUnknown() || data.Timezone.IsUnknown() {
+		return false, false
+	}
+	windows, err := parseSchedule(data.EnabledExpression.ValueString())
+	if err != nil {
+		return false, false
+	}
+	loc, err := time.LoadLocation(data.Timezone.ValueString())
+	if err != nil {
+		return false, false
+	}
+	return scheduleActiveAt(windows, r.now().In(loc)), true
+}
+
+// apply enables or disables the group as planned, evaluating the schedule if
+// the plan could not.
+func (r *BlockingScheduleResource) apply(ctx context.Context, data *BlockingScheduleResourceModel, diags *diag.Diagnostics) {
+	if data.Active.IsUnknown() || data.Active.IsNull() {
+		active, ok := r.evaluate(*data)
+		if !ok {
+			diags.AddError("Invalid blocking schedule", "The schedule or time zone could not be evaluated.")
+			return
+		}
+		data.Active = types.BoolValue(active)
+	}
+
+	name := data.Group.ValueString()
+	group, err := r.client.GetGroup(ctx, name)
+	if err != nil {
+		addAPIErrorf(diags, "Error applying blocking schedule", err, "Could not read group %s", name)
+		return
+	}
+	if group == nil {
+		diags.AddError("Error applying blocking schedule", fmt.Sprintf("Group %s does not exist.", name))
+		return
+	}
+
+	if group.Enabled != data.Active.ValueBool() {
+		tflog.Info(ctx, "Applying blocking schedule", map[string]interface{}{
+			"group":   name,
+			"enabled": data.Active.ValueBool(),
+		})
+		group.Enabled = data.Active.ValueBool()
+		if _, err := r.client.UpdateGroup(ctx, name, group); err != nil {
+			addAPIErrorf(diags, "Error applying blocking schedule", err, "Could not update group %s", name)
+			return
+		}
+	}
+
+	loc, _ := time.LoadLocation(data.Timezone.ValueString())
+	data.ID = types.StringValue(name)
+	data.EvaluatedAt = types.StringValue(r.now().In(loc).Format(time.RFC3339))
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccResourceBlockingSchedule_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// A window covering every minute enables the group
+			{
+				Config: testAccResourceBlockingScheduleConfig("daily 00:00-24:00"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_blocking_schedule.test", "active", "true"),
+					resource.TestCheckResourceAttr("pihole_blocking_schedule.test", "timezone", "UTC"),
+					resource.TestCheckResourceAttrSet("pihole_blocking_schedule.test", "evaluated_at"),
+				),
+			},
+			{
+				Config:      testAccResourceBlockingScheduleConfig("someday 21:00-07:00"),
+				ExpectError: regexp.MustCompile(`Invalid schedule expression`),
+			},
+		},
+	})
+}
+
+func testAccResourceBlockingScheduleConfig(expr string) string {
+	return fmt.Sprintf(`
+resource "pihole_group" "test" {
+  name = "tf-acc-schedule"
+
+  lifecycle {
+    ignore_changes = [enabled]
+  }
+}
+
+resource "pihole_blocking_schedule" "test" {
+  group              = pihole_group.test.name
+  enabled_expression = %q
+}
+`, expr)
+}
@@ -5,9 +5,11 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
-	"github.com/dklesev/terraform-provider-pihole/internal/client"
+	"github.com/dklesev/terraform-provider-pihole/internal/provider/convert"
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -21,6 +23,7 @@ import (
 var (
 	_ resource.Resource                = &ClientResource{}
 	_ resource.ResourceWithImportState = &ClientResource{}
+	_ resource.ResourceWithModifyPlan  = &ClientResource{}
 )
 
 func NewClientResource() resource.Resource {
@@ -28,7 +31,9 @@ func NewClientResource() resource.Resource {
 }
 
 type ClientResource struct {
-	client *client.Client
+	client        *pihole.Client
+	comments      commentSettings
+	plannedGroups *plannedGroups
 }
 
 type ClientResourceModel struct {
@@ -36,8 +41,11 @@ type ClientResourceModel struct {
 	Client       types.String `tfsdk:"client"`
 	Comment      types.String `tfsdk:"comment"`
 	Groups       types.List   `tfsdk:"groups"`
+	GroupNames   types.Set    `tfsdk:"group_names"`
 	DateAdded    types.Int64  `tfsdk:"date_added"`
 	DateModified types.Int64  `tfsdk:"date_modified"`
+
+	DeletionProtection types.Bool `tfsdk:"deletion_protection"`
 }
 
 func (r *ClientResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -93,6 +101,19 @@ resource "pihole_client" "guest_wifi" {
   comment = "Guest WiFi interface"
 }
 ` + "```" + `
+
+### By Group Name
+
+Groups can be named with ` + "`group_names`" + ` instead of their IDs in ` + "`groups`" + `,
+which change when a group is recreated. Referencing the group resource's name
+makes sure it is created first.
+
+` + "```hcl" + `
+resource "pihole_client" "tablet" {
+  client      = "192.168.1.120"
+  group_names = [pihole_group.kids.name, "iot"]
+}
+` + "```" + `
 `,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.Int64Attribute{
@@ -107,7 +128,7 @@ resource "pihole_client" "guest_wifi" {
 				Required:    true,
 			},
 			"comment": schema.StringAttribute{
-				Description: "A comment describing the client.",
+				Description: "A comment describing the client. The provider's `managed_by_tag` marker is appended in Pi-hole and hidden here.",
 				Optional:    true,
 			},
 			"groups": schema.ListAttribute{
@@ -116,6 +137,7 @@ resource "pihole_client" "guest_wifi" {
 				Computed:    true,
 				ElementType: types.Int64Type,
 			},
+			"group_names": groupNamesAttribute(),
 			"date_added": schema.Int64Attribute{
 				Description: "Unix timestamp when the client was created.",
 				Computed:    true,
@@ -127,6 +149,7 @@ resource "pihole_client" "guest_wifi" {
 				Description: "Unix timestamp when the client was last modified.",
 				Computed:    true,
 			},
+			"deletion_protection": deletionProtectionAttribute(),
 		},
 	}
 }
@@ -136,16 +159,18 @@ func (r *ClientResource) Configure(ctx context.Context, req resource.ConfigureRe
 		return
 	}
 
-	c, ok := req.ProviderData.(*client.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = c
+	r.client = data.client
+	r.comments = data.comments
+	r.plannedGroups = data.plannedGroups
 }
 
 func (r *ClientResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -160,26 +185,21 @@ func (r *ClientResource) Create(ctx context.Context, req resource.CreateRequest,
 		"client": data.Client.ValueString(),
 	})
 
-	var groups []int64
-	if !data.Groups.IsNull() && !data.Groups.IsUnknown() {
-		resp.Diagnostics.Append(data.Groups.ElementsAs(ctx, &groups, false)...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
+	groups, diags := resolveGroups(ctx, r.client, data.Groups, data.GroupNames)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	piholeClient := &client.PiholeClient{
+	piholeClient := &pihole.PiholeClient{
 		Client:  data.Client.ValueString(),
-		Comment: data.Comment.ValueString(),
+		Comment: r.comments.managed(data.Comment.ValueString()),
 		Groups:  groups,
 	}
 
 	created, err := r.client.CreateClient(ctx, piholeClient)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error creating client",
-			fmt.Sprintf("Could not create client %s: %s", data.Client.ValueString(), err.Error()),
-		)
+		addAPIErrorf(&resp.Diagnostics, "Error creating client", err, "Could not create client %s", data.Client.ValueString())
 		return
 	}
 
@@ -196,14 +216,12 @@ func (r *ClientResource) Read(ctx context.Context, req resource.ReadRequest, res
 	}
 
 	piholeClient, err := r.client.GetClient(ctx, data.Client.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error reading client",
-			fmt.Sprintf("Could not read client %s: %s", data.Client.ValueString(), err.Error()),
-		)
+	if err != nil && !errors.Is(err, pihole.ErrNotFound) {
+		addAPIErrorf(&resp.Diagnostics, "Error reading client", err, "Could not read client %s", data.Client.ValueString())
 		return
 	}
 
+	// Pi-hole answers 404 or an empty result for a client deleted outside Terraform.
 	if piholeClient == nil {
 		resp.State.RemoveResource(ctx)
 		return
@@ -223,26 +241,21 @@ func (r *ClientResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	var groups []int64
-	if !data.Groups.IsNull() && !data.Groups.IsUnknown() {
-		resp.Diagnostics.Append(data.Groups.ElementsAs(ctx, &groups, false)...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
+	groups, diags := resolveGroups(ctx, r.client, data.Groups, data.GroupNames)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	piholeClient := &client.PiholeClient{
+	piholeClient := &pihole.PiholeClient{
 		Client:  data.Client.ValueString(),
-		Comment: data.Comment.ValueString(),
+		Comment: r.comments.managed(data.Comment.ValueString()),
 		Groups:  groups,
 	}
 
 	updated, err := r.client.UpdateClient(ctx, state.Client.ValueString(), piholeClient)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error updating client",
-			fmt.Sprintf("Could not update client %s: %s", state.Client.ValueString(), err.Error()),
-		)
+		addAPIErrorf(&resp.Diagnostics, "Error updating client", err, "Could not update client %s", state.Client.ValueString())
 		return
 	}
 
@@ -258,37 +271,71 @@ func (r *ClientResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	checkDeletionProtection(ctx, req.State, "client", "client", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	err := r.client.DeleteClient(ctx, data.Client.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error deleting client",
-			fmt.Sprintf("Could not delete client %s: %s", data.Client.ValueString(), err.Error()),
-		)
+	if err != nil && !errors.Is(err, pihole.ErrNotFound) {
+		addAPIErrorf(&resp.Diagnostics, "Error deleting client", err, "Could not delete client %s", data.Client.ValueString())
 		return
 	}
 }
 
+// ModifyPlan rejects destroys of protected clients, warns before destroys
+// that Pi-hole may refuse and resolves group_names.
+func (r *ClientResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planDeletionProtection(ctx, req, resp, "client", "client")
+	warnDestructiveDisabled(r.client, req, resp)
+	planGroupNames(ctx, r.client, r.plannedGroups, req, resp, true)
+}
+
+// ImportState verifies that the client exists, so a typo in the import ID
+// fails here instead of on the first refresh, and suggests close matches.
 func (r *ClientResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("client"), req, resp)
+	piholeClient, err := r.client.GetClient(ctx, req.ID)
+	if err != nil && !errors.Is(err, pihole.ErrNotFound) {
+		addAPIErrorf(&resp.Diagnostics, "Error reading client", err, "Could not read client %s", req.ID)
+		return
+	}
+	if piholeClient == nil {
+		resp.Diagnostics.AddError("Client not found", r.notFoundDetail(ctx, req.ID))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("client"), piholeClient.Client)...)
+}
+
+// notFoundDetail describes a missing client, with the configured clients
+// that look like a typo of it.
+func (r *ClientResource) notFoundDetail(ctx context.Context, client string) string {
+	detail := fmt.Sprintf("Client %q not found in Pi-hole. The import ID is the client identifier: an IP address, subnet, MAC address, hostname or interface (e.g. 192.168.1.100).", client)
+
+	clients, err := r.client.GetClients(ctx, "")
+	if err != nil {
+		tflog.Debug(ctx, "Could not list clients for suggestions", map[string]interface{}{"error": err.Error()})
+		return detail
+	}
+	identifiers := make([]string, len(clients))
+	for i, c := range clients {
+		identifiers[i] = c.Client
+	}
+	if matches := closeMatches(client, identifiers); len(matches) > 0 {
+		detail += fmt.Sprintf("\n\nDid you mean %s?", quoteList(matches))
+	}
+	return detail
 }
 
-func (r *ClientResource) mapClientToModel(ctx context.Context, piholeClient *client.PiholeClient, data *ClientResourceModel, diags *diag.Diagnostics) {
+func (r *ClientResource) mapClientToModel(ctx context.Context, piholeClient *pihole.PiholeClient, data *ClientResourceModel, diags *diag.Diagnostics) {
 	data.ID = types.Int64Value(piholeClient.ID)
 	data.Client = types.StringValue(piholeClient.Client)
 
-	if piholeClient.Comment != "" {
-		data.Comment = types.StringValue(piholeClient.Comment)
-	} else {
-		data.Comment = types.StringNull()
-	}
+	data.Comment = convert.OptionalString(r.comments.unmanaged(piholeClient.Comment))
 
-	if len(piholeClient.Groups) > 0 {
-		groupsList, d := types.ListValueFrom(ctx, types.Int64Type, piholeClient.Groups)
-		diags.Append(d...)
-		data.Groups = groupsList
-	} else {
-		data.Groups = types.ListNull(types.Int64Type)
-	}
+	groups, d := convert.GroupList(ctx, piholeClient.Groups)
+	diags.Append(d...)
+	data.Groups = groups
 
 	data.DateAdded = types.Int64Value(piholeClient.DateAdded)
 	data.DateModified = types.Int64Value(piholeClient.DateModified)
@@ -5,6 +5,7 @@ package provider
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -29,6 +30,13 @@ func TestAccResourceClient_byIP(t *testing.T) {
 				ImportStateId:     "192.168.1.100",
 				ImportStateVerify: true,
 			},
+			// A typo in the import ID fails with a suggestion
+			{
+				ResourceName:  "pihole_client.test",
+				ImportState:   true,
+				ImportStateId: "192.168.1.10O",
+				ExpectError:   regexp.MustCompile(`(?s)Client not found.*Did you mean "192\.168\.1\.100"`),
+			},
 			// Update
 			{
 				Config: testAccResourceClientConfig("192.168.1.100", "Updated comment"),
@@ -41,32 +49,34 @@ func TestAccResourceClient_byIP(t *testing.T) {
 }
 
 func TestAccResourceClient_byMAC(t *testing.T) {
-	resource.Test(t, resource.TestCase{
-		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
-		Steps: []resource.TestStep{
-			{
-				Config: testAccResourceClientConfig("AA:BB:CC:DD:EE:FF", "Test client by MAC"),
-				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("pihole_client.test", "client", "AA:BB:CC:DD:EE:FF"),
-				),
+	testAccParallelTest(t, func(inst *testAccInstance) resource.TestCase {
+		return resource.TestCase{
+			ProtoV6ProviderFactories: inst.providerFactories(),
+			Steps: []resource.TestStep{
+				{
+					Config: testAccResourceClientConfig("AA:BB:CC:DD:EE:FF", "Test client by MAC"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("pihole_client.test", "client", "AA:BB:CC:DD:EE:FF"),
+					),
+				},
 			},
-		},
+		}
 	})
 }
 
 func TestAccResourceClient_bySubnet(t *testing.T) {
-	resource.Test(t, resource.TestCase{
-		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
-		Steps: []resource.TestStep{
-			{
-				Config: testAccResourceClientConfig("192.168.10.0/24", "Test client by subnet"),
-				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("pihole_client.test", "client", "192.168.10.0/24"),
-				),
+	testAccParallelTest(t, func(inst *testAccInstance) resource.TestCase {
+		return resource.TestCase{
+			ProtoV6ProviderFactories: inst.providerFactories(),
+			Steps: []resource.TestStep{
+				{
+					Config: testAccResourceClientConfig("192.168.10.0/24", "Test client by subnet"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("pihole_client.test", "client", "192.168.10.0/24"),
+					),
+				},
 			},
-		},
+		}
 	})
 }
 
@@ -86,6 +96,47 @@ func TestAccResourceClient_withGroups(t *testing.T) {
 	})
 }
 
+func TestAccResourceClient_groupNames(t *testing.T) {
+	testAccParallelTest(t, func(inst *testAccInstance) resource.TestCase {
+		return resource.TestCase{
+			ProtoV6ProviderFactories: inst.providerFactories(),
+			Steps: []resource.TestStep{
+				{
+					Config: testAccResourceClientGroupNamesConfig("pihole_group.test.name"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("pihole_client.test", "group_names.#", "1"),
+						resource.TestCheckResourceAttr("pihole_client.test", "groups.#", "1"),
+						resource.TestCheckResourceAttrPair("pihole_client.test", "groups.0", "pihole_group.test", "id"),
+					),
+				},
+				// A replaced group is resolved to its new ID
+				{
+					Config: testAccResourceClientGroupNamesConfig("pihole_group.test.name"),
+					Taint:  []string{"pihole_group.test"},
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttrPair("pihole_client.test", "groups.0", "pihole_group.test", "id"),
+					),
+				},
+				// Unknown names fail with the names that look like a typo
+				{
+					Config:      testAccResourceClientGroupNamesConfig(`"client-names-grup"`),
+					ExpectError: regexp.MustCompile(`(?s)No group is named "client-names-grup".*Did you mean\s+"tf-acc-client-names"\?`),
+				},
+				{
+					Config: testAccResourceClientGroupNamesConfig("pihole_group.test.name") + `
+resource "pihole_client" "both" {
+  client      = "192.168.1.202"
+  groups      = [0]
+  group_names = ["Default"]
+}
+`,
+					ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+				},
+			},
+		}
+	})
+}
+
 func testAccResourceClientConfig(client, comment string) string {
 	return fmt.Sprintf(`
 resource "pihole_client" "test" {
@@ -98,7 +149,7 @@ resource "pihole_client" "test" {
 func testAccResourceClientWithGroupConfig() string {
 	return `
 resource "pihole_group" "test" {
-  name = "client-test-group"
+  name = "tf-acc-client"
 }
 
 resource "pihole_client" "test" {
@@ -108,3 +159,16 @@ resource "pihole_client" "test" {
 }
 `
 }
+
+func testAccResourceClientGroupNamesConfig(groupName string) string {
+	return fmt.Sprintf(`
+resource "pihole_group" "test" {
+  name = "tf-acc-client-names"
+}
+
+resource "pihole_client" "test" {
+  client      = "192.168.1.201"
+  group_names = [%s]
+}
+`, groupName)
+}
@@ -5,14 +5,15 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
-	"github.com/dklesev/terraform-provider-pihole/internal/client"
+	"github.com/dklesev/terraform-provider-pihole/internal/provider/convert"
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -20,6 +21,8 @@ import (
 var (
 	_ resource.Resource                = &CNAMERecordResource{}
 	_ resource.ResourceWithImportState = &CNAMERecordResource{}
+	_ resource.ResourceWithModifyPlan  = &CNAMERecordResource{}
+	_ resource.ResourceWithMoveState   = &CNAMERecordResource{}
 )
 
 func NewCNAMERecordResource() resource.Resource {
@@ -27,13 +30,14 @@ func NewCNAMERecordResource() resource.Resource {
 }
 
 type CNAMERecordResource struct {
-	client *client.Client
+	client *pihole.Client
 }
 
 type CNAMERecordResourceModel struct {
-	ID     types.String `tfsdk:"id"`
-	Domain types.String `tfsdk:"domain"`
-	Target types.String `tfsdk:"target"`
+	ID     types.String    `tfsdk:"id"`
+	Domain types.String    `tfsdk:"domain"`
+	Target types.String    `tfsdk:"target"`
+	Verify *dnsVerifyModel `tfsdk:"verify"`
 }
 
 func (r *CNAMERecordResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -54,6 +58,30 @@ resource "pihole_cname_record" "www" {
   target = "server.example.local"
 }
 ` + "```" + `
+
+### Verify the Alias Resolves
+
+Pi-hole only answers CNAME records whose target it knows, e.g. from a
+` + "`pihole_local_dns`" + ` record.
+
+` + "```hcl" + `
+resource "pihole_cname_record" "nas" {
+  domain = "files.lan"
+  target = pihole_local_dns.nas.hostname
+
+  verify {}
+}
+` + "```" + `
+
+## Import
+
+Records can be imported using the format ` + "`domain,target`" + `, or by the domain
+alone:
+
+` + "```shell" + `
+terraform import pihole_cname_record.www www.example.local,server.example.local
+terraform import pihole_cname_record.www www.example.local
+` + "```" + `
 `,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -63,18 +91,15 @@ resource "pihole_cname_record" "www" {
 			"domain": schema.StringAttribute{
 				Required:    true,
 				Description: "The domain name (alias).",
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
 			},
 			"target": schema.StringAttribute{
 				Required:    true,
 				Description: "The target domain (canonical name).",
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"verify": dnsVerifyBlock(),
+		},
 	}
 }
 
@@ -82,12 +107,12 @@ func (r *CNAMERecordResource) Configure(ctx context.Context, req resource.Config
 	if req.ProviderData == nil {
 		return
 	}
-	c, ok := req.ProviderData.(*client.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
-		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *client.Client, got: %T.", req.ProviderData))
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData))
 		return
 	}
-	r.client = c
+	r.client = data.client
 }
 
 func (r *CNAMERecordResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -101,13 +126,15 @@ func (r *CNAMERecordResource) Create(ctx context.Context, req resource.CreateReq
 	value := fmt.Sprintf("%s,%s", data.Domain.ValueString(), data.Target.ValueString())
 	tflog.Debug(ctx, "Creating CNAME record", map[string]interface{}{"value": value})
 
-	if err := r.client.AddConfigArrayItem(ctx, "dns/cnameRecords", value); err != nil {
-		resp.Diagnostics.AddError("Error adding CNAME record", err.Error())
+	if err := r.client.AddConfigArrayItemVerified(ctx, "dns/cnameRecords", value, data.finder()); err != nil {
+		addAPIError(&resp.Diagnostics, "Error adding CNAME record", err)
 		return
 	}
 
 	data.ID = types.StringValue(value)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	verifyDNS(ctx, r.client, data.Verify, data.Domain.ValueString(), verifyCNAME(data.Domain.ValueString(), data.Target.ValueString()), &resp.Diagnostics)
 }
 
 func (r *CNAMERecordResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -121,19 +148,11 @@ func (r *CNAMERecordResource) Read(ctx context.Context, req resource.ReadRequest
 
 	config, err := r.client.GetDNSConfig(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading DNS config", err.Error())
+		addAPIError(&resp.Diagnostics, "Error reading DNS config", err)
 		return
 	}
 
-	found := false
-	for _, c := range config.CNAMERecords {
-		if c == value {
-			found = true
-			break
-		}
-	}
-
-	if !found {
+	if _, found := convert.FindCNAMERecord(config.CNAMERecords, data.Domain.ValueString(), data.Target.ValueString()); !found {
 		resp.State.RemoveResource(ctx)
 		return
 	}
@@ -143,7 +162,41 @@ func (r *CNAMERecordResource) Read(ctx context.Context, req resource.ReadRequest
 }
 
 func (r *CNAMERecordResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics.AddError("Update not supported", "Changes require replacement")
+	var data, state CNAMERecordResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, err := r.client.GetDNSConfig(ctx)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error reading DNS config", err)
+		return
+	}
+
+	value := fmt.Sprintf("%s,%s", data.Domain.ValueString(), data.Target.ValueString())
+	tflog.Debug(ctx, "Updating CNAME record", map[string]interface{}{"value": value})
+
+	// Swap the entry in place so dependents are not replaced with it
+	old, found := convert.FindCNAMERecord(config.CNAMERecords, state.Domain.ValueString(), state.Target.ValueString())
+	switch {
+	case found && old == value:
+		// Only the verify block changed.
+	case found:
+		err = r.client.ReplaceConfigArrayItem(ctx, "dns/cnameRecords", old, value, state.finder(), data.finder())
+	default:
+		err = r.client.AddConfigArrayItemVerified(ctx, "dns/cnameRecords", value, data.finder())
+	}
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error updating CNAME record", err)
+		return
+	}
+
+	data.ID = types.StringValue(value)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	verifyDNS(ctx, r.client, data.Verify, data.Domain.ValueString(), verifyCNAME(data.Domain.ValueString(), data.Target.ValueString()), &resp.Diagnostics)
 }
 
 func (r *CNAMERecordResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -153,27 +206,98 @@ func (r *CNAMERecordResource) Delete(ctx context.Context, req resource.DeleteReq
 		return
 	}
 
-	value := fmt.Sprintf("%s,%s", data.Domain.ValueString(), data.Target.ValueString())
+	config, err := r.client.GetDNSConfig(ctx)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error reading DNS config", err)
+		return
+	}
+
+	// Delete the entry as stored, which may differ in case or spacing.
+	value, found := convert.FindCNAMERecord(config.CNAMERecords, data.Domain.ValueString(), data.Target.ValueString())
+	if !found {
+		return
+	}
 	tflog.Debug(ctx, "Deleting CNAME record", map[string]interface{}{"value": value})
 
-	if err := r.client.DeleteConfigArrayItem(ctx, "dns/cnameRecords", value); err != nil {
-		resp.Diagnostics.AddError("Error deleting CNAME record", err.Error())
+	if err := r.client.DeleteConfigArrayItemVerified(ctx, "dns/cnameRecords", value, data.finder()); err != nil && !errors.Is(err, pihole.ErrNotFound) {
+		addAPIError(&resp.Diagnostics, "Error deleting CNAME record", err)
 		return
 	}
 }
 
+// ModifyPlan warns before destroys that Pi-hole may refuse.
+func (r *CNAMERecordResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	warnDestructiveDisabled(r.client, req, resp)
+}
+
 func (r *CNAMERecordResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Import format: "domain,target"
+	// Import format: "domain,target", or the domain alone as with the
+	// ryanwholey/pihole provider
 	parts := strings.SplitN(req.ID, ",", 2)
+	if len(parts) == 1 && parts[0] != "" {
+		target := r.lookupTarget(ctx, parts[0], &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		parts = []string{parts[0], target}
+	}
 	if len(parts) != 2 {
-		resp.Diagnostics.AddError("Invalid import ID", "Expected format: 'domain,target'")
+		resp.Diagnostics.AddError("Invalid import ID", "Expected format: 'domain,target' or 'domain'")
 		return
 	}
 
 	data := CNAMERecordResourceModel{
-		ID:     types.StringValue(req.ID),
+		ID:     types.StringValue(parts[0] + "," + parts[1]),
 		Domain: types.StringValue(parts[0]),
 		Target: types.StringValue(parts[1]),
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// lookupTarget returns the target of the CNAME record of domain.
+func (r *CNAMERecordResource) lookupTarget(ctx context.Context, domain string, diags *diag.Diagnostics) string {
+	config, err := r.client.GetDNSConfig(ctx)
+	if err != nil {
+		addAPIError(diags, "Error reading DNS config", err)
+		return ""
+	}
+
+	for _, line := range config.CNAMERecords {
+		if d, target, ok := convert.ParseCNAMERecord(line); ok && convert.SameHostname(d, domain) {
+			return target
+		}
+	}
+	diags.AddError("Invalid import ID", fmt.Sprintf("No CNAME record exists for %s.", domain))
+	return ""
+}
+
+// MoveState turns pihole_cname_record resources of the ryanwholey/pihole
+// provider into CNAME records of this provider.
+func (r *CNAMERecordResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{{
+		StateMover: func(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+			var source struct {
+				Domain string `json:"domain"`
+				Target string `json:"target"`
+			}
+			if !ryanwholeyState(req, "pihole_cname_record", &source, &resp.Diagnostics) {
+				return
+			}
+
+			data := CNAMERecordResourceModel{
+				ID:     types.StringValue(source.Domain + "," + source.Target),
+				Domain: types.StringValue(source.Domain),
+				Target: types.StringValue(source.Target),
+			}
+			resp.Diagnostics.Append(resp.TargetState.Set(ctx, &data)...)
+		},
+	}}
+}
+
+// finder looks the record up in dns.cnameRecords, which may store it in
+// another case or spacing.
+func (m CNAMERecordResourceModel) finder() pihole.ItemFinder {
+	return func(records []string) (string, bool) {
+		return convert.FindCNAMERecord(records, m.Domain.ValueString(), m.Target.ValueString())
+	}
+}
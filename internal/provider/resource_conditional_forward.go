@@ -0,0 +1,272 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dklesev/terraform-provider-pihole/internal/provider/convert"
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = &ConditionalForwardResource{}
+	_ resource.ResourceWithImportState = &ConditionalForwardResource{}
+	_ resource.ResourceWithModifyPlan  = &ConditionalForwardResource{}
+)
+
+func NewConditionalForwardResource() resource.Resource {
+	return &ConditionalForwardResource{}
+}
+
+// ConditionalForwardResource manages a "server=/domain/ip" line in
+// misc.dnsmasq_lines.
+type ConditionalForwardResource struct {
+	client *pihole.Client
+}
+
+type ConditionalForwardResourceModel struct {
+	ID     types.String `tfsdk:"id"`
+	Domain types.String `tfsdk:"domain"`
+	Server types.String `tfsdk:"server"`
+	Port   types.Int64  `tfsdk:"port"`
+}
+
+func (r *ConditionalForwardResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_conditional_forward"
+}
+
+func (r *ConditionalForwardResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Forwards DNS queries for a domain to a specific server.",
+		MarkdownDescription: `
+Forwards DNS queries for a domain and its subdomains to a specific DNS server,
+e.g. a company domain to the corporate resolver over a VPN. The resource adds a
+dnsmasq ` + "`server=/domain/ip`" + ` line to the ` + "`misc.dnsmasq_lines`" + ` config.
+
+~> **Note:** ` + "`pihole_config_misc`" + ` manages ` + "`dnsmasq_lines`" + ` as a whole list and
+removes lines it does not know. When both are used, add ` + "`dnsmasq_lines`" + ` to
+the ` + "`ignore_changes`" + ` of ` + "`pihole_config_misc`" + `.
+
+## Example Usage
+
+` + "```hcl" + `
+resource "pihole_conditional_forward" "corp" {
+  domain = "corp.example.com"
+  server = "10.0.0.1"
+}
+
+resource "pihole_conditional_forward" "consul" {
+  domain = "consul"
+  server = "127.0.0.1"
+  port   = 8600
+}
+` + "```" + `
+`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Resource identifier (`domain,server` or `domain,server#port`).",
+			},
+			"domain": schema.StringAttribute{
+				Required:    true,
+				Description: "The domain to forward, including its subdomains.",
+			},
+			"server": schema.StringAttribute{
+				Required:    true,
+				Description: "The IP address of the DNS server to forward to.",
+			},
+			"port": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(53),
+				Description: "The port of the DNS server. Default: 53.",
+				Validators: []validator.Int64{
+					int64validator.Between(1, 65535),
+				},
+			},
+		},
+	}
+}
+
+func (r *ConditionalForwardResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData))
+		return
+	}
+	r.client = data.client
+}
+
+func (r *ConditionalForwardResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ConditionalForwardResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	line := data.server().String()
+	tflog.Debug(ctx, "Creating conditional forward", map[string]interface{}{"value": line})
+
+	if err := r.client.AddConfigArrayItemVerified(ctx, "misc/dnsmasq_lines", line, data.finder()); err != nil {
+		addAPIError(&resp.Diagnostics, "Error adding conditional forward", err)
+		return
+	}
+
+	data.ID = types.StringValue(data.id())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConditionalForwardResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ConditionalForwardResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, err := r.client.GetMiscConfig(ctx)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error reading misc config", err)
+		return
+	}
+
+	if _, found := convert.FindServerLine(config.DnsmasqLines, data.server()); !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.ID = types.StringValue(data.id())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConditionalForwardResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state ConditionalForwardResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, err := r.client.GetMiscConfig(ctx)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error reading misc config", err)
+		return
+	}
+
+	line := data.server().String()
+	tflog.Debug(ctx, "Updating conditional forward", map[string]interface{}{"value": line})
+
+	// Swap the entry in place so dependents are not replaced with it
+	if old, found := convert.FindServerLine(config.DnsmasqLines, state.server()); found {
+		err = r.client.ReplaceConfigArrayItem(ctx, "misc/dnsmasq_lines", old, line, state.finder(), data.finder())
+	} else {
+		err = r.client.AddConfigArrayItemVerified(ctx, "misc/dnsmasq_lines", line, data.finder())
+	}
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error updating conditional forward", err)
+		return
+	}
+
+	data.ID = types.StringValue(data.id())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConditionalForwardResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ConditionalForwardResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, err := r.client.GetMiscConfig(ctx)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error reading misc config", err)
+		return
+	}
+
+	// Delete the line as stored, which may differ in case or spacing.
+	line, found := convert.FindServerLine(config.DnsmasqLines, data.server())
+	if !found {
+		return
+	}
+	tflog.Debug(ctx, "Deleting conditional forward", map[string]interface{}{"value": line})
+
+	if err := r.client.DeleteConfigArrayItemVerified(ctx, "misc/dnsmasq_lines", line, data.finder()); err != nil && !errors.Is(err, pihole.ErrNotFound) {
+		addAPIError(&resp.Diagnostics, "Error deleting conditional forward", err)
+		return
+	}
+}
+
+// ModifyPlan warns before destroys that Pi-hole may refuse.
+func (r *ConditionalForwardResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	warnDestructiveDisabled(r.client, req, resp)
+}
+
+func (r *ConditionalForwardResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import format: "domain,server" or "domain,server#port"
+	domain, server, ok := strings.Cut(req.ID, ",")
+	if !ok || domain == "" || server == "" {
+		resp.Diagnostics.AddError("Invalid import ID", "Expected format: 'domain,server' or 'domain,server#port'")
+		return
+	}
+
+	port := int64(53)
+	if host, p, ok := strings.Cut(server, "#"); ok {
+		n, err := strconv.ParseInt(p, 10, 64)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("port"), "Invalid import ID", fmt.Sprintf("Invalid port %q.", p))
+			return
+		}
+		server, port = host, n
+	}
+
+	data := ConditionalForwardResourceModel{
+		Domain: types.StringValue(domain),
+		Server: types.StringValue(server),
+		Port:   types.Int64Value(port),
+	}
+	data.ID = types.StringValue(data.id())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// server returns the server= line fields described by the model.
+func (m ConditionalForwardResourceModel) server() convert.DnsmasqServer {
+	return convert.DnsmasqServer{
+		Domain: m.Domain.ValueString(),
+		Server: m.Server.ValueString(),
+		Port:   m.Port.ValueInt64(),
+	}
+}
+
+// finder looks the server= line up in misc.dnsmasq_lines, which may store
+// it in another case or spacing.
+func (m ConditionalForwardResourceModel) finder() pihole.ItemFinder {
+	return func(lines []string) (string, bool) {
+		return convert.FindServerLine(lines, m.server())
+	}
+}
+
+// id formats the resource ID, leaving out the default port.
+func (m ConditionalForwardResourceModel) id() string {
+	id := m.Domain.ValueString() + "," + m.Server.ValueString()
+	if m.Port.ValueInt64() != 53 {
+		id += fmt.Sprintf("#%d", m.Port.ValueInt64())
+	}
+	return id
+}
@@ -7,36 +7,44 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/dklesev/terraform-provider-pihole/internal/client"
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 var (
 	_ resource.Resource                = &ConfigDatabaseResource{}
 	_ resource.ResourceWithImportState = &ConfigDatabaseResource{}
+	_ resource.ResourceWithModifyPlan  = &ConfigDatabaseResource{}
 )
 
 func NewConfigDatabaseResource() resource.Resource {
 	return &ConfigDatabaseResource{}
 }
 
+// maxDatabaseDays is the longest history FTL keeps, in days: longer periods
+// overflow the 32-bit seconds it computes the cutoff in.
+const maxDatabaseDays = 24855
+
 type ConfigDatabaseResource struct {
-	client *client.Client
+	client *pihole.Client
 }
 
 type ConfigDatabaseResourceModel struct {
-	ID            types.String `tfsdk:"id"`
-	DBImport      types.Bool   `tfsdk:"db_import"`
-	MaxDBDays     types.Int64  `tfsdk:"max_db_days"`
-	DBInterval    types.Int64  `tfsdk:"db_interval"`
-	UseWAL        types.Bool   `tfsdk:"use_wal"`
-	ParseARPCache types.Bool   `tfsdk:"parse_arp_cache"`
-	NetworkExpire types.Int64  `tfsdk:"network_expire"`
+	ID               types.String `tfsdk:"id"`
+	ServerValuesJSON types.String `tfsdk:"server_values_json"`
+	OnDestroy        types.String `tfsdk:"on_destroy"`
+	DBImport         types.Bool   `tfsdk:"db_import"`
+	MaxDBDays        types.Int64  `tfsdk:"max_db_days"`
+	DBInterval       types.Int64  `tfsdk:"db_interval"`
+	UseWAL           types.Bool   `tfsdk:"use_wal"`
+	ParseARPCache    types.Bool   `tfsdk:"parse_arp_cache"`
+	NetworkExpire    types.Int64  `tfsdk:"network_expire"`
 }
 
 func (r *ConfigDatabaseResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -46,45 +54,86 @@ func (r *ConfigDatabaseResource) Metadata(ctx context.Context, req resource.Meta
 func (r *ConfigDatabaseResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages Pi-hole database configuration.",
+		MarkdownDescription: `
+Manages how Pi-hole keeps its query history and network table in the
+long-term database: how long queries are kept, how often they are written,
+and when devices expire from the network table.
+
+Lower ` + "`max_db_days`" + ` and higher ` + "`db_interval`" + ` values reduce the writes to
+SD cards on small devices; ` + "`max_db_days = 0`" + ` disables the query history.
+
+~> **Note:** Pi-hole's API has no action to vacuum or flush the long-term
+database, so this provider cannot offer one. Pi-hole removes queries older than
+` + "`max_db_days`" + ` itself, and SQLite reuses the space they took.
+
+## Example Usage
+
+` + "```hcl" + `
+resource "pihole_config_database" "settings" {
+  max_db_days    = 30 # Keep a month of history
+  db_interval    = 300
+  network_expire = 30
+}
+` + "```" + `
+
+## Import
+
+The database configuration can be imported using the section name, ` + "`database`" + `:
+
+` + "```shell" + `
+terraform import pihole_config_database.settings database
+` + "```" + `
+`,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
 			},
+			"server_values_json": serverValuesAttribute(),
+			"on_destroy":         onDestroyAttribute(),
 			"db_import": schema.BoolAttribute{
-				Description: "Import database on startup.",
+				Description: "Whether to load the queries of the last 24 hours from the database when FTL starts, so the dashboard survives restarts.",
 				Optional:    true,
 				Computed:    true,
 				Default:     booldefault.StaticBool(true),
 			},
 			"max_db_days": schema.Int64Attribute{
-				Description: "Maximum database history in days.",
+				Description: "How many days of queries the database keeps, at most 24855. 0 disables the query history: queries are no longer stored, and `db_import` and `db_interval` have no effect.",
 				Optional:    true,
 				Computed:    true,
 				Default:     int64default.StaticInt64(91),
+				Validators: []validator.Int64{
+					int64validator.Between(0, maxDatabaseDays),
+				},
 			},
 			"db_interval": schema.Int64Attribute{
-				Description: "Database write interval in seconds.",
+				Description: "How often, in seconds, FTL writes new queries to the database.",
 				Optional:    true,
 				Computed:    true,
 				Default:     int64default.StaticInt64(60),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
 			},
 			"use_wal": schema.BoolAttribute{
-				Description: "Use WAL mode for database.",
+				Description: "Whether the database uses SQLite's write-ahead log, which lets the web interface read while FTL writes.",
 				Optional:    true,
 				Computed:    true,
 				Default:     booldefault.StaticBool(true),
 			},
 			"parse_arp_cache": schema.BoolAttribute{
-				Description: "Parse ARP cache for network table.",
+				Description: "Whether FTL reads the ARP cache of its host to fill the network table.",
 				Optional:    true,
 				Computed:    true,
 				Default:     booldefault.StaticBool(true),
 			},
 			"network_expire": schema.Int64Attribute{
-				Description: "Network table entry expiration in days.",
+				Description: "How many days addresses and host names stay in the network table after they were last seen, at most 24855.",
 				Optional:    true,
 				Computed:    true,
 				Default:     int64default.StaticInt64(91),
+				Validators: []validator.Int64{
+					int64validator.Between(0, maxDatabaseDays),
+				},
 			},
 		},
 	}
@@ -94,12 +143,12 @@ func (r *ConfigDatabaseResource) Configure(ctx context.Context, req resource.Con
 	if req.ProviderData == nil {
 		return
 	}
-	c, ok := req.ProviderData.(*client.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
-		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *client.Client, got: %T.", req.ProviderData))
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData))
 		return
 	}
-	r.client = c
+	r.client = data.client
 }
 
 func (r *ConfigDatabaseResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -108,12 +157,14 @@ func (r *ConfigDatabaseResource) Create(ctx context.Context, req resource.Create
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	if err := r.updateConfig(ctx, &data); err != nil {
-		resp.Diagnostics.AddError("Error updating database config", err.Error())
+	snapshotConfig(ctx, r.client, "database", r.configValues(&data), resp.Private)
+
+	if err := r.updateConfig(ctx, &data, nil); err != nil {
+		addAPIError(&resp.Diagnostics, "Error updating database config", err)
 		return
 	}
 	if err := r.readConfig(ctx, &data); err != nil {
-		resp.Diagnostics.AddError("Error reading database config", err.Error())
+		addAPIError(&resp.Diagnostics, "Error reading database config", err)
 		return
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -126,39 +177,66 @@ func (r *ConfigDatabaseResource) Read(ctx context.Context, req resource.ReadRequ
 		return
 	}
 	if err := r.readConfig(ctx, &data); err != nil {
-		resp.Diagnostics.AddError("Error reading database config", err.Error())
+		addAPIError(&resp.Diagnostics, "Error reading database config", err)
 		return
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *ConfigDatabaseResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data ConfigDatabaseResourceModel
+	var data, state ConfigDatabaseResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	if err := r.updateConfig(ctx, &data); err != nil {
-		resp.Diagnostics.AddError("Error updating database config", err.Error())
+	if err := r.updateConfig(ctx, &data, r.configValues(&state)); err != nil {
+		addAPIError(&resp.Diagnostics, "Error updating database config", err)
 		return
 	}
 	if err := r.readConfig(ctx, &data); err != nil {
-		resp.Diagnostics.AddError("Error reading database config", err.Error())
+		addAPIError(&resp.Diagnostics, "Error reading database config", err)
 		return
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *ConfigDatabaseResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	tflog.Debug(ctx, "Removing database config from state")
+	var data ConfigDatabaseResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	destroyConfig(ctx, r.client, "database", data.OnDestroy, r.configValues(&data), req.Private, &resp.Diagnostics)
+}
+
+// ModifyPlan checks the planned values against the options Pi-hole reports.
+func (r *ConfigDatabaseResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var data ConfigDatabaseResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	validateConfigValues(ctx, r.client, "database", r.configValues(&data), &resp.Diagnostics)
 }
 
 func (r *ConfigDatabaseResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	checkConfigImportID(req.ID, "database", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var data ConfigDatabaseResourceModel
 	if err := r.readConfig(ctx, &data); err != nil {
-		resp.Diagnostics.AddError("Error importing database config", err.Error())
+		addAPIError(&resp.Diagnostics, "Error importing database config", err)
 		return
 	}
+	data.OnDestroy = types.StringValue(onDestroyNoop)
+	snapshotConfig(ctx, r.client, "database", r.configValues(&data), resp.Private)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -168,6 +246,7 @@ func (r *ConfigDatabaseResource) readConfig(ctx context.Context, data *ConfigDat
 		return err
 	}
 	data.ID = types.StringValue("database")
+	data.ServerValuesJSON = serverValuesJSON(config)
 	data.DBImport = types.BoolValue(config.DBImport)
 	data.MaxDBDays = types.Int64Value(int64(config.MaxDBDays))
 	data.DBInterval = types.Int64Value(int64(config.DBInterval))
@@ -179,16 +258,19 @@ func (r *ConfigDatabaseResource) readConfig(ctx context.Context, data *ConfigDat
 	return nil
 }
 
-func (r *ConfigDatabaseResource) updateConfig(ctx context.Context, data *ConfigDatabaseResourceModel) error {
-	cfg := map[string]interface{}{
-		"DBimport":   data.DBImport.ValueBool(),
-		"maxDBdays":  data.MaxDBDays.ValueInt64(),
-		"DBinterval": data.DBInterval.ValueInt64(),
-		"useWAL":     data.UseWAL.ValueBool(),
+func (r *ConfigDatabaseResource) configValues(data *ConfigDatabaseResourceModel) map[string]interface{} {
+	return map[string]interface{}{
+		"DBimport":   data.DBImport,
+		"maxDBdays":  data.MaxDBDays,
+		"DBinterval": data.DBInterval,
+		"useWAL":     data.UseWAL,
 		"network": map[string]interface{}{
-			"parseARPcache": data.ParseARPCache.ValueBool(),
-			"expire":        data.NetworkExpire.ValueInt64(),
+			"parseARPcache": data.ParseARPCache,
+			"expire":        data.NetworkExpire,
 		},
 	}
-	return r.client.UpdateConfig(ctx, "database", cfg)
+}
+
+func (r *ConfigDatabaseResource) updateConfig(ctx context.Context, data *ConfigDatabaseResourceModel, prior map[string]interface{}) error {
+	return r.client.UpdateConfig(ctx, "database", configPatch(r.configValues(data), prior))
 }
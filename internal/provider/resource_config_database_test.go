@@ -0,0 +1,62 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccResourceConfigDatabase_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceConfigDatabaseConfig(30, 300),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_config_database.test", "max_db_days", "30"),
+					resource.TestCheckResourceAttr("pihole_config_database.test", "db_interval", "300"),
+					resource.TestCheckResourceAttr("pihole_config_database.test", "use_wal", "true"),
+				),
+			},
+			// 0 disables the query history
+			{
+				Config: testAccResourceConfigDatabaseConfig(0, 300),
+				Check:  resource.TestCheckResourceAttr("pihole_config_database.test", "max_db_days", "0"),
+			},
+			// Bounds are checked during plan
+			{
+				Config:      testAccResourceConfigDatabaseConfig(maxDatabaseDays+1, 300),
+				ExpectError: regexp.MustCompile(`Attribute max_db_days value must be between 0 and 24855`),
+			},
+			{
+				Config:      testAccResourceConfigDatabaseConfig(91, 0),
+				ExpectError: regexp.MustCompile(`Attribute db_interval value must be at least 1`),
+			},
+			{
+				Config: testAccResourceConfigDatabaseConfig(91, 60),
+				Check:  resource.TestCheckResourceAttr("pihole_config_database.test", "max_db_days", "91"),
+			},
+			{
+				ResourceName:      "pihole_config_database.test",
+				ImportState:       true,
+				ImportStateId:     "database",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccResourceConfigDatabaseConfig(maxDBDays, dbInterval int) string {
+	return fmt.Sprintf(`
+resource "pihole_config_database" "test" {
+  max_db_days = %d
+  db_interval = %d
+}
+`, maxDBDays, dbInterval)
+}
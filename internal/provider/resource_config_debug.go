@@ -6,18 +6,21 @@ package provider
 import (
 	"context"
 	"fmt"
+	"sort"
 
-	"github.com/dklesev/terraform-provider-pihole/internal/client"
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 var (
-	_ resource.Resource                = &ConfigDebugResource{}
-	_ resource.ResourceWithImportState = &ConfigDebugResource{}
+	_ resource.Resource                   = &ConfigDebugResource{}
+	_ resource.ResourceWithImportState    = &ConfigDebugResource{}
+	_ resource.ResourceWithModifyPlan     = &ConfigDebugResource{}
+	_ resource.ResourceWithValidateConfig = &ConfigDebugResource{}
 )
 
 func NewConfigDebugResource() resource.Resource {
@@ -25,18 +28,44 @@ func NewConfigDebugResource() resource.Resource {
 }
 
 type ConfigDebugResource struct {
-	client *client.Client
+	client *pihole.Client
 }
 
 type ConfigDebugResourceModel struct {
-	ID         types.String `tfsdk:"id"`
-	Database   types.Bool   `tfsdk:"database"`
-	Networking types.Bool   `tfsdk:"networking"`
-	Queries    types.Bool   `tfsdk:"queries"`
-	API        types.Bool   `tfsdk:"api"`
-	Resolver   types.Bool   `tfsdk:"resolver"`
-	Events     types.Bool   `tfsdk:"events"`
-	All        types.Bool   `tfsdk:"all"`
+	ID               types.String `tfsdk:"id"`
+	ServerValuesJSON types.String `tfsdk:"server_values_json"`
+	OnDestroy        types.String `tfsdk:"on_destroy"`
+	Database         types.Bool   `tfsdk:"database"`
+	Networking       types.Bool   `tfsdk:"networking"`
+	Locks            types.Bool   `tfsdk:"locks"`
+	Queries          types.Bool   `tfsdk:"queries"`
+	Flags            types.Bool   `tfsdk:"flags"`
+	Shmem            types.Bool   `tfsdk:"shmem"`
+	GC               types.Bool   `tfsdk:"gc"`
+	ARP              types.Bool   `tfsdk:"arp"`
+	Regex            types.Bool   `tfsdk:"regex"`
+	API              types.Bool   `tfsdk:"api"`
+	TLS              types.Bool   `tfsdk:"tls"`
+	Overtime         types.Bool   `tfsdk:"overtime"`
+	Status           types.Bool   `tfsdk:"status"`
+	Caps             types.Bool   `tfsdk:"caps"`
+	DNSSEC           types.Bool   `tfsdk:"dnssec"`
+	Vectors          types.Bool   `tfsdk:"vectors"`
+	Resolver         types.Bool   `tfsdk:"resolver"`
+	EDNS0            types.Bool   `tfsdk:"edns0"`
+	Clients          types.Bool   `tfsdk:"clients"`
+	AliasClients     types.Bool   `tfsdk:"aliasclients"`
+	Events           types.Bool   `tfsdk:"events"`
+	Helper           types.Bool   `tfsdk:"helper"`
+	Config           types.Bool   `tfsdk:"config"`
+	Inotify          types.Bool   `tfsdk:"inotify"`
+	Webserver        types.Bool   `tfsdk:"webserver"`
+	Extra            types.Bool   `tfsdk:"extra"`
+	Reserved         types.Bool   `tfsdk:"reserved"`
+	NTP              types.Bool   `tfsdk:"ntp"`
+	Netlink          types.Bool   `tfsdk:"netlink"`
+	Timing           types.Bool   `tfsdk:"timing"`
+	All              types.Bool   `tfsdk:"all"`
 }
 
 func (r *ConfigDebugResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -46,48 +75,70 @@ func (r *ConfigDebugResource) Metadata(ctx context.Context, req resource.Metadat
 func (r *ConfigDebugResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages Pi-hole debug configuration.",
+		MarkdownDescription: `
+Manages the debug logging of FTL to ` + "`/var/log/pihole/FTL.log`" + `. Debug logging
+is verbose and meant for troubleshooting, so keep it off in production and
+enable only the areas under investigation.
+
+Set ` + "`all = true`" + ` to turn on every flag at once. Individual flags cannot be set
+along with it and then all read as enabled; unset flags follow ` + "`all`" + `, so
+turning it off again turns them off as well.
+
+## Example Usage
+
+` + "```hcl" + `
+resource "pihole_config_debug" "settings" {
+  api     = true
+  queries = true
+}
+` + "```" + `
+
+## Import
+
+The debug configuration can be imported using the section name, ` + "`debug`" + `:
+
+` + "```shell" + `
+terraform import pihole_config_debug.settings debug
+` + "```" + `
+`,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
 			},
-			"database": schema.BoolAttribute{
-				Description: "Enable database debugging.",
-				Optional:    true,
-				Computed:    true,
-				Default:     booldefault.StaticBool(false),
-			},
-			"networking": schema.BoolAttribute{
-				Description: "Enable networking debugging.",
-				Optional:    true,
-				Computed:    true,
-				Default:     booldefault.StaticBool(false),
-			},
-			"queries": schema.BoolAttribute{
-				Description: "Enable query debugging.",
-				Optional:    true,
-				Computed:    true,
-				Default:     booldefault.StaticBool(false),
-			},
-			"api": schema.BoolAttribute{
-				Description: "Enable API debugging.",
-				Optional:    true,
-				Computed:    true,
-				Default:     booldefault.StaticBool(false),
-			},
-			"resolver": schema.BoolAttribute{
-				Description: "Enable resolver debugging.",
-				Optional:    true,
-				Computed:    true,
-				Default:     booldefault.StaticBool(false),
-			},
-			"events": schema.BoolAttribute{
-				Description: "Enable events debugging.",
-				Optional:    true,
-				Computed:    true,
-				Default:     booldefault.StaticBool(false),
-			},
+			"server_values_json": serverValuesAttribute(),
+			"on_destroy":         onDestroyAttribute(),
+			"database":           debugAttribute("Log database actions, such as the SQL statements and how long storing queries took."),
+			"networking":         debugAttribute("Log the network interfaces FTL detects on startup."),
+			"locks":              debugAttribute("Log waiting for, obtaining and releasing shared memory locks."),
+			"queries":            debugAttribute("Log extensive query information: domains, types, replies, etc."),
+			"flags":              debugAttribute("Log the flags of queries received by the DNS hooks. Only effective together with `queries`."),
+			"shmem":              debugAttribute("Log creating and enlarging shared memory objects."),
+			"gc":                 debugAttribute("Log garbage collection: what is removed and how long it took."),
+			"arp":                debugAttribute("Log ARP table processing, e.g. how long parsing took and whether MAC addresses are valid."),
+			"regex":              debugAttribute("Log details about regex matching."),
+			"api":                debugAttribute("Log API requests, their parameters and authentication attempts."),
+			"tls":                debugAttribute("Log details about TLS connections, such as versions, cipher suites and certificate chains."),
+			"overtime":           debugAttribute("Log memory operations on the over-time statistics."),
+			"status":             debugAttribute("Log status changes of individual queries."),
+			"caps":               debugAttribute("Log the capabilities granted to the FTL process."),
+			"dnssec":             debugAttribute("Log DNSSEC activity."),
+			"vectors":            debugAttribute("Log allocating, referencing, appending to and deleting FTL's dynamic vectors."),
+			"resolver":           debugAttribute("Log the resolution of client host names."),
+			"edns0":              debugAttribute("Log EDNS(0) data received with queries."),
+			"clients":            debugAttribute("Log client events, such as interface changes, and how clients are assigned to groups."),
+			"aliasclients":       debugAttribute("Log alias-client processing."),
+			"events":             debugAttribute("Log FTL's event queue."),
+			"helper":             debugAttribute("Log script helpers, e.g. of `dhcp-script`."),
+			"config":             debugAttribute("Log configuration parsing."),
+			"inotify":            debugAttribute("Log file system events FTL watches in `/etc/pihole`."),
+			"webserver":          debugAttribute("Log webserver events."),
+			"extra":              debugAttribute("Log additional information for temporary investigations. What is logged may change with any release."),
+			"reserved":           debugAttribute("Reserved flag without a documented purpose."),
+			"ntp":                debugAttribute("Log NTP synchronization."),
+			"netlink":            debugAttribute("Log netlink communication and parsing."),
+			"timing":             debugAttribute("Log timing information."),
 			"all": schema.BoolAttribute{
-				Description: "Enable all debugging (overrides individual settings).",
+				Description: "Enable every debug flag at once. Cannot be combined with individual flags, which then all read as enabled. Default: false.",
 				Optional:    true,
 				Computed:    true,
 				Default:     booldefault.StaticBool(false),
@@ -96,16 +147,26 @@ func (r *ConfigDebugResource) Schema(ctx context.Context, req resource.SchemaReq
 	}
 }
 
+// debugAttribute returns the schema of a debug flag. Unset flags follow
+// "all", which Pi-hole applies to every flag, see ModifyPlan.
+func debugAttribute(description string) schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Description: description + " Default: the value of `all`.",
+		Optional:    true,
+		Computed:    true,
+	}
+}
+
 func (r *ConfigDebugResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
-	c, ok := req.ProviderData.(*client.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
-		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *client.Client, got: %T.", req.ProviderData))
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData))
 		return
 	}
-	r.client = c
+	r.client = data.client
 }
 
 func (r *ConfigDebugResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -114,12 +175,14 @@ func (r *ConfigDebugResource) Create(ctx context.Context, req resource.CreateReq
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	if err := r.updateConfig(ctx, &data); err != nil {
-		resp.Diagnostics.AddError("Error updating debug config", err.Error())
+	snapshotConfig(ctx, r.client, "debug", r.configValues(&data), resp.Private)
+
+	if err := r.updateConfig(ctx, &data, nil); err != nil {
+		addAPIError(&resp.Diagnostics, "Error updating debug config", err)
 		return
 	}
 	if err := r.readConfig(ctx, &data); err != nil {
-		resp.Diagnostics.AddError("Error reading debug config", err.Error())
+		addAPIError(&resp.Diagnostics, "Error reading debug config", err)
 		return
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -132,39 +195,105 @@ func (r *ConfigDebugResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 	if err := r.readConfig(ctx, &data); err != nil {
-		resp.Diagnostics.AddError("Error reading debug config", err.Error())
+		addAPIError(&resp.Diagnostics, "Error reading debug config", err)
 		return
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *ConfigDebugResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data ConfigDebugResourceModel
+	var data, state ConfigDebugResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	if err := r.updateConfig(ctx, &data); err != nil {
-		resp.Diagnostics.AddError("Error updating debug config", err.Error())
+	if err := r.updateConfig(ctx, &data, r.configValues(&state)); err != nil {
+		addAPIError(&resp.Diagnostics, "Error updating debug config", err)
 		return
 	}
 	if err := r.readConfig(ctx, &data); err != nil {
-		resp.Diagnostics.AddError("Error reading debug config", err.Error())
+		addAPIError(&resp.Diagnostics, "Error reading debug config", err)
 		return
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *ConfigDebugResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	tflog.Debug(ctx, "Removing debug config from state")
+	var data ConfigDebugResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	destroyConfig(ctx, r.client, "debug", data.OnDestroy, r.configValues(&data), req.Private, &resp.Diagnostics)
+}
+
+// ValidateConfig rejects individual flags next to all = true, which turns on
+// every flag regardless.
+func (r *ConfigDebugResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ConfigDebugResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || !data.All.ValueBool() {
+		return
+	}
+
+	configured := r.configValues(&data)
+	for _, name := range debugFlags(configured) {
+		if configured[name].(types.Bool).IsNull() {
+			continue
+		}
+		resp.Diagnostics.AddAttributeError(
+			path.Root(name),
+			"Conflicting debug flags",
+			fmt.Sprintf("all = true enables every debug flag, so %s cannot be set as well. Remove %s or set all to false.", name, name),
+		)
+	}
+}
+
+// ModifyPlan plans the unset flags with the value of all, as Pi-hole sets
+// every flag when all changes, and checks the planned values against the
+// options Pi-hole reports.
+func (r *ConfigDebugResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var data, config ConfigDebugResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.All.IsUnknown() {
+		configured := r.configValues(&config)
+		for _, name := range debugFlags(configured) {
+			if configured[name].(types.Bool).IsNull() {
+				resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(name), data.All)...)
+			}
+		}
+		resp.Diagnostics.Append(resp.Plan.Get(ctx, &data)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	validateConfigValues(ctx, r.client, "debug", r.configValues(&data), &resp.Diagnostics)
 }
 
 func (r *ConfigDebugResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	checkConfigImportID(req.ID, "debug", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var data ConfigDebugResourceModel
 	if err := r.readConfig(ctx, &data); err != nil {
-		resp.Diagnostics.AddError("Error importing debug config", err.Error())
+		addAPIError(&resp.Diagnostics, "Error importing debug config", err)
 		return
 	}
+	data.OnDestroy = types.StringValue(onDestroyNoop)
+	snapshotConfig(ctx, r.client, "debug", r.configValues(&data), resp.Private)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -174,25 +303,100 @@ func (r *ConfigDebugResource) readConfig(ctx context.Context, data *ConfigDebugR
 		return err
 	}
 	data.ID = types.StringValue("debug")
+	data.ServerValuesJSON = serverValuesJSON(config)
 	data.Database = types.BoolValue(config.Database)
 	data.Networking = types.BoolValue(config.Networking)
+	data.Locks = types.BoolValue(config.Locks)
 	data.Queries = types.BoolValue(config.Queries)
+	data.Flags = types.BoolValue(config.Flags)
+	data.Shmem = types.BoolValue(config.Shmem)
+	data.GC = types.BoolValue(config.GC)
+	data.ARP = types.BoolValue(config.ARP)
+	data.Regex = types.BoolValue(config.Regex)
 	data.API = types.BoolValue(config.API)
+	data.TLS = types.BoolValue(config.TLS)
+	data.Overtime = types.BoolValue(config.Overtime)
+	data.Status = types.BoolValue(config.Status)
+	data.Caps = types.BoolValue(config.Caps)
+	data.DNSSEC = types.BoolValue(config.DNSSEC)
+	data.Vectors = types.BoolValue(config.Vectors)
 	data.Resolver = types.BoolValue(config.Resolver)
+	data.EDNS0 = types.BoolValue(config.EDNS0)
+	data.Clients = types.BoolValue(config.Clients)
+	data.AliasClients = types.BoolValue(config.AliasClients)
 	data.Events = types.BoolValue(config.Events)
+	data.Helper = types.BoolValue(config.Helper)
+	data.Config = types.BoolValue(config.Config)
+	data.Inotify = types.BoolValue(config.Inotify)
+	data.Webserver = types.BoolValue(config.Webserver)
+	data.Extra = types.BoolValue(config.Extra)
+	data.Reserved = types.BoolValue(config.Reserved)
+	data.NTP = types.BoolValue(config.NTP)
+	data.Netlink = types.BoolValue(config.Netlink)
+	data.Timing = types.BoolValue(config.Timing)
 	data.All = types.BoolValue(config.All)
 	return nil
 }
 
-func (r *ConfigDebugResource) updateConfig(ctx context.Context, data *ConfigDebugResourceModel) error {
-	cfg := map[string]interface{}{
-		"database":   data.Database.ValueBool(),
-		"networking": data.Networking.ValueBool(),
-		"queries":    data.Queries.ValueBool(),
-		"api":        data.API.ValueBool(),
-		"resolver":   data.Resolver.ValueBool(),
-		"events":     data.Events.ValueBool(),
-		"all":        data.All.ValueBool(),
+func (r *ConfigDebugResource) configValues(data *ConfigDebugResourceModel) map[string]interface{} {
+	return map[string]interface{}{
+		"database":     data.Database,
+		"networking":   data.Networking,
+		"locks":        data.Locks,
+		"queries":      data.Queries,
+		"flags":        data.Flags,
+		"shmem":        data.Shmem,
+		"gc":           data.GC,
+		"arp":          data.ARP,
+		"regex":        data.Regex,
+		"api":          data.API,
+		"tls":          data.TLS,
+		"overtime":     data.Overtime,
+		"status":       data.Status,
+		"caps":         data.Caps,
+		"dnssec":       data.DNSSEC,
+		"vectors":      data.Vectors,
+		"resolver":     data.Resolver,
+		"edns0":        data.EDNS0,
+		"clients":      data.Clients,
+		"aliasclients": data.AliasClients,
+		"events":       data.Events,
+		"helper":       data.Helper,
+		"config":       data.Config,
+		"inotify":      data.Inotify,
+		"webserver":    data.Webserver,
+		"extra":        data.Extra,
+		"reserved":     data.Reserved,
+		"ntp":          data.NTP,
+		"netlink":      data.Netlink,
+		"timing":       data.Timing,
+		"all":          data.All,
+	}
+}
+
+// updateConfig writes the planned flags. Pi-hole sets or clears every flag
+// when all changes, so all is written first on its own, and then all the
+// individual flags.
+func (r *ConfigDebugResource) updateConfig(ctx context.Context, data *ConfigDebugResourceModel, prior map[string]interface{}) error {
+	values := r.configValues(data)
+	if priorAll, ok := prior["all"].(types.Bool); !ok || !priorAll.Equal(data.All) {
+		if err := r.client.UpdateConfig(ctx, "debug", configPatch(map[string]interface{}{"all": data.All}, nil)); err != nil {
+			return err
+		}
+		prior = nil
+	}
+	delete(values, "all")
+	return r.client.UpdateConfig(ctx, "debug", configPatch(values, prior))
+}
+
+// debugFlags returns the names of the individual flags among values, sorted.
+func debugFlags(values map[string]interface{}) []string {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		if name != "all" {
+			names = append(names, name)
+		}
 	}
-	return r.client.UpdateConfig(ctx, "debug", cfg)
+	sort.Strings(names)
+	return names
 }
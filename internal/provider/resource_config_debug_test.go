@@ -0,0 +1,69 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccResourceConfigDebug_all(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Individual flags, the others off
+			{
+				Config: `
+resource "pihole_config_debug" "test" {
+  api = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_config_debug.test", "api", "true"),
+					resource.TestCheckResourceAttr("pihole_config_debug.test", "queries", "false"),
+					resource.TestCheckResourceAttr("pihole_config_debug.test", "all", "false"),
+				),
+			},
+			// all = true turns every flag on without drift
+			{
+				Config: `
+resource "pihole_config_debug" "test" {
+  all = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_config_debug.test", "all", "true"),
+					resource.TestCheckResourceAttr("pihole_config_debug.test", "api", "true"),
+					resource.TestCheckResourceAttr("pihole_config_debug.test", "netlink", "true"),
+				),
+			},
+			// Individual flags cannot be combined with all = true
+			{
+				Config: `
+resource "pihole_config_debug" "test" {
+  all     = true
+  queries = false
+}
+`,
+				ExpectError: regexp.MustCompile(`Conflicting debug flags`),
+			},
+			// Turning all off clears every flag
+			{
+				Config: `
+resource "pihole_config_debug" "test" {
+  all = false
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_config_debug.test", "all", "false"),
+					resource.TestCheckResourceAttr("pihole_config_debug.test", "api", "false"),
+					resource.TestCheckResourceAttr("pihole_config_debug.test", "netlink", "false"),
+				),
+			},
+		},
+	})
+}
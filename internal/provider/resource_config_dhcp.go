@@ -7,7 +7,7 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/dklesev/terraform-provider-pihole/internal/client"
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
@@ -19,6 +19,7 @@ import (
 var (
 	_ resource.Resource                = &ConfigDHCPResource{}
 	_ resource.ResourceWithImportState = &ConfigDHCPResource{}
+	_ resource.ResourceWithModifyPlan  = &ConfigDHCPResource{}
 )
 
 func NewConfigDHCPResource() resource.Resource {
@@ -26,11 +27,13 @@ func NewConfigDHCPResource() resource.Resource {
 }
 
 type ConfigDHCPResource struct {
-	client *client.Client
+	client *pihole.Client
 }
 
 type ConfigDHCPResourceModel struct {
 	ID                   types.String `tfsdk:"id"`
+	ServerValuesJSON     types.String `tfsdk:"server_values_json"`
+	OnDestroy            types.String `tfsdk:"on_destroy"`
 	Active               types.Bool   `tfsdk:"active"`
 	Start                types.String `tfsdk:"start"`
 	End                  types.String `tfsdk:"end"`
@@ -72,6 +75,8 @@ resource "pihole_config_dhcp" "settings" {
 				Description: "Identifier for this resource (always 'dhcp').",
 				Computed:    true,
 			},
+			"server_values_json": serverValuesAttribute(),
+			"on_destroy":         onDestroyAttribute(),
 			"active": schema.BoolAttribute{
 				Description: "Enable DHCP server.",
 				Optional:    true,
@@ -147,16 +152,16 @@ func (r *ConfigDHCPResource) Configure(ctx context.Context, req resource.Configu
 		return
 	}
 
-	c, ok := req.ProviderData.(*client.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = c
+	r.client = data.client
 }
 
 func (r *ConfigDHCPResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -169,13 +174,15 @@ func (r *ConfigDHCPResource) Create(ctx context.Context, req resource.CreateRequ
 
 	tflog.Debug(ctx, "Creating DHCP config")
 
-	if err := r.updateConfig(ctx, &data); err != nil {
-		resp.Diagnostics.AddError("Error updating DHCP config", err.Error())
+	snapshotConfig(ctx, r.client, "dhcp", r.configValues(&data), resp.Private)
+
+	if err := r.updateConfig(ctx, &data, nil); err != nil {
+		addAPIError(&resp.Diagnostics, "Error updating DHCP config", err)
 		return
 	}
 
 	if err := r.readConfig(ctx, &data); err != nil {
-		resp.Diagnostics.AddError("Error reading DHCP config", err.Error())
+		addAPIError(&resp.Diagnostics, "Error reading DHCP config", err)
 		return
 	}
 
@@ -191,7 +198,7 @@ func (r *ConfigDHCPResource) Read(ctx context.Context, req resource.ReadRequest,
 	}
 
 	if err := r.readConfig(ctx, &data); err != nil {
-		resp.Diagnostics.AddError("Error reading DHCP config", err.Error())
+		addAPIError(&resp.Diagnostics, "Error reading DHCP config", err)
 		return
 	}
 
@@ -199,22 +206,23 @@ func (r *ConfigDHCPResource) Read(ctx context.Context, req resource.ReadRequest,
 }
 
 func (r *ConfigDHCPResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data ConfigDHCPResourceModel
+	var data, state ConfigDHCPResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	tflog.Debug(ctx, "Updating DHCP config")
 
-	if err := r.updateConfig(ctx, &data); err != nil {
-		resp.Diagnostics.AddError("Error updating DHCP config", err.Error())
+	if err := r.updateConfig(ctx, &data, r.configValues(&state)); err != nil {
+		addAPIError(&resp.Diagnostics, "Error updating DHCP config", err)
 		return
 	}
 
 	if err := r.readConfig(ctx, &data); err != nil {
-		resp.Diagnostics.AddError("Error reading DHCP config", err.Error())
+		addAPIError(&resp.Diagnostics, "Error reading DHCP config", err)
 		return
 	}
 
@@ -222,18 +230,44 @@ func (r *ConfigDHCPResource) Update(ctx context.Context, req resource.UpdateRequ
 }
 
 func (r *ConfigDHCPResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	tflog.Debug(ctx, "Removing DHCP config from state (config remains in Pi-hole)")
+	var data ConfigDHCPResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	destroyConfig(ctx, r.client, "dhcp", data.OnDestroy, r.configValues(&data), req.Private, &resp.Diagnostics)
+}
+
+// ModifyPlan checks the planned values against the options Pi-hole reports.
+func (r *ConfigDHCPResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var data ConfigDHCPResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	validateConfigValues(ctx, r.client, "dhcp", r.configValues(&data), &resp.Diagnostics)
 }
 
 func (r *ConfigDHCPResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	checkConfigImportID(req.ID, "dhcp", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	tflog.Debug(ctx, "Importing DHCP config from Pi-hole")
 
 	var data ConfigDHCPResourceModel
 	if err := r.readConfig(ctx, &data); err != nil {
-		resp.Diagnostics.AddError("Error importing DHCP config", err.Error())
+		addAPIError(&resp.Diagnostics, "Error importing DHCP config", err)
 		return
 	}
 
+	data.OnDestroy = types.StringValue(onDestroyNoop)
+	snapshotConfig(ctx, r.client, "dhcp", r.configValues(&data), resp.Private)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -244,6 +278,7 @@ func (r *ConfigDHCPResource) readConfig(ctx context.Context, data *ConfigDHCPRes
 	}
 
 	data.ID = types.StringValue("dhcp")
+	data.ServerValuesJSON = serverValuesJSON(config)
 	data.Active = types.BoolValue(config.Active)
 	data.Start = types.StringValue(config.Start)
 	data.End = types.StringValue(config.End)
@@ -259,22 +294,24 @@ func (r *ConfigDHCPResource) readConfig(ctx context.Context, data *ConfigDHCPRes
 	return nil
 }
 
-func (r *ConfigDHCPResource) updateConfig(ctx context.Context, data *ConfigDHCPResourceModel) error {
-	dhcpConfig := map[string]interface{}{
-		"active":               data.Active.ValueBool(),
-		"start":                data.Start.ValueString(),
-		"end":                  data.End.ValueString(),
-		"router":               data.Router.ValueString(),
-		"netmask":              data.Netmask.ValueString(),
-		"leaseTime":            data.LeaseTime.ValueString(),
-		"ipv6":                 data.IPv6.ValueBool(),
-		"rapidCommit":          data.RapidCommit.ValueBool(),
-		"multiDNS":             data.MultiDNS.ValueBool(),
-		"logging":              data.Logging.ValueBool(),
-		"ignoreUnknownClients": data.IgnoreUnknownClients.ValueBool(),
+func (r *ConfigDHCPResource) configValues(data *ConfigDHCPResourceModel) map[string]interface{} {
+	return map[string]interface{}{
+		"active":               data.Active,
+		"start":                data.Start,
+		"end":                  data.End,
+		"router":               data.Router,
+		"netmask":              data.Netmask,
+		"leaseTime":            data.LeaseTime,
+		"ipv6":                 data.IPv6,
+		"rapidCommit":          data.RapidCommit,
+		"multiDNS":             data.MultiDNS,
+		"logging":              data.Logging,
+		"ignoreUnknownClients": data.IgnoreUnknownClients,
 	}
+}
 
-	if err := r.client.UpdateConfig(ctx, "dhcp", dhcpConfig); err != nil {
+func (r *ConfigDHCPResource) updateConfig(ctx context.Context, data *ConfigDHCPResourceModel, prior map[string]interface{}) error {
+	if err := r.client.UpdateConfig(ctx, "dhcp", configPatch(r.configValues(data), prior)); err != nil {
 		return fmt.Errorf("failed to update dhcp config: %w", err)
 	}
 
@@ -6,8 +6,12 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net"
+	"strings"
 
-	"github.com/dklesev/terraform-provider-pihole/internal/client"
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
@@ -20,6 +24,9 @@ import (
 var (
 	_ resource.Resource                = &ConfigDNSResource{}
 	_ resource.ResourceWithImportState = &ConfigDNSResource{}
+	_ resource.ResourceWithModifyPlan  = &ConfigDNSResource{}
+
+	_ resource.ResourceWithConfigValidators = &ConfigDNSResource{}
 )
 
 func NewConfigDNSResource() resource.Resource {
@@ -27,24 +34,28 @@ func NewConfigDNSResource() resource.Resource {
 }
 
 type ConfigDNSResource struct {
-	client *client.Client
+	client *pihole.Client
 }
 
 type ConfigDNSResourceModel struct {
-	ID               types.String `tfsdk:"id"`
-	Port             types.Int64  `tfsdk:"port"`
-	Interface        types.String `tfsdk:"interface"`
-	ListeningMode    types.String `tfsdk:"listening_mode"`
-	DNSSEC           types.Bool   `tfsdk:"dnssec"`
-	QueryLogging     types.Bool   `tfsdk:"query_logging"`
-	DomainNeeded     types.Bool   `tfsdk:"domain_needed"`
-	ExpandHosts      types.Bool   `tfsdk:"expand_hosts"`
-	BogusPriv        types.Bool   `tfsdk:"bogus_priv"`
-	CNAMEDeepInspect types.Bool   `tfsdk:"cname_deep_inspect"`
-	BlockESNI        types.Bool   `tfsdk:"block_esni"`
-	BlockTTL         types.Int64  `tfsdk:"block_ttl"`
-	PiholePTR        types.String `tfsdk:"pihole_ptr"`
-	ReplyWhenBusy    types.String `tfsdk:"reply_when_busy"`
+	ID                types.String `tfsdk:"id"`
+	ServerValuesJSON  types.String `tfsdk:"server_values_json"`
+	OnDestroy         types.String `tfsdk:"on_destroy"`
+	Port              types.Int64  `tfsdk:"port"`
+	Interface         types.String `tfsdk:"interface"`
+	ValidateInterface types.Bool   `tfsdk:"validate_interface"`
+	ListeningMode     types.String `tfsdk:"listening_mode"`
+	DNSSEC            types.Bool   `tfsdk:"dnssec"`
+	QueryLogging      types.Bool   `tfsdk:"query_logging"`
+	DomainNeeded      types.Bool   `tfsdk:"domain_needed"`
+	ExpandHosts       types.Bool   `tfsdk:"expand_hosts"`
+	BogusPriv         types.Bool   `tfsdk:"bogus_priv"`
+	CNAMEDeepInspect  types.Bool   `tfsdk:"cname_deep_inspect"`
+	BlockESNI         types.Bool   `tfsdk:"block_esni"`
+	BlockTTL          types.Int64  `tfsdk:"block_ttl"`
+	PiholePTR         types.String `tfsdk:"pihole_ptr"`
+	ReplyWhenBusy     types.String `tfsdk:"reply_when_busy"`
+	Upstreams         types.List   `tfsdk:"upstreams"`
 	// Domain settings
 	DomainName  types.String `tfsdk:"domain_name"`
 	DomainLocal types.Bool   `tfsdk:"domain_local"`
@@ -54,12 +65,19 @@ type ConfigDNSResourceModel struct {
 	// Blocking settings
 	BlockingActive types.Bool   `tfsdk:"blocking_active"`
 	BlockingMode   types.String `tfsdk:"blocking_mode"`
+	// Reply settings
+	ReplyBlockingIPv4 types.String `tfsdk:"reply_blocking_ipv4"`
+	ReplyBlockingIPv6 types.String `tfsdk:"reply_blocking_ipv6"`
 	// Special domains
 	MozillaCanary      types.Bool `tfsdk:"mozilla_canary"`
 	ICloudPrivateRelay types.Bool `tfsdk:"icloud_private_relay"`
 	// Rate limiting
 	RateLimitCount    types.Int64 `tfsdk:"rate_limit_count"`
 	RateLimitInterval types.Int64 `tfsdk:"rate_limit_interval"`
+	// Live lists, whoever manages them
+	CurrentHosts        types.List `tfsdk:"current_hosts"`
+	CurrentCNAMERecords types.List `tfsdk:"current_cname_records"`
+	CurrentUpstreams    types.List `tfsdk:"current_upstreams"`
 }
 
 func (r *ConfigDNSResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -72,6 +90,9 @@ func (r *ConfigDNSResource) Schema(ctx context.Context, req resource.SchemaReque
 		MarkdownDescription: `
 Manages Pi-hole DNS configuration settings including DNSSEC, caching, blocking mode, and rate limiting.
 
+~> **Note:** Clients and the ` + "`verify`" + ` blocks of DNS record resources query port 53.
+Moving ` + "`port`" + ` elsewhere makes the plan warn; Pi-hole stops answering them.
+
 ## Example Usage
 
 ` + "```hcl" + `
@@ -89,12 +110,29 @@ resource "pihole_config_dns" "settings" {
   rate_limit_interval = 60
 }
 ` + "```" + `
+
+## Auditing Records and Upstreams
+
+` + "`current_hosts`" + `, ` + "`current_cname_records`" + ` and ` + "`current_upstreams`" + ` list the
+local DNS records, CNAME records and upstream servers Pi-hole has, whether
+this configuration, other resources or the web interface added them. Use them to
+find entries no resource manages, e.g. to import them:
+
+` + "```hcl" + `
+output "cname_records" {
+  # "domain,target" or "domain,target,ttl"; the first two parts are the import ID
+  # of pihole_cname_record
+  value = pihole_config_dns.settings.current_cname_records
+}
+` + "```" + `
 `,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description: "Identifier for this resource (always 'dns').",
 				Computed:    true,
 			},
+			"server_values_json": serverValuesAttribute(),
+			"on_destroy":         onDestroyAttribute(),
 			"port": schema.Int64Attribute{
 				Description: "DNS port (default: 53).",
 				Optional:    true,
@@ -107,6 +145,13 @@ resource "pihole_config_dns" "settings" {
 				Computed:    true,
 				Default:     stringdefault.StaticString(""),
 			},
+			"validate_interface": schema.BoolAttribute{
+				Description: "Check during apply that `interface` exists on the Pi-hole host and warn if it does not; " +
+					"FTL silently falls back to other interfaces when it is missing. Not sent to Pi-hole. Default: false.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
 			"listening_mode": schema.StringAttribute{
 				Description: "Listening mode: LOCAL, SINGLE, BIND, ALL.",
 				Optional:    true,
@@ -173,6 +218,12 @@ resource "pihole_config_dns" "settings" {
 				Computed:    true,
 				Default:     stringdefault.StaticString("ALLOW"),
 			},
+			"upstreams": schema.ListAttribute{
+				Description: "Upstream DNS servers, replacing Pi-hole's whole list. Leave unset to keep the list unmanaged, " +
+					"e.g. for `pihole_dns_upstream` resources; a configuration may use only one of the two.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
 			// Domain settings
 			"domain_name": schema.StringAttribute{
 				Description: "Local domain name.",
@@ -207,10 +258,24 @@ resource "pihole_config_dns" "settings" {
 				Default:     booldefault.StaticBool(true),
 			},
 			"blocking_mode": schema.StringAttribute{
-				Description: "Blocking mode: NULL, IP-NODATA-AAAA, IP, NXDOMAIN.",
+				Description: "Blocking mode: NULL, IP-NODATA-AAAA, IP, NXDOMAIN. " +
+					"IP answers with `reply_blocking_ipv4` and `reply_blocking_ipv6`, IP-NODATA-AAAA with `reply_blocking_ipv4`; without them FTL answers with the address of the interface the query arrived on.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("NULL"),
+			},
+			// Reply settings
+			"reply_blocking_ipv4": schema.StringAttribute{
+				Description: "IPv4 address blocked A queries are answered with in the IP and IP-NODATA-AAAA blocking modes.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+			},
+			"reply_blocking_ipv6": schema.StringAttribute{
+				Description: "IPv6 address blocked AAAA queries are answered with in the IP blocking mode.",
 				Optional:    true,
 				Computed:    true,
-				Default:     stringdefault.StaticString("NULL"),
+				Default:     stringdefault.StaticString(""),
 			},
 			// Special domains
 			"mozilla_canary": schema.BoolAttribute{
@@ -238,25 +303,47 @@ resource "pihole_config_dns" "settings" {
 				Computed:    true,
 				Default:     int64default.StaticInt64(60),
 			},
+			// Live lists
+			"current_hosts": schema.ListAttribute{
+				Description: "The local DNS records Pi-hole has, as `IP hostname...` lines, including the ones managed by other resources or outside Terraform.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"current_cname_records": schema.ListAttribute{
+				Description: "The CNAME records Pi-hole has, as `domain,target[,ttl]` lines, including the ones managed by other resources or outside Terraform.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"current_upstreams": schema.ListAttribute{
+				Description: "The upstream DNS servers Pi-hole uses, whether `upstreams`, `pihole_dns_upstream` resources or the web interface set them.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
 		},
 	}
 }
 
+func (r *ConfigDNSResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		blockingReplyValidator{},
+	}
+}
+
 func (r *ConfigDNSResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	c, ok := req.ProviderData.(*client.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = c
+	r.client = data.client
 }
 
 func (r *ConfigDNSResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -269,13 +356,20 @@ func (r *ConfigDNSResource) Create(ctx context.Context, req resource.CreateReque
 
 	tflog.Debug(ctx, "Creating DNS config")
 
-	if err := r.updateConfig(ctx, &data); err != nil {
-		resp.Diagnostics.AddError("Error updating DNS config", err.Error())
+	r.checkInterface(ctx, &data, &resp.Diagnostics)
+
+	snapshotConfig(ctx, r.client, "dns", r.configValues(&data), resp.Private)
+
+	if err := r.updateConfig(ctx, &data, nil); err != nil {
+		addAPIError(&resp.Diagnostics, "Error updating DNS config", err)
 		return
 	}
 
-	if err := r.readConfig(ctx, &data); err != nil {
-		resp.Diagnostics.AddError("Error reading DNS config", err.Error())
+	if err := r.readConfig(ctx, &data, &resp.Diagnostics); err != nil {
+		addAPIError(&resp.Diagnostics, "Error reading DNS config", err)
+		return
+	}
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
@@ -290,8 +384,11 @@ func (r *ConfigDNSResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	if err := r.readConfig(ctx, &data); err != nil {
-		resp.Diagnostics.AddError("Error reading DNS config", err.Error())
+	if err := r.readConfig(ctx, &data, &resp.Diagnostics); err != nil {
+		addAPIError(&resp.Diagnostics, "Error reading DNS config", err)
+		return
+	}
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
@@ -299,22 +396,28 @@ func (r *ConfigDNSResource) Read(ctx context.Context, req resource.ReadRequest,
 }
 
 func (r *ConfigDNSResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data ConfigDNSResourceModel
+	var data, state ConfigDNSResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	tflog.Debug(ctx, "Updating DNS config")
 
-	if err := r.updateConfig(ctx, &data); err != nil {
-		resp.Diagnostics.AddError("Error updating DNS config", err.Error())
+	r.checkInterface(ctx, &data, &resp.Diagnostics)
+
+	if err := r.updateConfig(ctx, &data, r.configValues(&state)); err != nil {
+		addAPIError(&resp.Diagnostics, "Error updating DNS config", err)
 		return
 	}
 
-	if err := r.readConfig(ctx, &data); err != nil {
-		resp.Diagnostics.AddError("Error reading DNS config", err.Error())
+	if err := r.readConfig(ctx, &data, &resp.Diagnostics); err != nil {
+		addAPIError(&resp.Diagnostics, "Error reading DNS config", err)
+		return
+	}
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
@@ -322,28 +425,124 @@ func (r *ConfigDNSResource) Update(ctx context.Context, req resource.UpdateReque
 }
 
 func (r *ConfigDNSResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	tflog.Debug(ctx, "Removing DNS config from state (config remains in Pi-hole)")
+	var data ConfigDNSResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	destroyConfig(ctx, r.client, "dns", data.OnDestroy, r.configValues(&data), req.Private, &resp.Diagnostics)
+}
+
+// ModifyPlan checks the planned values against the options Pi-hole reports,
+// rejects upstreams that pihole_dns_upstream resources manage, too, and
+// warns when DNS moves off port 53.
+func (r *ConfigDNSResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var data ConfigDNSResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	validateConfigValues(ctx, r.client, "dns", r.configValues(&data), &resp.Diagnostics)
+	if !data.Upstreams.IsNull() {
+		claimUpstreams(r.client, upstreamsOwnerConfigDNS, path.Root("upstreams"), &resp.Diagnostics)
+	}
+
+	if data.Port.IsNull() || data.Port.IsUnknown() || data.Port.ValueInt64() == 53 {
+		return
+	}
+	var prior types.Int64
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("port"), &prior)...)
+	}
+	if prior.Equal(data.Port) {
+		return
+	}
+	resp.Diagnostics.AddAttributeWarning(
+		path.Root("port"),
+		"DNS port change",
+		fmt.Sprintf("Pi-hole will answer DNS on port %d only. Clients, including DHCP clients and the verify blocks "+
+			"of DNS record resources, query port 53 and get no answers from Pi-hole after this change.", data.Port.ValueInt64()),
+	)
 }
 
 func (r *ConfigDNSResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	checkConfigImportID(req.ID, "dns", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	tflog.Debug(ctx, "Importing DNS config from Pi-hole")
 
 	var data ConfigDNSResourceModel
-	if err := r.readConfig(ctx, &data); err != nil {
-		resp.Diagnostics.AddError("Error importing DNS config", err.Error())
+	if err := r.readConfig(ctx, &data, &resp.Diagnostics); err != nil {
+		addAPIError(&resp.Diagnostics, "Error importing DNS config", err)
 		return
 	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.ValidateInterface = types.BoolValue(false)
+	data.OnDestroy = types.StringValue(onDestroyNoop)
+	snapshotConfig(ctx, r.client, "dns", r.configValues(&data), resp.Private)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
-func (r *ConfigDNSResource) readConfig(ctx context.Context, data *ConfigDNSResourceModel) error {
+// checkInterface warns when validate_interface is set and the configured
+// interface is missing or down on the Pi-hole host.
+func (r *ConfigDNSResource) checkInterface(ctx context.Context, data *ConfigDNSResourceModel, diags *diag.Diagnostics) {
+	name := data.Interface.ValueString()
+	if !data.ValidateInterface.ValueBool() || name == "" {
+		return
+	}
+
+	interfaces, err := r.client.GetNetworkInterfaces(ctx)
+	if err != nil {
+		diags.AddAttributeWarning(
+			path.Root("interface"),
+			"Could not verify DNS interface",
+			fmt.Sprintf("Listing the network interfaces of the Pi-hole host failed: %s", err),
+		)
+		return
+	}
+
+	names := make([]string, 0, len(interfaces))
+	for _, iface := range interfaces {
+		if iface.Name != name {
+			names = append(names, iface.Name)
+			continue
+		}
+		if !iface.Up {
+			diags.AddAttributeWarning(
+				path.Root("interface"),
+				"DNS interface is down",
+				fmt.Sprintf("The interface %q exists on the Pi-hole host but is down, so Pi-hole will not answer queries on it until it comes up.", name),
+			)
+		}
+		return
+	}
+
+	diags.AddAttributeWarning(
+		path.Root("interface"),
+		"DNS interface not found",
+		fmt.Sprintf("The interface %q does not exist on the Pi-hole host, so FTL will fall back to other interfaces. Available interfaces: %s.",
+			name, strings.Join(names, ", ")),
+	)
+}
+
+// readConfig reads the DNS section into data. API failures are returned;
+// problems converting the lists are added to diags.
+func (r *ConfigDNSResource) readConfig(ctx context.Context, data *ConfigDNSResourceModel, diags *diag.Diagnostics) error {
 	config, err := r.client.GetDNSConfig(ctx)
 	if err != nil {
 		return err
 	}
 
 	data.ID = types.StringValue("dns")
+	data.ServerValuesJSON = serverValuesJSON(config)
 	data.Port = types.Int64Value(int64(config.Port))
 	data.Interface = types.StringValue(config.Interface)
 	data.ListeningMode = types.StringValue(config.ListeningMode)
@@ -358,6 +557,35 @@ func (r *ConfigDNSResource) readConfig(ctx context.Context, data *ConfigDNSResou
 	data.PiholePTR = types.StringValue(config.PiholePTR)
 	data.ReplyWhenBusy = types.StringValue(config.ReplyWhenBusy)
 
+	// Upstreams stay null unless the configuration manages them
+	if !data.Upstreams.IsNull() {
+		upstreams := config.Upstreams
+		if upstreams == nil {
+			upstreams = []string{}
+		}
+		list, d := types.ListValueFrom(ctx, types.StringType, upstreams)
+		diags.Append(d...)
+		data.Upstreams = list
+	}
+
+	// Live lists
+	for _, live := range []struct {
+		target *types.List
+		values []string
+	}{
+		{&data.CurrentHosts, config.Hosts},
+		{&data.CurrentCNAMERecords, config.CNAMERecords},
+		{&data.CurrentUpstreams, config.Upstreams},
+	} {
+		values := live.values
+		if values == nil {
+			values = []string{}
+		}
+		list, d := types.ListValueFrom(ctx, types.StringType, values)
+		diags.Append(d...)
+		*live.target = list
+	}
+
 	// Domain settings
 	if config.Domain != nil {
 		data.DomainName = types.StringValue(config.Domain.Name)
@@ -376,6 +604,18 @@ func (r *ConfigDNSResource) readConfig(ctx context.Context, data *ConfigDNSResou
 		data.BlockingMode = types.StringValue(config.Blocking.Mode)
 	}
 
+	// Reply settings; FTL only answers with the addresses it is forced to
+	data.ReplyBlockingIPv4 = types.StringValue("")
+	data.ReplyBlockingIPv6 = types.StringValue("")
+	if config.Reply != nil && config.Reply.Blocking != nil {
+		if config.Reply.Blocking.Force4 {
+			data.ReplyBlockingIPv4 = types.StringValue(config.Reply.Blocking.IPv4)
+		}
+		if config.Reply.Blocking.Force6 {
+			data.ReplyBlockingIPv6 = types.StringValue(config.Reply.Blocking.IPv6)
+		}
+	}
+
 	// Special domains
 	if config.SpecialDomains != nil {
 		data.MozillaCanary = types.BoolValue(config.SpecialDomains.MozillaCanary)
@@ -391,46 +631,151 @@ func (r *ConfigDNSResource) readConfig(ctx context.Context, data *ConfigDNSResou
 	return nil
 }
 
-func (r *ConfigDNSResource) updateConfig(ctx context.Context, data *ConfigDNSResourceModel) error {
-	dnsConfig := map[string]interface{}{
-		"port":             data.Port.ValueInt64(),
-		"interface":        data.Interface.ValueString(),
-		"listeningMode":    data.ListeningMode.ValueString(),
-		"dnssec":           data.DNSSEC.ValueBool(),
-		"queryLogging":     data.QueryLogging.ValueBool(),
-		"domainNeeded":     data.DomainNeeded.ValueBool(),
-		"expandHosts":      data.ExpandHosts.ValueBool(),
-		"bogusPriv":        data.BogusPriv.ValueBool(),
-		"CNAMEdeepInspect": data.CNAMEDeepInspect.ValueBool(),
-		"blockESNI":        data.BlockESNI.ValueBool(),
-		"blockTTL":         data.BlockTTL.ValueInt64(),
-		"piholePTR":        data.PiholePTR.ValueString(),
-		"replyWhenBusy":    data.ReplyWhenBusy.ValueString(),
+func (r *ConfigDNSResource) configValues(data *ConfigDNSResourceModel) map[string]interface{} {
+	return map[string]interface{}{
+		"port":             data.Port,
+		"interface":        data.Interface,
+		"listeningMode":    data.ListeningMode,
+		"dnssec":           data.DNSSEC,
+		"queryLogging":     data.QueryLogging,
+		"domainNeeded":     data.DomainNeeded,
+		"expandHosts":      data.ExpandHosts,
+		"bogusPriv":        data.BogusPriv,
+		"CNAMEdeepInspect": data.CNAMEDeepInspect,
+		"blockESNI":        data.BlockESNI,
+		"blockTTL":         data.BlockTTL,
+		"piholePTR":        data.PiholePTR,
+		"replyWhenBusy":    data.ReplyWhenBusy,
+		"upstreams":        data.Upstreams,
 		"domain": map[string]interface{}{
-			"name":  data.DomainName.ValueString(),
-			"local": data.DomainLocal.ValueBool(),
+			"name":  data.DomainName,
+			"local": data.DomainLocal,
 		},
 		"cache": map[string]interface{}{
-			"size":      data.CacheSize.ValueInt64(),
-			"optimizer": data.CacheOptimizer.ValueInt64(),
+			"size":      data.CacheSize,
+			"optimizer": data.CacheOptimizer,
 		},
 		"blocking": map[string]interface{}{
-			"active": data.BlockingActive.ValueBool(),
-			"mode":   data.BlockingMode.ValueString(),
+			"active": data.BlockingActive,
+			"mode":   data.BlockingMode,
+		},
+		"reply": map[string]interface{}{
+			"blocking": map[string]interface{}{
+				"force4": replyForced(data.ReplyBlockingIPv4),
+				"IPv4":   data.ReplyBlockingIPv4,
+				"force6": replyForced(data.ReplyBlockingIPv6),
+				"IPv6":   data.ReplyBlockingIPv6,
+			},
 		},
 		"specialDomains": map[string]interface{}{
-			"mozillaCanary":      data.MozillaCanary.ValueBool(),
-			"iCloudPrivateRelay": data.ICloudPrivateRelay.ValueBool(),
+			"mozillaCanary":      data.MozillaCanary,
+			"iCloudPrivateRelay": data.ICloudPrivateRelay,
 		},
 		"rateLimit": map[string]interface{}{
-			"count":    data.RateLimitCount.ValueInt64(),
-			"interval": data.RateLimitInterval.ValueInt64(),
+			"count":    data.RateLimitCount,
+			"interval": data.RateLimitInterval,
 		},
 	}
+}
 
-	if err := r.client.UpdateConfig(ctx, "dns", dnsConfig); err != nil {
+func (r *ConfigDNSResource) updateConfig(ctx context.Context, data *ConfigDNSResourceModel, prior map[string]interface{}) error {
+	if err := r.client.UpdateConfig(ctx, "dns", configPatch(r.configValues(data), prior)); err != nil {
 		return fmt.Errorf("failed to update dns config: %w", err)
 	}
 
 	return nil
 }
+
+// replyForced reports whether FTL must answer with a configured reply
+// address instead of the address of the interface the query arrived on.
+func replyForced(ip types.String) types.Bool {
+	if ip.IsNull() || ip.IsUnknown() {
+		return types.BoolUnknown()
+	}
+	return types.BoolValue(ip.ValueString() != "")
+}
+
+// blockingReplyValidator warns about blocking modes whose reply addresses
+// are missing and rejects reply addresses the blocking mode does not use;
+// FTL accepts both and quietly answers with something else.
+type blockingReplyValidator struct{}
+
+func (v blockingReplyValidator) Description(ctx context.Context) string {
+	return v.MarkdownDescription(ctx)
+}
+
+func (v blockingReplyValidator) MarkdownDescription(ctx context.Context) string {
+	return "reply_blocking_ipv4 and reply_blocking_ipv6 must match the addresses blocking_mode answers with"
+}
+
+func (v blockingReplyValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var mode, ipv4, ipv6 types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("blocking_mode"), &mode)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("reply_blocking_ipv4"), &ipv4)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("reply_blocking_ipv6"), &ipv6)...)
+	if resp.Diagnostics.HasError() || mode.IsUnknown() {
+		return
+	}
+
+	checkBlockingReply(mode.ValueString(), ipv4, ipv6, &resp.Diagnostics)
+}
+
+// checkBlockingReply adds a warning for every reply address the blocking
+// mode needs but is not set, and an error for every reply address that is set
+// but not used or is of the wrong family. An empty mode is the default, NULL.
+func checkBlockingReply(mode string, ipv4, ipv6 types.String, diags *diag.Diagnostics) {
+	replies := []struct {
+		attribute string
+		value     types.String
+		family    string
+		modes     []string
+	}{
+		{"reply_blocking_ipv4", ipv4, "IPv4", []string{"IP", "IP-NODATA-AAAA"}},
+		{"reply_blocking_ipv6", ipv6, "IPv6", []string{"IP"}},
+	}
+
+	for _, reply := range replies {
+		if reply.value.IsUnknown() {
+			continue
+		}
+		used := false
+		for _, m := range reply.modes {
+			used = used || strings.EqualFold(mode, m)
+		}
+		value := reply.value.ValueString()
+
+		switch {
+		case used && value == "":
+			// Valid before the reply addresses could be managed, so only warn.
+			diags.AddAttributeWarning(
+				path.Root(reply.attribute),
+				"Missing blocking reply address",
+				fmt.Sprintf("blocking_mode %q answers blocked queries with an %s address; without %s, FTL answers with "+
+					"the address of the interface the query arrived on. Set it to the address clients should receive.",
+					mode, reply.family, reply.attribute),
+			)
+		case !used && value != "":
+			diags.AddAttributeError(
+				path.Root(reply.attribute),
+				"Unused blocking reply address",
+				fmt.Sprintf("%s is only used when blocking_mode is %s; remove it or change blocking_mode.",
+					reply.attribute, strings.Join(reply.modes, " or ")),
+			)
+		case value != "" && !isIPFamily(value, reply.family):
+			diags.AddAttributeError(
+				path.Root(reply.attribute),
+				"Invalid blocking reply address",
+				fmt.Sprintf("%q is not an %s address.", value, reply.family),
+			)
+		}
+	}
+}
+
+// isIPFamily reports whether s is an address of family "IPv4" or "IPv6".
+func isIPFamily(s, family string) bool {
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return false
+	}
+	return (ip.To4() != nil) == (family == "IPv4")
+}
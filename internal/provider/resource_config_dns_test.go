@@ -4,8 +4,11 @@
 package provider
 
 import (
+	"regexp"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
@@ -32,6 +35,35 @@ func TestAccResourceConfigDNS_basic(t *testing.T) {
 				ImportStateId:     "dns",
 				ImportStateVerify: true,
 			},
+			// The ID of another section is refused
+			{
+				ResourceName:  "pihole_config_dns.test",
+				ImportState:   true,
+				ImportStateId: "dhcp",
+				ExpectError:   regexp.MustCompile(`must be imported with the ID "dns"`),
+			},
+		},
+	})
+}
+
+func TestAccResourceConfigDNS_validateInterface(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The container's interface exists, so the apply succeeds without warnings
+			{
+				Config: `
+resource "pihole_config_dns" "test" {
+  interface          = "eth0"
+  validate_interface = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_config_dns.test", "interface", "eth0"),
+					resource.TestCheckResourceAttr("pihole_config_dns.test", "validate_interface", "true"),
+				),
+			},
 		},
 	})
 }
@@ -108,8 +140,159 @@ func TestAccResourceConfigDNS_rateLimit(t *testing.T) {
 	})
 }
 
+func TestAccResourceConfigDNS_blockingModeIP(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// IP mode without reply addresses only warns, as it did before
+			// the addresses could be set
+			{
+				Config: `
+resource "pihole_config_dns" "test" {
+  blocking_mode = "IP"
+}
+`,
+				Check: resource.TestCheckResourceAttr("pihole_config_dns.test", "reply_blocking_ipv4", ""),
+			},
+			{
+				Config: `
+resource "pihole_config_dns" "test" {
+  blocking_mode       = "IP"
+  reply_blocking_ipv4 = "192.0.2.1"
+  reply_blocking_ipv6 = "2001:db8::1"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_config_dns.test", "blocking_mode", "IP"),
+					resource.TestCheckResourceAttr("pihole_config_dns.test", "reply_blocking_ipv4", "192.0.2.1"),
+					resource.TestCheckResourceAttr("pihole_config_dns.test", "reply_blocking_ipv6", "2001:db8::1"),
+				),
+			},
+			// Back to the default mode, which uses neither address
+			{
+				Config: `
+resource "pihole_config_dns" "test" {
+  blocking_mode = "NULL"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_config_dns.test", "blocking_mode", "NULL"),
+					resource.TestCheckResourceAttr("pihole_config_dns.test", "reply_blocking_ipv4", ""),
+					resource.TestCheckResourceAttr("pihole_config_dns.test", "reply_blocking_ipv6", ""),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceConfigDNS_upstreams(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "pihole_config_dns" "test" {
+  upstreams = ["9.9.9.9", "149.112.112.112"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_config_dns.test", "upstreams.#", "2"),
+					resource.TestCheckResourceAttr("pihole_config_dns.test", "upstreams.0", "9.9.9.9"),
+				),
+			},
+			// Managing the list and single upstreams at once fails the plan
+			{
+				Config: `
+resource "pihole_config_dns" "test" {
+  upstreams = ["9.9.9.9", "149.112.112.112"]
+}
+
+resource "pihole_dns_upstream" "test" {
+  upstream = "1.1.1.1"
+}
+`,
+				ExpectError: regexp.MustCompile(`Conflicting DNS upstream management`),
+			},
+		},
+	})
+}
+
+func TestCheckBlockingReply(t *testing.T) {
+	unset := types.StringNull()
+	tests := []struct {
+		name       string
+		mode       string
+		ipv4, ipv6 types.String
+		want       []string
+		wantError  bool
+	}{
+		{"default mode", "", unset, unset, nil, false},
+		{"NULL with address", "NULL", types.StringValue("192.0.2.1"), unset, []string{"Unused blocking reply address"}, true},
+		{"IP complete", "IP", types.StringValue("192.0.2.1"), types.StringValue("2001:db8::1"), nil, false},
+		{"IP missing both", "IP", unset, types.StringValue(""), []string{"Missing blocking reply address", "Missing blocking reply address"}, false},
+		{"IP unknown", "IP", types.StringUnknown(), types.StringUnknown(), nil, false},
+		{"IP swapped families", "IP", types.StringValue("2001:db8::1"), types.StringValue("192.0.2.1"), []string{"Invalid blocking reply address", "Invalid blocking reply address"}, true},
+		{"IP-NODATA-AAAA", "IP-NODATA-AAAA", types.StringValue("192.0.2.1"), unset, nil, false},
+		{"IP-NODATA-AAAA with IPv6", "IP-NODATA-AAAA", types.StringValue("192.0.2.1"), types.StringValue("2001:db8::1"), []string{"Unused blocking reply address"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			checkBlockingReply(tt.mode, tt.ipv4, tt.ipv6, &diags)
+			if diags.HasError() != tt.wantError {
+				t.Errorf("checkBlockingReply() has error = %t, want %t", diags.HasError(), tt.wantError)
+			}
+
+			var got []string
+			for _, d := range diags {
+				got = append(got, d.Summary())
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("checkBlockingReply() = %q, want %q", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("checkBlockingReply() = %q, want %q", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
 // Test config helpers
 
+func TestAccResourceConfigDNS_currentLists(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "pihole_local_dns" "unmanaged" {
+  hostname = "audit.tfacc.local"
+  ip       = "192.168.99.31"
+}
+
+resource "pihole_config_dns" "test" {
+  query_logging = true
+
+  depends_on = [pihole_local_dns.unmanaged]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckTypeSetElemAttr("pihole_config_dns.test", "current_hosts.*", "192.168.99.31 audit.tfacc.local"),
+					resource.TestCheckResourceAttrSet("pihole_config_dns.test", "current_cname_records.#"),
+					resource.TestCheckResourceAttrSet("pihole_config_dns.test", "current_upstreams.#"),
+					resource.TestCheckNoResourceAttr("pihole_config_dns.test", "upstreams"),
+				),
+			},
+		},
+	})
+}
+
 func testAccResourceConfigDNSBasic() string {
 	return `
 resource "pihole_config_dns" "test" {
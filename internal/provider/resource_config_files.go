@@ -7,17 +7,17 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/dklesev/terraform-provider-pihole/internal/client"
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 var (
 	_ resource.Resource                = &ConfigFilesResource{}
 	_ resource.ResourceWithImportState = &ConfigFilesResource{}
+	_ resource.ResourceWithModifyPlan  = &ConfigFilesResource{}
 )
 
 func NewConfigFilesResource() resource.Resource {
@@ -25,19 +25,21 @@ func NewConfigFilesResource() resource.Resource {
 }
 
 type ConfigFilesResource struct {
-	client *client.Client
+	client *pihole.Client
 }
 
 type ConfigFilesResourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	PID          types.String `tfsdk:"pid"`
-	Database     types.String `tfsdk:"database"`
-	Gravity      types.String `tfsdk:"gravity"`
-	GravityTmp   types.String `tfsdk:"gravity_tmp"`
-	MacVendor    types.String `tfsdk:"mac_vendor"`
-	LogFTL       types.String `tfsdk:"log_ftl"`
-	LogDnsmasq   types.String `tfsdk:"log_dnsmasq"`
-	LogWebserver types.String `tfsdk:"log_webserver"`
+	ID               types.String `tfsdk:"id"`
+	ServerValuesJSON types.String `tfsdk:"server_values_json"`
+	OnDestroy        types.String `tfsdk:"on_destroy"`
+	PID              types.String `tfsdk:"pid"`
+	Database         types.String `tfsdk:"database"`
+	Gravity          types.String `tfsdk:"gravity"`
+	GravityTmp       types.String `tfsdk:"gravity_tmp"`
+	MacVendor        types.String `tfsdk:"mac_vendor"`
+	LogFTL           types.String `tfsdk:"log_ftl"`
+	LogDnsmasq       types.String `tfsdk:"log_dnsmasq"`
+	LogWebserver     types.String `tfsdk:"log_webserver"`
 }
 
 func (r *ConfigFilesResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -47,10 +49,35 @@ func (r *ConfigFilesResource) Metadata(ctx context.Context, req resource.Metadat
 func (r *ConfigFilesResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages Pi-hole file paths configuration (read-only for most paths).",
+		MarkdownDescription: `
+Manages where Pi-hole keeps its databases, logs and PID file. The defaults
+suit nearly every installation; change a path only to move files to other
+storage, e.g. the query database off an SD card. FTL does not move existing
+files, so copy them to the new path before applying, and changing a path
+restarts FTL.
+
+## Example Usage
+
+` + "```hcl" + `
+resource "pihole_config_files" "settings" {
+  database = "/mnt/ssd/pihole/pihole-FTL.db"
+}
+` + "```" + `
+
+## Import
+
+The file paths configuration can be imported using the section name, ` + "`files`" + `:
+
+` + "```shell" + `
+terraform import pihole_config_files.settings files
+` + "```" + `
+`,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
 			},
+			"server_values_json": serverValuesAttribute(),
+			"on_destroy":         onDestroyAttribute(),
 			"pid": schema.StringAttribute{
 				Description: "PID file path.",
 				Optional:    true,
@@ -107,12 +134,12 @@ func (r *ConfigFilesResource) Configure(ctx context.Context, req resource.Config
 	if req.ProviderData == nil {
 		return
 	}
-	c, ok := req.ProviderData.(*client.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
-		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *client.Client, got: %T.", req.ProviderData))
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData))
 		return
 	}
-	r.client = c
+	r.client = data.client
 }
 
 func (r *ConfigFilesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -121,12 +148,14 @@ func (r *ConfigFilesResource) Create(ctx context.Context, req resource.CreateReq
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	if err := r.updateConfig(ctx, &data); err != nil {
-		resp.Diagnostics.AddError("Error updating files config", err.Error())
+	snapshotConfig(ctx, r.client, "files", r.configValues(&data), resp.Private)
+
+	if err := r.updateConfig(ctx, &data, nil); err != nil {
+		addAPIError(&resp.Diagnostics, "Error updating files config", err)
 		return
 	}
 	if err := r.readConfig(ctx, &data); err != nil {
-		resp.Diagnostics.AddError("Error reading files config", err.Error())
+		addAPIError(&resp.Diagnostics, "Error reading files config", err)
 		return
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -139,39 +168,66 @@ func (r *ConfigFilesResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 	if err := r.readConfig(ctx, &data); err != nil {
-		resp.Diagnostics.AddError("Error reading files config", err.Error())
+		addAPIError(&resp.Diagnostics, "Error reading files config", err)
 		return
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *ConfigFilesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data ConfigFilesResourceModel
+	var data, state ConfigFilesResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	if err := r.updateConfig(ctx, &data); err != nil {
-		resp.Diagnostics.AddError("Error updating files config", err.Error())
+	if err := r.updateConfig(ctx, &data, r.configValues(&state)); err != nil {
+		addAPIError(&resp.Diagnostics, "Error updating files config", err)
 		return
 	}
 	if err := r.readConfig(ctx, &data); err != nil {
-		resp.Diagnostics.AddError("Error reading files config", err.Error())
+		addAPIError(&resp.Diagnostics, "Error reading files config", err)
 		return
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *ConfigFilesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	tflog.Debug(ctx, "Removing files config from state")
+	var data ConfigFilesResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	destroyConfig(ctx, r.client, "files", data.OnDestroy, r.configValues(&data), req.Private, &resp.Diagnostics)
+}
+
+// ModifyPlan checks the planned values against the options Pi-hole reports.
+func (r *ConfigFilesResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var data ConfigFilesResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	validateConfigValues(ctx, r.client, "files", r.configValues(&data), &resp.Diagnostics)
 }
 
 func (r *ConfigFilesResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	checkConfigImportID(req.ID, "files", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var data ConfigFilesResourceModel
 	if err := r.readConfig(ctx, &data); err != nil {
-		resp.Diagnostics.AddError("Error importing files config", err.Error())
+		addAPIError(&resp.Diagnostics, "Error importing files config", err)
 		return
 	}
+	data.OnDestroy = types.StringValue(onDestroyNoop)
+	snapshotConfig(ctx, r.client, "files", r.configValues(&data), resp.Private)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -181,6 +237,7 @@ func (r *ConfigFilesResource) readConfig(ctx context.Context, data *ConfigFilesR
 		return err
 	}
 	data.ID = types.StringValue("files")
+	data.ServerValuesJSON = serverValuesJSON(config)
 	data.PID = types.StringValue(config.PID)
 	data.Database = types.StringValue(config.Database)
 	data.Gravity = types.StringValue(config.Gravity)
@@ -194,18 +251,21 @@ func (r *ConfigFilesResource) readConfig(ctx context.Context, data *ConfigFilesR
 	return nil
 }
 
-func (r *ConfigFilesResource) updateConfig(ctx context.Context, data *ConfigFilesResourceModel) error {
-	cfg := map[string]interface{}{
-		"pid":         data.PID.ValueString(),
-		"database":    data.Database.ValueString(),
-		"gravity":     data.Gravity.ValueString(),
-		"gravity_tmp": data.GravityTmp.ValueString(),
-		"macvendor":   data.MacVendor.ValueString(),
+func (r *ConfigFilesResource) configValues(data *ConfigFilesResourceModel) map[string]interface{} {
+	return map[string]interface{}{
+		"pid":         data.PID,
+		"database":    data.Database,
+		"gravity":     data.Gravity,
+		"gravity_tmp": data.GravityTmp,
+		"macvendor":   data.MacVendor,
 		"log": map[string]interface{}{
-			"ftl":       data.LogFTL.ValueString(),
-			"dnsmasq":   data.LogDnsmasq.ValueString(),
-			"webserver": data.LogWebserver.ValueString(),
+			"ftl":       data.LogFTL,
+			"dnsmasq":   data.LogDnsmasq,
+			"webserver": data.LogWebserver,
 		},
 	}
-	return r.client.UpdateConfig(ctx, "files", cfg)
+}
+
+func (r *ConfigFilesResource) updateConfig(ctx context.Context, data *ConfigFilesResourceModel, prior map[string]interface{}) error {
+	return r.client.UpdateConfig(ctx, "files", configPatch(r.configValues(data), prior))
 }
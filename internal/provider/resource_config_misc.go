@@ -6,19 +6,29 @@ package provider
 import (
 	"context"
 	"fmt"
+	"slices"
 
-	"github.com/dklesev/terraform-provider-pihole/internal/client"
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+	"github.com/hashicorp/terraform-plugin-framework-validators/boolvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 var (
-	_ resource.Resource                = &ConfigMiscResource{}
-	_ resource.ResourceWithImportState = &ConfigMiscResource{}
+	_ resource.Resource                 = &ConfigMiscResource{}
+	_ resource.ResourceWithImportState  = &ConfigMiscResource{}
+	_ resource.ResourceWithModifyPlan   = &ConfigMiscResource{}
+	_ resource.ResourceWithUpgradeState = &ConfigMiscResource{}
 )
 
 func NewConfigMiscResource() resource.Resource {
@@ -26,24 +36,35 @@ func NewConfigMiscResource() resource.Resource {
 }
 
 type ConfigMiscResource struct {
-	client *client.Client
+	client *pihole.Client
 }
 
 type ConfigMiscResourceModel struct {
-	ID              types.String `tfsdk:"id"`
-	PrivacyLevel    types.Int64  `tfsdk:"privacy_level"`
-	DelayStartup    types.Int64  `tfsdk:"delay_startup"`
-	Nice            types.Int64  `tfsdk:"nice"`
-	Addr2Line       types.Bool   `tfsdk:"addr2line"`
-	EtcDnsmasqD     types.Bool   `tfsdk:"etc_dnsmasq_d"`
-	DnsmasqLines    types.List   `tfsdk:"dnsmasq_lines"`
-	ExtraLogging    types.Bool   `tfsdk:"extra_logging"`
-	ReadOnly        types.Bool   `tfsdk:"read_only"`
-	NormalizeCPU    types.Bool   `tfsdk:"normalize_cpu"`
-	HideDnsmasqWarn types.Bool   `tfsdk:"hide_dnsmasq_warn"`
-	CheckLoad       types.Bool   `tfsdk:"check_load"`
-	CheckShmem      types.Int64  `tfsdk:"check_shmem"`
-	CheckDisk       types.Int64  `tfsdk:"check_disk"`
+	ID               types.String          `tfsdk:"id"`
+	ServerValuesJSON types.String          `tfsdk:"server_values_json"`
+	OnDestroy        types.String          `tfsdk:"on_destroy"`
+	PrivacyLevel     types.Int64           `tfsdk:"privacy_level"`
+	DelayStartup     types.Int64           `tfsdk:"delay_startup"`
+	Nice             types.Int64           `tfsdk:"nice"`
+	Addr2Line        types.Bool            `tfsdk:"addr2line"`
+	EtcDnsmasqD      types.Bool            `tfsdk:"etc_dnsmasq_d"`
+	DnsmasqLines     types.List            `tfsdk:"dnsmasq_lines"`
+	ExtraLogging     types.Bool            `tfsdk:"extra_logging"`
+	ReadOnly         types.Bool            `tfsdk:"read_only"`
+	NormalizeCPU     types.Bool            `tfsdk:"normalize_cpu"`
+	HideDnsmasqWarn  types.Bool            `tfsdk:"hide_dnsmasq_warn"`
+	Unordered        types.Bool            `tfsdk:"dnsmasq_lines_unordered"`
+	Check            *configMiscCheckModel `tfsdk:"check"`
+	CheckLoad        types.Bool            `tfsdk:"check_load"`
+	CheckShmem       types.Int64           `tfsdk:"check_shmem"`
+	CheckDisk        types.Int64           `tfsdk:"check_disk"`
+}
+
+// configMiscCheckModel is the check block.
+type configMiscCheckModel struct {
+	Load  types.Bool  `tfsdk:"load"`
+	Shmem types.Int64 `tfsdk:"shmem"`
+	Disk  types.Int64 `tfsdk:"disk"`
 }
 
 func (r *ConfigMiscResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -67,14 +88,47 @@ resource "pihole_config_misc" "settings" {
     "address=/custom.local/192.168.1.100",
     "server=/corp.local/10.0.0.1"
   ]
+
+  check {
+    load  = true
+    shmem = 90
+    disk  = 90
+  }
+}
+` + "```" + `
+
+## System Checks
+
+The ` + "`check`" + ` block manages the resource checks Pi-hole warns about in its
+diagnosis messages. Without the block, Pi-hole keeps its check settings as they are.
+
+~> **Note:** The ` + "`check_load`" + `, ` + "`check_shmem`" + ` and ` + "`check_disk`" + ` attributes
+are deprecated aliases of the block and will be removed in a future release; a
+configuration may use only one of the two. Replace them with the block:
+` + "`check_load = false`" + ` becomes ` + "`check { load = false }`" + `.
+
+## Unordered dnsmasq Lines
+
+Pi-hole keeps ` + "`dnsmasq_lines`" + ` in the order they were written, and dnsmasq reads
+them in that order, so a different order in the configuration is a change. When the
+order does not matter, set ` + "`dnsmasq_lines_unordered = true`" + ` and lines that are only
+reordered, in the configuration or in Pi-hole, no longer show up in plans.
+
+` + "```hcl" + `
+resource "pihole_config_misc" "settings" {
+  dnsmasq_lines_unordered = true
+  dnsmasq_lines           = sort(var.dnsmasq_lines)
 }
 ` + "```" + `
 `,
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description: "Identifier for this resource (always 'misc').",
 				Computed:    true,
 			},
+			"server_values_json": serverValuesAttribute(),
+			"on_destroy":         onDestroyAttribute(),
 			"privacy_level": schema.Int64Attribute{
 				Description: "Privacy level for statistics (0-3). 0=show everything, 3=hide everything.",
 				Optional:    true,
@@ -111,6 +165,12 @@ resource "pihole_config_misc" "settings" {
 				Computed:    true,
 				ElementType: types.StringType,
 			},
+			"dnsmasq_lines_unordered": schema.BoolAttribute{
+				Description: "Whether to ignore the order of `dnsmasq_lines`, so lines that are only reordered are no change. Default: false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
 			"extra_logging": schema.BoolAttribute{
 				Description: "Enable extra debug logging.",
 				Optional:    true,
@@ -136,22 +196,65 @@ resource "pihole_config_misc" "settings" {
 				Default:     booldefault.StaticBool(false),
 			},
 			"check_load": schema.BoolAttribute{
-				Description: "Enable system load checking.",
-				Optional:    true,
-				Computed:    true,
-				Default:     booldefault.StaticBool(true),
+				Description:        "Enable system load checking. Deprecated: use `check.load`.",
+				DeprecationMessage: "Use the load attribute of the check block instead.",
+				Optional:           true,
+				Computed:           true,
+				Validators: []validator.Bool{
+					boolvalidator.ConflictsWith(path.MatchRoot("check")),
+				},
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"check_shmem": schema.Int64Attribute{
-				Description: "Shared memory usage threshold (%).",
-				Optional:    true,
-				Computed:    true,
-				Default:     int64default.StaticInt64(90),
+				Description:        "Shared memory usage threshold (%). Deprecated: use `check.shmem`.",
+				DeprecationMessage: "Use the shmem attribute of the check block instead.",
+				Optional:           true,
+				Computed:           true,
+				Validators: []validator.Int64{
+					int64validator.ConflictsWith(path.MatchRoot("check")),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
 			},
 			"check_disk": schema.Int64Attribute{
-				Description: "Disk usage threshold (%).",
-				Optional:    true,
-				Computed:    true,
-				Default:     int64default.StaticInt64(90),
+				Description:        "Disk usage threshold (%). Deprecated: use `check.disk`.",
+				DeprecationMessage: "Use the disk attribute of the check block instead.",
+				Optional:           true,
+				Computed:           true,
+				Validators: []validator.Int64{
+					int64validator.ConflictsWith(path.MatchRoot("check")),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"check": schema.SingleNestedBlock{
+				Description: "System resource checks. Without the block, Pi-hole's check settings are not managed.",
+				Attributes: map[string]schema.Attribute{
+					"load": schema.BoolAttribute{
+						Description: "Enable system load checking.",
+						Optional:    true,
+						Computed:    true,
+						Default:     booldefault.StaticBool(true),
+					},
+					"shmem": schema.Int64Attribute{
+						Description: "Shared memory usage threshold (%).",
+						Optional:    true,
+						Computed:    true,
+						Default:     int64default.StaticInt64(90),
+					},
+					"disk": schema.Int64Attribute{
+						Description: "Disk usage threshold (%).",
+						Optional:    true,
+						Computed:    true,
+						Default:     int64default.StaticInt64(90),
+					},
+				},
 			},
 		},
 	}
@@ -162,16 +265,16 @@ func (r *ConfigMiscResource) Configure(ctx context.Context, req resource.Configu
 		return
 	}
 
-	c, ok := req.ProviderData.(*client.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = c
+	r.client = data.client
 }
 
 func (r *ConfigMiscResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -185,14 +288,16 @@ func (r *ConfigMiscResource) Create(ctx context.Context, req resource.CreateRequ
 	tflog.Debug(ctx, "Creating misc config")
 
 	// Build the config update
-	if err := r.updateConfig(ctx, &data); err != nil {
-		resp.Diagnostics.AddError("Error updating misc config", err.Error())
+	snapshotConfig(ctx, r.client, "misc", r.configValues(&data), resp.Private)
+
+	if err := r.updateConfig(ctx, &data, nil); err != nil {
+		addAPIError(&resp.Diagnostics, "Error updating misc config", err)
 		return
 	}
 
 	// Read back the config to get computed values
 	if err := r.readConfig(ctx, &data); err != nil {
-		resp.Diagnostics.AddError("Error reading misc config", err.Error())
+		addAPIError(&resp.Diagnostics, "Error reading misc config", err)
 		return
 	}
 
@@ -208,7 +313,7 @@ func (r *ConfigMiscResource) Read(ctx context.Context, req resource.ReadRequest,
 	}
 
 	if err := r.readConfig(ctx, &data); err != nil {
-		resp.Diagnostics.AddError("Error reading misc config", err.Error())
+		addAPIError(&resp.Diagnostics, "Error reading misc config", err)
 		return
 	}
 
@@ -216,22 +321,23 @@ func (r *ConfigMiscResource) Read(ctx context.Context, req resource.ReadRequest,
 }
 
 func (r *ConfigMiscResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data ConfigMiscResourceModel
+	var data, state ConfigMiscResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	tflog.Debug(ctx, "Updating misc config")
 
-	if err := r.updateConfig(ctx, &data); err != nil {
-		resp.Diagnostics.AddError("Error updating misc config", err.Error())
+	if err := r.updateConfig(ctx, &data, r.configValues(&state)); err != nil {
+		addAPIError(&resp.Diagnostics, "Error updating misc config", err)
 		return
 	}
 
 	if err := r.readConfig(ctx, &data); err != nil {
-		resp.Diagnostics.AddError("Error reading misc config", err.Error())
+		addAPIError(&resp.Diagnostics, "Error reading misc config", err)
 		return
 	}
 
@@ -239,20 +345,79 @@ func (r *ConfigMiscResource) Update(ctx context.Context, req resource.UpdateRequ
 }
 
 func (r *ConfigMiscResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	// Config resources don't really "delete" - we just remove from state
-	tflog.Debug(ctx, "Removing misc config from state (config remains in Pi-hole)")
+	var data ConfigMiscResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	destroyConfig(ctx, r.client, "misc", data.OnDestroy, r.configValues(&data), req.Private, &resp.Diagnostics)
+}
+
+// ModifyPlan checks the planned values against the options Pi-hole reports
+// and, with dnsmasq_lines_unordered, keeps the order of dnsmasq_lines from
+// state when the configuration only reorders them.
+func (r *ConfigMiscResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var data ConfigMiscResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	validateConfigValues(ctx, r.client, "misc", r.configValues(&data), &resp.Diagnostics)
+
+	if req.State.Raw.IsNull() || !data.Unordered.ValueBool() {
+		return
+	}
+	var prior types.List
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("dnsmasq_lines"), &prior)...)
+	if resp.Diagnostics.HasError() || data.DnsmasqLines.IsUnknown() || prior.IsNull() {
+		return
+	}
+	if sameLinesUnordered(ctx, data.DnsmasqLines, prior) && !data.DnsmasqLines.Equal(prior) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("dnsmasq_lines"), prior)...)
+	}
+}
+
+// UpgradeState carries the state of version 0 over. The check_load,
+// check_shmem and check_disk values are kept in the deprecated attributes,
+// which keep their state when unset, so configurations that moved to the
+// check block plan no changes.
+func (r *ConfigMiscResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: configMiscSchemaV0(),
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior configMiscResourceModelV0
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				data := upgradeConfigMiscStateV0(prior)
+				resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			},
+		},
+	}
 }
 
 func (r *ConfigMiscResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// For config resources, the import ID is ignored - we just read the current config
+	checkConfigImportID(req.ID, "misc", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	tflog.Debug(ctx, "Importing misc config from Pi-hole")
 
 	var data ConfigMiscResourceModel
 	if err := r.readConfig(ctx, &data); err != nil {
-		resp.Diagnostics.AddError("Error importing misc config", err.Error())
+		addAPIError(&resp.Diagnostics, "Error importing misc config", err)
 		return
 	}
 
+	data.OnDestroy = types.StringValue(onDestroyNoop)
+	snapshotConfig(ctx, r.client, "misc", r.configValues(&data), resp.Private)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -262,8 +427,7 @@ func (r *ConfigMiscResource) readConfig(ctx context.Context, data *ConfigMiscRes
 		return err
 	}
 
-	// Set ID for singleton resource
-	data.ID = types.StringValue("misc")
+	data.ServerValuesJSON = serverValuesJSON(config)
 
 	data.PrivacyLevel = types.Int64Value(int64(config.PrivacyLevel))
 	data.DelayStartup = types.Int64Value(int64(config.DelayStartup))
@@ -281,50 +445,168 @@ func (r *ConfigMiscResource) readConfig(ctx context.Context, data *ConfigMiscRes
 	if diags.HasError() {
 		return fmt.Errorf("failed to convert dnsmasq_lines")
 	}
-	data.DnsmasqLines = lines
+	if !data.Unordered.ValueBool() || !sameLinesUnordered(ctx, lines, data.DnsmasqLines) {
+		data.DnsmasqLines = lines
+	}
+	if data.Unordered.IsNull() {
+		data.Unordered = types.BoolValue(false)
+	}
 
-	// Handle check settings
+	// The checks are only read back when they are managed, through the
+	// block or the deprecated attributes. The deprecated attributes are
+	// computed only to keep the values upgraded from version 0 without a
+	// diff; left unset, they stay null.
+	if data.CheckLoad.IsUnknown() {
+		data.CheckLoad = types.BoolNull()
+	}
+	if data.CheckShmem.IsUnknown() {
+		data.CheckShmem = types.Int64Null()
+	}
+	if data.CheckDisk.IsUnknown() {
+		data.CheckDisk = types.Int64Null()
+	}
 	if config.Check != nil {
-		data.CheckLoad = types.BoolValue(config.Check.Load)
-		data.CheckShmem = types.Int64Value(int64(config.Check.Shmem))
-		data.CheckDisk = types.Int64Value(int64(config.Check.Disk))
+		if data.Check != nil {
+			data.Check = &configMiscCheckModel{
+				Load:  types.BoolValue(config.Check.Load),
+				Shmem: types.Int64Value(int64(config.Check.Shmem)),
+				Disk:  types.Int64Value(int64(config.Check.Disk)),
+			}
+		}
+		if !data.CheckLoad.IsNull() {
+			data.CheckLoad = types.BoolValue(config.Check.Load)
+		}
+		if !data.CheckShmem.IsNull() {
+			data.CheckShmem = types.Int64Value(int64(config.Check.Shmem))
+		}
+		if !data.CheckDisk.IsNull() {
+			data.CheckDisk = types.Int64Value(int64(config.Check.Disk))
+		}
 	}
 
+	// Set ID for singleton resource
+	data.ID = types.StringValue("misc")
 	return nil
 }
 
-func (r *ConfigMiscResource) updateConfig(ctx context.Context, data *ConfigMiscResourceModel) error {
-	// Build the misc config values
-	miscConfig := map[string]interface{}{
-		"privacylevel":      data.PrivacyLevel.ValueInt64(),
-		"delay_startup":     data.DelayStartup.ValueInt64(),
-		"nice":              data.Nice.ValueInt64(),
-		"addr2line":         data.Addr2Line.ValueBool(),
-		"etc_dnsmasq_d":     data.EtcDnsmasqD.ValueBool(),
-		"extraLogging":      data.ExtraLogging.ValueBool(),
-		"readOnly":          data.ReadOnly.ValueBool(),
-		"normalizeCPU":      data.NormalizeCPU.ValueBool(),
-		"hide_dnsmasq_warn": data.HideDnsmasqWarn.ValueBool(),
-		"check": map[string]interface{}{
-			"load":  data.CheckLoad.ValueBool(),
-			"shmem": data.CheckShmem.ValueInt64(),
-			"disk":  data.CheckDisk.ValueInt64(),
-		},
+func (r *ConfigMiscResource) configValues(data *ConfigMiscResourceModel) map[string]interface{} {
+	values := map[string]interface{}{
+		"privacylevel":      data.PrivacyLevel,
+		"delay_startup":     data.DelayStartup,
+		"nice":              data.Nice,
+		"addr2line":         data.Addr2Line,
+		"etc_dnsmasq_d":     data.EtcDnsmasqD,
+		"extraLogging":      data.ExtraLogging,
+		"readOnly":          data.ReadOnly,
+		"normalizeCPU":      data.NormalizeCPU,
+		"hide_dnsmasq_warn": data.HideDnsmasqWarn,
+		"dnsmasq_lines":     data.DnsmasqLines,
 	}
-
-	// Handle dnsmasq_lines
-	if !data.DnsmasqLines.IsNull() && !data.DnsmasqLines.IsUnknown() {
-		var lines []string
-		if diags := data.DnsmasqLines.ElementsAs(ctx, &lines, false); diags.HasError() {
-			return fmt.Errorf("failed to parse dnsmasq_lines")
+	if data.Check != nil {
+		values["check"] = map[string]interface{}{
+			"load":  data.Check.Load,
+			"shmem": data.Check.Shmem,
+			"disk":  data.Check.Disk,
+		}
+	} else {
+		// Null attributes are left out of the payload.
+		values["check"] = map[string]interface{}{
+			"load":  data.CheckLoad,
+			"shmem": data.CheckShmem,
+			"disk":  data.CheckDisk,
 		}
-		miscConfig["dnsmasq_lines"] = lines
 	}
+	return values
+}
 
-	// Send single PATCH request with all misc config
-	if err := r.client.UpdateConfig(ctx, "misc", miscConfig); err != nil {
+func (r *ConfigMiscResource) updateConfig(ctx context.Context, data *ConfigMiscResourceModel, prior map[string]interface{}) error {
+	if err := r.client.UpdateConfig(ctx, "misc", configPatch(r.configValues(data), prior)); err != nil {
 		return fmt.Errorf("failed to update misc config: %w", err)
 	}
 
 	return nil
 }
+
+// sameLinesUnordered reports whether two known lists of strings hold the
+// same lines, in any order.
+func sameLinesUnordered(ctx context.Context, a, b types.List) bool {
+	if a.IsNull() || a.IsUnknown() || b.IsNull() || b.IsUnknown() {
+		return false
+	}
+	var x, y []string
+	if a.ElementsAs(ctx, &x, false).HasError() || b.ElementsAs(ctx, &y, false).HasError() {
+		return false
+	}
+	slices.Sort(x)
+	slices.Sort(y)
+	return slices.Equal(x, y)
+}
+
+// configMiscResourceModelV0 is the state of version 0, with flat check
+// attributes.
+type configMiscResourceModelV0 struct {
+	ID               types.String `tfsdk:"id"`
+	ServerValuesJSON types.String `tfsdk:"server_values_json"`
+	OnDestroy        types.String `tfsdk:"on_destroy"`
+	PrivacyLevel     types.Int64  `tfsdk:"privacy_level"`
+	DelayStartup     types.Int64  `tfsdk:"delay_startup"`
+	Nice             types.Int64  `tfsdk:"nice"`
+	Addr2Line        types.Bool   `tfsdk:"addr2line"`
+	EtcDnsmasqD      types.Bool   `tfsdk:"etc_dnsmasq_d"`
+	DnsmasqLines     types.List   `tfsdk:"dnsmasq_lines"`
+	ExtraLogging     types.Bool   `tfsdk:"extra_logging"`
+	ReadOnly         types.Bool   `tfsdk:"read_only"`
+	NormalizeCPU     types.Bool   `tfsdk:"normalize_cpu"`
+	HideDnsmasqWarn  types.Bool   `tfsdk:"hide_dnsmasq_warn"`
+	CheckLoad        types.Bool   `tfsdk:"check_load"`
+	CheckShmem       types.Int64  `tfsdk:"check_shmem"`
+	CheckDisk        types.Int64  `tfsdk:"check_disk"`
+}
+
+// configMiscSchemaV0 is the schema of version 0, for reading its state.
+func configMiscSchemaV0() *schema.Schema {
+	return &schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":                 schema.StringAttribute{Computed: true},
+			"server_values_json": schema.StringAttribute{Computed: true},
+			"on_destroy":         schema.StringAttribute{Optional: true, Computed: true},
+			"privacy_level":      schema.Int64Attribute{Optional: true, Computed: true},
+			"delay_startup":      schema.Int64Attribute{Optional: true, Computed: true},
+			"nice":               schema.Int64Attribute{Optional: true, Computed: true},
+			"addr2line":          schema.BoolAttribute{Optional: true, Computed: true},
+			"etc_dnsmasq_d":      schema.BoolAttribute{Optional: true, Computed: true},
+			"dnsmasq_lines":      schema.ListAttribute{Optional: true, Computed: true, ElementType: types.StringType},
+			"extra_logging":      schema.BoolAttribute{Optional: true, Computed: true},
+			"read_only":          schema.BoolAttribute{Optional: true, Computed: true},
+			"normalize_cpu":      schema.BoolAttribute{Optional: true, Computed: true},
+			"hide_dnsmasq_warn":  schema.BoolAttribute{Optional: true, Computed: true},
+			"check_load":         schema.BoolAttribute{Optional: true, Computed: true},
+			"check_shmem":        schema.Int64Attribute{Optional: true, Computed: true},
+			"check_disk":         schema.Int64Attribute{Optional: true, Computed: true},
+		},
+	}
+}
+
+// upgradeConfigMiscStateV0 converts the state of version 0. Checks at
+// Pi-hole's defaults, which version 0 managed without them being configured,
+// become null so that configurations without them plan no change.
+func upgradeConfigMiscStateV0(prior configMiscResourceModelV0) ConfigMiscResourceModel {
+	data := ConfigMiscResourceModel{
+		ID:               prior.ID,
+		ServerValuesJSON: prior.ServerValuesJSON,
+		OnDestroy:        prior.OnDestroy,
+		PrivacyLevel:     prior.PrivacyLevel,
+		DelayStartup:     prior.DelayStartup,
+		Nice:             prior.Nice,
+		Addr2Line:        prior.Addr2Line,
+		EtcDnsmasqD:      prior.EtcDnsmasqD,
+		DnsmasqLines:     prior.DnsmasqLines,
+		ExtraLogging:     prior.ExtraLogging,
+		ReadOnly:         prior.ReadOnly,
+		NormalizeCPU:     prior.NormalizeCPU,
+		HideDnsmasqWarn:  prior.HideDnsmasqWarn,
+		Unordered:        types.BoolValue(false),
+	}
+	data.CheckLoad, data.CheckShmem, data.CheckDisk = prior.CheckLoad, prior.CheckShmem, prior.CheckDisk
+	return data
+}
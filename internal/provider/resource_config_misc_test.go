@@ -4,10 +4,15 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"regexp"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 )
 
 func TestAccResourceConfigMisc_basic(t *testing.T) {
@@ -23,7 +28,6 @@ func TestAccResourceConfigMisc_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("pihole_config_misc.test", "etc_dnsmasq_d", "false"),
 					resource.TestCheckResourceAttr("pihole_config_misc.test", "extra_logging", "false"),
 					resource.TestCheckResourceAttr("pihole_config_misc.test", "read_only", "false"),
-					resource.TestCheckResourceAttr("pihole_config_misc.test", "check_load", "true"),
 					resource.TestCheckResourceAttrSet("pihole_config_misc.test", "nice"),
 				),
 			},
@@ -87,6 +91,36 @@ func TestAccResourceConfigMisc_withDnsmasqLines(t *testing.T) {
 	})
 }
 
+func TestAccResourceConfigMisc_unorderedDnsmasqLines(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceConfigMiscUnordered("address=/a.local/192.168.1.1", "address=/b.local/192.168.1.2"),
+				Check:  resource.TestCheckResourceAttr("pihole_config_misc.test", "dnsmasq_lines.0", "address=/a.local/192.168.1.1"),
+			},
+			// Reordering the lines is no change
+			{
+				Config: testAccResourceConfigMiscUnordered("address=/b.local/192.168.1.2", "address=/a.local/192.168.1.1"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			// Other changes are applied in the configured order
+			{
+				Config: testAccResourceConfigMiscUnordered("address=/c.local/192.168.1.3", "address=/a.local/192.168.1.1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_config_misc.test", "dnsmasq_lines.0", "address=/c.local/192.168.1.3"),
+					resource.TestCheckResourceAttr("pihole_config_misc.test", "dnsmasq_lines.1", "address=/a.local/192.168.1.1"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccResourceConfigMisc_privacyLevels(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
@@ -127,6 +161,11 @@ func TestAccResourceConfigMisc_privacyLevels(t *testing.T) {
 					resource.TestCheckResourceAttr("pihole_config_misc.test", "privacy_level", "0"),
 				),
 			},
+			// Levels Pi-hole does not offer are rejected during plan
+			{
+				Config:      testAccResourceConfigMiscPrivacyLevel(4),
+				ExpectError: regexp.MustCompile(`misc.privacylevel: 4 is not one of the allowed values`),
+			},
 		},
 	})
 }
@@ -136,29 +175,61 @@ func TestAccResourceConfigMisc_checkSettings(t *testing.T) {
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
+			// The deprecated attributes conflict with the block
+			{
+				Config: `
+resource "pihole_config_misc" "test" {
+  check_load = false
+
+  check {
+    load = true
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
 			// Default check settings
 			{
 				Config: testAccResourceConfigMiscCheckSettings(true, 90, 90),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("pihole_config_misc.test", "check_load", "true"),
-					resource.TestCheckResourceAttr("pihole_config_misc.test", "check_shmem", "90"),
-					resource.TestCheckResourceAttr("pihole_config_misc.test", "check_disk", "90"),
+					resource.TestCheckResourceAttr("pihole_config_misc.test", "check.load", "true"),
+					resource.TestCheckResourceAttr("pihole_config_misc.test", "check.shmem", "90"),
+					resource.TestCheckResourceAttr("pihole_config_misc.test", "check.disk", "90"),
 				),
 			},
 			// Disable load checking
 			{
 				Config: testAccResourceConfigMiscCheckSettings(false, 90, 90),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("pihole_config_misc.test", "check_load", "false"),
+					resource.TestCheckResourceAttr("pihole_config_misc.test", "check.load", "false"),
 				),
 			},
 			// Lower thresholds
 			{
 				Config: testAccResourceConfigMiscCheckSettings(true, 80, 75),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("pihole_config_misc.test", "check_load", "true"),
-					resource.TestCheckResourceAttr("pihole_config_misc.test", "check_shmem", "80"),
-					resource.TestCheckResourceAttr("pihole_config_misc.test", "check_disk", "75"),
+					resource.TestCheckResourceAttr("pihole_config_misc.test", "check.load", "true"),
+					resource.TestCheckResourceAttr("pihole_config_misc.test", "check.shmem", "80"),
+					resource.TestCheckResourceAttr("pihole_config_misc.test", "check.disk", "75"),
+				),
+			},
+			// The deprecated attributes still manage the checks
+			{
+				Config: testAccResourceConfigMiscDeprecatedCheckSettings(false, 85),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_config_misc.test", "check_load", "false"),
+					resource.TestCheckResourceAttr("pihole_config_misc.test", "check_disk", "85"),
+					resource.TestCheckNoResourceAttr("pihole_config_misc.test", "check_shmem"),
+					resource.TestCheckNoResourceAttr("pihole_config_misc.test", "check.load"),
+				),
+			},
+			// Without the block the checks are left alone; the deprecated
+			// attributes keep their values
+			{
+				Config: testAccResourceConfigMiscBasic(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("pihole_config_misc.test", "check.load"),
+					resource.TestCheckResourceAttr("pihole_config_misc.test", "check_load", "false"),
 				),
 			},
 		},
@@ -182,9 +253,9 @@ func TestAccResourceConfigMisc_allFields(t *testing.T) {
 					resource.TestCheckResourceAttr("pihole_config_misc.test", "read_only", "false"),
 					resource.TestCheckResourceAttr("pihole_config_misc.test", "normalize_cpu", "true"),
 					resource.TestCheckResourceAttr("pihole_config_misc.test", "hide_dnsmasq_warn", "false"),
-					resource.TestCheckResourceAttr("pihole_config_misc.test", "check_load", "true"),
-					resource.TestCheckResourceAttr("pihole_config_misc.test", "check_shmem", "85"),
-					resource.TestCheckResourceAttr("pihole_config_misc.test", "check_disk", "80"),
+					resource.TestCheckResourceAttr("pihole_config_misc.test", "check.load", "true"),
+					resource.TestCheckResourceAttr("pihole_config_misc.test", "check.shmem", "85"),
+					resource.TestCheckResourceAttr("pihole_config_misc.test", "check.disk", "80"),
 					resource.TestCheckResourceAttr("pihole_config_misc.test", "dnsmasq_lines.#", "2"),
 				),
 			},
@@ -225,6 +296,15 @@ resource "pihole_config_misc" "test" {
 `
 }
 
+func testAccResourceConfigMiscUnordered(lines ...string) string {
+	return fmt.Sprintf(`
+resource "pihole_config_misc" "test" {
+  dnsmasq_lines_unordered = true
+  dnsmasq_lines           = [%q, %q]
+}
+`, lines[0], lines[1])
+}
+
 func testAccResourceConfigMiscPrivacyLevel(level int) string {
 	return fmt.Sprintf(`
 resource "pihole_config_misc" "test" {
@@ -233,12 +313,23 @@ resource "pihole_config_misc" "test" {
 `, level)
 }
 
+func testAccResourceConfigMiscDeprecatedCheckSettings(checkLoad bool, checkDisk int) string {
+	return fmt.Sprintf(`
+resource "pihole_config_misc" "test" {
+  check_load = %t
+  check_disk = %d
+}
+`, checkLoad, checkDisk)
+}
+
 func testAccResourceConfigMiscCheckSettings(checkLoad bool, checkShmem, checkDisk int) string {
 	return fmt.Sprintf(`
 resource "pihole_config_misc" "test" {
-  check_load  = %t
-  check_shmem = %d
-  check_disk  = %d
+  check {
+    load  = %t
+    shmem = %d
+    disk  = %d
+  }
 }
 `, checkLoad, checkShmem, checkDisk)
 }
@@ -255,13 +346,66 @@ resource "pihole_config_misc" "test" {
   read_only         = false
   normalize_cpu     = true
   hide_dnsmasq_warn = false
-  check_load        = true
-  check_shmem       = 85
-  check_disk        = 80
   dnsmasq_lines     = [
     "address=/test.local/192.168.1.100",
     "server=/corp.local/10.0.0.1"
   ]
+
+  check {
+    load  = true
+    shmem = 85
+    disk  = 80
+  }
 }
 `
 }
+
+func TestSameLinesUnordered(t *testing.T) {
+	ctx := context.Background()
+	list := func(lines ...string) types.List {
+		values := make([]attr.Value, len(lines))
+		for i, line := range lines {
+			values[i] = types.StringValue(line)
+		}
+		return types.ListValueMust(types.StringType, values)
+	}
+	tests := []struct {
+		name string
+		a, b types.List
+		want bool
+	}{
+		{"same order", list("a", "b"), list("a", "b"), true},
+		{"reordered", list("a", "b"), list("b", "a"), true},
+		{"different lines", list("a", "b"), list("a", "c"), false},
+		{"duplicate", list("a", "a"), list("a", "b"), false},
+		{"empty", list(), list(), true},
+		{"null", types.ListNull(types.StringType), list(), false},
+		{"unknown", types.ListUnknown(types.StringType), list(), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sameLinesUnordered(ctx, tt.a, tt.b); got != tt.want {
+				t.Errorf("sameLinesUnordered() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpgradeConfigMiscStateV0(t *testing.T) {
+	prior := configMiscResourceModelV0{
+		ID:         types.StringValue("misc"),
+		CheckLoad:  types.BoolValue(false),
+		CheckShmem: types.Int64Value(90),
+		CheckDisk:  types.Int64Value(80),
+	}
+	got := upgradeConfigMiscStateV0(prior)
+	if got.Check != nil {
+		t.Errorf("check = %+v, want no block", got.Check)
+	}
+	if got.CheckLoad.ValueBool() || got.CheckShmem.ValueInt64() != 90 || got.CheckDisk.ValueInt64() != 80 {
+		t.Errorf("check_load, check_shmem, check_disk = %s, %s, %s, want false, 90, 80", got.CheckLoad, got.CheckShmem, got.CheckDisk)
+	}
+	if !got.Unordered.Equal(types.BoolValue(false)) {
+		t.Errorf("dnsmasq_lines_unordered = %s, want false", got.Unordered)
+	}
+}
@@ -7,19 +7,19 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/dklesev/terraform-provider-pihole/internal/client"
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 var (
 	_ resource.Resource                = &ConfigNTPResource{}
 	_ resource.ResourceWithImportState = &ConfigNTPResource{}
+	_ resource.ResourceWithModifyPlan  = &ConfigNTPResource{}
 )
 
 func NewConfigNTPResource() resource.Resource {
@@ -27,19 +27,21 @@ func NewConfigNTPResource() resource.Resource {
 }
 
 type ConfigNTPResource struct {
-	client *client.Client
+	client *pihole.Client
 }
 
 type ConfigNTPResourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	IPv4Active   types.Bool   `tfsdk:"ipv4_active"`
-	IPv4Address  types.String `tfsdk:"ipv4_address"`
-	IPv6Active   types.Bool   `tfsdk:"ipv6_active"`
-	IPv6Address  types.String `tfsdk:"ipv6_address"`
-	SyncActive   types.Bool   `tfsdk:"sync_active"`
-	SyncServer   types.String `tfsdk:"sync_server"`
-	SyncInterval types.Int64  `tfsdk:"sync_interval"`
-	SyncCount    types.Int64  `tfsdk:"sync_count"`
+	ID               types.String `tfsdk:"id"`
+	ServerValuesJSON types.String `tfsdk:"server_values_json"`
+	OnDestroy        types.String `tfsdk:"on_destroy"`
+	IPv4Active       types.Bool   `tfsdk:"ipv4_active"`
+	IPv4Address      types.String `tfsdk:"ipv4_address"`
+	IPv6Active       types.Bool   `tfsdk:"ipv6_active"`
+	IPv6Address      types.String `tfsdk:"ipv6_address"`
+	SyncActive       types.Bool   `tfsdk:"sync_active"`
+	SyncServer       types.String `tfsdk:"sync_server"`
+	SyncInterval     types.Int64  `tfsdk:"sync_interval"`
+	SyncCount        types.Int64  `tfsdk:"sync_count"`
 }
 
 func (r *ConfigNTPResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -49,10 +51,39 @@ func (r *ConfigNTPResource) Metadata(ctx context.Context, req resource.MetadataR
 func (r *ConfigNTPResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages Pi-hole NTP server configuration.",
+		MarkdownDescription: `
+Manages the NTP server built into Pi-hole and the synchronization of the
+system clock. Pi-hole answers NTP requests from clients on the addresses of
+` + "`ipv4_address`" + ` and ` + "`ipv6_address`" + `, or on all addresses when they are
+empty, and sets the clock from ` + "`sync_server`" + ` every ` + "`sync_interval`" + ` seconds.
+
+## Example Usage
+
+` + "```hcl" + `
+resource "pihole_config_ntp" "settings" {
+  ipv4_active = true
+  ipv6_active = false
+
+  sync_active   = true
+  sync_server   = "time.cloudflare.com"
+  sync_interval = 3600
+}
+` + "```" + `
+
+## Import
+
+The NTP configuration can be imported using the section name, ` + "`ntp`" + `:
+
+` + "```shell" + `
+terraform import pihole_config_ntp.settings ntp
+` + "```" + `
+`,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
 			},
+			"server_values_json": serverValuesAttribute(),
+			"on_destroy":         onDestroyAttribute(),
 			"ipv4_active": schema.BoolAttribute{
 				Description: "Enable IPv4 NTP server.",
 				Optional:    true,
@@ -109,12 +140,12 @@ func (r *ConfigNTPResource) Configure(ctx context.Context, req resource.Configur
 	if req.ProviderData == nil {
 		return
 	}
-	c, ok := req.ProviderData.(*client.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
-		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *client.Client, got: %T.", req.ProviderData))
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData))
 		return
 	}
-	r.client = c
+	r.client = data.client
 }
 
 func (r *ConfigNTPResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -123,12 +154,14 @@ func (r *ConfigNTPResource) Create(ctx context.Context, req resource.CreateReque
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	if err := r.updateConfig(ctx, &data); err != nil {
-		resp.Diagnostics.AddError("Error updating NTP config", err.Error())
+	snapshotConfig(ctx, r.client, "ntp", r.configValues(&data), resp.Private)
+
+	if err := r.updateConfig(ctx, &data, nil); err != nil {
+		addAPIError(&resp.Diagnostics, "Error updating NTP config", err)
 		return
 	}
 	if err := r.readConfig(ctx, &data); err != nil {
-		resp.Diagnostics.AddError("Error reading NTP config", err.Error())
+		addAPIError(&resp.Diagnostics, "Error reading NTP config", err)
 		return
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -141,39 +174,66 @@ func (r *ConfigNTPResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 	if err := r.readConfig(ctx, &data); err != nil {
-		resp.Diagnostics.AddError("Error reading NTP config", err.Error())
+		addAPIError(&resp.Diagnostics, "Error reading NTP config", err)
 		return
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *ConfigNTPResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data ConfigNTPResourceModel
+	var data, state ConfigNTPResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	if err := r.updateConfig(ctx, &data); err != nil {
-		resp.Diagnostics.AddError("Error updating NTP config", err.Error())
+	if err := r.updateConfig(ctx, &data, r.configValues(&state)); err != nil {
+		addAPIError(&resp.Diagnostics, "Error updating NTP config", err)
 		return
 	}
 	if err := r.readConfig(ctx, &data); err != nil {
-		resp.Diagnostics.AddError("Error reading NTP config", err.Error())
+		addAPIError(&resp.Diagnostics, "Error reading NTP config", err)
 		return
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *ConfigNTPResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	tflog.Debug(ctx, "Removing NTP config from state")
+	var data ConfigNTPResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	destroyConfig(ctx, r.client, "ntp", data.OnDestroy, r.configValues(&data), req.Private, &resp.Diagnostics)
+}
+
+// ModifyPlan checks the planned values against the options Pi-hole reports.
+func (r *ConfigNTPResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var data ConfigNTPResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	validateConfigValues(ctx, r.client, "ntp", r.configValues(&data), &resp.Diagnostics)
 }
 
 func (r *ConfigNTPResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	checkConfigImportID(req.ID, "ntp", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var data ConfigNTPResourceModel
 	if err := r.readConfig(ctx, &data); err != nil {
-		resp.Diagnostics.AddError("Error importing NTP config", err.Error())
+		addAPIError(&resp.Diagnostics, "Error importing NTP config", err)
 		return
 	}
+	data.OnDestroy = types.StringValue(onDestroyNoop)
+	snapshotConfig(ctx, r.client, "ntp", r.configValues(&data), resp.Private)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -183,6 +243,7 @@ func (r *ConfigNTPResource) readConfig(ctx context.Context, data *ConfigNTPResou
 		return err
 	}
 	data.ID = types.StringValue("ntp")
+	data.ServerValuesJSON = serverValuesJSON(config)
 	if config.IPv4 != nil {
 		data.IPv4Active = types.BoolValue(config.IPv4.Active)
 		data.IPv4Address = types.StringValue(config.IPv4.Address)
@@ -200,22 +261,25 @@ func (r *ConfigNTPResource) readConfig(ctx context.Context, data *ConfigNTPResou
 	return nil
 }
 
-func (r *ConfigNTPResource) updateConfig(ctx context.Context, data *ConfigNTPResourceModel) error {
-	cfg := map[string]interface{}{
+func (r *ConfigNTPResource) configValues(data *ConfigNTPResourceModel) map[string]interface{} {
+	return map[string]interface{}{
 		"ipv4": map[string]interface{}{
-			"active":  data.IPv4Active.ValueBool(),
-			"address": data.IPv4Address.ValueString(),
+			"active":  data.IPv4Active,
+			"address": data.IPv4Address,
 		},
 		"ipv6": map[string]interface{}{
-			"active":  data.IPv6Active.ValueBool(),
-			"address": data.IPv6Address.ValueString(),
+			"active":  data.IPv6Active,
+			"address": data.IPv6Address,
 		},
 		"sync": map[string]interface{}{
-			"active":   data.SyncActive.ValueBool(),
-			"server":   data.SyncServer.ValueString(),
-			"interval": data.SyncInterval.ValueInt64(),
-			"count":    data.SyncCount.ValueInt64(),
+			"active":   data.SyncActive,
+			"server":   data.SyncServer,
+			"interval": data.SyncInterval,
+			"count":    data.SyncCount,
 		},
 	}
-	return r.client.UpdateConfig(ctx, "ntp", cfg)
+}
+
+func (r *ConfigNTPResource) updateConfig(ctx context.Context, data *ConfigNTPResourceModel, prior map[string]interface{}) error {
+	return r.client.UpdateConfig(ctx, "ntp", configPatch(r.configValues(data), prior))
 }
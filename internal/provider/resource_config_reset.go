@@ -0,0 +1,241 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource               = &ConfigResetResource{}
+	_ resource.ResourceWithModifyPlan = &ConfigResetResource{}
+)
+
+// configSections are the config sections the API can change.
+var configSections = []string{"dns", "dhcp", "ntp", "resolver", "database", "webserver", "files", "misc", "debug"}
+
+func NewConfigResetResource() resource.Resource {
+	return &ConfigResetResource{}
+}
+
+// ConfigResetResource resets options of a config section to Pi-hole's
+// defaults when it is created. It owns nothing in Pi-hole.
+type ConfigResetResource struct {
+	client *pihole.Client
+}
+
+type ConfigResetResourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	Section   types.String `tfsdk:"section"`
+	Keys      types.Set    `tfsdk:"keys"`
+	Triggers  types.Map    `tfsdk:"triggers"`
+	ResetKeys types.List   `tfsdk:"reset_keys"`
+}
+
+func (r *ConfigResetResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_config_reset"
+}
+
+func (r *ConfigResetResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Resets options of a Pi-hole config section to their defaults.",
+		MarkdownDescription: `
+Resets options of a Pi-hole config section to their defaults when the resource
+is created, e.g. to return an instance to a known baseline before the
+` + "`pihole_config_*`" + ` resources configure it. Change ` + "`triggers`" + ` to reset again.
+
+Options set through ` + "`FTLCONF_*`" + ` environment variables cannot be changed
+through the API and are skipped, as are options already at their default.
+Destroying the resource changes nothing in Pi-hole.
+
+Resetting the whole ` + "`webserver`" + ` section leaves ` + "`port`" + `, ` + "`api.pwhash`" + ` and
+` + "`api.app_pwhash`" + ` alone, since resetting them moves the API or removes the
+passwords the provider logs in with. List them in ` + "`keys`" + ` to reset them too.
+
+## Example Usage
+
+` + "```hcl" + `
+resource "pihole_config_reset" "debug" {
+  section = "debug"
+
+  triggers = {
+    incident = var.incident_id
+  }
+}
+
+resource "pihole_config_reset" "dns_cache" {
+  section = "dns"
+  keys    = ["cache.size", "cache.optimizer"]
+}
+` + "```" + `
+`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The section that was reset.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"section": schema.StringAttribute{
+				Description: "The config section to reset, e.g. `dns`.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(configSections...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"keys": schema.SetAttribute{
+				Description: "The options to reset, as dotted paths below the section (e.g. `cache.size`). " +
+					"Default: every option in the section, except `port`, `api.pwhash` and `api.app_pwhash` of `webserver`.",
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Description: "Arbitrary values that reset the section again when they change.",
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"reset_keys": schema.ListAttribute{
+				Description: "The options that were not at their default and have been reset.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (r *ConfigResetResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData))
+		return
+	}
+	r.client = data.client
+}
+
+func (r *ConfigResetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ConfigResetResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var keys []string
+	if !data.Keys.IsNull() {
+		resp.Diagnostics.Append(data.Keys.ElementsAs(ctx, &keys, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		sort.Strings(keys)
+	}
+
+	section := data.Section.ValueString()
+	tflog.Info(ctx, "Resetting config section", map[string]interface{}{"section": section, "keys": keys})
+
+	reset, err := r.client.ResetConfigSection(ctx, section, keys)
+	if err != nil {
+		addAPIErrorf(&resp.Diagnostics, "Error resetting config", err, "Could not reset the %s config", section)
+		return
+	}
+
+	values := make([]attr.Value, len(reset))
+	for i, key := range reset {
+		values[i] = types.StringValue(key)
+	}
+	data.ID = types.StringValue(section)
+	data.ResetKeys = types.ListValueMust(types.StringType, values)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConfigResetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// The reset happened at create time; there is nothing to refresh.
+}
+
+func (r *ConfigResetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Every configurable attribute requires replacement, so only the
+	// computed values carry over.
+	var data, state ConfigResetResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.ResetKeys = state.ResetKeys
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConfigResetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Nothing to delete; the reset options keep their defaults.
+}
+
+// ModifyPlan checks that the keys to reset are options of the section, so a
+// typo fails the plan instead of the apply.
+func (r *ConfigResetResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var data ConfigResetResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if data.Section.IsUnknown() || data.Keys.IsNull() || data.Keys.IsUnknown() {
+		return
+	}
+
+	var keys []types.String
+	resp.Diagnostics.Append(data.Keys.ElementsAs(ctx, &keys, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	defaults, err := r.client.GetConfigDefaults(ctx, data.Section.ValueString())
+	if err != nil {
+		tflog.Debug(ctx, "Skipping config reset key validation", map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	for _, key := range keys {
+		if key.IsUnknown() {
+			continue
+		}
+		if _, ok := defaults[key.ValueString()]; !ok {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("keys"),
+				"Unknown config option",
+				fmt.Sprintf("The %s config has no option %q.", data.Section.ValueString(), key.ValueString()),
+			)
+		}
+	}
+}
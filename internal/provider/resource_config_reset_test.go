@@ -0,0 +1,42 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccResourceConfigReset_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "pihole_config_reset" "test" {
+  section = "debug"
+  keys    = ["api", "queries"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_config_reset.test", "id", "debug"),
+					resource.TestCheckResourceAttr("pihole_config_reset.test", "keys.#", "2"),
+					resource.TestCheckResourceAttrSet("pihole_config_reset.test", "reset_keys.#"),
+				),
+			},
+			{
+				Config: `
+resource "pihole_config_reset" "test" {
+  section = "debug"
+  keys    = ["no_such_option"]
+}
+`,
+				ExpectError: regexp.MustCompile(`Unknown config option`),
+			},
+		},
+	})
+}
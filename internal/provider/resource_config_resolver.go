@@ -7,18 +7,18 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/dklesev/terraform-provider-pihole/internal/client"
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 var (
 	_ resource.Resource                = &ConfigResolverResource{}
 	_ resource.ResourceWithImportState = &ConfigResolverResource{}
+	_ resource.ResourceWithModifyPlan  = &ConfigResolverResource{}
 )
 
 func NewConfigResolverResource() resource.Resource {
@@ -26,15 +26,17 @@ func NewConfigResolverResource() resource.Resource {
 }
 
 type ConfigResolverResource struct {
-	client *client.Client
+	client *pihole.Client
 }
 
 type ConfigResolverResourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	ResolveIPv4  types.Bool   `tfsdk:"resolve_ipv4"`
-	ResolveIPv6  types.Bool   `tfsdk:"resolve_ipv6"`
-	NetworkNames types.Bool   `tfsdk:"network_names"`
-	RefreshNames types.String `tfsdk:"refresh_names"`
+	ID               types.String `tfsdk:"id"`
+	ServerValuesJSON types.String `tfsdk:"server_values_json"`
+	OnDestroy        types.String `tfsdk:"on_destroy"`
+	ResolveIPv4      types.Bool   `tfsdk:"resolve_ipv4"`
+	ResolveIPv6      types.Bool   `tfsdk:"resolve_ipv6"`
+	NetworkNames     types.Bool   `tfsdk:"network_names"`
+	RefreshNames     types.String `tfsdk:"refresh_names"`
 }
 
 func (r *ConfigResolverResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -44,10 +46,37 @@ func (r *ConfigResolverResource) Metadata(ctx context.Context, req resource.Meta
 func (r *ConfigResolverResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages Pi-hole resolver configuration.",
+		MarkdownDescription: `
+Manages how Pi-hole finds the host names of its clients, which the query
+log, the network table and the dashboard show instead of bare addresses.
+Names are looked up with reverse DNS queries for the enabled address families
+and refreshed according to ` + "`refresh_names`" + `.
+
+## Example Usage
+
+` + "```hcl" + `
+resource "pihole_config_resolver" "settings" {
+  resolve_ipv4  = true
+  resolve_ipv6  = false
+  network_names = true
+  refresh_names = "IPV4_ONLY"
+}
+` + "```" + `
+
+## Import
+
+The resolver configuration can be imported using the section name, ` + "`resolver`" + `:
+
+` + "```shell" + `
+terraform import pihole_config_resolver.settings resolver
+` + "```" + `
+`,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
 			},
+			"server_values_json": serverValuesAttribute(),
+			"on_destroy":         onDestroyAttribute(),
 			"resolve_ipv4": schema.BoolAttribute{
 				Description: "Resolve IPv4 addresses.",
 				Optional:    true,
@@ -80,12 +109,12 @@ func (r *ConfigResolverResource) Configure(ctx context.Context, req resource.Con
 	if req.ProviderData == nil {
 		return
 	}
-	c, ok := req.ProviderData.(*client.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
-		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *client.Client, got: %T.", req.ProviderData))
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData))
 		return
 	}
-	r.client = c
+	r.client = data.client
 }
 
 func (r *ConfigResolverResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -94,12 +123,14 @@ func (r *ConfigResolverResource) Create(ctx context.Context, req resource.Create
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	if err := r.updateConfig(ctx, &data); err != nil {
-		resp.Diagnostics.AddError("Error updating resolver config", err.Error())
+	snapshotConfig(ctx, r.client, "resolver", r.configValues(&data), resp.Private)
+
+	if err := r.updateConfig(ctx, &data, nil); err != nil {
+		addAPIError(&resp.Diagnostics, "Error updating resolver config", err)
 		return
 	}
 	if err := r.readConfig(ctx, &data); err != nil {
-		resp.Diagnostics.AddError("Error reading resolver config", err.Error())
+		addAPIError(&resp.Diagnostics, "Error reading resolver config", err)
 		return
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -112,39 +143,66 @@ func (r *ConfigResolverResource) Read(ctx context.Context, req resource.ReadRequ
 		return
 	}
 	if err := r.readConfig(ctx, &data); err != nil {
-		resp.Diagnostics.AddError("Error reading resolver config", err.Error())
+		addAPIError(&resp.Diagnostics, "Error reading resolver config", err)
 		return
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *ConfigResolverResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data ConfigResolverResourceModel
+	var data, state ConfigResolverResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	if err := r.updateConfig(ctx, &data); err != nil {
-		resp.Diagnostics.AddError("Error updating resolver config", err.Error())
+	if err := r.updateConfig(ctx, &data, r.configValues(&state)); err != nil {
+		addAPIError(&resp.Diagnostics, "Error updating resolver config", err)
 		return
 	}
 	if err := r.readConfig(ctx, &data); err != nil {
-		resp.Diagnostics.AddError("Error reading resolver config", err.Error())
+		addAPIError(&resp.Diagnostics, "Error reading resolver config", err)
 		return
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *ConfigResolverResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	tflog.Debug(ctx, "Removing resolver config from state")
+	var data ConfigResolverResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	destroyConfig(ctx, r.client, "resolver", data.OnDestroy, r.configValues(&data), req.Private, &resp.Diagnostics)
+}
+
+// ModifyPlan checks the planned values against the options Pi-hole reports.
+func (r *ConfigResolverResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var data ConfigResolverResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	validateConfigValues(ctx, r.client, "resolver", r.configValues(&data), &resp.Diagnostics)
 }
 
 func (r *ConfigResolverResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	checkConfigImportID(req.ID, "resolver", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var data ConfigResolverResourceModel
 	if err := r.readConfig(ctx, &data); err != nil {
-		resp.Diagnostics.AddError("Error importing resolver config", err.Error())
+		addAPIError(&resp.Diagnostics, "Error importing resolver config", err)
 		return
 	}
+	data.OnDestroy = types.StringValue(onDestroyNoop)
+	snapshotConfig(ctx, r.client, "resolver", r.configValues(&data), resp.Private)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -154,6 +212,7 @@ func (r *ConfigResolverResource) readConfig(ctx context.Context, data *ConfigRes
 		return err
 	}
 	data.ID = types.StringValue("resolver")
+	data.ServerValuesJSON = serverValuesJSON(config)
 	data.ResolveIPv4 = types.BoolValue(config.ResolveIPv4)
 	data.ResolveIPv6 = types.BoolValue(config.ResolveIPv6)
 	data.NetworkNames = types.BoolValue(config.NetworkNames)
@@ -161,12 +220,15 @@ func (r *ConfigResolverResource) readConfig(ctx context.Context, data *ConfigRes
 	return nil
 }
 
-func (r *ConfigResolverResource) updateConfig(ctx context.Context, data *ConfigResolverResourceModel) error {
-	cfg := map[string]interface{}{
-		"resolveIPv4":  data.ResolveIPv4.ValueBool(),
-		"resolveIPv6":  data.ResolveIPv6.ValueBool(),
-		"networkNames": data.NetworkNames.ValueBool(),
-		"refreshNames": data.RefreshNames.ValueString(),
+func (r *ConfigResolverResource) configValues(data *ConfigResolverResourceModel) map[string]interface{} {
+	return map[string]interface{}{
+		"resolveIPv4":  data.ResolveIPv4,
+		"resolveIPv6":  data.ResolveIPv6,
+		"networkNames": data.NetworkNames,
+		"refreshNames": data.RefreshNames,
 	}
-	return r.client.UpdateConfig(ctx, "resolver", cfg)
+}
+
+func (r *ConfigResolverResource) updateConfig(ctx context.Context, data *ConfigResolverResourceModel, prior map[string]interface{}) error {
+	return r.client.UpdateConfig(ctx, "resolver", configPatch(r.configValues(data), prior))
 }
@@ -6,20 +6,33 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
 
-	"github.com/dklesev/terraform-provider-pihole/internal/client"
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/float64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 var (
 	_ resource.Resource                = &ConfigWebserverResource{}
 	_ resource.ResourceWithImportState = &ConfigWebserverResource{}
+	_ resource.ResourceWithModifyPlan  = &ConfigWebserverResource{}
 )
 
 func NewConfigWebserverResource() resource.Resource {
@@ -27,19 +40,27 @@ func NewConfigWebserverResource() resource.Resource {
 }
 
 type ConfigWebserverResource struct {
-	client *client.Client
+	client *pihole.Client
 }
 
 type ConfigWebserverResourceModel struct {
-	ID             types.String `tfsdk:"id"`
-	Domain         types.String `tfsdk:"domain"`
-	Port           types.String `tfsdk:"port"`
-	Threads        types.Int64  `tfsdk:"threads"`
-	ServeAll       types.Bool   `tfsdk:"serve_all"`
-	SessionTimeout types.Int64  `tfsdk:"session_timeout"`
-	SessionRestore types.Bool   `tfsdk:"session_restore"`
-	InterfaceBoxed types.Bool   `tfsdk:"interface_boxed"`
-	InterfaceTheme types.String `tfsdk:"interface_theme"`
+	ID               types.String  `tfsdk:"id"`
+	ServerValuesJSON types.String  `tfsdk:"server_values_json"`
+	OnDestroy        types.String  `tfsdk:"on_destroy"`
+	Domain           types.String  `tfsdk:"domain"`
+	Port             types.String  `tfsdk:"port"`
+	Threads          types.Int64   `tfsdk:"threads"`
+	ServeAll         types.Bool    `tfsdk:"serve_all"`
+	SessionTimeout   types.Int64   `tfsdk:"session_timeout"`
+	SessionRestore   types.Bool    `tfsdk:"session_restore"`
+	InterfaceBoxed   types.Bool    `tfsdk:"interface_boxed"`
+	InterfaceTheme   types.String  `tfsdk:"interface_theme"`
+	TLSCert          types.String  `tfsdk:"tls_cert"`
+	PathsWebroot     types.String  `tfsdk:"paths_webroot"`
+	PathsWebhome     types.String  `tfsdk:"paths_webhome"`
+	PathsPrefix      types.String  `tfsdk:"paths_prefix"`
+	TempLimit        types.Float64 `tfsdk:"temp_limit"`
+	TempUnit         types.String  `tfsdk:"temp_unit"`
 }
 
 func (r *ConfigWebserverResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -49,10 +70,74 @@ func (r *ConfigWebserverResource) Metadata(ctx context.Context, req resource.Met
 func (r *ConfigWebserverResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages Pi-hole webserver configuration.",
+		MarkdownDescription: `
+Manages the webserver of Pi-hole, which serves the web interface and the API:
+its domain, ports and threads, login sessions, the paths of the interface, and
+its look, including how it shows the host temperature.
+
+~> **Note:** The provider talks to Pi-hole through this webserver. When a new
+` + "`port`" + ` no longer serves the provider ` + "`url`" + `, the plan warns, the port is changed
+after the other options, and the apply reports the URL to configure. Pi-hole
+resources applied after it in the same run fail until the provider ` + "`url`" + ` is
+updated, so apply such a change on its own, e.g. with ` + "`-target`" + `.
+
+## Example Usage
+
+` + "```hcl" + `
+resource "pihole_config_webserver" "settings" {
+  domain          = "pihole.example.com"
+  session_timeout = 3600
+  interface_theme = "default-dark"
+  temp_unit       = "F"
+  temp_limit      = 140
+}
+` + "```" + `
+
+## TLS Certificates
+
+Pi-hole serves the certificate and private key in the PEM file at ` + "`tls_cert`" + `
+on its host. The API has no endpoint to upload certificate content, so certificates
+renewed by Terraform or ACME clients must be written to that file by the same
+pipeline, e.g. over SSH; setting ` + "`tls_cert`" + ` to a new path restarts the
+webserver with the file found there.
+
+` + "```hcl" + `
+resource "pihole_config_webserver" "settings" {
+  tls_cert = "/etc/pihole/certs/pihole.example.com.pem"
+}
+` + "```" + `
+
+## Reverse Proxies
+
+Pi-hole serves the web interface at ` + "`paths_webhome`" + ` below its document root,
+` + "`paths_webroot`" + `. Behind a reverse proxy that publishes Pi-hole under a path,
+set ` + "`paths_prefix`" + ` to that path so the links and redirects of the interface
+include it. The prefix only changes the URLs Pi-hole generates: Pi-hole still
+serves the API at ` + "`/api`" + `, so the provider ` + "`url`" + ` keeps pointing at Pi-hole itself,
+not at the proxy path.
+
+` + "```hcl" + `
+# Published by the proxy at https://proxy.example.com/pihole/admin/
+resource "pihole_config_webserver" "settings" {
+  paths_prefix  = "/pihole"
+  paths_webhome = "/admin/"
+}
+` + "```" + `
+
+## Import
+
+The webserver configuration can be imported using the section name, ` + "`webserver`" + `:
+
+` + "```shell" + `
+terraform import pihole_config_webserver.settings webserver
+` + "```" + `
+`,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
 			},
+			"server_values_json": serverValuesAttribute(),
+			"on_destroy":         onDestroyAttribute(),
 			"domain": schema.StringAttribute{
 				Description: "Webserver domain.",
 				Optional:    true,
@@ -60,7 +145,7 @@ func (r *ConfigWebserverResource) Schema(ctx context.Context, req resource.Schem
 				Default:     stringdefault.StaticString("pi.hole"),
 			},
 			"port": schema.StringAttribute{
-				Description: "Webserver port configuration.",
+				Description: "Webserver port configuration: comma-separated ports, each with an optional address and the flags `s` (TLS), `r` (redirect to HTTPS) and `o` (optional).",
 				Optional:    true,
 				Computed:    true,
 				Default:     stringdefault.StaticString("80o,443os,[::]:80o,[::]:443os"),
@@ -101,6 +186,57 @@ func (r *ConfigWebserverResource) Schema(ctx context.Context, req resource.Schem
 				Computed:    true,
 				Default:     stringdefault.StaticString("default-auto"),
 			},
+			"tls_cert": schema.StringAttribute{
+				Description: "Path of the PEM file, on the Pi-hole host, holding the certificate and private key served over TLS. " +
+					"Pi-hole creates a self-signed certificate there when the file does not exist. Default: the path Pi-hole already uses.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"paths_webroot": schema.StringAttribute{
+				Description: "Document root of the webserver on the Pi-hole host.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("/var/www/html"),
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^/`), "must be an absolute path"),
+				},
+			},
+			"paths_webhome": schema.StringAttribute{
+				Description: "Path of the web interface below the document root, starting and ending with a slash, e.g. `/admin/`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("/admin/"),
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^/(.*/)?$`), "must start and end with a slash"),
+				},
+			},
+			"paths_prefix": schema.StringAttribute{
+				Description: "Path a reverse proxy publishes Pi-hole under, e.g. `/pihole`, prepended to the links and redirects of the web interface. Empty when Pi-hole is not behind such a proxy.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^(/.*[^/])?$`), "must be empty or start with a slash and not end with one"),
+				},
+			},
+			"temp_limit": schema.Float64Attribute{
+				Description: "Host temperature above which the web interface warns, in `temp_unit`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     float64default.StaticFloat64(60),
+			},
+			"temp_unit": schema.StringAttribute{
+				Description: "Unit the web interface shows the host temperature in: `C` (Celsius), `F` (Fahrenheit) or `K` (Kelvin).",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("C"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("C", "F", "K"),
+				},
+			},
 		},
 	}
 }
@@ -109,12 +245,12 @@ func (r *ConfigWebserverResource) Configure(ctx context.Context, req resource.Co
 	if req.ProviderData == nil {
 		return
 	}
-	c, ok := req.ProviderData.(*client.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
-		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *client.Client, got: %T.", req.ProviderData))
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData))
 		return
 	}
-	r.client = c
+	r.client = data.client
 }
 
 func (r *ConfigWebserverResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -123,12 +259,19 @@ func (r *ConfigWebserverResource) Create(ctx context.Context, req resource.Creat
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	if err := r.updateConfig(ctx, &data); err != nil {
-		resp.Diagnostics.AddError("Error updating webserver config", err.Error())
+	snapshotConfig(ctx, r.client, "webserver", r.configValues(&data), resp.Private)
+
+	moved, err := r.updateConfig(ctx, &data, nil)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error updating webserver config", err)
+		return
+	}
+	if moved {
+		r.setMovedState(ctx, &data, &resp.State, &resp.Diagnostics)
 		return
 	}
 	if err := r.readConfig(ctx, &data); err != nil {
-		resp.Diagnostics.AddError("Error reading webserver config", err.Error())
+		addAPIError(&resp.Diagnostics, "Error reading webserver config", err)
 		return
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -141,39 +284,91 @@ func (r *ConfigWebserverResource) Read(ctx context.Context, req resource.ReadReq
 		return
 	}
 	if err := r.readConfig(ctx, &data); err != nil {
-		resp.Diagnostics.AddError("Error reading webserver config", err.Error())
+		addAPIError(&resp.Diagnostics, "Error reading webserver config", err)
 		return
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *ConfigWebserverResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data ConfigWebserverResourceModel
+	var data, state ConfigWebserverResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	if err := r.updateConfig(ctx, &data); err != nil {
-		resp.Diagnostics.AddError("Error updating webserver config", err.Error())
+	moved, err := r.updateConfig(ctx, &data, r.configValues(&state))
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error updating webserver config", err)
+		return
+	}
+	if moved {
+		r.setMovedState(ctx, &data, &resp.State, &resp.Diagnostics)
 		return
 	}
 	if err := r.readConfig(ctx, &data); err != nil {
-		resp.Diagnostics.AddError("Error reading webserver config", err.Error())
+		addAPIError(&resp.Diagnostics, "Error reading webserver config", err)
 		return
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *ConfigWebserverResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	tflog.Debug(ctx, "Removing webserver config from state")
+	var data ConfigWebserverResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	destroyConfig(ctx, r.client, "webserver", data.OnDestroy, r.configValues(&data), req.Private, &resp.Diagnostics)
+}
+
+// ModifyPlan checks the planned values against the options Pi-hole reports
+// and warns when the new ports no longer serve the provider URL.
+func (r *ConfigWebserverResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var data ConfigWebserverResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	validateConfigValues(ctx, r.client, "webserver", r.configValues(&data), &resp.Diagnostics)
+
+	if r.client == nil || data.Port.IsNull() || data.Port.IsUnknown() {
+		return
+	}
+	var prior types.String
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("port"), &prior)...)
+	}
+	if prior.Equal(data.Port) || webserverServesURL(data.Port.ValueString(), r.client.URL()) {
+		return
+	}
+	resp.Diagnostics.AddAttributeWarning(
+		path.Root("port"),
+		"Webserver port change disconnects the provider",
+		fmt.Sprintf("With port = %q, Pi-hole no longer serves the provider URL %s. ", data.Port.ValueString(), r.client.URL())+
+			"The port is changed after the other webserver options, but Pi-hole resources applied after this one fail, "+
+			"as does the next plan, until the provider url is updated"+webserverURLHint(data.Port.ValueString(), r.client.URL())+
+			". Apply this change on its own, e.g. with -target.",
+	)
 }
 
 func (r *ConfigWebserverResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	checkConfigImportID(req.ID, "webserver", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var data ConfigWebserverResourceModel
 	if err := r.readConfig(ctx, &data); err != nil {
-		resp.Diagnostics.AddError("Error importing webserver config", err.Error())
+		addAPIError(&resp.Diagnostics, "Error importing webserver config", err)
 		return
 	}
+	data.OnDestroy = types.StringValue(onDestroyNoop)
+	snapshotConfig(ctx, r.client, "webserver", r.configValues(&data), resp.Private)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -183,6 +378,7 @@ func (r *ConfigWebserverResource) readConfig(ctx context.Context, data *ConfigWe
 		return err
 	}
 	data.ID = types.StringValue("webserver")
+	data.ServerValuesJSON = serverValuesJSON(config)
 	data.Domain = types.StringValue(config.Domain)
 	data.Port = types.StringValue(config.Port)
 	data.Threads = types.Int64Value(int64(config.Threads))
@@ -195,23 +391,189 @@ func (r *ConfigWebserverResource) readConfig(ctx context.Context, data *ConfigWe
 		data.InterfaceBoxed = types.BoolValue(config.Interface.Boxed)
 		data.InterfaceTheme = types.StringValue(config.Interface.Theme)
 	}
+	if config.TLS != nil {
+		data.TLSCert = types.StringValue(config.TLS.Cert)
+	}
+	if config.Paths != nil {
+		data.PathsWebroot = types.StringValue(config.Paths.Webroot)
+		data.PathsWebhome = types.StringValue(config.Paths.Webhome)
+		data.PathsPrefix = types.StringValue(config.Paths.Prefix)
+	}
+	if config.API != nil && config.API.Temp != nil {
+		data.TempLimit = types.Float64Value(config.API.Temp.Limit)
+		data.TempUnit = types.StringValue(config.API.Temp.Unit)
+	}
 	return nil
 }
 
-func (r *ConfigWebserverResource) updateConfig(ctx context.Context, data *ConfigWebserverResourceModel) error {
-	cfg := map[string]interface{}{
-		"domain":    data.Domain.ValueString(),
-		"port":      data.Port.ValueString(),
-		"threads":   data.Threads.ValueInt64(),
-		"serve_all": data.ServeAll.ValueBool(),
+func (r *ConfigWebserverResource) configValues(data *ConfigWebserverResourceModel) map[string]interface{} {
+	return map[string]interface{}{
+		"domain":    data.Domain,
+		"port":      data.Port,
+		"threads":   data.Threads,
+		"serve_all": data.ServeAll,
 		"session": map[string]interface{}{
-			"timeout": data.SessionTimeout.ValueInt64(),
-			"restore": data.SessionRestore.ValueBool(),
+			"timeout": data.SessionTimeout,
+			"restore": data.SessionRestore,
 		},
 		"interface": map[string]interface{}{
-			"boxed": data.InterfaceBoxed.ValueBool(),
-			"theme": data.InterfaceTheme.ValueString(),
+			"boxed": data.InterfaceBoxed,
+			"theme": data.InterfaceTheme,
+		},
+		"tls": map[string]interface{}{
+			"cert": data.TLSCert,
 		},
+		"paths": map[string]interface{}{
+			"webroot": data.PathsWebroot,
+			"webhome": data.PathsWebhome,
+			"prefix":  data.PathsPrefix,
+		},
+		"api": map[string]interface{}{
+			"temp": map[string]interface{}{
+				"limit": data.TempLimit,
+				"unit":  data.TempUnit,
+			},
+		},
+	}
+}
+
+// updateConfig patches the options that differ from prior. A port change
+// that stops Pi-hole serving the provider URL is sent last, on its own, and
+// moved reports that the API is no longer reachable.
+func (r *ConfigWebserverResource) updateConfig(ctx context.Context, data *ConfigWebserverResourceModel, prior map[string]interface{}) (moved bool, err error) {
+	values := configPatch(r.configValues(data), prior)
+	port, ok := values["port"]
+	if !ok || webserverServesURL(data.Port.ValueString(), r.client.URL()) {
+		return false, r.client.UpdateConfig(ctx, "webserver", values)
+	}
+
+	delete(values, "port")
+	if err := r.client.UpdateConfig(ctx, "webserver", values); err != nil {
+		return false, err
+	}
+	// FTL restarts on the new port, so waiting for the old URL to answer
+	// would only time out.
+	err = r.client.UpdateConfig(pihole.WithoutRestartWait(ctx), "webserver", map[string]interface{}{"port": port})
+	return err == nil, err
+}
+
+// setMovedState saves the planned values after a port change made the API
+// unreachable, and tells the user which URL to configure.
+func (r *ConfigWebserverResource) setMovedState(ctx context.Context, data *ConfigWebserverResourceModel, state *tfsdk.State, diags *diag.Diagnostics) {
+	data.ID = types.StringValue("webserver")
+	if data.ServerValuesJSON.IsUnknown() {
+		data.ServerValuesJSON = types.StringNull()
+	}
+	diags.Append(state.Set(ctx, data)...)
+
+	diags.AddAttributeWarning(
+		path.Root("port"),
+		"Provider URL no longer reachable",
+		fmt.Sprintf("Pi-hole now listens on %q and no longer serves %s. ", data.Port.ValueString(), r.client.URL())+
+			"Update the provider url"+webserverURLHint(data.Port.ValueString(), r.client.URL())+
+			" before the next plan; Pi-hole resources applied after this one in the current run fail.",
+	)
+}
+
+// webserverListener is one entry of the webserver.port setting, e.g.
+// "[::]:443os".
+type webserverListener struct {
+	Port     int
+	TLS      bool
+	Redirect bool
+}
+
+// parseWebserverPorts parses the webserver.port setting, skipping entries it
+// cannot read.
+func parseWebserverPorts(ports string) []webserverListener {
+	var listeners []webserverListener
+	for _, entry := range strings.Split(ports, ",") {
+		entry = strings.TrimSpace(entry)
+		address := strings.TrimRight(entry, "abcdefghijklmnopqrstuvwxyz")
+		flags := entry[len(address):]
+		entry = address
+		if i := strings.LastIndex(entry, ":"); i >= 0 {
+			entry = entry[i+1:]
+		}
+		port, err := strconv.Atoi(strings.TrimPrefix(entry, "+"))
+		if err != nil || port < 1 || port > 65535 {
+			continue
+		}
+		listeners = append(listeners, webserverListener{
+			Port:     port,
+			TLS:      strings.Contains(flags, "s"),
+			Redirect: strings.Contains(flags, "r"),
+		})
+	}
+	return listeners
+}
+
+// urlPort returns the port of u, or the default port of its scheme.
+func urlPort(u *url.URL) int {
+	if port, err := strconv.Atoi(u.Port()); err == nil {
+		return port
+	}
+	if u.Scheme == "https" {
+		return 443
+	}
+	return 80
+}
+
+// webserverServesURL reports whether the webserver.port setting still serves
+// the API at u. Settings it cannot parse are assumed to.
+func webserverServesURL(ports string, u *url.URL) bool {
+	listeners := parseWebserverPorts(ports)
+	if len(listeners) == 0 {
+		return true
+	}
+	for _, l := range listeners {
+		if l.Port == urlPort(u) && l.TLS == (u.Scheme == "https") && !l.Redirect {
+			return true
+		}
+	}
+	return false
+}
+
+// webserverURL returns u moved to the first port of the webserver.port
+// setting that serves the API, preferring one with the same scheme.
+func webserverURL(ports string, u *url.URL) (string, bool) {
+	var found *webserverListener
+	listeners := parseWebserverPorts(ports)
+	for i := range listeners {
+		if listeners[i].Redirect {
+			continue
+		}
+		if listeners[i].TLS == (u.Scheme == "https") {
+			found = &listeners[i]
+			break
+		}
+		if found == nil {
+			found = &listeners[i]
+		}
+	}
+	if found == nil {
+		return "", false
+	}
+
+	moved := *u
+	moved.Scheme = "http"
+	if found.TLS {
+		moved.Scheme = "https"
+	}
+	moved.Host = u.Hostname()
+	if strings.Contains(moved.Host, ":") {
+		moved.Host = "[" + moved.Host + "]"
+	}
+	if found.Port != urlPort(&moved) {
+		moved.Host = net.JoinHostPort(u.Hostname(), strconv.Itoa(found.Port))
+	}
+	return moved.String(), true
+}
+
+// webserverURLHint formats the suggested provider URL for a diagnostic.
+func webserverURLHint(ports string, u *url.URL) string {
+	if moved, ok := webserverURL(ports, u); ok {
+		return fmt.Sprintf(" to %s", moved)
 	}
-	return r.client.UpdateConfig(ctx, "webserver", cfg)
+	return ""
 }
@@ -0,0 +1,82 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestParseWebserverPorts(t *testing.T) {
+	got := parseWebserverPorts("80o,443os,[::]:80o,[::]:443os,127.0.0.1:8080r, +8443s,bogus")
+	want := []webserverListener{
+		{Port: 80},
+		{Port: 443, TLS: true},
+		{Port: 80},
+		{Port: 443, TLS: true},
+		{Port: 8080, Redirect: true},
+		{Port: 8443, TLS: true},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseWebserverPorts() = %+v, want %+v", got, want)
+	}
+}
+
+func TestWebserverServesURL(t *testing.T) {
+	tests := []struct {
+		ports string
+		url   string
+		want  bool
+	}{
+		{"80o,443os,[::]:80o,[::]:443os", "http://pi.hole", true},
+		{"80o,443os,[::]:80o,[::]:443os", "https://pi.hole/", true},
+		{"80o,443os", "http://pi.hole:8080", false},
+		{"8080", "http://pi.hole", false},
+		{"8080", "http://pi.hole:8080", true},
+		{"80r,443s", "http://pi.hole", false},
+		{"443s", "http://pi.hole:443", false},
+		{"", "http://pi.hole", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ports+" "+tt.url, func(t *testing.T) {
+			u, err := url.Parse(tt.url)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := webserverServesURL(tt.ports, u); got != tt.want {
+				t.Errorf("webserverServesURL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWebserverURL(t *testing.T) {
+	tests := []struct {
+		ports string
+		url   string
+		want  string
+	}{
+		{"8080o,8443os", "http://pi.hole/", "http://pi.hole:8080/"},
+		{"8080o,8443os", "https://pi.hole/", "https://pi.hole:8443/"},
+		{"80r,443s", "http://pi.hole:8080/", "https://pi.hole/"},
+		{"[::]:8080", "http://[fd00::53]/admin/", "http://[fd00::53]:8080/admin/"},
+		{"80", "http://[fd00::53]:8080/", "http://[fd00::53]/"},
+		{"80r", "http://pi.hole/", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ports+" "+tt.url, func(t *testing.T) {
+			u, err := url.Parse(tt.url)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, _ := webserverURL(tt.ports, u)
+			if got != tt.want {
+				t.Errorf("webserverURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
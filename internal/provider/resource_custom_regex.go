@@ -0,0 +1,368 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dklesev/terraform-provider-pihole/internal/provider/convert"
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = &CustomRegexResource{}
+	_ resource.ResourceWithImportState = &CustomRegexResource{}
+	_ resource.ResourceWithModifyPlan  = &CustomRegexResource{}
+)
+
+func NewCustomRegexResource() resource.Resource {
+	return &CustomRegexResource{}
+}
+
+// CustomRegexResource manages a regex domain entry built from one of the
+// regexTemplates, so users need not write the regex themselves.
+type CustomRegexResource struct {
+	client   *pihole.Client
+	comments commentSettings
+}
+
+type CustomRegexResourceModel struct {
+	ID           types.Int64  `tfsdk:"id"`
+	Template     types.String `tfsdk:"template"`
+	Value        types.String `tfsdk:"value"`
+	QueryType    types.String `tfsdk:"query_type"`
+	Type         types.String `tfsdk:"type"`
+	Enabled      types.Bool   `tfsdk:"enabled"`
+	Comment      types.String `tfsdk:"comment"`
+	Groups       types.Set    `tfsdk:"groups"`
+	Regex        types.String `tfsdk:"regex"`
+	DateAdded    types.Int64  `tfsdk:"date_added"`
+	DateModified types.Int64  `tfsdk:"date_modified"`
+}
+
+func (r *CustomRegexResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_custom_regex"
+}
+
+func (r *CustomRegexResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a Pi-hole regex rule built from a template.",
+		MarkdownDescription: `
+Manages a Pi-hole regex rule built from one of the templates Pi-hole's
+documentation recommends, so common rules need no hand-written regex. The
+resource creates a ` + "`pihole_domain`" + ` entry of kind ` + "`regex`" + `; the generated
+rule is exported as ` + "`regex`" + `.
+
+- ` + "`tld`" + `: every domain in a top-level domain, e.g. ` + "`(\\.|^)xyz$`" + ` for ` + "`xyz`" + `
+- ` + "`domain`" + `: the domain and its subdomains, e.g. ` + "`(\\.|^)example\\.com$`" + `
+- ` + "`subdomains`" + `: only the subdomains, e.g. ` + "`\\.example\\.com$`" + `
+- ` + "`keyword`" + `: every domain containing the value, e.g. ` + "`example\\.com`" + `
+
+With ` + "`cname_deep_inspect`" + ` enabled in ` + "`pihole_config_dns`" + `, the rules also
+block domains whose CNAME chain leads to a matching domain.
+
+## Example Usage
+
+` + "```hcl" + `
+resource "pihole_custom_regex" "xyz" {
+  template = "tld"
+  value    = "xyz"
+}
+
+resource "pihole_custom_regex" "tracker" {
+  template = "domain"
+  value    = "tracker.example.com"
+  comment  = "Tracker and all of its subdomains"
+}
+
+# Answer only A queries for a dual-stack service with a broken IPv6 setup
+resource "pihole_custom_regex" "no_aaaa" {
+  template   = "domain"
+  value      = "legacy.example.com"
+  query_type = "AAAA"
+}
+` + "```" + `
+
+## Import
+
+Rules can be imported using the format ` + "`type/template/value`" + ` or
+` + "`type/template/value/query_type`" + `:
+
+` + "```shell" + `
+terraform import pihole_custom_regex.xyz deny/tld/xyz
+` + "```" + `
+`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Description: "The unique identifier of the domain entry in Pi-hole.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"template": schema.StringAttribute{
+				Description: "The rule template: " + regexTemplateDescription() + ".",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(regexTemplateNames()...),
+				},
+			},
+			"value": schema.StringAttribute{
+				Description: "The top-level domain, domain or keyword the template is applied to. It matches literally.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"query_type": schema.StringAttribute{
+				Description: "Only match these query types, e.g. `AAAA`, `A,AAAA`, or `!A` for all but A.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(queryTypeRegexp, "must be upper-case query types separated by commas, optionally prefixed with !"),
+				},
+			},
+			"type": schema.StringAttribute{
+				Description: "Whether the rule denies or allows the matching domains: 'allow' or 'deny'. Default: 'deny'.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("deny"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("allow", "deny"),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Description: "Whether the rule is enabled. Default: true.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"comment": schema.StringAttribute{
+				Description: "A comment describing the rule. The provider's `managed_by_tag` marker is appended in Pi-hole and hidden here.",
+				Optional:    true,
+			},
+			"groups": schema.SetAttribute{
+				Description: "List of group IDs this rule applies to. Default group ID is 0.",
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.Int64Type,
+			},
+			"regex": schema.StringAttribute{
+				Description: "The regex rule the template generates.",
+				Computed:    true,
+			},
+			"date_added": schema.Int64Attribute{
+				Description: "Unix timestamp when the rule was created.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"date_modified": schema.Int64Attribute{
+				Description: "Unix timestamp when the rule was last modified.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *CustomRegexResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData))
+		return
+	}
+	r.client = data.client
+	r.comments = data.comments
+}
+
+func (r *CustomRegexResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data CustomRegexResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domain := r.domain(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Creating custom regex", map[string]interface{}{"regex": domain.Domain, "type": domain.Type})
+
+	created, err := r.client.CreateDomain(ctx, domain)
+	if err != nil {
+		addAPIErrorf(&resp.Diagnostics, "Error creating custom regex", err, "Could not create regex %s", domain.Domain)
+		return
+	}
+
+	r.mapDomainToModel(ctx, created, &data, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CustomRegexResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data CustomRegexResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domain, err := r.client.GetDomain(ctx, data.Type.ValueString(), "regex", data.Regex.ValueString())
+	if err != nil && !errors.Is(err, pihole.ErrNotFound) {
+		addAPIErrorf(&resp.Diagnostics, "Error reading custom regex", err, "Could not read regex %s", data.Regex.ValueString())
+		return
+	}
+	if domain == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	r.mapDomainToModel(ctx, domain, &data, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CustomRegexResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state CustomRegexResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domain := r.domain(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updated, err := r.client.UpdateDomain(ctx, state.Type.ValueString(), "regex", state.Regex.ValueString(), domain)
+	if err != nil {
+		addAPIErrorf(&resp.Diagnostics, "Error updating custom regex", err, "Could not update regex %s", state.Regex.ValueString())
+		return
+	}
+
+	r.mapDomainToModel(ctx, updated, &data, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CustomRegexResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data CustomRegexResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.DeleteDomain(ctx, data.Type.ValueString(), "regex", data.Regex.ValueString()); err != nil && !errors.Is(err, pihole.ErrNotFound) {
+		addAPIErrorf(&resp.Diagnostics, "Error deleting custom regex", err, "Could not delete regex %s", data.Regex.ValueString())
+		return
+	}
+}
+
+// ModifyPlan shows the generated regex in the plan, rejects values the
+// template cannot use and warns before destroys that Pi-hole may refuse.
+func (r *CustomRegexResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	warnDestructiveDisabled(r.client, req, resp)
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var data CustomRegexResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if data.Template.IsUnknown() || data.Value.IsUnknown() || data.QueryType.IsUnknown() {
+		return
+	}
+
+	rule, err := buildRegexRule(data.Template.ValueString(), data.Value.ValueString(), data.QueryType.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("value"), "Invalid custom regex", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("regex"), rule)...)
+}
+
+func (r *CustomRegexResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import format: type/template/value or type/template/value/query_type
+	parts := strings.SplitN(req.ID, "/", 4)
+	if len(parts) < 3 {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			"Import ID must be in the format: type/template/value[/query_type] (e.g., deny/tld/xyz)",
+		)
+		return
+	}
+
+	data := CustomRegexResourceModel{
+		Type:     types.StringValue(parts[0]),
+		Template: types.StringValue(parts[1]),
+		Value:    types.StringValue(parts[2]),
+		Groups:   types.SetNull(types.Int64Type),
+	}
+	queryType := ""
+	if len(parts) == 4 {
+		queryType = parts[3]
+		data.QueryType = types.StringValue(queryType)
+	}
+
+	rule, err := buildRegexRule(parts[1], parts[2], queryType)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+	data.Regex = types.StringValue(rule)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// domain returns the domain entry described by the planned model.
+func (r *CustomRegexResource) domain(ctx context.Context, data *CustomRegexResourceModel, diags *diag.Diagnostics) *pihole.Domain {
+	rule, err := buildRegexRule(data.Template.ValueString(), data.Value.ValueString(), data.QueryType.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("value"), "Invalid custom regex", err.Error())
+		return nil
+	}
+
+	groups, d := convert.Int64s(ctx, data.Groups)
+	diags.Append(d...)
+
+	return &pihole.Domain{
+		Domain:  rule,
+		Type:    data.Type.ValueString(),
+		Kind:    "regex",
+		Enabled: data.Enabled.ValueBool(),
+		Comment: r.comments.managedBy("pihole_custom_regex", data.Comment.ValueString()),
+		Groups:  groups,
+	}
+}
+
+func (r *CustomRegexResource) mapDomainToModel(ctx context.Context, domain *pihole.Domain, data *CustomRegexResourceModel, diags *diag.Diagnostics) {
+	data.ID = types.Int64Value(domain.ID)
+	data.Regex = types.StringValue(domain.Domain)
+	data.Type = types.StringValue(domain.Type)
+	data.Enabled = types.BoolValue(domain.Enabled)
+	data.Comment = convert.OptionalString(r.comments.unmanaged(domain.Comment))
+
+	groups, d := convert.GroupSet(ctx, domain.Groups)
+	diags.Append(d...)
+	data.Groups = groups
+
+	data.DateAdded = types.Int64Value(domain.DateAdded)
+	data.DateModified = types.Int64Value(domain.DateModified)
+}
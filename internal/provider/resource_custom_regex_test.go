@@ -0,0 +1,60 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccResourceCustomRegex_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create
+			{
+				Config: testAccResourceCustomRegexConfig("tld", "tf-acc-zone", ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_custom_regex.test", "regex", `(\.|^)tf-acc-zone$`),
+					resource.TestCheckResourceAttr("pihole_custom_regex.test", "type", "deny"),
+					resource.TestCheckResourceAttr("pihole_custom_regex.test", "enabled", "true"),
+					resource.TestCheckResourceAttrSet("pihole_custom_regex.test", "id"),
+				),
+			},
+			// Import
+			{
+				ResourceName:      "pihole_custom_regex.test",
+				ImportState:       true,
+				ImportStateId:     "deny/tld/tf-acc-zone",
+				ImportStateVerify: true,
+			},
+			// Update the rule in place
+			{
+				Config: testAccResourceCustomRegexConfig("domain", "tf-acc-tracker.tf-acc-zone.example.com", "AAAA"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_custom_regex.test", "regex", `(\.|^)tf-acc-tracker\.tf-acc-zone\.example\.com$;querytype=AAAA`),
+					resource.TestCheckResourceAttr("pihole_custom_regex.test", "query_type", "AAAA"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceCustomRegexConfig(template, value, queryType string) string {
+	queryTypeLine := ""
+	if queryType != "" {
+		queryTypeLine = fmt.Sprintf("query_type = %q", queryType)
+	}
+	return fmt.Sprintf(`
+resource "pihole_custom_regex" "test" {
+  template = %q
+  value    = %q
+  comment  = "Acceptance test"
+  %s
+}
+`, template, value, queryTypeLine)
+}
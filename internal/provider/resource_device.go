@@ -0,0 +1,371 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/dklesev/terraform-provider-pihole/internal/provider/convert"
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = &DeviceResource{}
+	_ resource.ResourceWithImportState = &DeviceResource{}
+	_ resource.ResourceWithModifyPlan  = &DeviceResource{}
+)
+
+func NewDeviceResource() resource.Resource {
+	return &DeviceResource{}
+}
+
+// DeviceResource manages one Pi-hole client entry per identifier of a
+// device, keeping their comment and groups in sync.
+type DeviceResource struct {
+	client   *pihole.Client
+	comments commentSettings
+}
+
+type DeviceResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Identifiers types.Set    `tfsdk:"identifiers"`
+	Comment     types.String `tfsdk:"comment"`
+	Groups      types.List   `tfsdk:"groups"`
+	ClientIDs   types.Map    `tfsdk:"client_ids"`
+}
+
+func (r *DeviceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_device"
+}
+
+func (r *DeviceResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a device with several client identifiers as one set of Pi-hole clients.",
+		MarkdownDescription: `
+Manages a device with several client identifiers, such as the wired and Wi-Fi
+MAC addresses of a laptop, as one logical device. Every identifier becomes its
+own Pi-hole client entry, and all of them share the same comment and groups.
+Adding or removing an identifier creates or deletes only that entry.
+
+Do not also manage the same identifiers with ` + "`pihole_client`" + `.
+
+## Example Usage
+
+` + "```hcl" + `
+resource "pihole_device" "laptop" {
+  identifiers = ["AA:BB:CC:DD:EE:01", "AA:BB:CC:DD:EE:02"]
+  groups      = [pihole_group.trusted.id]
+  comment     = "Work laptop"
+}
+` + "```" + `
+`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The sorted identifiers, joined by commas.",
+				Computed:    true,
+			},
+			"identifiers": schema.SetAttribute{
+				Description: "The client identifiers of the device (IP, MAC, hostname, CIDR subnet, or interface prefixed with ':').",
+				Required:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+			"comment": schema.StringAttribute{
+				Description: "A comment describing the device, set on every client entry. The provider's `managed_by_tag` marker is appended in Pi-hole and hidden here.",
+				Optional:    true,
+			},
+			"groups": schema.ListAttribute{
+				Description: "List of group IDs the device belongs to. Default group ID is 0.",
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.Int64Type,
+			},
+			"client_ids": schema.MapAttribute{
+				Description: "The Pi-hole client ID of each identifier.",
+				Computed:    true,
+				ElementType: types.Int64Type,
+			},
+		},
+	}
+}
+
+func (r *DeviceResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.client
+	r.comments = data.comments
+}
+
+func (r *DeviceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data DeviceResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	identifiers, groups := r.planned(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Creating device", map[string]interface{}{
+		"identifiers": identifiers,
+	})
+
+	var entries []pihole.PiholeClient
+	for _, identifier := range identifiers {
+		created, err := r.client.CreateClient(ctx, &pihole.PiholeClient{
+			Client:  identifier,
+			Comment: r.comments.managedBy("pihole_device", data.Comment.ValueString()),
+			Groups:  groups,
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error creating device",
+				fmt.Sprintf("Could not create client %s: %s", identifier, r.rollback(ctx, entries, err)),
+			)
+			return
+		}
+		entries = append(entries, *created)
+	}
+
+	r.mapClientsToModel(ctx, entries, &data, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// rollback deletes the entries created before a failed create so they are
+// not left behind untracked, and returns err with any rollback failure.
+func (r *DeviceResource) rollback(ctx context.Context, entries []pihole.PiholeClient, err error) error {
+	identifiers := make([]string, len(entries))
+	for i, entry := range entries {
+		identifiers[i] = entry.Client
+	}
+	if rollbackErr := r.client.DeleteClients(ctx, identifiers); rollbackErr != nil {
+		return errors.Join(err, fmt.Errorf("removing the clients created so far (%s): %w", strings.Join(identifiers, ", "), rollbackErr))
+	}
+	return err
+}
+
+func (r *DeviceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data DeviceResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var identifiers []string
+	resp.Diagnostics.Append(data.Identifiers.ElementsAs(ctx, &identifiers, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	entries, err := r.findClients(ctx, identifiers)
+	if err != nil {
+		addAPIErrorf(&resp.Diagnostics, "Error reading device", err, "Could not read clients %s", strings.Join(identifiers, ", "))
+		return
+	}
+
+	// Identifiers deleted outside Terraform drop out of state and are
+	// recreated on the next apply.
+	if len(entries) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	r.mapClientsToModel(ctx, entries, &data, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DeviceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data DeviceResourceModel
+	var state DeviceResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	identifiers, groups := r.planned(ctx, &data, &resp.Diagnostics)
+	var previous []string
+	resp.Diagnostics.Append(state.Identifiers.ElementsAs(ctx, &previous, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var removed []string
+	for _, identifier := range previous {
+		if !slices.Contains(identifiers, identifier) {
+			removed = append(removed, identifier)
+		}
+	}
+	if err := r.client.DeleteClients(ctx, removed); err != nil {
+		addAPIErrorf(&resp.Diagnostics, "Error updating device", err, "Could not delete clients %s", strings.Join(removed, ", "))
+		return
+	}
+
+	comment := r.comments.managedBy("pihole_device", data.Comment.ValueString())
+	entries := make([]pihole.PiholeClient, 0, len(identifiers))
+	for _, identifier := range identifiers {
+		entry := &pihole.PiholeClient{Client: identifier, Comment: comment, Groups: groups}
+
+		var err error
+		if slices.Contains(previous, identifier) {
+			entry, err = r.client.UpdateClient(ctx, identifier, entry)
+		} else {
+			entry, err = r.client.CreateClient(ctx, entry)
+		}
+		if err != nil {
+			addAPIErrorf(&resp.Diagnostics, "Error updating device", err, "Could not update client %s", identifier)
+			return
+		}
+		entries = append(entries, *entry)
+	}
+
+	r.mapClientsToModel(ctx, entries, &data, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DeviceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data DeviceResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var identifiers []string
+	resp.Diagnostics.Append(data.Identifiers.ElementsAs(ctx, &identifiers, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.DeleteClients(ctx, identifiers); err != nil && !errors.Is(err, pihole.ErrNotFound) {
+		addAPIErrorf(&resp.Diagnostics, "Error deleting device", err, "Could not delete clients %s", strings.Join(identifiers, ", "))
+		return
+	}
+}
+
+// ModifyPlan warns before destroys that Pi-hole may refuse.
+func (r *DeviceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	warnDestructiveDisabled(r.client, req, resp)
+}
+
+// ImportState imports a device from its identifiers, separated by commas.
+func (r *DeviceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	var identifiers []attr.Value
+	for _, identifier := range strings.Split(req.ID, ",") {
+		if identifier = strings.TrimSpace(identifier); identifier != "" {
+			identifiers = append(identifiers, types.StringValue(identifier))
+		}
+	}
+	if len(identifiers) == 0 {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("Expected the device's client identifiers separated by commas, e.g. AA:BB:CC:DD:EE:01,AA:BB:CC:DD:EE:02, got %q.", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("identifiers"), types.SetValueMust(types.StringType, identifiers))...)
+}
+
+// planned returns the sorted identifiers and the groups of a planned device.
+func (r *DeviceResource) planned(ctx context.Context, data *DeviceResourceModel, diags *diag.Diagnostics) ([]string, []int64) {
+	var identifiers []string
+	diags.Append(data.Identifiers.ElementsAs(ctx, &identifiers, false)...)
+	sort.Strings(identifiers)
+
+	var groups []int64
+	if !data.Groups.IsNull() && !data.Groups.IsUnknown() {
+		diags.Append(data.Groups.ElementsAs(ctx, &groups, false)...)
+	}
+	return identifiers, groups
+}
+
+// findClients returns the client entries of identifiers that exist, sorted
+// by identifier.
+func (r *DeviceResource) findClients(ctx context.Context, identifiers []string) ([]pihole.PiholeClient, error) {
+	all, err := r.client.GetClients(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []pihole.PiholeClient
+	for _, entry := range all {
+		if slices.Contains(identifiers, entry.Client) {
+			entries = append(entries, entry)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Client < entries[j].Client })
+	return entries, nil
+}
+
+// mapClientsToModel stores the client entries of a device. Comment and
+// groups come from the first entry that differs from the model, so drift on
+// any single entry shows up in the plan.
+func (r *DeviceResource) mapClientsToModel(ctx context.Context, entries []pihole.PiholeClient, data *DeviceResourceModel, diags *diag.Diagnostics) {
+	identifiers := make([]attr.Value, len(entries))
+	clientIDs := make(map[string]attr.Value, len(entries))
+	names := make([]string, len(entries))
+	for i, entry := range entries {
+		identifiers[i] = types.StringValue(entry.Client)
+		clientIDs[entry.Client] = types.Int64Value(entry.ID)
+		names[i] = entry.Client
+	}
+	sort.Strings(names)
+
+	data.ID = types.StringValue(strings.Join(names, ","))
+	data.Identifiers = types.SetValueMust(types.StringType, identifiers)
+	data.ClientIDs = types.MapValueMust(types.Int64Type, clientIDs)
+
+	var current []int64
+	if !data.Groups.IsNull() && !data.Groups.IsUnknown() {
+		diags.Append(data.Groups.ElementsAs(ctx, &current, false)...)
+	}
+
+	shared := entries[0]
+	for _, entry := range entries {
+		if r.comments.unmanaged(entry.Comment) != data.Comment.ValueString() ||
+			!slices.Equal(entry.Groups, current) {
+			shared = entry
+			break
+		}
+	}
+
+	data.Comment = convert.OptionalString(r.comments.unmanaged(shared.Comment))
+
+	groups, d := convert.GroupList(ctx, shared.Groups)
+	diags.Append(d...)
+	data.Groups = groups
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccResourceDevice_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceDeviceConfig(`"AA:BB:CC:DD:EE:01", "AA:BB:CC:DD:EE:02"`, "Test device"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_device.test", "identifiers.#", "2"),
+					resource.TestCheckResourceAttr("pihole_device.test", "comment", "Test device"),
+					resource.TestCheckResourceAttr("pihole_device.test", "id", "AA:BB:CC:DD:EE:01,AA:BB:CC:DD:EE:02"),
+					resource.TestCheckResourceAttrSet("pihole_device.test", "client_ids.AA:BB:CC:DD:EE:01"),
+					resource.TestCheckResourceAttrSet("pihole_device.test", "client_ids.AA:BB:CC:DD:EE:02"),
+				),
+			},
+			{
+				ResourceName:      "pihole_device.test",
+				ImportState:       true,
+				ImportStateId:     "AA:BB:CC:DD:EE:01,AA:BB:CC:DD:EE:02",
+				ImportStateVerify: true,
+			},
+			// Swap one identifier and change the shared comment
+			{
+				Config: testAccResourceDeviceConfig(`"AA:BB:CC:DD:EE:01", "192.168.1.150"`, "Updated device"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_device.test", "identifiers.#", "2"),
+					resource.TestCheckResourceAttr("pihole_device.test", "comment", "Updated device"),
+					resource.TestCheckResourceAttr("pihole_device.test", "id", "192.168.1.150,AA:BB:CC:DD:EE:01"),
+					resource.TestCheckNoResourceAttr("pihole_device.test", "client_ids.AA:BB:CC:DD:EE:02"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceDeviceConfig(identifiers, comment string) string {
+	return fmt.Sprintf(`
+resource "pihole_device" "test" {
+  identifiers = [%s]
+  comment     = %q
+}
+`, identifiers, comment)
+}
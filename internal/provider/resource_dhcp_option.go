@@ -0,0 +1,292 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dklesev/terraform-provider-pihole/internal/provider/convert"
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = &DHCPOptionResource{}
+	_ resource.ResourceWithImportState = &DHCPOptionResource{}
+	_ resource.ResourceWithModifyPlan  = &DHCPOptionResource{}
+)
+
+func NewDHCPOptionResource() resource.Resource {
+	return &DHCPOptionResource{}
+}
+
+// DHCPOptionResource manages a "dhcp-option=" line in misc.dnsmasq_lines.
+type DHCPOptionResource struct {
+	client *pihole.Client
+}
+
+type DHCPOptionResourceModel struct {
+	ID     types.String `tfsdk:"id"`
+	Option types.String `tfsdk:"option"`
+	Tag    types.String `tfsdk:"tag"`
+	Values types.List   `tfsdk:"values"`
+}
+
+func (r *DHCPOptionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dhcp_option"
+}
+
+func (r *DHCPOptionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages an option Pi-hole's DHCP server sends to clients.",
+		MarkdownDescription: `
+Manages an option Pi-hole's DHCP server sends to clients, such as the NTP
+servers or the domain search list. The resource adds a dnsmasq
+` + "`dhcp-option=`" + ` line to the ` + "`misc.dnsmasq_lines`" + ` config. Options Pi-hole
+sets itself (router, DNS server, domain) are better changed through
+` + "`pihole_config_dhcp`" + `.
+
+~> **Note:** ` + "`pihole_config_misc`" + ` manages ` + "`dnsmasq_lines`" + ` as a whole list and
+removes lines it does not know. When both are used, add ` + "`dnsmasq_lines`" + ` to
+the ` + "`ignore_changes`" + ` of ` + "`pihole_config_misc`" + `.
+
+## Example Usage
+
+` + "```hcl" + `
+resource "pihole_dhcp_option" "ntp" {
+  option = "ntp-server"
+  values = ["192.168.1.1"]
+}
+
+resource "pihole_dhcp_option" "search" {
+  option = "119"
+  values = ["lan", "home.arpa"]
+}
+` + "```" + `
+
+### Options for Tagged Clients
+
+` + "```hcl" + `
+resource "pihole_dhcp_option" "kids_dns" {
+  tag    = "kids"
+  option = "dns-server"
+  values = ["192.168.1.53"]
+}
+` + "```" + `
+`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Resource identifier (the line without `dhcp-option=`).",
+			},
+			"option": schema.StringAttribute{
+				Required: true,
+				Description: "The option number (e.g. `42`) or dnsmasq name (e.g. `ntp-server`). " +
+					"Prefixed forms such as `option6:` or `vendor:` are passed through.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"tag": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only send the option to clients with this dnsmasq tag.",
+			},
+			"values": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "The option values. Omit to send the option empty.",
+			},
+		},
+	}
+}
+
+func (r *DHCPOptionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData))
+		return
+	}
+	r.client = data.client
+}
+
+func (r *DHCPOptionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data DHCPOptionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	option := data.option(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	line := option.String()
+	tflog.Debug(ctx, "Creating DHCP option", map[string]interface{}{"value": line})
+
+	if err := r.client.AddConfigArrayItemVerified(ctx, "misc/dnsmasq_lines", line, dhcpOptionFinder(option)); err != nil {
+		addAPIError(&resp.Diagnostics, "Error adding DHCP option", err)
+		return
+	}
+
+	data.ID = types.StringValue(strings.TrimPrefix(line, "dhcp-option="))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DHCPOptionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data DHCPOptionResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	option := data.option(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, err := r.client.GetMiscConfig(ctx)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error reading misc config", err)
+		return
+	}
+
+	if _, found := convert.FindDHCPOptionLine(config.DnsmasqLines, option); !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.ID = types.StringValue(strings.TrimPrefix(option.String(), "dhcp-option="))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DHCPOptionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state DHCPOptionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	option := data.option(ctx, &resp.Diagnostics)
+	previous := state.option(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, err := r.client.GetMiscConfig(ctx)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error reading misc config", err)
+		return
+	}
+
+	line := option.String()
+	tflog.Debug(ctx, "Updating DHCP option", map[string]interface{}{"value": line})
+
+	// Swap the entry in place so dependents are not replaced with it
+	if old, found := convert.FindDHCPOptionLine(config.DnsmasqLines, previous); found {
+		err = r.client.ReplaceConfigArrayItem(ctx, "misc/dnsmasq_lines", old, line, dhcpOptionFinder(previous), dhcpOptionFinder(option))
+	} else {
+		err = r.client.AddConfigArrayItemVerified(ctx, "misc/dnsmasq_lines", line, dhcpOptionFinder(option))
+	}
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error updating DHCP option", err)
+		return
+	}
+
+	data.ID = types.StringValue(strings.TrimPrefix(line, "dhcp-option="))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DHCPOptionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data DHCPOptionResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	option := data.option(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, err := r.client.GetMiscConfig(ctx)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error reading misc config", err)
+		return
+	}
+
+	// Delete the line as stored, which may differ in case or spacing.
+	line, found := convert.FindDHCPOptionLine(config.DnsmasqLines, option)
+	if !found {
+		return
+	}
+	tflog.Debug(ctx, "Deleting DHCP option", map[string]interface{}{"value": line})
+
+	if err := r.client.DeleteConfigArrayItemVerified(ctx, "misc/dnsmasq_lines", line, dhcpOptionFinder(option)); err != nil && !errors.Is(err, pihole.ErrNotFound) {
+		addAPIError(&resp.Diagnostics, "Error deleting DHCP option", err)
+		return
+	}
+}
+
+// ModifyPlan warns before destroys that Pi-hole may refuse.
+func (r *DHCPOptionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	warnDestructiveDisabled(r.client, req, resp)
+}
+
+func (r *DHCPOptionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import format: the line without "dhcp-option=", e.g. "tag:kids,option:dns-server,192.168.1.53"
+	option, ok := convert.ParseDHCPOptionLine("dhcp-option=" + req.ID)
+	if !ok {
+		resp.Diagnostics.AddError("Invalid import ID", "Expected format: '[tag:<tag>,]<option>[,<value>...]'")
+		return
+	}
+
+	data := DHCPOptionResourceModel{
+		ID:     types.StringValue(strings.TrimPrefix(option.String(), "dhcp-option=")),
+		Option: types.StringValue(option.Option),
+		Tag:    convert.OptionalString(option.Tag),
+		Values: types.ListNull(types.StringType),
+	}
+	if len(option.Values) > 0 {
+		values := make([]attr.Value, len(option.Values))
+		for i, v := range option.Values {
+			values[i] = types.StringValue(v)
+		}
+		data.Values = types.ListValueMust(types.StringType, values)
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// option returns the dhcp-option= line fields described by the model.
+func (m DHCPOptionResourceModel) option(ctx context.Context, diags *diag.Diagnostics) convert.DHCPOption {
+	option := convert.DHCPOption{
+		Tag:    m.Tag.ValueString(),
+		Option: strings.TrimPrefix(m.Option.ValueString(), "option:"),
+	}
+	if !m.Values.IsNull() {
+		diags.Append(m.Values.ElementsAs(ctx, &option.Values, false)...)
+	}
+	return option
+}
+
+// dhcpOptionFinder looks the dhcp-option= line of option up in
+// misc.dnsmasq_lines, which may store it in another case or spacing.
+func dhcpOptionFinder(option convert.DHCPOption) pihole.ItemFinder {
+	return func(lines []string) (string, bool) {
+		return convert.FindDHCPOptionLine(lines, option)
+	}
+}
@@ -5,21 +5,26 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"strings"
 
-	"github.com/dklesev/terraform-provider-pihole/internal/client"
+	"github.com/dklesev/terraform-provider-pihole/internal/provider/convert"
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 var (
-	_ resource.Resource                = &DHCPStaticLeaseResource{}
-	_ resource.ResourceWithImportState = &DHCPStaticLeaseResource{}
+	_ resource.Resource                     = &DHCPStaticLeaseResource{}
+	_ resource.ResourceWithImportState      = &DHCPStaticLeaseResource{}
+	_ resource.ResourceWithConfigValidators = &DHCPStaticLeaseResource{}
+	_ resource.ResourceWithModifyPlan       = &DHCPStaticLeaseResource{}
 )
 
 func NewDHCPStaticLeaseResource() resource.Resource {
@@ -27,14 +32,15 @@ func NewDHCPStaticLeaseResource() resource.Resource {
 }
 
 type DHCPStaticLeaseResource struct {
-	client *client.Client
+	client *pihole.Client
 }
 
 type DHCPStaticLeaseResourceModel struct {
-	ID       types.String `tfsdk:"id"`
-	MAC      types.String `tfsdk:"mac"`
-	IP       types.String `tfsdk:"ip"`
-	Hostname types.String `tfsdk:"hostname"`
+	ID        types.String `tfsdk:"id"`
+	MAC       types.String `tfsdk:"mac"`
+	IP        types.String `tfsdk:"ip"`
+	Hostname  types.String `tfsdk:"hostname"`
+	LeaseTime types.String `tfsdk:"lease_time"`
 }
 
 func (r *DHCPStaticLeaseResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -47,6 +53,9 @@ func (r *DHCPStaticLeaseResource) Schema(ctx context.Context, req resource.Schem
 		MarkdownDescription: `
 Manages a DHCP static lease (MAC -> IP reservation) in Pi-hole.
 
+At least one of ` + "`ip`" + ` and ` + "`hostname`" + ` must be set. A lease with only a
+hostname keeps a dynamic address but always hands out the same name.
+
 ## Example Usage
 
 ` + "```hcl" + `
@@ -55,6 +64,12 @@ resource "pihole_dhcp_static_lease" "server" {
   ip       = "192.168.1.100"
   hostname = "server"
 }
+
+resource "pihole_dhcp_static_lease" "laptop" {
+  mac        = "11:22:33:44:55:66"
+  hostname   = "laptop"
+  lease_time = "12h"
+}
 ` + "```" + `
 `,
 		Attributes: map[string]schema.Attribute{
@@ -65,38 +80,45 @@ resource "pihole_dhcp_static_lease" "server" {
 			"mac": schema.StringAttribute{
 				Required:    true,
 				Description: "The MAC address of the device.",
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
 			},
 			"ip": schema.StringAttribute{
-				Required:    true,
+				Optional:    true,
 				Description: "The reserved IP address.",
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
 			},
 			"hostname": schema.StringAttribute{
-				Required:    true,
+				Optional:    true,
 				Description: "The hostname for the device.",
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+			},
+			"lease_time": schema.StringAttribute{
+				Optional:    true,
+				Description: "Lease time for the device, e.g. `3600`, `12h` or `infinite`. Defaults to the DHCP range lease time.",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(convert.LeaseTimeRegexp, "must be a number of seconds, optionally suffixed with s, m, h, d or w, or \"infinite\""),
 				},
 			},
 		},
 	}
 }
 
+func (r *DHCPStaticLeaseResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.AtLeastOneOf(
+			path.MatchRoot("ip"),
+			path.MatchRoot("hostname"),
+		),
+	}
+}
+
 func (r *DHCPStaticLeaseResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
-	c, ok := req.ProviderData.(*client.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
-		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *client.Client, got: %T.", req.ProviderData))
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData))
 		return
 	}
-	r.client = c
+	r.client = data.client
 }
 
 func (r *DHCPStaticLeaseResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -106,12 +128,11 @@ func (r *DHCPStaticLeaseResource) Create(ctx context.Context, req resource.Creat
 		return
 	}
 
-	// Format: "MAC,IP,hostname"
-	value := fmt.Sprintf("%s,%s,%s", data.MAC.ValueString(), data.IP.ValueString(), data.Hostname.ValueString())
+	value := data.lease().String()
 	tflog.Debug(ctx, "Creating DHCP static lease", map[string]interface{}{"value": value})
 
-	if err := r.client.AddConfigArrayItem(ctx, "dhcp/hosts", value); err != nil {
-		resp.Diagnostics.AddError("Error adding DHCP static lease", err.Error())
+	if err := r.client.AddConfigArrayItemVerified(ctx, "dhcp/hosts", value, data.finder()); err != nil {
+		addAPIError(&resp.Diagnostics, "Error adding DHCP static lease", err)
 		return
 	}
 
@@ -126,33 +147,53 @@ func (r *DHCPStaticLeaseResource) Read(ctx context.Context, req resource.ReadReq
 		return
 	}
 
-	value := fmt.Sprintf("%s,%s,%s", data.MAC.ValueString(), data.IP.ValueString(), data.Hostname.ValueString())
-
 	config, err := r.client.GetDHCPConfig(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading DHCP config", err.Error())
+		addAPIError(&resp.Diagnostics, "Error reading DHCP config", err)
 		return
 	}
 
-	found := false
-	for _, h := range config.Hosts {
-		if h == value {
-			found = true
-			break
-		}
-	}
-
+	line, found := convert.FindDHCPHost(config.Hosts, data.MAC.ValueString(), data.IP.ValueString(), data.Hostname.ValueString())
 	if !found {
 		resp.State.RemoveResource(ctx)
 		return
 	}
 
-	data.ID = types.StringValue(value)
+	data.LeaseTime = convert.OptionalString(convert.ParseDHCPHost(line).LeaseTime)
+	data.ID = types.StringValue(data.lease().String())
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *DHCPStaticLeaseResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics.AddError("Update not supported", "Changes require replacement")
+	var data, state DHCPStaticLeaseResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, err := r.client.GetDHCPConfig(ctx)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error reading DHCP config", err)
+		return
+	}
+
+	value := data.lease().String()
+	tflog.Debug(ctx, "Updating DHCP static lease", map[string]interface{}{"value": value})
+
+	// Swap the entry in place so dependents are not replaced with it
+	if old, found := convert.FindDHCPHost(config.Hosts, state.MAC.ValueString(), state.IP.ValueString(), state.Hostname.ValueString()); found {
+		err = r.client.ReplaceConfigArrayItem(ctx, "dhcp/hosts", old, value, state.finder(), data.finder())
+	} else {
+		err = r.client.AddConfigArrayItemVerified(ctx, "dhcp/hosts", value, data.finder())
+	}
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error updating DHCP static lease", err)
+		return
+	}
+
+	data.ID = types.StringValue(value)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *DHCPStaticLeaseResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -162,28 +203,62 @@ func (r *DHCPStaticLeaseResource) Delete(ctx context.Context, req resource.Delet
 		return
 	}
 
-	value := fmt.Sprintf("%s,%s,%s", data.MAC.ValueString(), data.IP.ValueString(), data.Hostname.ValueString())
+	config, err := r.client.GetDHCPConfig(ctx)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error reading DHCP config", err)
+		return
+	}
+
+	// Delete the entry as stored, which may use a different MAC notation.
+	value, found := convert.FindDHCPHost(config.Hosts, data.MAC.ValueString(), data.IP.ValueString(), data.Hostname.ValueString())
+	if !found {
+		return
+	}
 	tflog.Debug(ctx, "Deleting DHCP static lease", map[string]interface{}{"value": value})
 
-	if err := r.client.DeleteConfigArrayItem(ctx, "dhcp/hosts", value); err != nil {
-		resp.Diagnostics.AddError("Error deleting DHCP static lease", err.Error())
+	if err := r.client.DeleteConfigArrayItemVerified(ctx, "dhcp/hosts", value, data.finder()); err != nil && !errors.Is(err, pihole.ErrNotFound) {
+		addAPIError(&resp.Diagnostics, "Error deleting DHCP static lease", err)
 		return
 	}
 }
 
+// ModifyPlan warns before destroys that Pi-hole may refuse.
+func (r *DHCPStaticLeaseResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	warnDestructiveDisabled(r.client, req, resp)
+}
+
 func (r *DHCPStaticLeaseResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Import format: "MAC,IP,hostname"
-	parts := strings.SplitN(req.ID, ",", 3)
-	if len(parts) != 3 {
-		resp.Diagnostics.AddError("Invalid import ID", "Expected format: 'MAC,IP,hostname'")
+	// Import format: the dhcp.hosts entry, e.g. "MAC,IP,hostname" or "MAC,hostname,12h"
+	lease := convert.ParseDHCPHost(req.ID)
+	if lease.MAC == "" || (lease.IP == "" && lease.Hostname == "") {
+		resp.Diagnostics.AddError("Invalid import ID", "Expected format: 'MAC[,IP][,hostname][,lease_time]' with at least an IP or hostname")
 		return
 	}
 
 	data := DHCPStaticLeaseResourceModel{
-		ID:       types.StringValue(req.ID),
-		MAC:      types.StringValue(parts[0]),
-		IP:       types.StringValue(parts[1]),
-		Hostname: types.StringValue(parts[2]),
+		ID:        types.StringValue(lease.String()),
+		MAC:       types.StringValue(lease.MAC),
+		IP:        convert.OptionalString(lease.IP),
+		Hostname:  convert.OptionalString(lease.Hostname),
+		LeaseTime: convert.OptionalString(lease.LeaseTime),
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// lease returns the dhcp.hosts fields described by the model.
+func (m DHCPStaticLeaseResourceModel) lease() convert.DHCPHost {
+	return convert.DHCPHost{
+		MAC:       m.MAC.ValueString(),
+		IP:        m.IP.ValueString(),
+		Hostname:  m.Hostname.ValueString(),
+		LeaseTime: m.LeaseTime.ValueString(),
+	}
+}
+
+// finder looks the lease up in dhcp.hosts, which may store the MAC in
+// another notation.
+func (m DHCPStaticLeaseResourceModel) finder() pihole.ItemFinder {
+	return func(hosts []string) (string, bool) {
+		return convert.FindDHCPHost(hosts, m.MAC.ValueString(), m.IP.ValueString(), m.Hostname.ValueString())
+	}
+}
@@ -7,7 +7,7 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/dklesev/terraform-provider-pihole/internal/client"
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -23,7 +23,7 @@ func NewDNSBlockingResource() resource.Resource {
 }
 
 type DNSBlockingResource struct {
-	client *client.Client
+	client *pihole.Client
 }
 
 type DNSBlockingResourceModel struct {
@@ -91,16 +91,16 @@ func (r *DNSBlockingResource) Configure(ctx context.Context, req resource.Config
 		return
 	}
 
-	c, ok := req.ProviderData.(*client.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = c
+	r.client = data.client
 }
 
 func (r *DNSBlockingResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -123,10 +123,7 @@ func (r *DNSBlockingResource) Create(ctx context.Context, req resource.CreateReq
 
 	result, err := r.client.SetDNSBlocking(ctx, data.Enabled.ValueBool(), timer)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error setting DNS blocking",
-			fmt.Sprintf("Could not set DNS blocking: %s", err.Error()),
-		)
+		addAPIErrorf(&resp.Diagnostics, "Error setting DNS blocking", err, "Could not set DNS blocking")
 		return
 	}
 
@@ -145,10 +142,7 @@ func (r *DNSBlockingResource) Read(ctx context.Context, req resource.ReadRequest
 
 	result, err := r.client.GetDNSBlocking(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error reading DNS blocking",
-			fmt.Sprintf("Could not read DNS blocking status: %s", err.Error()),
-		)
+		addAPIErrorf(&resp.Diagnostics, "Error reading DNS blocking", err, "Could not read DNS blocking status")
 		return
 	}
 
@@ -183,10 +177,7 @@ func (r *DNSBlockingResource) Update(ctx context.Context, req resource.UpdateReq
 
 	result, err := r.client.SetDNSBlocking(ctx, data.Enabled.ValueBool(), timer)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error updating DNS blocking",
-			fmt.Sprintf("Could not update DNS blocking: %s", err.Error()),
-		)
+		addAPIErrorf(&resp.Diagnostics, "Error updating DNS blocking", err, "Could not update DNS blocking")
 		return
 	}
 
@@ -201,15 +192,12 @@ func (r *DNSBlockingResource) Delete(ctx context.Context, req resource.DeleteReq
 
 	_, err := r.client.SetDNSBlocking(ctx, true, nil)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error resetting DNS blocking",
-			fmt.Sprintf("Could not reset DNS blocking to enabled: %s", err.Error()),
-		)
+		addAPIErrorf(&resp.Diagnostics, "Error resetting DNS blocking", err, "Could not reset DNS blocking to enabled")
 		return
 	}
 }
 
-func (r *DNSBlockingResource) mapDNSBlockingToModel(blocking *client.DNSBlocking, data *DNSBlockingResourceModel) {
+func (r *DNSBlockingResource) mapDNSBlockingToModel(blocking *pihole.DNSBlocking, data *DNSBlockingResourceModel) {
 	data.ID = types.StringValue("blocking")
 	data.Enabled = types.BoolValue(blocking.Blocking == "enabled")
 
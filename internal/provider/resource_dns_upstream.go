@@ -5,9 +5,11 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
-	"github.com/dklesev/terraform-provider-pihole/internal/client"
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -19,6 +21,7 @@ import (
 var (
 	_ resource.Resource                = &DNSUpstreamResource{}
 	_ resource.ResourceWithImportState = &DNSUpstreamResource{}
+	_ resource.ResourceWithModifyPlan  = &DNSUpstreamResource{}
 )
 
 func NewDNSUpstreamResource() resource.Resource {
@@ -26,7 +29,7 @@ func NewDNSUpstreamResource() resource.Resource {
 }
 
 type DNSUpstreamResource struct {
-	client *client.Client
+	client *pihole.Client
 }
 
 type DNSUpstreamResourceModel struct {
@@ -43,6 +46,8 @@ func (r *DNSUpstreamResource) Schema(ctx context.Context, req resource.SchemaReq
 		Description: "Manages a Pi-hole DNS upstream server.",
 		MarkdownDescription: `
 Manages a single DNS upstream server in Pi-hole. Each upstream is an individual resource.
+To manage the whole list instead, use the ` + "`upstreams`" + ` attribute of ` + "`pihole_config_dns`" + `;
+a configuration may use only one of the two.
 
 ## Example Usage
 
@@ -80,12 +85,12 @@ func (r *DNSUpstreamResource) Configure(ctx context.Context, req resource.Config
 	if req.ProviderData == nil {
 		return
 	}
-	c, ok := req.ProviderData.(*client.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
-		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *client.Client, got: %T.", req.ProviderData))
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData))
 		return
 	}
-	r.client = c
+	r.client = data.client
 }
 
 func (r *DNSUpstreamResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -99,8 +104,8 @@ func (r *DNSUpstreamResource) Create(ctx context.Context, req resource.CreateReq
 	tflog.Debug(ctx, "Creating DNS upstream", map[string]interface{}{"upstream": upstream})
 
 	// PUT /api/config/dns/upstreams/{upstream}
-	if err := r.client.AddConfigArrayItem(ctx, "dns/upstreams", upstream); err != nil {
-		resp.Diagnostics.AddError("Error adding DNS upstream", err.Error())
+	if err := r.client.AddConfigArrayItemVerified(ctx, "dns/upstreams", upstream, nil); err != nil {
+		addAPIError(&resp.Diagnostics, "Error adding DNS upstream", err)
 		return
 	}
 
@@ -120,7 +125,7 @@ func (r *DNSUpstreamResource) Read(ctx context.Context, req resource.ReadRequest
 	// Check if upstream still exists
 	config, err := r.client.GetDNSConfig(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading DNS config", err.Error())
+		addAPIError(&resp.Diagnostics, "Error reading DNS config", err)
 		return
 	}
 
@@ -157,19 +162,28 @@ func (r *DNSUpstreamResource) Delete(ctx context.Context, req resource.DeleteReq
 	tflog.Debug(ctx, "Deleting DNS upstream", map[string]interface{}{"upstream": upstream})
 
 	// DELETE /api/config/dns/upstreams/{upstream}
-	if err := r.client.DeleteConfigArrayItem(ctx, "dns/upstreams", upstream); err != nil {
-		resp.Diagnostics.AddError("Error deleting DNS upstream", err.Error())
+	if err := r.client.DeleteConfigArrayItemVerified(ctx, "dns/upstreams", upstream, nil); err != nil && !errors.Is(err, pihole.ErrNotFound) {
+		addAPIError(&resp.Diagnostics, "Error deleting DNS upstream", err)
 		return
 	}
 }
 
+// ModifyPlan warns before destroys that Pi-hole may refuse and rejects
+// upstreams that pihole_config_dns manages as a list, too.
+func (r *DNSUpstreamResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	warnDestructiveDisabled(r.client, req, resp)
+	if !req.Plan.Raw.IsNull() {
+		claimUpstreams(r.client, upstreamsOwnerResource, path.Root("upstream"), &resp.Diagnostics)
+	}
+}
+
 func (r *DNSUpstreamResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	upstream := req.ID
 
 	// Verify it exists
 	config, err := r.client.GetDNSConfig(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading DNS config", err.Error())
+		addAPIError(&resp.Diagnostics, "Error reading DNS config", err)
 		return
 	}
 
@@ -5,10 +5,14 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
 
-	"github.com/dklesev/terraform-provider-pihole/internal/client"
+	"github.com/dklesev/terraform-provider-pihole/internal/provider/convert"
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -23,8 +27,10 @@ import (
 )
 
 var (
-	_ resource.Resource                = &DomainResource{}
-	_ resource.ResourceWithImportState = &DomainResource{}
+	_ resource.Resource                   = &DomainResource{}
+	_ resource.ResourceWithImportState    = &DomainResource{}
+	_ resource.ResourceWithModifyPlan     = &DomainResource{}
+	_ resource.ResourceWithValidateConfig = &DomainResource{}
 )
 
 func NewDomainResource() resource.Resource {
@@ -32,19 +38,25 @@ func NewDomainResource() resource.Resource {
 }
 
 type DomainResource struct {
-	client *client.Client
+	client        *pihole.Client
+	comments      commentSettings
+	plannedGroups *plannedGroups
 }
 
 type DomainResourceModel struct {
-	ID           types.Int64  `tfsdk:"id"`
-	Domain       types.String `tfsdk:"domain"`
-	Type         types.String `tfsdk:"type"`
-	Kind         types.String `tfsdk:"kind"`
-	Enabled      types.Bool   `tfsdk:"enabled"`
-	Comment      types.String `tfsdk:"comment"`
-	Groups       types.Set    `tfsdk:"groups"`
-	DateAdded    types.Int64  `tfsdk:"date_added"`
-	DateModified types.Int64  `tfsdk:"date_modified"`
+	ID                 types.Int64  `tfsdk:"id"`
+	Domain             types.String `tfsdk:"domain"`
+	Type               types.String `tfsdk:"type"`
+	Kind               types.String `tfsdk:"kind"`
+	Enabled            types.Bool   `tfsdk:"enabled"`
+	Comment            types.String `tfsdk:"comment"`
+	Groups             types.Set    `tfsdk:"groups"`
+	GroupNames         types.Set    `tfsdk:"group_names"`
+	Exceptions         types.Set    `tfsdk:"exceptions"`
+	DateAdded          types.Int64  `tfsdk:"date_added"`
+	DateModified       types.Int64  `tfsdk:"date_modified"`
+	AdoptExisting      types.Bool   `tfsdk:"adopt_existing"`
+	DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
 }
 
 func (r *DomainResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -85,6 +97,39 @@ resource "pihole_domain" "allow_google" {
 }
 ` + "```" + `
 
+### Deny Rule with Exceptions
+
+` + "`exceptions`" + ` creates exact allow entries for domains a deny rule would
+block. They get the rule's groups, enabled state and comment, and are deleted
+together with the rule.
+
+` + "```hcl" + `
+resource "pihole_domain" "block_example" {
+  domain     = "(\\.|^)example\\.com$"
+  type       = "deny"
+  kind       = "regex"
+  exceptions = ["docs.example.com", "status.example.com"]
+}
+` + "```" + `
+
+Exceptions are not imported; add them to the configuration after an import
+and the next apply creates any that are missing.
+
+### Adopt an Existing Entry
+
+With ` + "`adopt_existing = true`" + ` an entry of the same domain, type and kind that
+is already in Pi-hole is taken over instead of failing to create it, and
+changed to match the configuration:
+
+` + "```hcl" + `
+resource "pihole_domain" "block_tracker" {
+  domain         = "tracker.example.com"
+  type           = "deny"
+  kind           = "exact"
+  adopt_existing = true
+}
+` + "```" + `
+
 ## Import
 
 Domains can be imported using the format ` + "`type/kind/domain`" + `:
@@ -129,7 +174,7 @@ terraform import pihole_domain.example deny/exact/ads.example.com
 				Default:     booldefault.StaticBool(true),
 			},
 			"comment": schema.StringAttribute{
-				Description: "A comment describing the domain entry.",
+				Description: "A comment describing the domain entry. The provider's `managed_by_tag` marker is appended in Pi-hole and hidden here.",
 				Optional:    true,
 			},
 			"groups": schema.SetAttribute{
@@ -138,6 +183,15 @@ terraform import pihole_domain.example deny/exact/ads.example.com
 				Computed:    true,
 				ElementType: types.Int64Type,
 			},
+			"group_names": groupNamesAttribute(),
+			"exceptions": schema.SetAttribute{
+				Description: "Exact domains allowed despite this deny rule. They are created as allow entries with the rule's groups, enabled state and comment, and deleted with the rule. Only for type 'deny'.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
 			"date_added": schema.Int64Attribute{
 				Description: "Unix timestamp when the domain was created.",
 				Computed:    true,
@@ -149,6 +203,8 @@ terraform import pihole_domain.example deny/exact/ads.example.com
 				Description: "Unix timestamp when the domain was last modified.",
 				Computed:    true,
 			},
+			"adopt_existing":      adoptExistingAttribute(),
+			"deletion_protection": deletionProtectionAttribute(),
 		},
 	}
 }
@@ -158,19 +214,23 @@ func (r *DomainResource) Configure(ctx context.Context, req resource.ConfigureRe
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.client
+	r.comments = data.comments
+	r.plannedGroups = data.plannedGroups
 }
 
 func (r *DomainResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer trackGravityChange(ctx, r.client, &resp.Diagnostics)()
+
 	var data DomainResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -184,33 +244,48 @@ func (r *DomainResource) Create(ctx context.Context, req resource.CreateRequest,
 		"kind":   data.Kind.ValueString(),
 	})
 
-	var groups []int64
-	if !data.Groups.IsNull() && !data.Groups.IsUnknown() {
-		resp.Diagnostics.Append(data.Groups.ElementsAs(ctx, &groups, false)...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
+	groups, diags := resolveGroups(ctx, r.client, data.Groups, data.GroupNames)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	domain := &client.Domain{
+	domain := &pihole.Domain{
 		Domain:  data.Domain.ValueString(),
 		Type:    data.Type.ValueString(),
 		Kind:    data.Kind.ValueString(),
 		Enabled: data.Enabled.ValueBool(),
-		Comment: data.Comment.ValueString(),
+		Comment: r.comments.managed(data.Comment.ValueString()),
 		Groups:  groups,
 	}
 
 	created, err := r.client.CreateDomain(ctx, domain)
+	if errors.Is(err, pihole.ErrExists) && data.AdoptExisting.ValueBool() {
+		tflog.Info(ctx, "Adopting existing domain", map[string]interface{}{"domain": domain.Domain})
+		created, err = r.client.UpdateDomain(ctx, domain.Type, domain.Kind, domain.Domain, domain)
+	}
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error creating domain",
-			fmt.Sprintf("Could not create domain %s: %s", data.Domain.ValueString(), err.Error()),
-		)
+		importID := fmt.Sprintf("%s/%s/%s", domain.Type, domain.Kind, domain.Domain)
+		addCreateError(&resp.Diagnostics, "Error creating domain", "domain "+data.Domain.ValueString(), err, "pihole_domain", importID)
 		return
 	}
 
 	r.mapDomainToModel(ctx, created, &data, &resp.Diagnostics)
+	if data.Exceptions.IsNull() {
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	// Save the rule first so a failure below leaves a tainted rule that the
+	// next apply replaces, instead of an untracked one.
+	bare := data
+	bare.Exceptions = types.SetNull(types.StringType)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &bare)...)
+
+	r.syncExceptions(ctx, &bare, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -223,24 +298,28 @@ func (r *DomainResource) Read(ctx context.Context, req resource.ReadRequest, res
 	}
 
 	domain, err := r.client.GetDomain(ctx, data.Type.ValueString(), data.Kind.ValueString(), data.Domain.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error reading domain",
-			fmt.Sprintf("Could not read domain %s: %s", data.Domain.ValueString(), err.Error()),
-		)
+	if err != nil && !errors.Is(err, pihole.ErrNotFound) {
+		addAPIErrorf(&resp.Diagnostics, "Error reading domain", err, "Could not read domain %s", data.Domain.ValueString())
 		return
 	}
 
+	// Pi-hole answers 404 or an empty result for a domain deleted outside Terraform.
 	if domain == nil {
 		resp.State.RemoveResource(ctx)
 		return
 	}
 
 	r.mapDomainToModel(ctx, domain, &data, &resp.Diagnostics)
+	r.readExceptions(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *DomainResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer trackGravityChange(ctx, r.client, &resp.Diagnostics)()
+
 	var data DomainResourceModel
 	var state DomainResourceModel
 
@@ -250,20 +329,18 @@ func (r *DomainResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	var groups []int64
-	if !data.Groups.IsNull() && !data.Groups.IsUnknown() {
-		resp.Diagnostics.Append(data.Groups.ElementsAs(ctx, &groups, false)...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
+	groups, diags := resolveGroups(ctx, r.client, data.Groups, data.GroupNames)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	domain := &client.Domain{
+	domain := &pihole.Domain{
 		Domain:  data.Domain.ValueString(),
 		Type:    data.Type.ValueString(),
 		Kind:    data.Kind.ValueString(),
 		Enabled: data.Enabled.ValueBool(),
-		Comment: data.Comment.ValueString(),
+		Comment: r.comments.managed(data.Comment.ValueString()),
 		Groups:  groups,
 	}
 
@@ -274,18 +351,21 @@ func (r *DomainResource) Update(ctx context.Context, req resource.UpdateRequest,
 		domain,
 	)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error updating domain",
-			fmt.Sprintf("Could not update domain %s: %s", state.Domain.ValueString(), err.Error()),
-		)
+		addAPIErrorf(&resp.Diagnostics, "Error updating domain", err, "Could not update domain %s", state.Domain.ValueString())
 		return
 	}
 
 	r.mapDomainToModel(ctx, updated, &data, &resp.Diagnostics)
+	r.syncExceptions(ctx, &state, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *DomainResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer trackGravityChange(ctx, r.client, &resp.Diagnostics)()
+
 	var data DomainResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -293,14 +373,44 @@ func (r *DomainResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	checkDeletionProtection(ctx, req.State, "domain", "domain", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	err := r.client.DeleteDomain(ctx, data.Type.ValueString(), data.Kind.ValueString(), data.Domain.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error deleting domain",
-			fmt.Sprintf("Could not delete domain %s: %s", data.Domain.ValueString(), err.Error()),
-		)
+	if err != nil && !errors.Is(err, pihole.ErrNotFound) {
+		addAPIErrorf(&resp.Diagnostics, "Error deleting domain", err, "Could not delete domain %s", data.Domain.ValueString())
 		return
 	}
+
+	r.syncExceptions(ctx, &data, &DomainResourceModel{Exceptions: types.SetNull(types.StringType)}, &resp.Diagnostics)
+}
+
+// ValidateConfig rejects exceptions on allow entries, which have nothing to
+// make exceptions from.
+func (r *DomainResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data DomainResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.Type.IsUnknown() || data.Exceptions.IsNull() {
+		return
+	}
+
+	if data.Type.ValueString() != "deny" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("exceptions"),
+			"Invalid domain exceptions",
+			"exceptions can only be set when type is \"deny\".",
+		)
+	}
+}
+
+// ModifyPlan rejects destroys of protected domains, warns before destroys
+// that Pi-hole may refuse and resolves group_names.
+func (r *DomainResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planDeletionProtection(ctx, req, resp, "domain", "domain")
+	warnDestructiveDisabled(r.client, req, resp)
+	planGroupNames(ctx, r.client, r.plannedGroups, req, resp, false)
 }
 
 func (r *DomainResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
@@ -319,27 +429,104 @@ func (r *DomainResource) ImportState(ctx context.Context, req resource.ImportSta
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("domain"), parts[2])...)
 }
 
-func (r *DomainResource) mapDomainToModel(ctx context.Context, domain *client.Domain, data *DomainResourceModel, diags *diag.Diagnostics) {
+func (r *DomainResource) mapDomainToModel(ctx context.Context, domain *pihole.Domain, data *DomainResourceModel, diags *diag.Diagnostics) {
 	data.ID = types.Int64Value(domain.ID)
 	data.Domain = types.StringValue(domain.Domain)
 	data.Type = types.StringValue(domain.Type)
 	data.Kind = types.StringValue(domain.Kind)
 	data.Enabled = types.BoolValue(domain.Enabled)
 
-	if domain.Comment != "" {
-		data.Comment = types.StringValue(domain.Comment)
-	} else {
-		data.Comment = types.StringNull()
-	}
+	data.Comment = convert.OptionalString(r.comments.unmanaged(domain.Comment))
 
-	if len(domain.Groups) > 0 {
-		groupsList, d := types.SetValueFrom(ctx, types.Int64Type, domain.Groups)
-		diags.Append(d...)
-		data.Groups = groupsList
-	} else {
-		data.Groups = types.SetNull(types.Int64Type)
-	}
+	groups, d := convert.GroupSet(ctx, domain.Groups)
+	diags.Append(d...)
+	data.Groups = groups
 
 	data.DateAdded = types.Int64Value(domain.DateAdded)
 	data.DateModified = types.Int64Value(domain.DateModified)
 }
+
+// syncExceptions brings the allow entries of the rule's exceptions from the
+// state in from to the model in to, which holds the rule as Pi-hole saved
+// it. Kept exceptions are updated when the rule's groups, enabled state or
+// comment changed, so they keep applying to the clients the rule does.
+func (r *DomainResource) syncExceptions(ctx context.Context, from, to *DomainResourceModel, diags *diag.Diagnostics) {
+	added, kept, removed := diffStringSets(ctx, from.Exceptions, to.Exceptions, diags)
+	if diags.HasError() {
+		return
+	}
+
+	var deletions []pihole.Domain
+	for _, name := range removed {
+		deletions = append(deletions, pihole.Domain{Domain: name, Type: "allow", Kind: "exact"})
+	}
+	if err := r.client.DeleteDomains(ctx, deletions); err != nil {
+		addAPIErrorf(diags, "Error updating domain exceptions", err, "Could not delete exceptions of %s", from.Domain.ValueString())
+		return
+	}
+	if len(added) == 0 && len(kept) == 0 {
+		return
+	}
+
+	groups, d := convert.Int64s(ctx, to.Groups)
+	diags.Append(d...)
+	if diags.HasError() {
+		return
+	}
+	exception := func(name string) pihole.Domain {
+		return pihole.Domain{
+			Domain:  name,
+			Type:    "allow",
+			Kind:    "exact",
+			Enabled: to.Enabled.ValueBool(),
+			Comment: r.comments.managed(to.Comment.ValueString()),
+			Groups:  groups,
+		}
+	}
+
+	var additions []pihole.Domain
+	for _, name := range added {
+		additions = append(additions, exception(name))
+	}
+	if _, err := r.client.CreateDomains(ctx, additions); err != nil {
+		addAPIErrorf(diags, "Error updating domain exceptions", err, "Could not create exceptions of %s", to.Domain.ValueString())
+		return
+	}
+
+	if from.Groups.Equal(to.Groups) && from.Enabled.Equal(to.Enabled) && from.Comment.Equal(to.Comment) {
+		return
+	}
+	for _, name := range kept {
+		domain := exception(name)
+		if _, err := r.client.UpdateDomain(ctx, "allow", "exact", name, &domain); err != nil {
+			addAPIErrorf(diags, "Error updating domain exceptions", err, "Could not update exception %s", name)
+			return
+		}
+	}
+}
+
+// readExceptions drops the exceptions deleted outside Terraform from data,
+// so the next apply creates them again.
+func (r *DomainResource) readExceptions(ctx context.Context, data *DomainResourceModel, diags *diag.Diagnostics) {
+	if data.Exceptions.IsNull() {
+		return
+	}
+	var wanted []string
+	diags.Append(data.Exceptions.ElementsAs(ctx, &wanted, false)...)
+	if diags.HasError() {
+		return
+	}
+
+	var names []string
+	err := r.client.ForEachDomain(ctx, "allow", "exact", "", func(d pihole.Domain) bool {
+		if slices.Contains(wanted, d.Domain) {
+			names = append(names, d.Domain)
+		}
+		return true
+	})
+	if err != nil {
+		addAPIErrorf(diags, "Error reading domain", err, "Could not read exceptions of %s", data.Domain.ValueString())
+		return
+	}
+	data.Exceptions = policyStringSet(names, data.Exceptions)
+}
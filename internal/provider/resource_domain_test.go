@@ -4,10 +4,15 @@
 package provider
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"regexp"
 	"testing"
 
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 func TestAccResourceDomain_exactDeny(t *testing.T) {
@@ -17,9 +22,9 @@ func TestAccResourceDomain_exactDeny(t *testing.T) {
 		Steps: []resource.TestStep{
 			// Create exact deny
 			{
-				Config: testAccResourceDomainConfig("test.example.com", "deny", "exact", true, "Exact deny test"),
+				Config: testAccResourceDomainConfig("tf-acc-test.example.com", "deny", "exact", true, "Exact deny test"),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("pihole_domain.test", "domain", "test.example.com"),
+					resource.TestCheckResourceAttr("pihole_domain.test", "domain", "tf-acc-test.example.com"),
 					resource.TestCheckResourceAttr("pihole_domain.test", "type", "deny"),
 					resource.TestCheckResourceAttr("pihole_domain.test", "kind", "exact"),
 					resource.TestCheckResourceAttr("pihole_domain.test", "enabled", "true"),
@@ -31,12 +36,12 @@ func TestAccResourceDomain_exactDeny(t *testing.T) {
 			{
 				ResourceName:      "pihole_domain.test",
 				ImportState:       true,
-				ImportStateId:     "deny/exact/test.example.com",
+				ImportStateId:     "deny/exact/tf-acc-test.example.com",
 				ImportStateVerify: true,
 			},
 			// Update
 			{
-				Config: testAccResourceDomainConfig("test.example.com", "deny", "exact", false, "Updated comment"),
+				Config: testAccResourceDomainConfig("tf-acc-test.example.com", "deny", "exact", false, "Updated comment"),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("pihole_domain.test", "enabled", "false"),
 					resource.TestCheckResourceAttr("pihole_domain.test", "comment", "Updated comment"),
@@ -52,9 +57,9 @@ func TestAccResourceDomain_exactAllow(t *testing.T) {
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccResourceDomainConfig("allowed.example.com", "allow", "exact", true, "Exact allow test"),
+				Config: testAccResourceDomainConfig("tf-acc-allowed.example.com", "allow", "exact", true, "Exact allow test"),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("pihole_domain.test", "domain", "allowed.example.com"),
+					resource.TestCheckResourceAttr("pihole_domain.test", "domain", "tf-acc-allowed.example.com"),
 					resource.TestCheckResourceAttr("pihole_domain.test", "type", "allow"),
 					resource.TestCheckResourceAttr("pihole_domain.test", "kind", "exact"),
 				),
@@ -62,7 +67,7 @@ func TestAccResourceDomain_exactAllow(t *testing.T) {
 			{
 				ResourceName:      "pihole_domain.test",
 				ImportState:       true,
-				ImportStateId:     "allow/exact/allowed.example.com",
+				ImportStateId:     "allow/exact/tf-acc-allowed.example.com",
 				ImportStateVerify: true,
 			},
 		},
@@ -92,9 +97,9 @@ func TestAccResourceDomain_regexAllow(t *testing.T) {
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccResourceDomainConfig("^.*\\.trusted\\.com$", "allow", "regex", true, "Regex allow test"),
+				Config: testAccResourceDomainConfig("tf-acc-.*\\.example\\.com$", "allow", "regex", true, "Regex allow test"),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("pihole_domain.test", "domain", "^.*\\.trusted\\.com$"),
+					resource.TestCheckResourceAttr("pihole_domain.test", "domain", "tf-acc-.*\\.example\\.com$"),
 					resource.TestCheckResourceAttr("pihole_domain.test", "type", "allow"),
 					resource.TestCheckResourceAttr("pihole_domain.test", "kind", "regex"),
 				),
@@ -111,7 +116,7 @@ func TestAccResourceDomain_withGroups(t *testing.T) {
 			{
 				Config: testAccResourceDomainWithGroupConfig(),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("pihole_domain.test", "domain", "grouped.example.com"),
+					resource.TestCheckResourceAttr("pihole_domain.test", "domain", "tf-acc-grouped.example.com"),
 					resource.TestCheckResourceAttr("pihole_domain.test", "groups.#", "1"),
 				),
 			},
@@ -119,6 +124,194 @@ func TestAccResourceDomain_withGroups(t *testing.T) {
 	})
 }
 
+func TestAccResourceDomain_managedByTag(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "pihole" {
+  managed_by_tag = "acc"
+}
+
+resource "pihole_domain" "test" {
+  domain  = "tf-acc-tagged.example.com"
+  type    = "deny"
+  kind    = "exact"
+  comment = "Tagged domain"
+}
+
+data "pihole_domains" "tagged" {
+  managed_by_tag = "acc"
+  depends_on     = [pihole_domain.test]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// The marker is stored in Pi-hole but hidden from the resource
+					resource.TestCheckResourceAttr("pihole_domain.test", "comment", "Tagged domain"),
+					resource.TestCheckResourceAttr("data.pihole_domains.tagged", "domains.#", "1"),
+					resource.TestCheckResourceAttr("data.pihole_domains.tagged", "domains.0.comment", "Tagged domain [tf:acc]"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceDomain_commentPrefix(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "pihole" {
+  comment_prefix = "[terraform]"
+  managed_by_tag = "acc"
+}
+
+resource "pihole_domain" "test" {
+  domain  = "tf-acc-prefixed.example.com"
+  type    = "deny"
+  kind    = "exact"
+  comment = "Prefixed domain"
+}
+
+data "pihole_domains" "prefixed" {
+  managed_by_tag = "acc"
+  depends_on     = [pihole_domain.test]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// The prefix is stored in Pi-hole but hidden from the resource
+					resource.TestCheckResourceAttr("pihole_domain.test", "comment", "Prefixed domain"),
+					resource.TestCheckResourceAttr("data.pihole_domains.prefixed", "domains.0.comment", "[terraform] Prefixed domain [tf:acc]"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceDomain_exceptions(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(*terraform.State) error {
+			// Destroying the rule removes its exceptions.
+			return testAccCheckAllowExact("tf-acc-docs.tf-acc-zone.example.com", false)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceDomainExceptionsConfig(`"tf-acc-docs.tf-acc-zone.example.com", "tf-acc-status.tf-acc-zone.example.com"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_domain.test", "exceptions.#", "2"),
+					func(*terraform.State) error {
+						return testAccCheckAllowExact("tf-acc-docs.tf-acc-zone.example.com", true)
+					},
+					func(*terraform.State) error {
+						return testAccCheckAllowExact("tf-acc-status.tf-acc-zone.example.com", true)
+					},
+				),
+			},
+			// Removing an exception deletes its allow entry
+			{
+				Config: testAccResourceDomainExceptionsConfig(`"tf-acc-docs.tf-acc-zone.example.com"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_domain.test", "exceptions.#", "1"),
+					func(*terraform.State) error {
+						return testAccCheckAllowExact("tf-acc-status.tf-acc-zone.example.com", false)
+					},
+				),
+			},
+			// Exceptions are only valid on deny rules
+			{
+				Config: `
+resource "pihole_domain" "test" {
+  domain     = "tf-acc-zone.example.com"
+  type       = "allow"
+  kind       = "exact"
+  exceptions = ["tf-acc-docs.tf-acc-zone.example.com"]
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid domain exceptions`),
+			},
+		},
+	})
+}
+
+func TestAccResourceDomain_adoptExisting(t *testing.T) {
+	const domain = "tf-acc-adopt.tf-acc-zone.example.com"
+	config := func(adopt bool) string {
+		return fmt.Sprintf(`
+resource "pihole_domain" "test" {
+  domain         = %q
+  type           = "deny"
+  kind           = "exact"
+  comment        = "Adopted"
+  adopt_existing = %t
+}
+`, domain, adopt)
+	}
+	createExisting := func() {
+		c, err := testAccAPIClient()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := c.CreateDomain(context.Background(), &pihole.Domain{Domain: domain, Type: "deny", Kind: "exact"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// An existing entry makes the creation fail with import advice
+			{
+				PreConfig:   createExisting,
+				Config:      config(false),
+				ExpectError: regexp.MustCompile(`already exists in Pi-hole`),
+			},
+			// adopt_existing takes it over and applies the configuration
+			{
+				Config: config(true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_domain.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("pihole_domain.test", "comment", "Adopted"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckAllowExact checks whether an exact allow entry exists in
+// Pi-hole.
+func testAccCheckAllowExact(domain string, exists bool) error {
+	c, err := testAccAPIClient()
+	if err != nil {
+		return err
+	}
+	d, err := c.GetDomain(context.Background(), "allow", "exact", domain)
+	if err != nil && !errors.Is(err, pihole.ErrNotFound) {
+		return err
+	}
+	if (d != nil) != exists {
+		return fmt.Errorf("allow entry %s exists = %t, want %t", domain, d != nil, exists)
+	}
+	return nil
+}
+
+func testAccResourceDomainExceptionsConfig(exceptions string) string {
+	return fmt.Sprintf(`
+resource "pihole_domain" "test" {
+  domain     = "(\\.|^)tf-acc-zone\\.example\\.com$"
+  type       = "deny"
+  kind       = "regex"
+  exceptions = [%s]
+}
+`, exceptions)
+}
+
 func testAccResourceDomainConfig(domain, domainType, kind string, enabled bool, comment string) string {
 	return fmt.Sprintf(`
 resource "pihole_domain" "test" {
@@ -134,11 +327,11 @@ resource "pihole_domain" "test" {
 func testAccResourceDomainWithGroupConfig() string {
 	return `
 resource "pihole_group" "test" {
-  name = "domain-test-group"
+  name = "tf-acc-domain"
 }
 
 resource "pihole_domain" "test" {
-  domain  = "grouped.example.com"
+  domain  = "tf-acc-grouped.example.com"
   type    = "deny"
   kind    = "exact"
   enabled = true
@@ -0,0 +1,412 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/dklesev/terraform-provider-pihole/internal/provider/convert"
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                   = &ForwardZoneResource{}
+	_ resource.ResourceWithImportState    = &ForwardZoneResource{}
+	_ resource.ResourceWithModifyPlan     = &ForwardZoneResource{}
+	_ resource.ResourceWithValidateConfig = &ForwardZoneResource{}
+)
+
+// Config arrays a forward zone is stored in, also reported as its mechanism.
+const (
+	forwardZoneRevServers   = "dns.revServers"
+	forwardZoneDnsmasqLines = "misc.dnsmasq_lines"
+)
+
+func NewForwardZoneResource() resource.Resource {
+	return &ForwardZoneResource{}
+}
+
+// ForwardZoneResource forwards the queries for a zone to a server: reverse
+// zones through a dns.revServers entry, other zones through a
+// "server=/domain/ip" line in misc.dnsmasq_lines.
+type ForwardZoneResource struct {
+	client *pihole.Client
+}
+
+type ForwardZoneResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Zone        types.String `tfsdk:"zone"`
+	Server      types.String `tfsdk:"server"`
+	Port        types.Int64  `tfsdk:"port"`
+	LocalDomain types.String `tfsdk:"local_domain"`
+	Mechanism   types.String `tfsdk:"mechanism"`
+}
+
+func (r *ForwardZoneResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_forward_zone"
+}
+
+func (r *ForwardZoneResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Forwards the DNS queries for a zone to a specific server.",
+		MarkdownDescription: `
+Forwards the DNS queries for a zone to a specific DNS server, e.g. a company
+domain to the corporate resolver over a VPN, or the reverse lookups of a
+network to the router that hands out its addresses.
+
+The zone is a domain or a network, and the resource picks how Pi-hole
+forwards it:
+
+- **Reverse zones**, given as a CIDR network such as ` + "`192.168.0.0/16`" + ` or as
+  its ` + "`in-addr.arpa`" + `/` + "`ip6.arpa`" + ` name, become a ` + "`dns.revServers`" + ` entry, the
+  conditional forwarding of the web interface. ` + "`local_domain`" + ` additionally
+  forwards the names of the network's devices.
+- **Forward zones**, any other domain, become a dnsmasq ` + "`server=/domain/ip`" + `
+  line in ` + "`misc.dnsmasq_lines`" + `, like ` + "`pihole_conditional_forward`" + `.
+
+` + "`mechanism`" + ` reports which one is used.
+
+~> **Note:** ` + "`pihole_config_misc`" + ` manages ` + "`dnsmasq_lines`" + ` as a whole list and
+removes lines it does not know. When both are used with forward zones, add
+` + "`dnsmasq_lines`" + ` to the ` + "`ignore_changes`" + ` of ` + "`pihole_config_misc`" + `. Do not manage the
+same domain with ` + "`pihole_forward_zone`" + ` and ` + "`pihole_conditional_forward`" + `.
+
+## Example Usage
+
+` + "```hcl" + `
+# Names and reverse lookups of the home network from the router
+resource "pihole_forward_zone" "home" {
+  zone         = "192.168.178.0/24"
+  server       = "192.168.178.1"
+  local_domain = "fritz.box"
+}
+
+# A company domain from the resolver behind the VPN
+resource "pihole_forward_zone" "corp" {
+  zone   = "corp.example"
+  server = "10.0.0.1"
+}
+` + "```" + `
+`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Resource identifier (`zone,server` or `zone,server#port`).",
+			},
+			"zone": schema.StringAttribute{
+				Required: true,
+				Description: "The zone to forward: a domain, including its subdomains, or a network for its reverse lookups, " +
+					"as a CIDR network (e.g. `10.0.0.0/8`) or its `in-addr.arpa` or `ip6.arpa` name.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"server": schema.StringAttribute{
+				Required:    true,
+				Description: "The IP address of the DNS server to forward to.",
+			},
+			"port": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(53),
+				Description: "The port of the DNS server. Default: 53.",
+				Validators: []validator.Int64{
+					int64validator.Between(1, 65535),
+				},
+			},
+			"local_domain": schema.StringAttribute{
+				Optional:    true,
+				Description: "For reverse zones, the domain of the devices in the network, whose names are forwarded to the server as well.",
+			},
+			"mechanism": schema.StringAttribute{
+				Computed: true,
+				Description: "How Pi-hole forwards the zone: `" + forwardZoneRevServers + "` for reverse zones, `" +
+					forwardZoneDnsmasqLines + "` for other zones.",
+			},
+		},
+	}
+}
+
+func (r *ForwardZoneResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData))
+		return
+	}
+	r.client = data.client
+}
+
+// ValidateConfig rejects zones that are neither a network nor a domain and
+// local_domain on forward zones.
+func (r *ForwardZoneResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ForwardZoneResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.Zone.IsUnknown() || data.Zone.IsNull() {
+		return
+	}
+
+	zone := data.Zone.ValueString()
+	_, reverse := convert.ReverseZoneNetwork(zone)
+	switch {
+	case net.ParseIP(zone) != nil:
+		host := "/32"
+		if strings.Contains(zone, ":") {
+			host = "/128"
+		}
+		resp.Diagnostics.AddAttributeError(path.Root("zone"), "Invalid forward zone",
+			fmt.Sprintf("%q is an IP address. Reverse zones are networks, e.g. %q.", zone, zone+host))
+	case !reverse && !isValidDomain(zone):
+		resp.Diagnostics.AddAttributeError(path.Root("zone"), "Invalid forward zone",
+			fmt.Sprintf("%q is neither a domain nor a CIDR network.", zone))
+	case !reverse && !data.LocalDomain.IsNull() && !data.LocalDomain.IsUnknown():
+		resp.Diagnostics.AddAttributeError(path.Root("local_domain"), "Invalid forward zone",
+			"local_domain can only be set for reverse zones. Forward the domain with another pihole_forward_zone instead.")
+	case !data.LocalDomain.IsNull() && !data.LocalDomain.IsUnknown() && !isValidDomain(data.LocalDomain.ValueString()):
+		resp.Diagnostics.AddAttributeError(path.Root("local_domain"), "Invalid forward zone",
+			fmt.Sprintf("%q is not a valid domain.", data.LocalDomain.ValueString()))
+	}
+}
+
+func (r *ForwardZoneResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ForwardZoneResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	array, entry := data.entry()
+	tflog.Debug(ctx, "Creating forward zone", map[string]interface{}{"array": array, "value": entry})
+
+	if err := r.client.AddConfigArrayItemVerified(ctx, configArrayPath(array), entry, data.finder()); err != nil {
+		addAPIErrorf(&resp.Diagnostics, "Error adding forward zone", err, "Could not add %s to %s", entry, array)
+		return
+	}
+
+	data.ID = types.StringValue(data.id())
+	data.Mechanism = types.StringValue(array)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ForwardZoneResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ForwardZoneResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	array, _ := data.entry()
+	current, found := r.find(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if array == forwardZoneRevServers {
+		s, _ := convert.ParseRevServer(current)
+		data.LocalDomain = convert.OptionalString(s.Domain)
+	}
+	data.ID = types.StringValue(data.id())
+	data.Mechanism = types.StringValue(array)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ForwardZoneResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state ForwardZoneResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	old, found := r.find(ctx, &state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	array, entry := data.entry()
+	tflog.Debug(ctx, "Updating forward zone", map[string]interface{}{"array": array, "value": entry})
+
+	// Swap the entry in place so dependents are not replaced with it
+	var err error
+	if found {
+		err = r.client.ReplaceConfigArrayItem(ctx, configArrayPath(array), old, entry, state.finder(), data.finder())
+	} else {
+		err = r.client.AddConfigArrayItemVerified(ctx, configArrayPath(array), entry, data.finder())
+	}
+	if err != nil {
+		addAPIErrorf(&resp.Diagnostics, "Error updating forward zone", err, "Could not write %s to %s", entry, array)
+		return
+	}
+
+	data.ID = types.StringValue(data.id())
+	data.Mechanism = types.StringValue(array)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ForwardZoneResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ForwardZoneResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Delete the entry as stored, which may differ in case or spacing.
+	current, found := r.find(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() || !found {
+		return
+	}
+	array, _ := data.entry()
+	tflog.Debug(ctx, "Deleting forward zone", map[string]interface{}{"array": array, "value": current})
+
+	if err := r.client.DeleteConfigArrayItemVerified(ctx, configArrayPath(array), current, data.finder()); err != nil && !errors.Is(err, pihole.ErrNotFound) {
+		addAPIErrorf(&resp.Diagnostics, "Error deleting forward zone", err, "Could not delete %s from %s", current, array)
+		return
+	}
+}
+
+// ModifyPlan warns before destroys that Pi-hole may refuse and plans the
+// mechanism of the zone.
+func (r *ForwardZoneResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	warnDestructiveDisabled(r.client, req, resp)
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var zone types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("zone"), &zone)...)
+	if resp.Diagnostics.HasError() || zone.IsUnknown() {
+		return
+	}
+	array, _ := ForwardZoneResourceModel{Zone: zone}.entry()
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("mechanism"), array)...)
+}
+
+func (r *ForwardZoneResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import format: "zone,server" or "zone,server#port"
+	zone, server, ok := strings.Cut(req.ID, ",")
+	if !ok || zone == "" || server == "" {
+		resp.Diagnostics.AddError("Invalid import ID", "Expected format: 'zone,server' or 'zone,server#port', e.g. '192.168.0.0/16,192.168.0.1'")
+		return
+	}
+
+	port := int64(53)
+	if host, p, ok := strings.Cut(server, "#"); ok {
+		n, err := strconv.ParseInt(p, 10, 64)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("port"), "Invalid import ID", fmt.Sprintf("Invalid port %q.", p))
+			return
+		}
+		server, port = host, n
+	}
+
+	data := ForwardZoneResourceModel{
+		Zone:        types.StringValue(zone),
+		Server:      types.StringValue(server),
+		Port:        types.Int64Value(port),
+		LocalDomain: types.StringNull(),
+	}
+	array, _ := data.entry()
+	data.ID = types.StringValue(data.id())
+	data.Mechanism = types.StringValue(array)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// find returns the stored entry of the zone described by the model.
+func (r *ForwardZoneResource) find(ctx context.Context, data *ForwardZoneResourceModel, diags *diag.Diagnostics) (string, bool) {
+	if _, reverse := convert.ReverseZoneNetwork(data.Zone.ValueString()); reverse {
+		config, err := r.client.GetDNSConfig(ctx)
+		if err != nil {
+			addAPIError(diags, "Error reading DNS config", err)
+			return "", false
+		}
+		if config == nil {
+			return "", false
+		}
+		return data.finder()(config.RevServers)
+	}
+
+	config, err := r.client.GetMiscConfig(ctx)
+	if err != nil {
+		addAPIError(diags, "Error reading misc config", err)
+		return "", false
+	}
+	if config == nil {
+		return "", false
+	}
+	return data.finder()(config.DnsmasqLines)
+}
+
+// finder looks the zone up in the config array it is stored in, which may
+// store it in another case or spacing.
+func (m ForwardZoneResourceModel) finder() pihole.ItemFinder {
+	return func(entries []string) (string, bool) {
+		if network, reverse := convert.ReverseZoneNetwork(m.Zone.ValueString()); reverse {
+			return convert.FindRevServer(entries, convert.RevServer{
+				Network: network,
+				Server:  m.Server.ValueString(),
+				Port:    m.Port.ValueInt64(),
+			})
+		}
+		return convert.FindServerLine(entries, convert.DnsmasqServer{
+			Domain: m.Zone.ValueString(),
+			Server: m.Server.ValueString(),
+			Port:   m.Port.ValueInt64(),
+		})
+	}
+}
+
+// entry returns the config array the zone is stored in and its entry there.
+func (m ForwardZoneResourceModel) entry() (string, string) {
+	if network, reverse := convert.ReverseZoneNetwork(m.Zone.ValueString()); reverse {
+		return forwardZoneRevServers, convert.RevServer{
+			Enabled: true,
+			Network: network,
+			Server:  m.Server.ValueString(),
+			Port:    m.Port.ValueInt64(),
+			Domain:  m.LocalDomain.ValueString(),
+		}.String()
+	}
+	return forwardZoneDnsmasqLines, convert.DnsmasqServer{
+		Domain: m.Zone.ValueString(),
+		Server: m.Server.ValueString(),
+		Port:   m.Port.ValueInt64(),
+	}.String()
+}
+
+// id formats the resource ID, leaving out the default port.
+func (m ForwardZoneResourceModel) id() string {
+	id := m.Zone.ValueString() + "," + m.Server.ValueString()
+	if m.Port.ValueInt64() != 53 {
+		id += fmt.Sprintf("#%d", m.Port.ValueInt64())
+	}
+	return id
+}
+
+// configArrayPath turns a config array name such as "dns.revServers" into
+// the path of its API endpoint, "dns/revServers".
+func configArrayPath(array string) string {
+	return strings.ReplaceAll(array, ".", "/")
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccResourceForwardZone_reverse(t *testing.T) {
+	testAccParallelTest(t, func(inst *testAccInstance) resource.TestCase {
+		return resource.TestCase{
+			ProtoV6ProviderFactories: inst.providerFactories(),
+			Steps: []resource.TestStep{
+				{
+					Config: testAccResourceForwardZoneConfig("10.98.0.0/16", "10.98.0.1", `local_domain = "tf-test.lan"`),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("pihole_forward_zone.test", "mechanism", "dns.revServers"),
+						resource.TestCheckResourceAttr("pihole_forward_zone.test", "id", "10.98.0.0/16,10.98.0.1"),
+						testAccCheckConfigArrayContains(inst, "dns/revServers", "true,10.98.0.0/16,10.98.0.1,tf-test.lan"),
+					),
+				},
+				// Changing the server swaps the entry in place
+				{
+					Config: testAccResourceForwardZoneConfig("10.98.0.0/16", "10.98.0.2", `local_domain = "tf-test.lan"`),
+					Check:  testAccCheckConfigArrayContains(inst, "dns/revServers", "true,10.98.0.0/16,10.98.0.2,tf-test.lan"),
+				},
+				{
+					ResourceName:      "pihole_forward_zone.test",
+					ImportState:       true,
+					ImportStateId:     "10.98.0.0/16,10.98.0.2",
+					ImportStateVerify: true,
+				},
+			},
+		}
+	})
+}
+
+func TestAccResourceForwardZone_forward(t *testing.T) {
+	testAccParallelTest(t, func(inst *testAccInstance) resource.TestCase {
+		return resource.TestCase{
+			ProtoV6ProviderFactories: inst.providerFactories(),
+			Steps: []resource.TestStep{
+				{
+					Config: testAccResourceForwardZoneConfig("tf-forward.example", "10.0.0.1", "port = 5353"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("pihole_forward_zone.test", "mechanism", "misc.dnsmasq_lines"),
+						testAccCheckConfigArrayContains(inst, "misc/dnsmasq_lines", "server=/tf-forward.example/10.0.0.1#5353"),
+					),
+				},
+				{
+					ResourceName:      "pihole_forward_zone.test",
+					ImportState:       true,
+					ImportStateId:     "tf-forward.example,10.0.0.1#5353",
+					ImportStateVerify: true,
+				},
+				{
+					Config:      testAccResourceForwardZoneConfig("tf-forward.example", "10.0.0.1", `local_domain = "lan"`),
+					ExpectError: regexp.MustCompile(`local_domain can only be set for reverse zones`),
+				},
+				{
+					Config:      testAccResourceForwardZoneConfig("10.0.0.1", "10.0.0.1", ""),
+					ExpectError: regexp.MustCompile(`is an IP address`),
+				},
+			},
+		}
+	})
+}
+
+// testAccCheckConfigArrayContains checks that the config array at path of
+// the instance holds item.
+func testAccCheckConfigArrayContains(inst *testAccInstance, path, item string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		c, err := inst.apiClient()
+		if err != nil {
+			return err
+		}
+		items, err := c.GetConfigArray(context.Background(), path)
+		if err != nil {
+			return err
+		}
+		if !slices.Contains(items, item) {
+			return fmt.Errorf("%s does not contain %q: %q", path, item, items)
+		}
+		return nil
+	}
+}
+
+func testAccResourceForwardZoneConfig(zone, server, extra string) string {
+	return fmt.Sprintf(`
+resource "pihole_forward_zone" "test" {
+  zone   = %q
+  server = %q
+  %s
+}
+`, zone, server, extra)
+}
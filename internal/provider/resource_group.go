@@ -5,9 +5,11 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
-	"github.com/dklesev/terraform-provider-pihole/internal/client"
+	"github.com/dklesev/terraform-provider-pihole/internal/provider/convert"
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -24,6 +26,8 @@ import (
 var (
 	_ resource.Resource                = &GroupResource{}
 	_ resource.ResourceWithImportState = &GroupResource{}
+	_ resource.ResourceWithModifyPlan  = &GroupResource{}
+	_ resource.ResourceWithMoveState   = &GroupResource{}
 )
 
 // NewGroupResource creates a new group resource.
@@ -33,7 +37,8 @@ func NewGroupResource() resource.Resource {
 
 // GroupResource defines the resource implementation.
 type GroupResource struct {
-	client *client.Client
+	client        *pihole.Client
+	plannedGroups *plannedGroups
 }
 
 // GroupResourceModel describes the resource data model.
@@ -44,6 +49,14 @@ type GroupResourceModel struct {
 	Description  types.String `tfsdk:"description"`
 	DateAdded    types.Int64  `tfsdk:"date_added"`
 	DateModified types.Int64  `tfsdk:"date_modified"`
+
+	ComputeCounts types.Bool  `tfsdk:"compute_counts"`
+	ClientCount   types.Int64 `tfsdk:"client_count"`
+	DomainCount   types.Int64 `tfsdk:"domain_count"`
+	ListCount     types.Int64 `tfsdk:"list_count"`
+
+	DeletionProtection types.Bool               `tfsdk:"deletion_protection"`
+	Preconditions      *groupPreconditionsModel `tfsdk:"preconditions"`
 }
 
 func (r *GroupResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -67,6 +80,71 @@ resource "pihole_group" "trusted_devices" {
   description = "Devices with relaxed ad blocking"
 }
 ` + "```" + `
+
+## Renaming
+
+The group is tracked by its ID, so changing ` + "`name`" + ` renames it in place and
+clients, domains and lists keep their assignment. A rename outside Terraform
+shows up as a change to ` + "`name`" + ` on the next plan instead of a lost group.
+
+## Preconditions
+
+Deleting a group that clients, domains or lists outside this configuration are
+still assigned to fails with a database error or quietly changes what they block.
+With a ` + "`preconditions`" + ` block the destroy checks the assignments first and
+fails with what still uses the group.
+
+` + "```hcl" + `
+resource "pihole_group" "kids" {
+  name = "kids"
+
+  preconditions {
+    no_lists = false
+  }
+}
+` + "```" + `
+
+## Assignment Counts
+
+With ` + "`compute_counts = true`" + `, the group reports how many clients, domain rules and
+lists are assigned to it in ` + "`client_count`" + `, ` + "`domain_count`" + ` and ` + "`list_count`" + `.
+Counting reads every client, domain rule and list from Pi-hole on each refresh, so it
+is off by default.
+
+The counts reflect the previous refresh, not the current apply: a new group is counted
+when it is created, before the lists and clients that use it are assigned, so those
+show up in the next plan or ` + "`terraform apply -refresh-only`" + `. Use them to report
+on assignments rather than in conditions that must hold on the first apply.
+
+` + "```hcl" + `
+resource "pihole_group" "kids" {
+  name           = "kids"
+  compute_counts = true
+}
+
+output "kids_assignments" {
+  value = {
+    clients = pihole_group.kids.client_count
+    domains = pihole_group.kids.domain_count
+    lists   = pihole_group.kids.list_count
+  }
+}
+` + "```" + `
+
+## Default Group
+
+Pi-hole ships with a built-in ` + "`Default`" + ` group (ID 0) that cannot be removed.
+Declaring a ` + "`pihole_group`" + ` named ` + "`Default`" + ` adopts the existing group instead
+of creating a new one; only ` + "`enabled`" + ` and ` + "`description`" + ` are managed.
+Renaming the adopted group is rejected at plan time, and destroying it only removes
+it from the Terraform state.
+
+` + "```hcl" + `
+resource "pihole_group" "default" {
+  name        = "Default"
+  description = "The default group"
+}
+` + "```" + `
 `,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.Int64Attribute{
@@ -104,6 +182,29 @@ resource "pihole_group" "trusted_devices" {
 				Description: "Unix timestamp when the group was last modified.",
 				Computed:    true,
 			},
+			"compute_counts": schema.BoolAttribute{
+				Description: "Whether to count the clients, domain rules and lists assigned to the group into `client_count`, `domain_count` and `list_count`. " +
+					"Counting reads all of them from Pi-hole on every refresh. Default: false.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"client_count": schema.Int64Attribute{
+				Description: "Number of clients assigned to the group at the last refresh. Null unless `compute_counts` is set.",
+				Computed:    true,
+			},
+			"domain_count": schema.Int64Attribute{
+				Description: "Number of domain rules assigned to the group at the last refresh. Null unless `compute_counts` is set.",
+				Computed:    true,
+			},
+			"list_count": schema.Int64Attribute{
+				Description: "Number of lists assigned to the group at the last refresh. Null unless `compute_counts` is set.",
+				Computed:    true,
+			},
+			"deletion_protection": deletionProtectionAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"preconditions": groupPreconditionsBlock(),
 		},
 	}
 }
@@ -114,16 +215,17 @@ func (r *GroupResource) Configure(ctx context.Context, req resource.ConfigureReq
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.client
+	r.plannedGroups = data.plannedGroups
 }
 
 func (r *GroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -138,22 +240,28 @@ func (r *GroupResource) Create(ctx context.Context, req resource.CreateRequest,
 		"name": data.Name.ValueString(),
 	})
 
-	group := &client.Group{
+	group := &pihole.Group{
 		Name:        data.Name.ValueString(),
 		Enabled:     data.Enabled.ValueBool(),
 		Description: data.Description.ValueString(),
 	}
 
+	if group.Name == pihole.DefaultGroupName {
+		r.adoptDefaultGroup(ctx, group, &data, resp)
+		return
+	}
+
 	created, err := r.client.CreateGroup(ctx, group)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error creating group",
-			fmt.Sprintf("Could not create group %s: %s", data.Name.ValueString(), err.Error()),
-		)
+		addAPIErrorf(&resp.Diagnostics, "Error creating group", err, "Could not create group %s", data.Name.ValueString())
 		return
 	}
 
 	r.mapGroupToModel(created, &data)
+	if err := r.readCounts(ctx, &data); err != nil {
+		addAPIErrorf(&resp.Diagnostics, "Error counting group assignments", err, "Could not count the assignments of group %s", data.Name.ValueString())
+		return
+	}
 
 	tflog.Debug(ctx, "Created group", map[string]interface{}{
 		"id":   created.ID,
@@ -175,22 +283,25 @@ func (r *GroupResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		"name": data.Name.ValueString(),
 	})
 
-	group, err := r.client.GetGroup(ctx, data.Name.ValueString())
+	group, err := r.currentGroup(ctx, data)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error reading group",
-			fmt.Sprintf("Could not read group %s: %s", data.Name.ValueString(), err.Error()),
-		)
+		addAPIErrorf(&resp.Diagnostics, "Error reading group", err, "Could not read group %s", data.Name.ValueString())
 		return
 	}
 
+	// Pi-hole answers 404 or an empty result for a group deleted outside Terraform.
 	if group == nil {
-		// Group was deleted outside of Terraform
 		resp.State.RemoveResource(ctx)
 		return
 	}
 
+	// A group renamed outside Terraform keeps its ID; the new name shows up
+	// as drift and the next apply renames it back.
 	r.mapGroupToModel(group, &data)
+	if err := r.readCounts(ctx, &data); err != nil {
+		addAPIErrorf(&resp.Diagnostics, "Error counting group assignments", err, "Could not count the assignments of group %s", data.Name.ValueString())
+		return
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -209,22 +320,36 @@ func (r *GroupResource) Update(ctx context.Context, req resource.UpdateRequest,
 		"name": data.Name.ValueString(),
 	})
 
-	group := &client.Group{
+	current, err := r.currentGroup(ctx, state)
+	if err != nil {
+		addAPIErrorf(&resp.Diagnostics, "Error reading group", err, "Could not read group %s", state.Name.ValueString())
+		return
+	}
+	if current == nil {
+		resp.Diagnostics.AddError(
+			"Error updating group",
+			fmt.Sprintf("Group %s (ID %d) no longer exists in Pi-hole.", state.Name.ValueString(), state.ID.ValueInt64()),
+		)
+		return
+	}
+
+	group := &pihole.Group{
 		Name:        data.Name.ValueString(),
 		Enabled:     data.Enabled.ValueBool(),
 		Description: data.Description.ValueString(),
 	}
 
-	updated, err := r.client.UpdateGroup(ctx, state.Name.ValueString(), group)
+	updated, err := r.client.UpdateGroup(ctx, current.Name, group)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error updating group",
-			fmt.Sprintf("Could not update group %s: %s", state.Name.ValueString(), err.Error()),
-		)
+		addAPIErrorf(&resp.Diagnostics, "Error updating group", err, "Could not update group %s", state.Name.ValueString())
 		return
 	}
 
 	r.mapGroupToModel(updated, &data)
+	if err := r.readCounts(ctx, &data); err != nil {
+		addAPIErrorf(&resp.Diagnostics, "Error counting group assignments", err, "Could not count the assignments of group %s", data.Name.ValueString())
+		return
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -237,18 +362,101 @@ func (r *GroupResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	checkDeletionProtection(ctx, req.State, "group", "name", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Deleting the built-in group corrupts Pi-hole's gravity database, so we
+	// only forget about it.
+	if !data.ID.IsNull() && data.ID.ValueInt64() == pihole.DefaultGroupID {
+		tflog.Info(ctx, "Removing built-in Default group from state (group remains in Pi-hole)")
+		return
+	}
+
 	tflog.Debug(ctx, "Deleting group", map[string]interface{}{
 		"name": data.Name.ValueString(),
 	})
 
-	err := r.client.DeleteGroup(ctx, data.Name.ValueString())
+	checkGroupPreconditions(ctx, r.client, data.Preconditions, data.ID.ValueInt64(), data.Name.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, err := r.currentGroup(ctx, data)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error deleting group",
-			fmt.Sprintf("Could not delete group %s: %s", data.Name.ValueString(), err.Error()),
+		addAPIErrorf(&resp.Diagnostics, "Error reading group", err, "Could not read group %s", data.Name.ValueString())
+		return
+	}
+	if current == nil {
+		return
+	}
+
+	err = r.client.DeleteGroup(ctx, current.Name)
+	if err != nil && !errors.Is(err, pihole.ErrNotFound) {
+		addAPIErrorf(&resp.Diagnostics, "Error deleting group", err, "Could not delete group %s", data.Name.ValueString())
+		return
+	}
+}
+
+// ModifyPlan plans null counts without compute_counts, records planned
+// creates for planGroupNames, rejects destroys of protected groups and
+// guards the built-in Default group: it cannot be renamed and a destroy only
+// removes it from state. Other groups get the usual warning when destructive
+// API actions are disabled.
+func (r *GroupResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if !req.Plan.Raw.IsNull() {
+		var computeCounts types.Bool
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("compute_counts"), &computeCounts)...)
+		if !computeCounts.IsUnknown() && !computeCounts.ValueBool() {
+			for _, name := range []string{"client_count", "domain_count", "list_count"} {
+				resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(name), types.Int64Null())...)
+			}
+		}
+	}
+	r.plannedGroups.record(ctx, req, "name")
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	planDeletionProtection(ctx, req, resp, "group", "name")
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state GroupResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.ID.IsNull() || state.ID.IsUnknown() || state.ID.ValueInt64() != pihole.DefaultGroupID {
+		warnDestructiveDisabled(r.client, req, resp)
+		return
+	}
+
+	if req.Plan.Raw.IsNull() {
+		resp.Diagnostics.AddWarning(
+			"Built-in Default group will not be deleted",
+			"The Default group (ID 0) is required by Pi-hole. Destroying this resource only removes it from the Terraform state.",
 		)
 		return
 	}
+
+	var plan GroupResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.Name.IsUnknown() && plan.Name.ValueString() != state.Name.ValueString() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("name"),
+			"Cannot rename the built-in Default group",
+			fmt.Sprintf("The Default group (ID 0) is managed in adopt mode; only enabled and description can be changed. Keep name = %q.", state.Name.ValueString()),
+		)
+	}
 }
 
 func (r *GroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
@@ -256,16 +464,114 @@ func (r *GroupResource) ImportState(ctx context.Context, req resource.ImportStat
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), req.ID)...)
 }
 
-func (r *GroupResource) mapGroupToModel(group *client.Group, data *GroupResourceModel) {
+// MoveState turns pihole_group resources of the ryanwholey/pihole provider
+// into groups of this provider. The computed attributes are filled by the
+// refresh that follows.
+func (r *GroupResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{{
+		StateMover: func(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+			var source struct {
+				Name        string `json:"name"`
+				Enabled     bool   `json:"enabled"`
+				Description string `json:"description"`
+			}
+			if !ryanwholeyState(req, "pihole_group", &source, &resp.Diagnostics) {
+				return
+			}
+
+			data := GroupResourceModel{
+				ID:           types.Int64Null(),
+				Name:         types.StringValue(source.Name),
+				Enabled:      types.BoolValue(source.Enabled),
+				Description:  convert.OptionalString(source.Description),
+				DateAdded:    types.Int64Null(),
+				DateModified: types.Int64Null(),
+			}
+			resp.Diagnostics.Append(resp.TargetState.Set(ctx, &data)...)
+		},
+	}}
+}
+
+// adoptDefaultGroup takes over the built-in Default group instead of creating
+// a new one, updating only its enabled flag and description.
+func (r *GroupResource) adoptDefaultGroup(ctx context.Context, group *pihole.Group, data *GroupResourceModel, resp *resource.CreateResponse) {
+	existing, err := r.client.GetGroup(ctx, group.Name)
+	if err != nil {
+		addAPIErrorf(&resp.Diagnostics, "Error reading group", err, "Could not read group %s", group.Name)
+		return
+	}
+
+	if existing == nil || !existing.IsDefault() {
+		resp.Diagnostics.AddError(
+			"Error adopting Default group",
+			fmt.Sprintf("Group %q was not found as the built-in Default group (ID 0).", group.Name),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "Adopting built-in Default group", map[string]interface{}{
+		"id": existing.ID,
+	})
+
+	updated, err := r.client.UpdateGroup(ctx, existing.Name, group)
+	if err != nil {
+		addAPIErrorf(&resp.Diagnostics, "Error updating group", err, "Could not update group %s", existing.Name)
+		return
+	}
+
+	r.mapGroupToModel(updated, data)
+	if err := r.readCounts(ctx, data); err != nil {
+		addAPIErrorf(&resp.Diagnostics, "Error counting group assignments", err, "Could not count the assignments of group %s", existing.Name)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+// currentGroup looks up the group of data by its ID, which stays the same
+// when the group is renamed, or by name while the ID is not known yet, e.g.
+// after an import or a move from another provider. It returns nil when the
+// group does not exist.
+func (r *GroupResource) currentGroup(ctx context.Context, data GroupResourceModel) (*pihole.Group, error) {
+	if !data.ID.IsNull() && !data.ID.IsUnknown() {
+		return r.client.GetGroupByID(ctx, data.ID.ValueInt64())
+	}
+
+	group, err := r.client.GetGroup(ctx, data.Name.ValueString())
+	if errors.Is(err, pihole.ErrNotFound) {
+		return nil, nil
+	}
+	return group, err
+}
+
+// readCounts fills the counts of data when compute_counts is set and clears
+// them otherwise. Resources that assign the group are applied after it, so
+// their assignments are only counted by the next refresh.
+func (r *GroupResource) readCounts(ctx context.Context, data *GroupResourceModel) error {
+	if !data.ComputeCounts.ValueBool() {
+		data.ComputeCounts = types.BoolValue(false)
+		data.ClientCount = types.Int64Null()
+		data.DomainCount = types.Int64Null()
+		data.ListCount = types.Int64Null()
+		return nil
+	}
+
+	counts, err := countGroupReferences(ctx, r.client, data.ID.ValueInt64())
+	if err != nil {
+		return err
+	}
+	data.ClientCount = types.Int64Value(int64(counts.Clients))
+	data.DomainCount = types.Int64Value(int64(counts.Domains))
+	data.ListCount = types.Int64Value(int64(counts.Lists))
+	return nil
+}
+
+func (r *GroupResource) mapGroupToModel(group *pihole.Group, data *GroupResourceModel) {
 	data.ID = types.Int64Value(group.ID)
 	data.Name = types.StringValue(group.Name)
 	data.Enabled = types.BoolValue(group.Enabled)
 
-	if group.Description != "" {
-		data.Description = types.StringValue(group.Description)
-	} else {
-		data.Description = types.StringNull()
-	}
+	data.Description = convert.OptionalString(group.Description)
 
 	data.DateAdded = types.Int64Value(group.DateAdded)
 	data.DateModified = types.Int64Value(group.DateModified)
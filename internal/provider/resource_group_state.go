@@ -0,0 +1,242 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = &GroupStateResource{}
+	_ resource.ResourceWithImportState = &GroupStateResource{}
+)
+
+func NewGroupStateResource() resource.Resource {
+	return &GroupStateResource{}
+}
+
+// GroupStateResource sets whether an existing group is enabled, without
+// managing the group itself.
+type GroupStateResource struct {
+	client *pihole.Client
+}
+
+type GroupStateResourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	Group   types.String `tfsdk:"group"`
+	GroupID types.Int64  `tfsdk:"group_id"`
+	Enabled types.Bool   `tfsdk:"enabled"`
+}
+
+func (r *GroupStateResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_group_state"
+}
+
+func (r *GroupStateResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Enables or disables an existing group without managing the group itself.",
+		MarkdownDescription: `
+Enables or disables an existing group without managing the group itself, so
+automation can switch a group created elsewhere on and off. Identify the group
+by ` + "`group`" + ` (name) or ` + "`group_id`" + `.
+
+Destroying the resource leaves the group as it is.
+
+## Example Usage
+
+` + "```hcl" + `
+resource "pihole_group_state" "kids_bedtime" {
+  group   = "kids-bedtime"
+  enabled = var.bedtime
+}
+` + "```" + `
+`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The name of the group.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"group": schema.StringAttribute{
+				Description: "The name of the group. Exactly one of `group` and `group_id` is required.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("group_id")),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"group_id": schema.Int64Attribute{
+				Description: "The ID of the group.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplaceIfConfigured(),
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Description: "Whether the group is enabled.",
+				Required:    true,
+			},
+		},
+	}
+}
+
+func (r *GroupStateResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.client
+}
+
+func (r *GroupStateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data GroupStateResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.apply(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *GroupStateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data GroupStateResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	group, err := r.findGroup(ctx, data)
+	if err != nil {
+		addAPIErrorf(&resp.Diagnostics, "Error reading group state", err, "Could not read group %s", data.ID.ValueString())
+		return
+	}
+
+	if group == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	mapGroupStateToModel(group, &data)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *GroupStateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data GroupStateResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.apply(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *GroupStateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// The group keeps its current state.
+}
+
+func (r *GroupStateResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("group"), req, resp)
+}
+
+// findGroup looks the group up by ID when known, otherwise by name. It
+// returns nil when the group does not exist.
+func (r *GroupStateResource) findGroup(ctx context.Context, data GroupStateResourceModel) (*pihole.Group, error) {
+	if data.GroupID.IsNull() || data.GroupID.IsUnknown() {
+		return r.client.GetGroup(ctx, data.Group.ValueString())
+	}
+
+	groups, err := r.client.GetGroups(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	for _, g := range groups {
+		if g.ID == data.GroupID.ValueInt64() {
+			return &g, nil
+		}
+	}
+	return nil, nil
+}
+
+// apply enables or disables the group as planned.
+func (r *GroupStateResource) apply(ctx context.Context, data *GroupStateResourceModel, diags *diag.Diagnostics) {
+	group, err := r.findGroup(ctx, *data)
+	if err != nil {
+		addAPIErrorf(diags, "Error setting group state", err, "Could not read group")
+		return
+	}
+	if group == nil {
+		diags.AddError(
+			"Group not found",
+			fmt.Sprintf("No group with name %s or ID %s exists.", data.Group.String(), data.GroupID.String()),
+		)
+		return
+	}
+
+	if group.Enabled != data.Enabled.ValueBool() {
+		tflog.Info(ctx, "Setting group state", map[string]interface{}{
+			"group":   group.Name,
+			"enabled": data.Enabled.ValueBool(),
+		})
+		group.Enabled = data.Enabled.ValueBool()
+		updated, err := r.client.UpdateGroup(ctx, group.Name, group)
+		if err != nil {
+			addAPIErrorf(diags, "Error setting group state", err, "Could not update group %s", group.Name)
+			return
+		}
+		group = updated
+	}
+
+	mapGroupStateToModel(group, data)
+}
+
+func mapGroupStateToModel(group *pihole.Group, data *GroupStateResourceModel) {
+	data.ID = types.StringValue(group.Name)
+	data.Group = types.StringValue(group.Name)
+	data.GroupID = types.Int64Value(group.ID)
+	data.Enabled = types.BoolValue(group.Enabled)
+}
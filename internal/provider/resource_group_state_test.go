@@ -0,0 +1,57 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccResourceGroupState_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceGroupStateConfig(false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_group_state.test", "id", "tf-acc-group-state"),
+					resource.TestCheckResourceAttr("pihole_group_state.test", "enabled", "false"),
+					resource.TestCheckResourceAttrPair("pihole_group_state.test", "group_id", "pihole_group.test", "id"),
+				),
+			},
+			{
+				ResourceName:      "pihole_group_state.test",
+				ImportState:       true,
+				ImportStateId:     "tf-acc-group-state",
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccResourceGroupStateConfig(true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_group_state.test", "enabled", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceGroupStateConfig(enabled bool) string {
+	return fmt.Sprintf(`
+resource "pihole_group" "test" {
+  name = "tf-acc-group-state"
+
+  lifecycle {
+    ignore_changes = [enabled]
+  }
+}
+
+resource "pihole_group_state" "test" {
+  group   = pihole_group.test.name
+  enabled = %t
+}
+`, enabled)
+}
@@ -4,10 +4,15 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"regexp"
 	"testing"
 
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 func TestAccResourceGroup_basic(t *testing.T) {
@@ -17,9 +22,9 @@ func TestAccResourceGroup_basic(t *testing.T) {
 		Steps: []resource.TestStep{
 			// Create and Read
 			{
-				Config: testAccResourceGroupConfig("test-group-basic", true, "Basic test group"),
+				Config: testAccResourceGroupConfig("tf-acc-group-basic", true, "Basic test group"),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("pihole_group.test", "name", "test-group-basic"),
+					resource.TestCheckResourceAttr("pihole_group.test", "name", "tf-acc-group-basic"),
 					resource.TestCheckResourceAttr("pihole_group.test", "enabled", "true"),
 					resource.TestCheckResourceAttr("pihole_group.test", "description", "Basic test group"),
 					resource.TestCheckResourceAttrSet("pihole_group.test", "id"),
@@ -30,60 +35,250 @@ func TestAccResourceGroup_basic(t *testing.T) {
 			{
 				ResourceName:      "pihole_group.test",
 				ImportState:       true,
-				ImportStateId:     "test-group-basic",
+				ImportStateId:     "tf-acc-group-basic",
 				ImportStateVerify: true,
 			},
 			// Update
 			{
-				Config: testAccResourceGroupConfig("test-group-basic", false, "Updated description"),
+				Config: testAccResourceGroupConfig("tf-acc-group-basic", false, "Updated description"),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("pihole_group.test", "name", "test-group-basic"),
+					resource.TestCheckResourceAttr("pihole_group.test", "name", "tf-acc-group-basic"),
 					resource.TestCheckResourceAttr("pihole_group.test", "enabled", "false"),
 					resource.TestCheckResourceAttr("pihole_group.test", "description", "Updated description"),
 				),
 			},
 			// Update name
 			{
-				Config: testAccResourceGroupConfig("test-group-renamed", false, "Updated description"),
+				Config: testAccResourceGroupConfig("tf-acc-group-renamed", false, "Updated description"),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("pihole_group.test", "name", "test-group-renamed"),
+					resource.TestCheckResourceAttr("pihole_group.test", "name", "tf-acc-group-renamed"),
 				),
 			},
 		},
 	})
 }
 
+func TestAccResourceGroup_renamedOutside(t *testing.T) {
+	testAccParallelTest(t, func(inst *testAccInstance) resource.TestCase {
+		return resource.TestCase{
+			ProtoV6ProviderFactories: inst.providerFactories(),
+			Steps: []resource.TestStep{
+				{
+					Config: testAccResourceGroupConfig("tf-acc-group-tracked", true, "Tracked by ID"),
+				},
+				// A rename in Pi-hole is found by ID and reverted
+				{
+					PreConfig: func() {
+						c, err := inst.apiClient()
+						if err != nil {
+							t.Fatal(err)
+						}
+						_, err = c.UpdateGroup(context.Background(), "tf-acc-group-tracked", &pihole.Group{Name: "tf-acc-group-manual", Enabled: true, Description: "Tracked by ID"})
+						if err != nil {
+							t.Fatal(err)
+						}
+					},
+					Config: testAccResourceGroupConfig("tf-acc-group-tracked", true, "Tracked by ID"),
+					ConfigPlanChecks: resource.ConfigPlanChecks{
+						PreApply: []plancheck.PlanCheck{
+							plancheck.ExpectResourceAction("pihole_group.test", plancheck.ResourceActionUpdate),
+						},
+					},
+					Check: resource.TestCheckResourceAttr("pihole_group.test", "name", "tf-acc-group-tracked"),
+				},
+			},
+		}
+	})
+}
+
 func TestAccResourceGroup_minimal(t *testing.T) {
+	testAccParallelTest(t, func(inst *testAccInstance) resource.TestCase {
+		return resource.TestCase{
+			ProtoV6ProviderFactories: inst.providerFactories(),
+			Steps: []resource.TestStep{
+				{
+					Config: testAccResourceGroupConfigMinimal("tf-acc-group-minimal"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("pihole_group.test", "name", "tf-acc-group-minimal"),
+						resource.TestCheckResourceAttr("pihole_group.test", "enabled", "true"),
+					),
+				},
+			},
+		}
+	})
+}
+
+func TestAccResourceGroup_disabled(t *testing.T) {
+	testAccParallelTest(t, func(inst *testAccInstance) resource.TestCase {
+		return resource.TestCase{
+			ProtoV6ProviderFactories: inst.providerFactories(),
+			Steps: []resource.TestStep{
+				{
+					Config: testAccResourceGroupConfig("tf-acc-group-disabled", false, "Disabled group"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("pihole_group.test", "enabled", "false"),
+					),
+				},
+			},
+		}
+	})
+}
+
+func TestAccResourceGroup_default(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
+			// Adopt the built-in group instead of creating it
 			{
-				Config: testAccResourceGroupConfigMinimal("test-group-minimal"),
+				Config: testAccResourceGroupConfig("Default", true, "Adopted default group"),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("pihole_group.test", "name", "test-group-minimal"),
-					resource.TestCheckResourceAttr("pihole_group.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("pihole_group.test", "id", "0"),
+					resource.TestCheckResourceAttr("pihole_group.test", "name", "Default"),
+					resource.TestCheckResourceAttr("pihole_group.test", "description", "Adopted default group"),
 				),
 			},
+			// Renaming is rejected at plan time
+			{
+				Config:      testAccResourceGroupConfig("NotDefault", true, "Adopted default group"),
+				ExpectError: regexp.MustCompile("Cannot rename the built-in Default group"),
+			},
+			// Destroying only removes it from state, the group must survive
+			{
+				Config: `# empty`,
+				Check: func(s *terraform.State) error {
+					return testAccCheckDefaultGroupExists()
+				},
+			},
 		},
 	})
 }
 
-func TestAccResourceGroup_disabled(t *testing.T) {
+func TestAccResourceGroup_counts(t *testing.T) {
+	config := func(computeCounts bool) string {
+		return fmt.Sprintf(`
+resource "pihole_group" "test" {
+  name           = "tf-acc-group-counts"
+  compute_counts = %t
+}
+
+resource "pihole_domain" "test" {
+  domain = "tf-acc-counts.example.com"
+  type   = "deny"
+  kind   = "exact"
+  groups = [pihole_group.test.id]
+}
+`, computeCounts)
+	}
+
+	testAccParallelTest(t, func(inst *testAccInstance) resource.TestCase {
+		return resource.TestCase{
+			ProtoV6ProviderFactories: inst.providerFactories(),
+			Steps: []resource.TestStep{
+				{
+					Config: config(true),
+				},
+				// The domain is assigned after the group is created, so it
+				// is counted on the next refresh
+				{
+					Config: config(true),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("pihole_group.test", "domain_count", "1"),
+						resource.TestCheckResourceAttr("pihole_group.test", "client_count", "0"),
+						resource.TestCheckResourceAttr("pihole_group.test", "list_count", "0"),
+					),
+				},
+				{
+					Config: config(false),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckNoResourceAttr("pihole_group.test", "domain_count"),
+						resource.TestCheckNoResourceAttr("pihole_group.test", "client_count"),
+					),
+				},
+			},
+		}
+	})
+}
+
+func TestAccResourceGroup_preconditions(t *testing.T) {
+	const name = "tf-acc-group-preconditions"
+	const domain = "tf-acc-preconditions.example.com"
+
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccResourceGroupConfig("test-group-disabled", false, "Disabled group"),
-				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("pihole_group.test", "enabled", "false"),
-				),
+				Config: fmt.Sprintf(`
+resource "pihole_group" "test" {
+  name = %q
+
+  preconditions {}
+}
+`, name),
+			},
+			// A domain added outside Terraform keeps the group from being destroyed
+			{
+				PreConfig: func() {
+					if err := testAccAssignDomain(name, domain); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:      `# empty`,
+				ExpectError: regexp.MustCompile(`Group still in use`),
+			},
+			{
+				PreConfig: func() {
+					c, err := testAccAPIClient()
+					if err != nil {
+						t.Fatal(err)
+					}
+					if err := c.DeleteDomain(context.Background(), "deny", "exact", domain); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: `# empty`,
 			},
 		},
 	})
 }
 
+// testAccAssignDomain adds domain to the group called name through the API.
+func testAccAssignDomain(name, domain string) error {
+	c, err := testAccAPIClient()
+	if err != nil {
+		return err
+	}
+	group, err := c.GetGroup(context.Background(), name)
+	if err != nil {
+		return err
+	}
+	_, err = c.CreateDomain(context.Background(), &pihole.Domain{
+		Domain:  domain,
+		Type:    "deny",
+		Kind:    "exact",
+		Enabled: true,
+		Groups:  []int64{group.ID},
+	})
+	return err
+}
+
+func testAccCheckDefaultGroupExists() error {
+	c, err := testAccAPIClient()
+	if err != nil {
+		return err
+	}
+
+	group, err := c.GetGroup(context.Background(), pihole.DefaultGroupName)
+	if err != nil {
+		return err
+	}
+	if group == nil || !group.IsDefault() {
+		return fmt.Errorf("built-in Default group was removed")
+	}
+	return nil
+}
+
 func testAccResourceGroupConfig(name string, enabled bool, description string) string {
 	return fmt.Sprintf(`
 resource "pihole_group" "test" {
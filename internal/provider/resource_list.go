@@ -5,10 +5,13 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 
-	"github.com/dklesev/terraform-provider-pihole/internal/client"
+	"github.com/dklesev/terraform-provider-pihole/internal/provider/convert"
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -25,6 +28,7 @@ import (
 var (
 	_ resource.Resource                = &ListResource{}
 	_ resource.ResourceWithImportState = &ListResource{}
+	_ resource.ResourceWithModifyPlan  = &ListResource{}
 )
 
 func NewListResource() resource.Resource {
@@ -32,20 +36,46 @@ func NewListResource() resource.Resource {
 }
 
 type ListResource struct {
-	client *client.Client
+	client        *pihole.Client
+	comments      commentSettings
+	plannedGroups *plannedGroups
 }
 
 type ListResourceModel struct {
-	ID           types.Int64  `tfsdk:"id"`
-	Address      types.String `tfsdk:"address"`
-	Type         types.String `tfsdk:"type"`
-	Enabled      types.Bool   `tfsdk:"enabled"`
-	Comment      types.String `tfsdk:"comment"`
-	Groups       types.Set    `tfsdk:"groups"`
-	DateAdded    types.Int64  `tfsdk:"date_added"`
-	DateModified types.Int64  `tfsdk:"date_modified"`
-	Number       types.Int64  `tfsdk:"number"`
-	Status       types.Int64  `tfsdk:"status"`
+	ID                 types.Int64  `tfsdk:"id"`
+	Address            types.String `tfsdk:"address"`
+	NormalizedAddress  types.String `tfsdk:"normalized_address"`
+	Type               types.String `tfsdk:"type"`
+	Enabled            types.Bool   `tfsdk:"enabled"`
+	Comment            types.String `tfsdk:"comment"`
+	Groups             types.Set    `tfsdk:"groups"`
+	GroupNames         types.Set    `tfsdk:"group_names"`
+	DateAdded          types.Int64  `tfsdk:"date_added"`
+	DateModified       types.Int64  `tfsdk:"date_modified"`
+	DateUpdated        types.Int64  `tfsdk:"date_updated"`
+	Number             types.Int64  `tfsdk:"number"`
+	InvalidDomains     types.Int64  `tfsdk:"invalid_domains"`
+	ABPEntries         types.Int64  `tfsdk:"abp_entries"`
+	Status             types.Int64  `tfsdk:"status"`
+	IgnoreGravityStats types.Bool   `tfsdk:"ignore_gravity_stats"`
+	AdoptExisting      types.Bool   `tfsdk:"adopt_existing"`
+	DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
+}
+
+// gravityStats are the attributes of the list that gravity changes on every
+// run, in a fixed order.
+func (m *ListResourceModel) gravityStats() []*types.Int64 {
+	return []*types.Int64{&m.DateUpdated, &m.Number, &m.InvalidDomains, &m.ABPEntries, &m.Status}
+}
+
+// keepGravityStats copies the known gravity stats of from to m.
+func (m *ListResourceModel) keepGravityStats(from *ListResourceModel) {
+	stats := m.gravityStats()
+	for i, v := range from.gravityStats() {
+		if !v.IsNull() && !v.IsUnknown() {
+			*stats[i] = *v
+		}
+	}
 }
 
 func (r *ListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -84,6 +114,59 @@ resource "pihole_list" "whitelist" {
 }
 ` + "```" + `
 
+### Adopt an Existing List
+
+With ` + "`adopt_existing = true`" + ` a list of the same address and type that is
+already in Pi-hole is taken over instead of failing to create it, and changed
+to match the configuration:
+
+` + "```hcl" + `
+resource "pihole_list" "stevenblack" {
+  address        = "https://raw.githubusercontent.com/StevenBlack/hosts/master/hosts"
+  type           = "block"
+  adopt_existing = true
+}
+` + "```" + `
+
+### Gravity Stats
+
+` + "`number`" + `, ` + "`invalid_domains`" + `, ` + "`abp_entries`" + `, ` + "`status`" + ` and ` + "`date_updated`" + ` change
+with every gravity run. Plans keep them from state unless the address or type
+changes, and ` + "`date_modified`" + ` is recomputed on every update. With
+` + "`ignore_gravity_stats = true`" + ` refreshes keep them from state as well, so they
+show the values from when the list was created, imported or last moved to a new
+address:
+
+` + "```hcl" + `
+resource "pihole_list" "oisd" {
+  address              = "https://big.oisd.nl"
+  type                 = "block"
+  ignore_gravity_stats = true
+}
+` + "```" + `
+
+### Address Normalization
+
+Pi-hole may store a list address in another form than configured, e.g. with a
+lowercase host or without a trailing slash. The provider compares addresses by
+` + "`normalized_address`" + `, in which scheme and host are lowercase and default ports
+and trailing slashes are removed, so such differences do not show up as changes.
+Path and query keep their case.
+
+### Deletion Protection
+
+` + "`deletion_protection = true`" + ` makes plans that destroy the list fail, e.g. for an
+allowlist that business applications depend on. Set it to false and apply
+before removing the resource:
+
+` + "```hcl" + `
+resource "pihole_list" "corporate_allowlist" {
+  address             = "https://intranet.example.com/pihole/allowlist.txt"
+  type                = "allow"
+  deletion_protection = true
+}
+` + "```" + `
+
 ## Import
 
 Lists can be imported using the format ` + "`type/address`" + `:
@@ -91,6 +174,13 @@ Lists can be imported using the format ` + "`type/address`" + `:
 ` + "```shell" + `
 terraform import pihole_list.example block/https://example.com/blocklist.txt
 ` + "```" + `
+
+or by their numeric ID with ` + "`id:<id>`" + `, e.g. when an address is used by both an
+allowlist and a blocklist or does not survive URL path encoding:
+
+` + "```shell" + `
+terraform import pihole_list.example id:12
+` + "```" + `
 `,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.Int64Attribute{
@@ -101,9 +191,13 @@ terraform import pihole_list.example block/https://example.com/blocklist.txt
 				},
 			},
 			"address": schema.StringAttribute{
-				Description: "The URL of the list.",
+				Description: "The URL of the list. Pi-hole may store it in another form, e.g. with a lowercase host or without a trailing slash; the configured form is kept as long as it has the same normalized address.",
 				Required:    true,
 			},
+			"normalized_address": schema.StringAttribute{
+				Description: "The address with scheme and host in lowercase, without a default port and without trailing slashes. Addresses with the same normalized address are the same list for the provider.",
+				Computed:    true,
+			},
 			"type": schema.StringAttribute{
 				Description: "The type of list: 'block' or 'allow'.",
 				Required:    true,
@@ -118,7 +212,7 @@ terraform import pihole_list.example block/https://example.com/blocklist.txt
 				Default:     booldefault.StaticBool(true),
 			},
 			"comment": schema.StringAttribute{
-				Description: "A comment describing the list.",
+				Description: "A comment describing the list. The provider's `managed_by_tag` marker is appended in Pi-hole and hidden here.",
 				Optional:    true,
 			},
 			"groups": schema.SetAttribute{
@@ -127,6 +221,7 @@ terraform import pihole_list.example block/https://example.com/blocklist.txt
 				Computed:    true,
 				ElementType: types.Int64Type,
 			},
+			"group_names": groupNamesAttribute(),
 			"date_added": schema.Int64Attribute{
 				Description: "Unix timestamp when the list was added.",
 				Computed:    true,
@@ -138,14 +233,32 @@ terraform import pihole_list.example block/https://example.com/blocklist.txt
 				Description: "Unix timestamp when the list was last modified.",
 				Computed:    true,
 			},
+			"date_updated": schema.Int64Attribute{
+				Description: "Unix timestamp when gravity last downloaded the list. 0 if it never has.",
+				Computed:    true,
+			},
 			"number": schema.Int64Attribute{
 				Description: "Number of domains in the list.",
 				Computed:    true,
 			},
+			"invalid_domains": schema.Int64Attribute{
+				Description: "Number of lines gravity could not parse as domains at the last download.",
+				Computed:    true,
+			},
+			"abp_entries": schema.Int64Attribute{
+				Description: "Number of Adblock Plus style entries in the list.",
+				Computed:    true,
+			},
 			"status": schema.Int64Attribute{
-				Description: "Download status of the list.",
+				Description: "Download status of the list: 1 = updated, 2 = unchanged, 3 = unavailable (cached copy used), 4 = unavailable (no copy).",
 				Computed:    true,
 			},
+			"ignore_gravity_stats": schema.BoolAttribute{
+				Description: "Whether refreshes keep date_updated, number, invalid_domains, abp_entries and status from state instead of reading them from Pi-hole, for configurations that do not use them and want no refresh noise after gravity runs. They are still read on create, import and address or type changes. Default: false.",
+				Optional:    true,
+			},
+			"adopt_existing":      adoptExistingAttribute(),
+			"deletion_protection": deletionProtectionAttribute(),
 		},
 	}
 }
@@ -155,19 +268,23 @@ func (r *ListResource) Configure(ctx context.Context, req resource.ConfigureRequ
 		return
 	}
 
-	c, ok := req.ProviderData.(*client.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = c
+	r.client = data.client
+	r.comments = data.comments
+	r.plannedGroups = data.plannedGroups
 }
 
 func (r *ListResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer trackGravityChange(ctx, r.client, &resp.Diagnostics)()
+
 	var data ListResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -180,28 +297,27 @@ func (r *ListResource) Create(ctx context.Context, req resource.CreateRequest, r
 		"type":    data.Type.ValueString(),
 	})
 
-	var groups []int64
-	if !data.Groups.IsNull() && !data.Groups.IsUnknown() {
-		resp.Diagnostics.Append(data.Groups.ElementsAs(ctx, &groups, false)...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
+	groups, diags := resolveGroups(ctx, r.client, data.Groups, data.GroupNames)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	list := &client.List{
+	list := &pihole.List{
 		Address: data.Address.ValueString(),
 		Type:    data.Type.ValueString(),
 		Enabled: data.Enabled.ValueBool(),
-		Comment: data.Comment.ValueString(),
+		Comment: r.comments.managed(data.Comment.ValueString()),
 		Groups:  groups,
 	}
 
 	created, err := r.client.CreateList(ctx, list)
+	if errors.Is(err, pihole.ErrExists) && data.AdoptExisting.ValueBool() {
+		tflog.Info(ctx, "Adopting existing list", map[string]interface{}{"address": list.Address})
+		created, err = r.updateList(ctx, types.Int64Null(), list.Type, list.Address, list)
+	}
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error creating list",
-			fmt.Sprintf("Could not create list %s: %s", data.Address.ValueString(), err.Error()),
-		)
+		addCreateError(&resp.Diagnostics, "Error creating list", "list "+data.Address.ValueString(), err, "pihole_list", list.Type+"/"+list.Address)
 		return
 	}
 
@@ -217,25 +333,29 @@ func (r *ListResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	list, err := r.client.GetList(ctx, data.Type.ValueString(), data.Address.ValueString())
+	list, err := r.findList(ctx, data.ID, data.Type.ValueString(), data.Address.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error reading list",
-			fmt.Sprintf("Could not read list %s: %s", data.Address.ValueString(), err.Error()),
-		)
+		addAPIErrorf(&resp.Diagnostics, "Error reading list", err, "Could not read list %s", data.Address.ValueString())
 		return
 	}
 
+	// Pi-hole answers 404 or an empty result for a list deleted outside Terraform.
 	if list == nil {
 		resp.State.RemoveResource(ctx)
 		return
 	}
 
+	stored := data
 	r.mapListToModel(ctx, list, &data, &resp.Diagnostics)
+	if data.IgnoreGravityStats.ValueBool() {
+		data.keepGravityStats(&stored)
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *ListResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer trackGravityChange(ctx, r.client, &resp.Diagnostics)()
+
 	var data ListResourceModel
 	var state ListResourceModel
 
@@ -245,36 +365,37 @@ func (r *ListResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
-	var groups []int64
-	if !data.Groups.IsNull() && !data.Groups.IsUnknown() {
-		resp.Diagnostics.Append(data.Groups.ElementsAs(ctx, &groups, false)...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
+	groups, diags := resolveGroups(ctx, r.client, data.Groups, data.GroupNames)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	list := &client.List{
+	list := &pihole.List{
 		Address: data.Address.ValueString(),
 		Type:    data.Type.ValueString(),
 		Enabled: data.Enabled.ValueBool(),
-		Comment: data.Comment.ValueString(),
+		Comment: r.comments.managed(data.Comment.ValueString()),
 		Groups:  groups,
 	}
 
-	updated, err := r.client.UpdateList(ctx, state.Type.ValueString(), state.Address.ValueString(), list)
+	updated, err := r.updateList(ctx, state.ID, state.Type.ValueString(), state.Address.ValueString(), list)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error updating list",
-			fmt.Sprintf("Could not update list %s: %s", state.Address.ValueString(), err.Error()),
-		)
+		addAPIErrorf(&resp.Diagnostics, "Error updating list", err, "Could not update list %s", state.Address.ValueString())
 		return
 	}
 
+	// The plan keeps the gravity stats from state unless the list moved;
+	// gravity may run between the refresh and this update.
+	planned := data
 	r.mapListToModel(ctx, updated, &data, &resp.Diagnostics)
+	data.keepGravityStats(&planned)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *ListResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer trackGravityChange(ctx, r.client, &resp.Diagnostics)()
+
 	var data ListResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -282,23 +403,74 @@ func (r *ListResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
-	err := r.client.DeleteList(ctx, data.Type.ValueString(), data.Address.ValueString())
+	checkDeletionProtection(ctx, req.State, "list", "address", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.findList(ctx, data.ID, data.Type.ValueString(), data.Address.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error deleting list",
-			fmt.Sprintf("Could not delete list %s: %s", data.Address.ValueString(), err.Error()),
-		)
+		addAPIErrorf(&resp.Diagnostics, "Error reading list", err, "Could not read list %s", data.Address.ValueString())
+		return
+	}
+	if stored == nil {
+		return
+	}
+
+	err = r.client.DeleteList(ctx, stored.Type, stored.Address)
+	if err != nil && !errors.Is(err, pihole.ErrNotFound) {
+		addAPIErrorf(&resp.Diagnostics, "Error deleting list", err, "Could not delete list %s", data.Address.ValueString())
 		return
 	}
 }
 
+// ModifyPlan rejects destroys of protected lists, warns before destroys that
+// Pi-hole may refuse, plans the normalized address, keeps the gravity stats
+// of lists whose address and type stay the same and resolves group_names.
+func (r *ListResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planDeletionProtection(ctx, req, resp, "list", "address")
+	warnDestructiveDisabled(r.client, req, resp)
+	if req.Plan.Raw.IsNull() || resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan ListResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !plan.Address.IsUnknown() {
+		plan.NormalizedAddress = types.StringValue(convert.NormalizeListAddress(plan.Address.ValueString()))
+	}
+
+	if !req.State.Raw.IsNull() {
+		var state ListResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		samePlace := !plan.Address.IsUnknown() && convert.SameListAddress(plan.Address.ValueString(), state.Address.ValueString())
+		if samePlace && plan.Type.Equal(state.Type) {
+			plan.keepGravityStats(&state)
+		}
+	}
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+	planGroupNames(ctx, r.client, r.plannedGroups, req, resp, false)
+}
+
 func (r *ListResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import format: id:<id>
+	if idString, ok := strings.CutPrefix(req.ID, "id:"); ok {
+		r.importByID(ctx, idString, resp)
+		return
+	}
+
 	// Import format: type/address
 	parts := strings.SplitN(req.ID, "/", 2)
 	if len(parts) != 2 {
 		resp.Diagnostics.AddError(
 			"Invalid import ID",
-			"Import ID must be in the format: type/address (e.g., block/https://example.com/list.txt)",
+			"Import ID must be in the format: type/address (e.g., block/https://example.com/list.txt) or id:<id> (e.g., id:12)",
 		)
 		return
 	}
@@ -307,28 +479,102 @@ func (r *ListResource) ImportState(ctx context.Context, req resource.ImportState
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("address"), parts[1])...)
 }
 
-func (r *ListResource) mapListToModel(ctx context.Context, list *client.List, data *ListResourceModel, diags *diag.Diagnostics) {
+// importByID imports the list with the numeric ID idString, which tells
+// apart lists of both types with the same address.
+func (r *ListResource) importByID(ctx context.Context, idString string, resp *resource.ImportStateResponse) {
+	id, err := strconv.ParseInt(idString, 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("The list ID in %q must be a number, e.g. id:12.", "id:"+idString),
+		)
+		return
+	}
+
+	list, err := r.client.GetListByID(ctx, id)
+	if err != nil {
+		addAPIErrorf(&resp.Diagnostics, "Error reading list", err, "Could not read list %d", id)
+		return
+	}
+	if list == nil {
+		resp.Diagnostics.AddError("List not found", fmt.Sprintf("No list with ID %d exists in Pi-hole.", id))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), list.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("type"), list.Type)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("address"), list.Address)...)
+}
+
+// findList looks up a list by its address as stored in Pi-hole, which only
+// reads that list. When that finds nothing, or a list with another ID, the
+// lists of the type are read once to find the list by its ID and then by its
+// normalized address, for addresses Pi-hole stored in another form or that
+// changed outside Terraform. It returns nil when the list does not exist.
+func (r *ListResource) findList(ctx context.Context, id types.Int64, listType, address string) (*pihole.List, error) {
+	knownID := !id.IsNull() && !id.IsUnknown()
+
+	list, err := r.client.GetList(ctx, listType, address)
+	if err != nil && !errors.Is(err, pihole.ErrNotFound) {
+		return nil, err
+	}
+	if list != nil && (!knownID || list.ID == id.ValueInt64()) {
+		return list, nil
+	}
+
+	lists, err := r.client.GetLists(ctx, listType, "")
+	if err != nil {
+		return nil, err
+	}
+	if knownID {
+		for i := range lists {
+			if lists[i].ID == id.ValueInt64() && lists[i].Type == listType {
+				return &lists[i], nil
+			}
+		}
+	}
+	for i := range lists {
+		if lists[i].Type == listType && convert.SameListAddress(lists[i].Address, address) {
+			return &lists[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// updateList updates the list found by findList, addressing it in the form
+// Pi-hole stored it in.
+func (r *ListResource) updateList(ctx context.Context, id types.Int64, listType, address string, list *pihole.List) (*pihole.List, error) {
+	stored, err := r.findList(ctx, id, listType, address)
+	if err != nil {
+		return nil, err
+	}
+	if stored == nil {
+		return nil, fmt.Errorf("%s list %s: %w", listType, address, pihole.ErrNotFound)
+	}
+	return r.client.UpdateList(ctx, stored.Type, stored.Address, list)
+}
+
+func (r *ListResource) mapListToModel(ctx context.Context, list *pihole.List, data *ListResourceModel, diags *diag.Diagnostics) {
 	data.ID = types.Int64Value(list.ID)
-	data.Address = types.StringValue(list.Address)
+	// Keep the configured form of the address Pi-hole normalized.
+	if data.Address.IsNull() || data.Address.IsUnknown() || !convert.SameListAddress(data.Address.ValueString(), list.Address) {
+		data.Address = types.StringValue(list.Address)
+	}
+	data.NormalizedAddress = types.StringValue(convert.NormalizeListAddress(list.Address))
 	data.Type = types.StringValue(list.Type)
 	data.Enabled = types.BoolValue(list.Enabled)
 
-	if list.Comment != "" {
-		data.Comment = types.StringValue(list.Comment)
-	} else {
-		data.Comment = types.StringNull()
-	}
+	data.Comment = convert.OptionalString(r.comments.unmanaged(list.Comment))
 
-	if len(list.Groups) > 0 {
-		groupsList, d := types.SetValueFrom(ctx, types.Int64Type, list.Groups)
-		diags.Append(d...)
-		data.Groups = groupsList
-	} else {
-		data.Groups = types.SetNull(types.Int64Type)
-	}
+	groups, d := convert.GroupSet(ctx, list.Groups)
+	diags.Append(d...)
+	data.Groups = groups
 
 	data.DateAdded = types.Int64Value(list.DateAdded)
 	data.DateModified = types.Int64Value(list.DateModified)
+	data.DateUpdated = types.Int64Value(list.DateUpdated)
 	data.Number = types.Int64Value(list.Number)
+	data.InvalidDomains = types.Int64Value(list.InvalidDomains)
+	data.ABPEntries = types.Int64Value(list.ABPEntries)
 	data.Status = types.Int64Value(int64(list.Status))
 }
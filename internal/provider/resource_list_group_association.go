@@ -0,0 +1,235 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = &ListGroupAssociationResource{}
+	_ resource.ResourceWithImportState = &ListGroupAssociationResource{}
+)
+
+func NewListGroupAssociationResource() resource.Resource {
+	return &ListGroupAssociationResource{}
+}
+
+// ListGroupAssociationResource assigns a group to a list that is managed
+// elsewhere, without owning the list.
+type ListGroupAssociationResource struct {
+	client *pihole.Client
+}
+
+type ListGroupAssociationResourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	Address types.String `tfsdk:"address"`
+	Type    types.String `tfsdk:"type"`
+	GroupID types.Int64  `tfsdk:"group_id"`
+}
+
+func (r *ListGroupAssociationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_list_group_association"
+}
+
+func (r *ListGroupAssociationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Assigns a group to an existing Pi-hole list without managing the list itself.",
+		MarkdownDescription: `
+Assigns a group to an existing Pi-hole list without managing the list itself,
+e.g. to apply a list another team or configuration subscribes to to your own
+group. The list's other groups and settings are left alone; destroying the
+resource only removes this group.
+
+The groups are changed by reading the list and writing it back. When another
+change overwrote them in between, the change is retried.
+
+Do not set ` + "`groups`" + ` on a ` + "`pihole_list`" + ` resource for the same list, or each
+apply undoes the other.
+
+## Example Usage
+
+` + "```hcl" + `
+resource "pihole_group" "kids" {
+  name = "kids"
+}
+
+resource "pihole_list_group_association" "kids_social" {
+  address  = "https://example.com/social-media.txt"
+  group_id = pihole_group.kids.id
+}
+` + "```" + `
+
+## Import
+
+Associations can be imported using the format ` + "`type/group_id/address`" + `:
+
+` + "```shell" + `
+terraform import pihole_list_group_association.kids_social block/3/https://example.com/social-media.txt
+` + "```" + `
+`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Resource identifier in the format type/group_id/address.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"address": schema.StringAttribute{
+				Required:    true,
+				Description: "The URL of the list.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("block"),
+				Description: "The type of the list: 'block' or 'allow'. Default: 'block'.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("block", "allow"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"group_id": schema.Int64Attribute{
+				Required:    true,
+				Description: "The ID of the group to assign to the list.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ListGroupAssociationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData))
+		return
+	}
+	r.client = data.client
+}
+
+func (r *ListGroupAssociationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ListGroupAssociationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	listType, address, groupID := data.Type.ValueString(), data.Address.ValueString(), data.GroupID.ValueInt64()
+	tflog.Debug(ctx, "Assigning group to list", map[string]interface{}{"address": address, "type": listType, "group_id": groupID})
+
+	if _, err := r.client.SetListGroup(ctx, listType, address, groupID, true); err != nil {
+		addAPIErrorf(&resp.Diagnostics, "Error assigning group to list", err, "Could not assign group %d to %s list %s", groupID, listType, address)
+		return
+	}
+
+	data.ID = types.StringValue(listGroupAssociationID(listType, groupID, address))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ListGroupAssociationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ListGroupAssociationResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	list, err := r.client.GetList(ctx, data.Type.ValueString(), data.Address.ValueString())
+	if err != nil && !errors.Is(err, pihole.ErrNotFound) {
+		addAPIErrorf(&resp.Diagnostics, "Error reading list", err, "Could not read list %s", data.Address.ValueString())
+		return
+	}
+
+	// The association is gone when the list was deleted or the group
+	// removed from it outside Terraform.
+	if list == nil || !slices.Contains(list.Groups, data.GroupID.ValueInt64()) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.ID = types.StringValue(listGroupAssociationID(data.Type.ValueString(), data.GroupID.ValueInt64(), data.Address.ValueString()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ListGroupAssociationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// All attributes require replace, so Update should not be called
+	resp.Diagnostics.AddError("Update not supported", "List group association changes require replacement")
+}
+
+func (r *ListGroupAssociationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ListGroupAssociationResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	listType, address, groupID := data.Type.ValueString(), data.Address.ValueString(), data.GroupID.ValueInt64()
+	tflog.Debug(ctx, "Removing group from list", map[string]interface{}{"address": address, "type": listType, "group_id": groupID})
+
+	_, err := r.client.SetListGroup(ctx, listType, address, groupID, false)
+	if err != nil && !errors.Is(err, pihole.ErrNotFound) {
+		addAPIErrorf(&resp.Diagnostics, "Error removing group from list", err, "Could not remove group %d from %s list %s", groupID, listType, address)
+		return
+	}
+}
+
+func (r *ListGroupAssociationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import format: type/group_id/address; the address contains slashes itself
+	parts := strings.SplitN(req.ID, "/", 3)
+	var groupID int64
+	var err error
+	if len(parts) == 3 {
+		groupID, err = strconv.ParseInt(parts[1], 10, 64)
+	}
+	if len(parts) != 3 || err != nil || (parts[0] != "block" && parts[0] != "allow") || parts[2] == "" {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			"Import ID must be in the format: type/group_id/address (e.g., block/3/https://example.com/list.txt)",
+		)
+		return
+	}
+
+	data := ListGroupAssociationResourceModel{
+		ID:      types.StringValue(req.ID),
+		Type:    types.StringValue(parts[0]),
+		GroupID: types.Int64Value(groupID),
+		Address: types.StringValue(parts[2]),
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// listGroupAssociationID returns the resource ID of an association.
+func listGroupAssociationID(listType string, groupID int64, address string) string {
+	return fmt.Sprintf("%s/%d/%s", listType, groupID, address)
+}
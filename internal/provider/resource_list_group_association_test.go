@@ -0,0 +1,82 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccResourceListGroupAssociation_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceListGroupAssociationConfig(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_list_group_association.test", "type", "block"),
+					resource.TestCheckResourceAttrPair("pihole_list_group_association.test", "group_id", "pihole_group.test", "id"),
+					testAccCheckListGroups("https://example.com/tf-acc-association.txt", "pihole_group.test"),
+				),
+			},
+			{
+				ResourceName:      "pihole_list_group_association.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccResourceListGroupAssociationConfig() string {
+	return `
+resource "pihole_group" "test" {
+  name = "tf-acc-list-association"
+}
+
+resource "pihole_list" "test" {
+  address = "https://example.com/tf-acc-association.txt"
+  type    = "block"
+}
+
+resource "pihole_list_group_association" "test" {
+  address  = pihole_list.test.address
+  group_id = pihole_group.test.id
+}
+`
+}
+
+// testAccCheckListGroups checks that the list keeps the default group and
+// has the group of the named resource as well.
+func testAccCheckListGroups(address, groupResource string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[groupResource]
+		if !ok {
+			return fmt.Errorf("%s not found in state", groupResource)
+		}
+		c, err := testAccAPIClient()
+		if err != nil {
+			return err
+		}
+		list, err := c.GetList(context.Background(), "block", address)
+		if err != nil {
+			return err
+		}
+		if list == nil {
+			return fmt.Errorf("list %s not found", address)
+		}
+		var groupID int64
+		fmt.Sscan(rs.Primary.Attributes["id"], &groupID)
+		if !slices.Contains(list.Groups, 0) || !slices.Contains(list.Groups, groupID) {
+			return fmt.Errorf("list %s has groups %v, want 0 and %d", address, list.Groups, groupID)
+		}
+		return nil
+	}
+}
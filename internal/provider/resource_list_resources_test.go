@@ -4,9 +4,11 @@
 package provider
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 )
 
 func TestAccResourceDNSUpstream_basic(t *testing.T) {
@@ -35,6 +37,38 @@ resource "pihole_dns_upstream" "test" {
 	})
 }
 
+func TestAccResourceAPIExclusion_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "pihole_api_exclusion" "client" {
+  type  = "client"
+  value = "192.168.1.250"
+}
+
+resource "pihole_api_exclusion" "domain" {
+  type  = "domain"
+  value = "(\\.|^)example-test\\.com$"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_api_exclusion.client", "id", "client/192.168.1.250"),
+					resource.TestCheckResourceAttr("pihole_api_exclusion.domain", "value", `(\.|^)example-test\.com$`),
+				),
+			},
+			{
+				ResourceName:      "pihole_api_exclusion.domain",
+				ImportState:       true,
+				ImportStateId:     `domain/(\.|^)example-test\.com$`,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
 func TestAccResourceLocalDNS_basic(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
@@ -73,6 +107,127 @@ resource "pihole_cname_record" "test" {
 					resource.TestCheckResourceAttr("pihole_cname_record.test", "target", "server.test.local"),
 				),
 			},
+			// Changing the target updates the entry in place
+			{
+				Config: `
+resource "pihole_cname_record" "test" {
+  domain = "www.test.local"
+  target = "other.test.local"
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("pihole_cname_record.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_cname_record.test", "id", "www.test.local,other.test.local"),
+					resource.TestCheckResourceAttr("pihole_cname_record.test", "target", "other.test.local"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourcePTRRecord_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "pihole_ptr_record" "test" {
+  ip       = "192.168.1.123"
+  hostname = "ptr.test.local"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_ptr_record.test", "id", "192.168.1.123,ptr.test.local"),
+					resource.TestCheckResourceAttr("pihole_ptr_record.test", "hostname", "ptr.test.local"),
+				),
+			},
+			{
+				ResourceName:      "pihole_ptr_record.test",
+				ImportState:       true,
+				ImportStateId:     "192.168.1.123,ptr.test.local",
+				ImportStateVerify: true,
+			},
+			// Changing the hostname updates the line in place
+			{
+				Config: `
+resource "pihole_ptr_record" "test" {
+  ip       = "192.168.1.123"
+  hostname = "other.test.local"
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("pihole_ptr_record.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("pihole_ptr_record.test", "hostname", "other.test.local"),
+			},
+		},
+	})
+}
+
+func TestAccResourcePTRRecord_invalidIP(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "pihole_ptr_record" "test" {
+  ip       = "gateway.lan"
+  hostname = "ptr.test.local"
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid IP address`),
+			},
+		},
+	})
+}
+
+func TestAccResourceConditionalForward_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "pihole_conditional_forward" "test" {
+  domain = "corp.test.local"
+  server = "10.0.0.1"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_conditional_forward.test", "id", "corp.test.local,10.0.0.1"),
+					resource.TestCheckResourceAttr("pihole_conditional_forward.test", "port", "53"),
+				),
+			},
+			// Changing the port updates the line in place
+			{
+				Config: `
+resource "pihole_conditional_forward" "test" {
+  domain = "corp.test.local"
+  server = "10.0.0.1"
+  port   = 5353
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("pihole_conditional_forward.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("pihole_conditional_forward.test", "id", "corp.test.local,10.0.0.1#5353"),
+			},
+			{
+				ResourceName:      "pihole_conditional_forward.test",
+				ImportState:       true,
+				ImportStateId:     "corp.test.local,10.0.0.1#5353",
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
@@ -96,6 +251,117 @@ resource "pihole_dhcp_static_lease" "test" {
 					resource.TestCheckResourceAttr("pihole_dhcp_static_lease.test", "hostname", "testhost"),
 				),
 			},
+			// Changing the IP updates the lease in place
+			{
+				Config: `
+resource "pihole_dhcp_static_lease" "test" {
+  mac      = "AA:BB:CC:DD:EE:FF"
+  ip       = "192.168.1.210"
+  hostname = "testhost"
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("pihole_dhcp_static_lease.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_dhcp_static_lease.test", "id", "AA:BB:CC:DD:EE:FF,192.168.1.210,testhost"),
+					resource.TestCheckResourceAttr("pihole_dhcp_static_lease.test", "ip", "192.168.1.210"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceDHCPStaticLease_partial(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "pihole_dhcp_static_lease" "test" {
+  mac      = "AA:BB:CC:DD:EE:01"
+  ip       = "192.168.1.201"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_dhcp_static_lease.test", "id", "AA:BB:CC:DD:EE:01,192.168.1.201"),
+					resource.TestCheckNoResourceAttr("pihole_dhcp_static_lease.test", "hostname"),
+				),
+			},
+			{
+				Config: `
+resource "pihole_dhcp_static_lease" "test" {
+  mac        = "AA:BB:CC:DD:EE:01"
+  hostname   = "partialhost"
+  lease_time = "12h"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_dhcp_static_lease.test", "id", "AA:BB:CC:DD:EE:01,partialhost,12h"),
+					resource.TestCheckNoResourceAttr("pihole_dhcp_static_lease.test", "ip"),
+					resource.TestCheckResourceAttr("pihole_dhcp_static_lease.test", "lease_time", "12h"),
+				),
+			},
+			{
+				ResourceName:      "pihole_dhcp_static_lease.test",
+				ImportState:       true,
+				ImportStateId:     "AA:BB:CC:DD:EE:01,partialhost,12h",
+				ImportStateVerify: true,
+			},
+			{
+				Config: `
+resource "pihole_dhcp_static_lease" "test" {
+  mac = "AA:BB:CC:DD:EE:01"
+}
+`,
+				ExpectError: regexp.MustCompile(`At least one attribute out of`),
+			},
+		},
+	})
+}
+
+func TestAccResourceDHCPOption_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "pihole_dhcp_option" "test" {
+  tag    = "tftest"
+  option = "ntp-server"
+  values = ["192.168.1.1"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_dhcp_option.test", "id", "tag:tftest,option:ntp-server,192.168.1.1"),
+					resource.TestCheckResourceAttr("pihole_dhcp_option.test", "values.#", "1"),
+				),
+			},
+			{
+				ResourceName:      "pihole_dhcp_option.test",
+				ImportState:       true,
+				ImportStateId:     "tag:tftest,option:ntp-server,192.168.1.1",
+				ImportStateVerify: true,
+			},
+			{
+				Config: `
+resource "pihole_dhcp_option" "test" {
+  tag    = "tftest"
+  option = "ntp-server"
+  values = ["192.168.1.1", "192.168.1.2"]
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("pihole_dhcp_option.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("pihole_dhcp_option.test", "values.#", "2"),
+			},
 		},
 	})
 }
@@ -5,9 +5,14 @@ package provider
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
 )
 
 func TestAccResourceList_blocklist(t *testing.T) {
@@ -16,24 +21,33 @@ func TestAccResourceList_blocklist(t *testing.T) {
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccResourceListConfig("https://block.example.com/acc-test-blocklist.txt", "block", true, "ACC test blocklist"),
+				Config: testAccResourceListConfig("https://example.com/tf-acc-blocklist.txt", "block", true, "ACC test blocklist"),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("pihole_list.test", "address", "https://block.example.com/acc-test-blocklist.txt"),
+					resource.TestCheckResourceAttr("pihole_list.test", "address", "https://example.com/tf-acc-blocklist.txt"),
 					resource.TestCheckResourceAttr("pihole_list.test", "type", "block"),
 					resource.TestCheckResourceAttr("pihole_list.test", "enabled", "true"),
 					resource.TestCheckResourceAttr("pihole_list.test", "comment", "ACC test blocklist"),
 					resource.TestCheckResourceAttrSet("pihole_list.test", "id"),
+					resource.TestCheckResourceAttrSet("pihole_list.test", "date_updated"),
+					resource.TestCheckResourceAttrSet("pihole_list.test", "invalid_domains"),
+					resource.TestCheckResourceAttrSet("pihole_list.test", "abp_entries"),
 				),
 			},
 			{
 				ResourceName:      "pihole_list.test",
 				ImportState:       true,
-				ImportStateId:     "block/https://block.example.com/acc-test-blocklist.txt",
+				ImportStateId:     "block/https://example.com/tf-acc-blocklist.txt",
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "pihole_list.test",
+				ImportState:       true,
+				ImportStateIdFunc: testAccListIDImportID,
 				ImportStateVerify: true,
 			},
 			// Update
 			{
-				Config: testAccResourceListConfig("https://block.example.com/acc-test-blocklist.txt", "block", false, "Disabled blocklist"),
+				Config: testAccResourceListConfig("https://example.com/tf-acc-blocklist.txt", "block", false, "Disabled blocklist"),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("pihole_list.test", "enabled", "false"),
 					resource.TestCheckResourceAttr("pihole_list.test", "comment", "Disabled blocklist"),
@@ -44,36 +58,159 @@ func TestAccResourceList_blocklist(t *testing.T) {
 }
 
 func TestAccResourceList_allowlist(t *testing.T) {
+	testAccParallelTest(t, func(inst *testAccInstance) resource.TestCase {
+		return resource.TestCase{
+			ProtoV6ProviderFactories: inst.providerFactories(),
+			Steps: []resource.TestStep{
+				{
+					Config: testAccResourceListConfig("https://raw.githubusercontent.com/anudeepND/whitelist/master/domains/whitelist.txt", "allow", true, "Community whitelist"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("pihole_list.test", "type", "allow"),
+						resource.TestCheckResourceAttr("pihole_list.test", "enabled", "true"),
+					),
+				},
+			},
+		}
+	})
+}
+
+func TestAccResourceList_withGroups(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccResourceListConfig("https://raw.githubusercontent.com/anudeepND/whitelist/master/domains/whitelist.txt", "allow", true, "Community whitelist"),
+				Config: testAccResourceListWithGroupConfig(),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("pihole_list.test", "type", "allow"),
-					resource.TestCheckResourceAttr("pihole_list.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("pihole_list.test", "groups.#", "1"),
 				),
 			},
 		},
 	})
 }
 
-func TestAccResourceList_withGroups(t *testing.T) {
+func TestAccResourceList_deletionProtection(t *testing.T) {
+	config := func(protected bool) string {
+		return fmt.Sprintf(`
+resource "pihole_list" "test" {
+  address             = "https://example.com/tf-acc-protected.txt"
+  type                = "allow"
+  deletion_protection = %t
+}
+`, protected)
+	}
+
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccResourceListWithGroupConfig(),
+				Config: config(true),
+				Check:  resource.TestCheckResourceAttr("pihole_list.test", "deletion_protection", "true"),
+			},
+			// Removing a protected list fails at plan time
+			{
+				Config:      `# empty`,
+				ExpectError: regexp.MustCompile(`Deletion protection enabled`),
+			},
+			// Once unprotected, the test's destroy succeeds
+			{
+				Config: config(false),
+				Check:  resource.TestCheckResourceAttr("pihole_list.test", "deletion_protection", "false"),
+			},
+		},
+	})
+}
+
+func TestAccResourceList_gravityStats(t *testing.T) {
+	config := func(address, comment string) string {
+		return fmt.Sprintf(`
+resource "pihole_list" "test" {
+  address              = %q
+  type                 = "block"
+  comment              = %q
+  ignore_gravity_stats = true
+}
+`, address, comment)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("https://example.com/tf-acc-stats.txt", "first"),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("pihole_list.test", "groups.#", "1"),
+					resource.TestCheckResourceAttr("pihole_list.test", "ignore_gravity_stats", "true"),
+					resource.TestCheckResourceAttrSet("pihole_list.test", "number"),
+					resource.TestCheckResourceAttrSet("pihole_list.test", "status"),
 				),
 			},
+			// Other changes keep the stats known in the plan
+			{
+				Config: config("https://example.com/tf-acc-stats.txt", "second"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("pihole_list.test", plancheck.ResourceActionUpdate),
+						plancheck.ExpectKnownValue("pihole_list.test", tfjsonpath.New("number"), knownvalue.NotNull()),
+						plancheck.ExpectUnknownValue("pihole_list.test", tfjsonpath.New("date_modified")),
+					},
+				},
+			},
+			// A new address recomputes them
+			{
+				Config: config("https://example.com/tf-acc-stats-moved.txt", "second"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectUnknownValue("pihole_list.test", tfjsonpath.New("number")),
+						plancheck.ExpectUnknownValue("pihole_list.test", tfjsonpath.New("status")),
+					},
+				},
+				Check: resource.TestCheckResourceAttrSet("pihole_list.test", "number"),
+			},
 		},
 	})
 }
 
+func TestAccResourceList_normalizedAddress(t *testing.T) {
+	config := testAccResourceListConfig("https://Example.com/tf-acc-normalized.txt/", "block", true, "Normalized")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_list.test", "address", "https://Example.com/tf-acc-normalized.txt/"),
+					resource.TestCheckResourceAttr("pihole_list.test", "normalized_address", "https://example.com/tf-acc-normalized.txt"),
+				),
+			},
+			// However Pi-hole stored the address, there is no diff
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+			{
+				ResourceName:            "pihole_list.test",
+				ImportState:             true,
+				ImportStateId:           "block/https://example.com/tf-acc-normalized.txt",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"address"},
+			},
+		},
+	})
+}
+
+// testAccListIDImportID returns the id:<id> import ID of pihole_list.test.
+func testAccListIDImportID(s *terraform.State) (string, error) {
+	rs, ok := s.RootModule().Resources["pihole_list.test"]
+	if !ok {
+		return "", fmt.Errorf("pihole_list.test not found in state")
+	}
+	return "id:" + rs.Primary.ID, nil
+}
+
 func testAccResourceListConfig(address, listType string, enabled bool, comment string) string {
 	return fmt.Sprintf(`
 resource "pihole_list" "test" {
@@ -88,11 +225,11 @@ resource "pihole_list" "test" {
 func testAccResourceListWithGroupConfig() string {
 	return `
 resource "pihole_group" "test" {
-  name = "list-test-group"
+  name = "tf-acc-list"
 }
 
 resource "pihole_list" "test" {
-  address = "https://example.com/test-list.txt"
+  address = "https://example.com/tf-acc-list.txt"
   type    = "block"
   enabled = true
   groups  = [pihole_group.test.id]
@@ -5,10 +5,14 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
 
-	"github.com/dklesev/terraform-provider-pihole/internal/client"
+	"github.com/dklesev/terraform-provider-pihole/internal/provider/convert"
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -20,6 +24,8 @@ import (
 var (
 	_ resource.Resource                = &LocalDNSResource{}
 	_ resource.ResourceWithImportState = &LocalDNSResource{}
+	_ resource.ResourceWithModifyPlan  = &LocalDNSResource{}
+	_ resource.ResourceWithMoveState   = &LocalDNSResource{}
 )
 
 func NewLocalDNSResource() resource.Resource {
@@ -27,13 +33,15 @@ func NewLocalDNSResource() resource.Resource {
 }
 
 type LocalDNSResource struct {
-	client *client.Client
+	client *pihole.Client
 }
 
 type LocalDNSResourceModel struct {
-	ID       types.String `tfsdk:"id"`
-	Hostname types.String `tfsdk:"hostname"`
-	IP       types.String `tfsdk:"ip"`
+	ID            types.String    `tfsdk:"id"`
+	Hostname      types.String    `tfsdk:"hostname"`
+	IP            types.String    `tfsdk:"ip"`
+	AdoptExisting types.Bool      `tfsdk:"adopt_existing"`
+	Verify        *dnsVerifyModel `tfsdk:"verify"`
 }
 
 func (r *LocalDNSResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -46,6 +54,10 @@ func (r *LocalDNSResource) Schema(ctx context.Context, req resource.SchemaReques
 		MarkdownDescription: `
 Manages a local DNS A record in Pi-hole (hostname -> IP mapping).
 
+Pi-hole hosts entries may list several hostnames for one IP (` + "`IP host1 host2`" + `).
+Each ` + "`pihole_local_dns`" + ` resource manages a single hostname and is found within
+such shared lines; destroying it only removes its own hostname from the line.
+
 ## Example Usage
 
 ` + "```hcl" + `
@@ -54,6 +66,42 @@ resource "pihole_local_dns" "server" {
   ip       = "192.168.1.100"
 }
 ` + "```" + `
+
+### Verify the Record Resolves
+
+` + "```hcl" + `
+resource "pihole_local_dns" "nas" {
+  hostname = "nas.lan"
+  ip       = "192.168.1.20"
+
+  verify {
+    timeout_seconds = 30
+  }
+}
+` + "```" + `
+
+### Adopt an Existing Record
+
+With ` + "`adopt_existing = true`" + ` a record with the same line that is already in
+Pi-hole is taken over instead of failing to create it:
+
+` + "```hcl" + `
+resource "pihole_local_dns" "router" {
+  hostname       = "router.lan"
+  ip             = "192.168.1.1"
+  adopt_existing = true
+}
+` + "```" + `
+
+## Import
+
+Records can be imported using the format ` + "`IP hostname`" + `, or by the hostname
+alone when it has a single record:
+
+` + "```shell" + `
+terraform import pihole_local_dns.server "192.168.1.100 server.lan"
+terraform import pihole_local_dns.server server.lan
+` + "```" + `
 `,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -74,6 +122,10 @@ resource "pihole_local_dns" "server" {
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"adopt_existing": adoptExistingAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"verify": dnsVerifyBlock(),
 		},
 	}
 }
@@ -82,12 +134,12 @@ func (r *LocalDNSResource) Configure(ctx context.Context, req resource.Configure
 	if req.ProviderData == nil {
 		return
 	}
-	c, ok := req.ProviderData.(*client.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
-		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *client.Client, got: %T.", req.ProviderData))
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData))
 		return
 	}
-	r.client = c
+	r.client = data.client
 }
 
 func (r *LocalDNSResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -101,13 +153,21 @@ func (r *LocalDNSResource) Create(ctx context.Context, req resource.CreateReques
 	value := fmt.Sprintf("%s %s", data.IP.ValueString(), data.Hostname.ValueString())
 	tflog.Debug(ctx, "Creating local DNS", map[string]interface{}{"value": value})
 
-	if err := r.client.AddConfigArrayItem(ctx, "dns/hosts", value); err != nil {
-		resp.Diagnostics.AddError("Error adding local DNS", err.Error())
+	err := r.client.AddConfigArrayItemVerified(ctx, "dns/hosts", value, data.finder())
+	if errors.Is(err, pihole.ErrExists) && data.AdoptExisting.ValueBool() {
+		// The line is exactly the record, there is nothing to change
+		tflog.Info(ctx, "Adopting existing local DNS", map[string]interface{}{"value": value})
+		err = nil
+	}
+	if err != nil {
+		addCreateError(&resp.Diagnostics, "Error adding local DNS", "local DNS record "+value, err, "pihole_local_dns", value)
 		return
 	}
 
 	data.ID = types.StringValue(value)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	verifyDNS(ctx, r.client, data.Verify, data.Hostname.ValueString(), verifyAddress(data.Hostname.ValueString(), data.IP.ValueString()), &resp.Diagnostics)
 }
 
 func (r *LocalDNSResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -121,19 +181,12 @@ func (r *LocalDNSResource) Read(ctx context.Context, req resource.ReadRequest, r
 
 	config, err := r.client.GetDNSConfig(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading DNS config", err.Error())
+		addAPIError(&resp.Diagnostics, "Error reading DNS config", err)
 		return
 	}
 
-	found := false
-	for _, h := range config.Hosts {
-		if h == value {
-			found = true
-			break
-		}
-	}
-
-	if !found {
+	// The record may live on a shared "IP host1 host2" line
+	if _, found := convert.FindHostsLine(config.Hosts, data.IP.ValueString(), data.Hostname.ValueString()); !found {
 		resp.State.RemoveResource(ctx)
 		return
 	}
@@ -142,8 +195,18 @@ func (r *LocalDNSResource) Read(ctx context.Context, req resource.ReadRequest, r
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// Update only runs when the verify block or adopt_existing changes; the
+// record itself requires replacement.
 func (r *LocalDNSResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics.AddError("Update not supported", "Changes require replacement")
+	var data LocalDNSResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	verifyDNS(ctx, r.client, data.Verify, data.Hostname.ValueString(), verifyAddress(data.Hostname.ValueString(), data.IP.ValueString()), &resp.Diagnostics)
 }
 
 func (r *LocalDNSResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -153,27 +216,122 @@ func (r *LocalDNSResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
-	value := fmt.Sprintf("%s %s", data.IP.ValueString(), data.Hostname.ValueString())
-	tflog.Debug(ctx, "Deleting local DNS", map[string]interface{}{"value": value})
+	ip := data.IP.ValueString()
+	hostname := data.Hostname.ValueString()
 
-	if err := r.client.DeleteConfigArrayItem(ctx, "dns/hosts", value); err != nil {
-		resp.Diagnostics.AddError("Error deleting local DNS", err.Error())
+	config, err := r.client.GetDNSConfig(ctx)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error reading DNS config", err)
 		return
 	}
+
+	line, found := convert.FindHostsLine(config.Hosts, ip, hostname)
+	if !found {
+		return
+	}
+
+	tflog.Debug(ctx, "Deleting local DNS", map[string]interface{}{"value": line})
+
+	if err := r.client.DeleteConfigArrayItemVerified(ctx, "dns/hosts", line, data.finder()); err != nil && !errors.Is(err, pihole.ErrNotFound) {
+		addAPIError(&resp.Diagnostics, "Error deleting local DNS", err)
+		return
+	}
+
+	// Keep the other hostnames that shared the line with this record. The
+	// line is rebuilt from the one Pi-hole stored, so it is matched exactly.
+	if remaining := convert.RemoveHostname(line, hostname); remaining != "" {
+		if err := r.client.AddConfigArrayItemVerified(ctx, "dns/hosts", remaining, nil); err != nil {
+			addAPIError(&resp.Diagnostics, "Error restoring remaining local DNS hostnames", err)
+			return
+		}
+	}
+}
+
+// ModifyPlan warns before destroys that Pi-hole may refuse.
+func (r *LocalDNSResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	warnDestructiveDisabled(r.client, req, resp)
 }
 
 func (r *LocalDNSResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Import format: "IP hostname"
+	// Import format: "IP hostname", or the hostname alone as with the
+	// ryanwholey/pihole provider
 	parts := strings.SplitN(req.ID, " ", 2)
+	if len(parts) == 1 && parts[0] != "" {
+		ip := r.lookupIP(ctx, parts[0], &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		parts = []string{ip, parts[0]}
+	}
 	if len(parts) != 2 {
-		resp.Diagnostics.AddError("Invalid import ID", "Expected format: 'IP hostname'")
+		resp.Diagnostics.AddError("Invalid import ID", "Expected format: 'IP hostname' or 'hostname'")
 		return
 	}
 
 	data := LocalDNSResourceModel{
-		ID:       types.StringValue(req.ID),
+		ID:       types.StringValue(parts[0] + " " + parts[1]),
 		IP:       types.StringValue(parts[0]),
 		Hostname: types.StringValue(parts[1]),
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// lookupIP returns the IP address of the only local DNS record of hostname.
+func (r *LocalDNSResource) lookupIP(ctx context.Context, hostname string, diags *diag.Diagnostics) string {
+	config, err := r.client.GetDNSConfig(ctx)
+	if err != nil {
+		addAPIError(diags, "Error reading DNS config", err)
+		return ""
+	}
+
+	var ips []string
+	for _, line := range config.Hosts {
+		ip, hostnames, ok := convert.ParseHostsLine(line)
+		if ok && slices.ContainsFunc(hostnames, func(h string) bool { return convert.SameHostname(h, hostname) }) {
+			ips = append(ips, ip)
+		}
+	}
+	switch len(ips) {
+	case 0:
+		diags.AddError("Invalid import ID", fmt.Sprintf("No local DNS record exists for %s.", hostname))
+	case 1:
+		return ips[0]
+	default:
+		diags.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("%s has records for %s; import one with the format 'IP hostname'.", hostname, strings.Join(ips, ", ")),
+		)
+	}
+	return ""
+}
+
+// MoveState turns pihole_dns_record resources of the ryanwholey/pihole
+// provider into local DNS records.
+func (r *LocalDNSResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{{
+		StateMover: func(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+			var source struct {
+				Domain string `json:"domain"`
+				IP     string `json:"ip"`
+			}
+			if !ryanwholeyState(req, "pihole_dns_record", &source, &resp.Diagnostics) {
+				return
+			}
+
+			data := LocalDNSResourceModel{
+				ID:       types.StringValue(source.IP + " " + source.Domain),
+				Hostname: types.StringValue(source.Domain),
+				IP:       types.StringValue(source.IP),
+			}
+			resp.Diagnostics.Append(resp.TargetState.Set(ctx, &data)...)
+		},
+	}}
+}
+
+// finder looks the record up in dns.hosts, where it may share a line with
+// other hostnames or differ in case or spacing.
+func (m LocalDNSResourceModel) finder() pihole.ItemFinder {
+	return func(hosts []string) (string, bool) {
+		return convert.FindHostsLine(hosts, m.IP.ValueString(), m.Hostname.ValueString())
+	}
+}
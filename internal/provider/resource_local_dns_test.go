@@ -0,0 +1,88 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccResourceLocalDNS_sharedLine(t *testing.T) {
+	const sharedLine = "192.168.1.60 multi-a.lan multi-b.lan"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			return testAccCheckHostsLineAndRemove("192.168.1.60 multi-b.lan")
+		},
+		Steps: []resource.TestStep{
+			// Import a hostname that shares its line with another one
+			{
+				PreConfig: func() {
+					c, err := testAccAPIClient()
+					if err != nil {
+						t.Fatal(err)
+					}
+					if err := c.AddConfigArrayItem(context.Background(), "dns/hosts", sharedLine); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:             testAccResourceLocalDNSConfig("multi-a.lan", "192.168.1.60"),
+				ResourceName:       "pihole_local_dns.test",
+				ImportState:        true,
+				ImportStateId:      "192.168.1.60 multi-a.lan",
+				ImportStatePersist: true,
+			},
+			// Read must keep finding it inside the shared line
+			{
+				Config:   testAccResourceLocalDNSConfig("multi-a.lan", "192.168.1.60"),
+				PlanOnly: true,
+			},
+			// Import by the hostname alone
+			{
+				Config:            testAccResourceLocalDNSConfig("multi-a.lan", "192.168.1.60"),
+				ResourceName:      "pihole_local_dns.test",
+				ImportState:       true,
+				ImportStateId:     "multi-a.lan",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// testAccCheckHostsLineAndRemove verifies that destroying a hostname kept the
+// rest of its line, then cleans the line up.
+func testAccCheckHostsLineAndRemove(line string) error {
+	c, err := testAccAPIClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	config, err := c.GetDNSConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, h := range config.Hosts {
+		if h == line {
+			return c.DeleteConfigArrayItem(ctx, "dns/hosts", line)
+		}
+	}
+	return fmt.Errorf("expected dns.hosts to contain %q, got %v", line, config.Hosts)
+}
+
+func testAccResourceLocalDNSConfig(hostname, ip string) string {
+	return fmt.Sprintf(`
+resource "pihole_local_dns" "test" {
+  hostname = %[1]q
+  ip       = %[2]q
+}
+`, hostname, ip)
+}
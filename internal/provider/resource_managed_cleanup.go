@@ -0,0 +1,443 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                     = &ManagedCleanupResource{}
+	_ resource.ResourceWithConfigValidators = &ManagedCleanupResource{}
+	_ resource.ResourceWithModifyPlan       = &ManagedCleanupResource{}
+)
+
+func NewManagedCleanupResource() resource.Resource {
+	return &ManagedCleanupResource{}
+}
+
+type ManagedCleanupResource struct {
+	client   *pihole.Client
+	comments commentSettings
+}
+
+type ManagedCleanupResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	Tag           types.String `tfsdk:"tag"`
+	KeepDomainIDs types.Set    `tfsdk:"keep_domain_ids"`
+	KeepListIDs   types.Set    `tfsdk:"keep_list_ids"`
+	KeepClientIDs types.Set    `tfsdk:"keep_client_ids"`
+	DryRun        types.Bool   `tfsdk:"dry_run"`
+	Orphans       types.List   `tfsdk:"orphans"`
+}
+
+func (r *ManagedCleanupResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_managed_cleanup"
+}
+
+func (r *ManagedCleanupResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Deletes domains, lists and clients carrying a managed tag that are not in the keep-lists.",
+		MarkdownDescription: `
+Deletes orphaned domains, lists and clients: entries whose comment carries the
+` + "`[tf:<tag>]`" + ` marker of the provider's ` + "`managed_by_tag`" + ` but whose IDs are not in the
+keep-lists. This recovers from lost or partially destroyed state without cleaning
+up in the web interface.
+
+Only the kinds of entries with a keep-list are cleaned up: without
+` + "`keep_client_ids`" + `, for example, no client is deleted. Entries created by
+` + "`pihole_device`" + `, ` + "`pihole_policy`" + `, ` + "`pihole_wildcard_block`" + ` and ` + "`pihole_custom_regex`" + `
+name their resource in the marker, e.g. ` + "`[tf:<tag>/pihole_device]`" + `, and are never
+cleaned up.
+
+Every refresh looks for orphans and lists them in ` + "`orphans`" + `; when there are any,
+the plan shows an update and applying it deletes them. Set ` + "`dry_run = true`" + ` to only
+report them. Orphans are deleted with one batch request per kind of entry, so
+the domains, lists and clients are each removed entirely or not at all.
+
+~> **Warning:** Any tagged entry of a kind with a keep-list that is not in it is
+deleted, including entries created by other configurations using the same tag.
+Give every workspace its own tag. Entries that the resources above created before
+they named themselves in the marker carry the plain marker until their next
+change; check ` + "`orphans`" + ` with ` + "`dry_run = true`" + ` before the first apply.
+
+## Example Usage
+
+` + "```hcl" + `
+provider "pihole" {
+  managed_by_tag = "prod"
+}
+
+resource "pihole_managed_cleanup" "prod" {
+  keep_domain_ids = [for d in pihole_domain.blocked : d.id]
+  keep_list_ids   = [for l in pihole_list.blocklists : l.id]
+  keep_client_ids = [for c in pihole_client.devices : c.id]
+}
+` + "```" + `
+`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Resource identifier (same as the tag).",
+			},
+			"tag": schema.StringAttribute{
+				Optional:    true,
+				Description: "The tag to clean up. Defaults to the provider's `managed_by_tag`.",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(managedTagRegexp, "may only contain letters, digits, '.', '_' and '-'"),
+				},
+			},
+			"keep_domain_ids": schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.Int64Type,
+				Description: "IDs of tagged domains to keep. Without it, no domain is cleaned up.",
+			},
+			"keep_list_ids": schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.Int64Type,
+				Description: "IDs of tagged lists to keep. Without it, no list is cleaned up.",
+			},
+			"keep_client_ids": schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.Int64Type,
+				Description: "IDs of tagged clients to keep. Without it, no client is cleaned up.",
+			},
+			"dry_run": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Only report orphans in `orphans` without deleting them. Default: false.",
+			},
+			"orphans": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "Tagged entries that are not in a keep-list, found during the last refresh or apply.",
+			},
+		},
+	}
+}
+
+func (r *ManagedCleanupResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData))
+		return
+	}
+	r.client = data.client
+	r.comments = data.comments
+}
+
+func (r *ManagedCleanupResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.AtLeastOneOf(
+			path.MatchRoot("keep_domain_ids"),
+			path.MatchRoot("keep_list_ids"),
+			path.MatchRoot("keep_client_ids"),
+		),
+	}
+}
+
+func (r *ManagedCleanupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ManagedCleanupResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.apply(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ManagedCleanupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ManagedCleanupResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tag := r.tag(data)
+	if tag == "" {
+		return
+	}
+
+	orphans, err := r.findOrphans(ctx, tag, &data)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error looking for orphaned entries", err)
+		return
+	}
+
+	data.Orphans = orphanDescriptions(orphans)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ManagedCleanupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ManagedCleanupResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.apply(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ManagedCleanupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Nothing to delete; tagged entries are left alone.
+}
+
+// ModifyPlan plans an update whenever the last refresh found orphans, so
+// that applying deletes them.
+func (r *ManagedCleanupResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	if req.State.Raw.IsNull() {
+		var plan ManagedCleanupResourceModel
+		resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if r.tag(plan) == "" && !plan.Tag.IsUnknown() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("tag"),
+				"Missing managed tag",
+				"Set tag or the provider's managed_by_tag so the cleanup knows which entries it owns.",
+			)
+		}
+		return
+	}
+
+	var state, plan ManagedCleanupResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if (len(state.Orphans.Elements()) > 0 && !plan.DryRun.ValueBool()) || !plan.KeepDomainIDs.Equal(state.KeepDomainIDs) ||
+		!plan.KeepListIDs.Equal(state.KeepListIDs) || !plan.KeepClientIDs.Equal(state.KeepClientIDs) ||
+		!plan.Tag.Equal(state.Tag) || !plan.DryRun.Equal(state.DryRun) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("orphans"), types.ListUnknown(types.StringType))...)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("orphans"), state.Orphans)...)
+}
+
+// tag returns the tag configured on the resource or, failing that, the
+// provider's managed_by_tag.
+func (r *ManagedCleanupResource) tag(data ManagedCleanupResourceModel) string {
+	if !data.Tag.IsNull() && !data.Tag.IsUnknown() {
+		return data.Tag.ValueString()
+	}
+	return r.comments.managedByTag
+}
+
+// apply finds the orphans for the planned keep-lists and deletes them unless
+// dry_run is set.
+func (r *ManagedCleanupResource) apply(ctx context.Context, data *ManagedCleanupResourceModel, diags *diag.Diagnostics) {
+	tag := r.tag(*data)
+	if tag == "" {
+		diags.AddAttributeError(
+			path.Root("tag"),
+			"Missing managed tag",
+			"Set tag or the provider's managed_by_tag so the cleanup knows which entries it owns.",
+		)
+		return
+	}
+	data.ID = types.StringValue(tag)
+
+	orphans, err := r.findOrphans(ctx, tag, data)
+	if err != nil {
+		addAPIError(diags, "Error looking for orphaned entries", err)
+		return
+	}
+
+	if data.DryRun.ValueBool() {
+		data.Orphans = orphanDescriptions(orphans)
+		return
+	}
+
+	remaining, err := r.deleteOrphans(ctx, orphans)
+	data.Orphans = orphanDescriptions(remaining)
+	if err != nil {
+		addAPIError(diags, "Error deleting orphaned entries", err)
+	}
+}
+
+// managedOrphan is a tagged entry that is not in any keep-list. Exactly one of
+// domain, list and client is set.
+type managedOrphan struct {
+	description string
+	domain      *pihole.Domain
+	list        *pihole.List
+	client      string
+}
+
+// deleteOrphans deletes the orphans with one batch request per kind of entry,
+// so each kind is removed entirely or not at all. It returns the orphans that
+// are left.
+func (r *ManagedCleanupResource) deleteOrphans(ctx context.Context, orphans []managedOrphan) ([]managedOrphan, error) {
+	var domains, lists, clients []managedOrphan
+	for _, o := range orphans {
+		tflog.Info(ctx, "Deleting orphaned entry", map[string]interface{}{"entry": o.description})
+		switch {
+		case o.domain != nil:
+			domains = append(domains, o)
+		case o.list != nil:
+			lists = append(lists, o)
+		default:
+			clients = append(clients, o)
+		}
+	}
+
+	var remaining []managedOrphan
+	var errs []error
+
+	domainBatch := make([]pihole.Domain, len(domains))
+	for i, o := range domains {
+		domainBatch[i] = *o.domain
+	}
+	if err := r.client.DeleteDomains(ctx, domainBatch); err != nil {
+		remaining = append(remaining, domains...)
+		errs = append(errs, fmt.Errorf("deleting %d domains: %w", len(domains), err))
+	}
+
+	listBatch := make([]pihole.List, len(lists))
+	for i, o := range lists {
+		listBatch[i] = *o.list
+	}
+	if err := r.client.DeleteLists(ctx, listBatch); err != nil {
+		remaining = append(remaining, lists...)
+		errs = append(errs, fmt.Errorf("deleting %d lists: %w", len(lists), err))
+	}
+
+	clientBatch := make([]string, len(clients))
+	for i, o := range clients {
+		clientBatch[i] = o.client
+	}
+	if err := r.client.DeleteClients(ctx, clientBatch); err != nil {
+		remaining = append(remaining, clients...)
+		errs = append(errs, fmt.Errorf("deleting %d clients: %w", len(clients), err))
+	}
+
+	return remaining, errors.Join(errs...)
+}
+
+// findOrphans returns the domains, lists and clients tagged with tag whose
+// IDs are not kept. Kinds of entries without a keep-list, and entries owned
+// by another resource than the one of their kind, are left out.
+func (r *ManagedCleanupResource) findOrphans(ctx context.Context, tag string, data *ManagedCleanupResourceModel) ([]managedOrphan, error) {
+	var orphans []managedOrphan
+
+	if !data.KeepDomainIDs.IsNull() {
+		domains, err := r.client.GetDomains(ctx, "", "", "")
+		if err != nil {
+			return nil, fmt.Errorf("listing domains: %w", err)
+		}
+		keepDomains := int64SetMembers(data.KeepDomainIDs)
+		for _, d := range domains {
+			if !isOrphan(d.Comment, tag) || keepDomains[d.ID] {
+				continue
+			}
+			orphans = append(orphans, managedOrphan{
+				description: fmt.Sprintf("domain %s/%s/%s (id %d)", d.Type, d.Kind, d.Domain, d.ID),
+				domain:      &d,
+			})
+		}
+	}
+
+	if !data.KeepListIDs.IsNull() {
+		lists, err := r.client.GetLists(ctx, "", "")
+		if err != nil {
+			return nil, fmt.Errorf("listing lists: %w", err)
+		}
+		keepLists := int64SetMembers(data.KeepListIDs)
+		for _, l := range lists {
+			if !isOrphan(l.Comment, tag) || keepLists[l.ID] {
+				continue
+			}
+			orphans = append(orphans, managedOrphan{
+				description: fmt.Sprintf("list %s %s (id %d)", l.Type, l.Address, l.ID),
+				list:        &l,
+			})
+		}
+	}
+
+	if !data.KeepClientIDs.IsNull() {
+		clients, err := r.client.GetClients(ctx, "")
+		if err != nil {
+			return nil, fmt.Errorf("listing clients: %w", err)
+		}
+		keepClients := int64SetMembers(data.KeepClientIDs)
+		for _, c := range clients {
+			if !isOrphan(c.Comment, tag) || keepClients[c.ID] {
+				continue
+			}
+			orphans = append(orphans, managedOrphan{
+				description: fmt.Sprintf("client %s (id %d)", c.Client, c.ID),
+				client:      c.Client,
+			})
+		}
+	}
+
+	return orphans, nil
+}
+
+// isOrphan reports whether an entry with comment is tagged with tag and
+// owned by the resource of its kind, so it is an orphan unless kept.
+func isOrphan(comment, tag string) bool {
+	return hasManagedTag(comment, tag) && managedTagOwner(comment, tag) == ""
+}
+
+// orphanDescriptions returns the sorted descriptions of orphans as a list.
+func orphanDescriptions(orphans []managedOrphan) types.List {
+	descriptions := make([]string, len(orphans))
+	for i, o := range orphans {
+		descriptions[i] = o.description
+	}
+	sort.Strings(descriptions)
+
+	elements := make([]attr.Value, len(descriptions))
+	for i, d := range descriptions {
+		elements[i] = types.StringValue(d)
+	}
+	return types.ListValueMust(types.StringType, elements)
+}
+
+// int64SetMembers returns the known elements of a set of numbers.
+func int64SetMembers(set types.Set) map[int64]bool {
+	members := make(map[int64]bool)
+	for _, e := range set.Elements() {
+		if v, ok := e.(types.Int64); ok && !v.IsNull() && !v.IsUnknown() {
+			members[v.ValueInt64()] = true
+		}
+	}
+	return members
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccResourceManagedCleanup_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// A tagged domain that no configuration owns is only reported
+			{
+				PreConfig: testAccCreateOrphanDomain(t, "tf-acc-orphan.example.com", "acc-cleanup"),
+				Config:    testAccResourceManagedCleanupConfig(true, ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_managed_cleanup.test", "id", "acc-cleanup"),
+					resource.TestCheckResourceAttr("pihole_managed_cleanup.test", "orphans.#", "1"),
+					resource.TestCheckResourceAttrPair("pihole_managed_cleanup.test", "keep_domain_ids.0", "pihole_domain.kept", "id"),
+				),
+			},
+			// Turning off dry_run deletes it and keeps the managed domain,
+			// the domain of the wildcard block and the orphaned client, as
+			// clients have no keep-list
+			{
+				PreConfig: testAccCreateOrphanClient(t, "10.0.99.1", "acc-cleanup"),
+				Config:    testAccResourceManagedCleanupConfig(false, ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_managed_cleanup.test", "orphans.#", "0"),
+					resource.TestCheckResourceAttr("data.pihole_domains.tagged", "domains.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.pihole_domains.tagged", "domains.*", map[string]string{"domain": "tf-acc-kept.example.com"}),
+					resource.TestCheckResourceAttr("data.pihole_clients.tagged", "clients.#", "1"),
+				),
+			},
+			// A keep-list for clients cleans them up as well
+			{
+				Config: testAccResourceManagedCleanupConfig(false, "keep_client_ids = []"),
+				Check:  resource.TestCheckResourceAttr("data.pihole_clients.tagged", "clients.#", "0"),
+			},
+		},
+	})
+}
+
+// testAccCreateOrphanDomain adds a tagged deny domain behind Terraform's back,
+// as if its state had been lost.
+func testAccCreateOrphanDomain(t *testing.T, domain, tag string) func() {
+	return func() {
+		c, err := testAccAPIClient()
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = c.CreateDomain(context.Background(), &pihole.Domain{
+			Domain:  domain,
+			Type:    "deny",
+			Kind:    "exact",
+			Enabled: true,
+			Comment: tagComment("Lost state", tag, ""),
+		})
+		if err != nil {
+			t.Fatalf("creating orphan domain: %s", err)
+		}
+	}
+}
+
+// testAccCreateOrphanClient adds a tagged client behind Terraform's back.
+func testAccCreateOrphanClient(t *testing.T, client, tag string) func() {
+	return func() {
+		c, err := testAccAPIClient()
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = c.CreateClient(context.Background(), &pihole.PiholeClient{
+			Client:  client,
+			Comment: tagComment("Lost state", tag, ""),
+		})
+		if err != nil {
+			t.Fatalf("creating orphan client: %s", err)
+		}
+	}
+}
+
+func TestIsOrphan(t *testing.T) {
+	tests := []struct {
+		comment string
+		want    bool
+	}{
+		{"Ads [tf:prod]", true},
+		{"[tf:prod]", true},
+		{"Laptop [tf:prod/pihole_device]", false},
+		{"Ads [tf:staging]", false},
+		{"Ads", false},
+	}
+	for _, tt := range tests {
+		if got := isOrphan(tt.comment, "prod"); got != tt.want {
+			t.Errorf("isOrphan(%q) = %t, want %t", tt.comment, got, tt.want)
+		}
+	}
+}
+
+func testAccResourceManagedCleanupConfig(dryRun bool, extra string) string {
+	return fmt.Sprintf(`
+provider "pihole" {
+  managed_by_tag = "acc-cleanup"
+}
+
+resource "pihole_domain" "kept" {
+  domain = "tf-acc-kept.example.com"
+  type   = "deny"
+  kind   = "exact"
+}
+
+resource "pihole_wildcard_block" "owned" {
+  domain = "tf-acc-owned.example.com"
+}
+
+resource "pihole_managed_cleanup" "test" {
+  keep_domain_ids = [pihole_domain.kept.id]
+  dry_run         = %t
+  %s
+
+  depends_on = [pihole_wildcard_block.owned]
+}
+
+data "pihole_domains" "tagged" {
+  managed_by_tag = "acc-cleanup"
+  depends_on     = [pihole_managed_cleanup.test]
+}
+
+data "pihole_clients" "tagged" {
+  managed_by_tag = "acc-cleanup"
+  depends_on     = [pihole_managed_cleanup.test]
+}
+`, dryRun, extra)
+}
@@ -0,0 +1,340 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	passwordTypeAdmin = "admin"
+	passwordTypeApp   = "app"
+)
+
+var (
+	_ resource.Resource                   = &PasswordResource{}
+	_ resource.ResourceWithValidateConfig = &PasswordResource{}
+	_ resource.ResourceWithUpgradeState   = &PasswordResource{}
+)
+
+// appPasswordHashKey is the private state key of the application password
+// hash that was activated, to notice when it is replaced outside Terraform.
+const appPasswordHashKey = "app_password_hash"
+
+func NewPasswordResource() resource.Resource {
+	return &PasswordResource{}
+}
+
+type PasswordResource struct {
+	client *pihole.Client
+}
+
+type PasswordResourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	Type            types.String `tfsdk:"type"`
+	Password        types.String `tfsdk:"password"`
+	AppPasswordHash types.String `tfsdk:"app_password_hash"`
+	PasswordVersion types.Int64  `tfsdk:"password_version"`
+}
+
+func (r *PasswordResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_password"
+}
+
+func (r *PasswordResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the Pi-hole web interface password or an application password.",
+		MarkdownDescription: `
+Manages the Pi-hole web interface (admin) password or an application password.
+Both are write-only (Terraform 1.11+), so no password is stored in the plan or state.
+
+- ` + "`type = \"admin\"`" + ` sets ` + "`webserver.api.password`" + ` to ` + "`password`" + `. Destroying the
+  resource leaves the current password unchanged.
+- ` + "`type = \"app\"`" + ` activates the application password whose hash is ` + "`app_password_hash`" + `,
+  usually generated by the ` + "`pihole_app_password`" + ` ephemeral resource in the same run.
+  Destroying the resource removes the application password.
+
+Terraform cannot see changes to write-only values, so the password is set when the
+resource is created and again whenever ` + "`password_version`" + ` changes. Bump it to rotate.
+
+Pi-hole ends all sessions when a password changes. The provider logs in again with
+the new admin password for the rest of the apply, but later runs must be configured
+with the new password. Authenticating the provider with an application password
+avoids this when rotating the admin password.
+
+~> **Note:** Before version 1 of this resource's schema, application passwords were
+generated by the resource, stored in the state as ` + "`app_password`" + ` and rotated with
+` + "`keepers`" + `. Existing state is upgraded without them; generate the password with
+the ` + "`pihole_app_password`" + ` ephemeral resource instead.
+
+## Example Usage
+
+` + "```hcl" + `
+resource "pihole_password" "admin" {
+  type             = "admin"
+  password         = var.pihole_admin_password
+  password_version = 1
+}
+
+# A new application password, handed to a write-only secret store in the
+# same run; only bumping password_version activates a new one.
+ephemeral "pihole_app_password" "terraform" {}
+
+resource "pihole_password" "terraform" {
+  type              = "app"
+  app_password_hash = ephemeral.pihole_app_password.terraform.hash
+  password_version  = 2
+}
+` + "```" + `
+`,
+		Version: 1,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Resource identifier (same as type).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"type": schema.StringAttribute{
+				Required:    true,
+				Description: "Which password to manage: `admin` or `app`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(passwordTypeAdmin, passwordTypeApp),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"password": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
+				Description: "The new admin password, write-only. Required for `admin`, not allowed for `app`.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"app_password_hash": schema.StringAttribute{
+				Optional:    true,
+				WriteOnly:   true,
+				Description: "The hash of the application password to activate, write-only, e.g. `ephemeral.pihole_app_password.<name>.hash`. Required for `app`, not allowed for `admin`.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"password_version": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Any number; changing it sets `password` or activates `app_password_hash` again, which Terraform cannot detect changes to.",
+			},
+		},
+	}
+}
+
+func (r *PasswordResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data PasswordResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.Type.IsUnknown() {
+		return
+	}
+
+	required, unexpected := path.Root("password"), path.Root("app_password_hash")
+	requiredValue, unexpectedValue := data.Password, data.AppPasswordHash
+	if data.Type.ValueString() == passwordTypeApp {
+		required, unexpected = unexpected, required
+		requiredValue, unexpectedValue = unexpectedValue, requiredValue
+	}
+	if requiredValue.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			required,
+			"Missing "+required.String(),
+			fmt.Sprintf("%s must be set when type is %q.", required, data.Type.ValueString()),
+		)
+	}
+	if !unexpectedValue.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			unexpected,
+			"Unexpected "+unexpected.String(),
+			fmt.Sprintf("%s is not used when type is %q; remove it.", unexpected, data.Type.ValueString()),
+		)
+	}
+}
+
+func (r *PasswordResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData))
+		return
+	}
+	r.client = data.client
+}
+
+func (r *PasswordResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data, config PasswordResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.apply(ctx, &config, resp.Private, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = data.Type
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PasswordResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data PasswordResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The admin password cannot be read back, so only the application
+	// password is checked for changes made outside Terraform.
+	if data.Type.ValueString() != passwordTypeApp {
+		return
+	}
+
+	activated, diags := req.Private.GetKey(ctx, appPasswordHashKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, err := r.client.GetWebserverConfig(ctx)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error reading webserver config", err)
+		return
+	}
+
+	// State upgraded from version 0 has no activated hash to compare.
+	hash := ""
+	if config.API != nil {
+		hash = config.API.AppPwhash
+	}
+	if hash == "" || (activated != nil && hash != string(activated)) {
+		tflog.Debug(ctx, "Application password was changed outside Terraform")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+}
+
+func (r *PasswordResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state, config PasswordResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.PasswordVersion.Equal(state.PasswordVersion) {
+		r.apply(ctx, &config, resp.Private, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PasswordResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data PasswordResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Type.ValueString() == passwordTypeAdmin {
+		resp.Diagnostics.AddWarning(
+			"Admin password left unchanged",
+			"Removing pihole_password does not reset the Pi-hole admin password; it keeps its current value.",
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "Removing application password")
+	if err := r.client.SetAppPasswordHash(ctx, ""); err != nil {
+		addAPIError(&resp.Diagnostics, "Error removing application password", err)
+		return
+	}
+}
+
+// apply sets the admin password or activates the application password from
+// the write-only values in config, and records an activated hash in private.
+func (r *PasswordResource) apply(ctx context.Context, config *PasswordResourceModel, private privateStateSetter, diags *diag.Diagnostics) {
+	if config.Type.ValueString() == passwordTypeAdmin {
+		tflog.Debug(ctx, "Setting admin password")
+		if err := r.client.SetPassword(ctx, config.Password.ValueString()); err != nil {
+			addAPIError(diags, "Error setting admin password", err)
+		}
+		return
+	}
+
+	tflog.Debug(ctx, "Activating application password")
+	hash := config.AppPasswordHash.ValueString()
+	if err := r.client.SetAppPasswordHash(ctx, hash); err != nil {
+		addAPIError(diags, "Error activating application password", err)
+		return
+	}
+	diags.Append(private.SetKey(ctx, appPasswordHashKey, []byte(hash))...)
+}
+
+// UpgradeState drops the generated application password and the keepers of
+// version 0, which stored the password in the state.
+func (r *PasswordResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"id":                schema.StringAttribute{Computed: true},
+					"type":              schema.StringAttribute{Required: true},
+					"password":          schema.StringAttribute{Optional: true, Sensitive: true},
+					"app_password":      schema.StringAttribute{Computed: true, Sensitive: true},
+					"app_password_hash": schema.StringAttribute{Computed: true},
+					"keepers":           schema.MapAttribute{Optional: true, ElementType: types.StringType},
+				},
+			},
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior struct {
+					ID   types.String `tfsdk:"id"`
+					Type types.String `tfsdk:"type"`
+				}
+				resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &prior.ID)...)
+				resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("type"), &prior.Type)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				data := PasswordResourceModel{
+					ID:              prior.ID,
+					Type:            prior.Type,
+					Password:        types.StringNull(),
+					AppPasswordHash: types.StringNull(),
+					PasswordVersion: types.Int64Null(),
+				}
+				resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			},
+		},
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccResourcePassword_app(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourcePasswordAppConfig(1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_password.test", "id", "app"),
+					resource.TestCheckResourceAttr("pihole_password.test", "password_version", "1"),
+					resource.TestCheckNoResourceAttr("pihole_password.test", "app_password_hash"),
+				),
+			},
+			// Bumping password_version activates the newly generated password
+			{
+				Config: testAccResourcePasswordAppConfig(2),
+				Check:  resource.TestCheckResourceAttr("pihole_password.test", "password_version", "2"),
+			},
+			{
+				Config: `
+resource "pihole_password" "test" {
+  type     = "app"
+  password = "secret"
+}
+`,
+				ExpectError: regexp.MustCompile(`Unexpected password`),
+			},
+		},
+	})
+}
+
+func TestAccResourcePassword_admin(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "pihole_password" "test" {
+  type = "admin"
+}
+`,
+				ExpectError: regexp.MustCompile(`Missing password`),
+			},
+			// Re-set the password the test instance already uses so later
+			// tests keep working.
+			{
+				Config: fmt.Sprintf(`
+resource "pihole_password" "test" {
+  type     = "admin"
+  password = %q
+}
+`, testAccPassword()),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_password.test", "id", "admin"),
+					resource.TestCheckNoResourceAttr("pihole_password.test", "password"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourcePasswordAppConfig(version int) string {
+	return fmt.Sprintf(`
+ephemeral "pihole_app_password" "test" {}
+
+resource "pihole_password" "test" {
+  type              = "app"
+  app_password_hash = ephemeral.pihole_app_password.test.hash
+  password_version  = %d
+}
+`, version)
+}
@@ -0,0 +1,453 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/dklesev/terraform-provider-pihole/internal/provider/convert"
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = &PolicyResource{}
+	_ resource.ResourceWithImportState = &PolicyResource{}
+	_ resource.ResourceWithModifyPlan  = &PolicyResource{}
+)
+
+func NewPolicyResource() resource.Resource {
+	return &PolicyResource{}
+}
+
+// PolicyResource manages a group together with the domains and clients
+// assigned to it.
+type PolicyResource struct {
+	client        *pihole.Client
+	comments      commentSettings
+	plannedGroups *plannedGroups
+}
+
+type PolicyResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	Group        types.String `tfsdk:"group"`
+	GroupID      types.Int64  `tfsdk:"group_id"`
+	Comment      types.String `tfsdk:"comment"`
+	DenyDomains  types.Set    `tfsdk:"deny_domains"`
+	DenyRegexes  types.Set    `tfsdk:"deny_regexes"`
+	AllowDomains types.Set    `tfsdk:"allow_domains"`
+	Clients      types.Set    `tfsdk:"clients"`
+}
+
+// policyDomainKinds maps the domain attributes of a policy to the type and
+// kind of their entries.
+var policyDomainKinds = []struct {
+	attribute  string
+	domainType string
+	kind       string
+}{
+	{"deny_domains", "deny", "exact"},
+	{"deny_regexes", "deny", "regex"},
+	{"allow_domains", "allow", "exact"},
+}
+
+// domainSet returns the attribute holding the domains of the given kind.
+func (m *PolicyResourceModel) domainSet(attribute string) *types.Set {
+	switch attribute {
+	case "deny_domains":
+		return &m.DenyDomains
+	case "deny_regexes":
+		return &m.DenyRegexes
+	default:
+		return &m.AllowDomains
+	}
+}
+
+func (r *PolicyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_policy"
+}
+
+func (r *PolicyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a group with its own denied and allowed domains and the clients it applies to.",
+		MarkdownDescription: `
+Manages a group with its own denied and allowed domains and the clients it
+applies to, such as parental controls for a few devices, in one resource. It
+creates the group, adds the domains with only that group assigned, and adds
+the clients to both the Default group and the policy group, so they keep the
+regular blocklists and additionally get the policy's rules.
+
+The policy owns every domain and client assigned to its group. Do not assign
+the group to entries managed by other resources, and do not manage the
+policy's clients with ` + "`pihole_client`" + ` or ` + "`pihole_device`" + `.
+
+## Example Usage
+
+` + "```hcl" + `
+resource "pihole_policy" "kids" {
+  group   = "kids"
+  comment = "Parental controls"
+
+  deny_domains  = ["tiktok.com", "www.tiktok.com"]
+  deny_regexes  = ["(\\.|^)roblox\\.com$"]
+  allow_domains = ["khanacademy.org"]
+  clients       = ["AA:BB:CC:DD:EE:10", "192.168.1.60"]
+}
+` + "```" + `
+`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The name of the policy group.",
+				Computed:    true,
+			},
+			"group": schema.StringAttribute{
+				Description: "The name of the group the policy creates.",
+				Required:    true,
+			},
+			"group_id": schema.Int64Attribute{
+				Description: "The ID of the policy group in Pi-hole.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"comment": schema.StringAttribute{
+				Description: "A comment set on the group and on every domain and client of the policy. The provider's `managed_by_tag` marker is appended to the domain and client comments in Pi-hole and hidden here.",
+				Optional:    true,
+			},
+			"deny_domains": schema.SetAttribute{
+				Description: "Exact domains blocked for the policy's clients.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"deny_regexes": schema.SetAttribute{
+				Description: "Regular expressions blocked for the policy's clients.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"allow_domains": schema.SetAttribute{
+				Description: "Exact domains always allowed for the policy's clients.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"clients": schema.SetAttribute{
+				Description: "Client identifiers the policy applies to (IP, MAC, hostname, CIDR subnet, or interface prefixed with ':').",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (r *PolicyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.client
+	r.comments = data.comments
+	r.plannedGroups = data.plannedGroups
+}
+
+func (r *PolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data PolicyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Creating policy", map[string]interface{}{
+		"group": data.Group.ValueString(),
+	})
+
+	group, err := r.client.CreateGroup(ctx, &pihole.Group{
+		Name:        data.Group.ValueString(),
+		Enabled:     true,
+		Description: data.Comment.ValueString(),
+	})
+	if err != nil {
+		addAPIErrorf(&resp.Diagnostics, "Error creating policy", err, "Could not create group %s", data.Group.ValueString())
+		return
+	}
+	data.ID = types.StringValue(group.Name)
+	data.GroupID = types.Int64Value(group.ID)
+
+	// Save the bare group first so a failure below leaves a tainted policy
+	// that the next apply replaces, instead of an untracked group.
+	bare := data
+	bare.DenyDomains = types.SetNull(types.StringType)
+	bare.DenyRegexes = types.SetNull(types.StringType)
+	bare.AllowDomains = types.SetNull(types.StringType)
+	bare.Clients = types.SetNull(types.StringType)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &bare)...)
+
+	r.sync(ctx, &PolicyResourceModel{}, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data PolicyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	group, err := r.client.GetGroup(ctx, data.ID.ValueString())
+	if err != nil && !errors.Is(err, pihole.ErrNotFound) {
+		addAPIErrorf(&resp.Diagnostics, "Error reading policy", err, "Could not read group %s", data.ID.ValueString())
+		return
+	}
+
+	// Pi-hole answers 404 or an empty result for a group deleted outside Terraform.
+	if group == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.ID = types.StringValue(group.Name)
+	data.Group = types.StringValue(group.Name)
+	data.GroupID = types.Int64Value(group.ID)
+	data.Comment = convert.OptionalString(group.Description)
+
+	domains, err := r.client.GetDomains(ctx, "", "", "")
+	if err != nil {
+		addAPIErrorf(&resp.Diagnostics, "Error reading policy", err, "Could not read domains")
+		return
+	}
+	for _, k := range policyDomainKinds {
+		var names []string
+		for _, d := range domains {
+			if d.Type == k.domainType && d.Kind == k.kind && slices.Contains(d.Groups, group.ID) {
+				names = append(names, d.Domain)
+			}
+		}
+		set := data.domainSet(k.attribute)
+		*set = policyStringSet(names, *set)
+	}
+
+	clients, err := r.client.GetClients(ctx, "")
+	if err != nil {
+		addAPIErrorf(&resp.Diagnostics, "Error reading policy", err, "Could not read clients")
+		return
+	}
+	var names []string
+	for _, c := range clients {
+		if slices.Contains(c.Groups, group.ID) {
+			names = append(names, c.Client)
+		}
+	}
+	data.Clients = policyStringSet(names, data.Clients)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data PolicyResourceModel
+	var state PolicyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.Group.Equal(state.Group) || !data.Comment.Equal(state.Comment) {
+		group, err := r.client.UpdateGroup(ctx, state.ID.ValueString(), &pihole.Group{
+			Name:        data.Group.ValueString(),
+			Enabled:     true,
+			Description: data.Comment.ValueString(),
+		})
+		if err != nil {
+			addAPIErrorf(&resp.Diagnostics, "Error updating policy", err, "Could not update group %s", state.ID.ValueString())
+			return
+		}
+		data.GroupID = types.Int64Value(group.ID)
+	}
+	data.ID = data.Group
+
+	r.sync(ctx, &state, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data PolicyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Empty the policy first so no entry is left pointing at a deleted group.
+	r.sync(ctx, &data, &PolicyResourceModel{Comment: data.Comment, GroupID: data.GroupID}, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.DeleteGroup(ctx, data.ID.ValueString()); err != nil && !errors.Is(err, pihole.ErrNotFound) {
+		addAPIErrorf(&resp.Diagnostics, "Error deleting policy", err, "Could not delete group %s", data.ID.ValueString())
+		return
+	}
+}
+
+// ModifyPlan records planned creates of the policy group for
+// planGroupNames and warns before destroys that Pi-hole may refuse.
+func (r *PolicyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.plannedGroups.record(ctx, req, "group")
+	warnDestructiveDisabled(r.client, req, resp)
+}
+
+func (r *PolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// sync brings the domains and clients of the policy group from the state in
+// from to the plan in to: it deletes removed entries, creates added ones and
+// updates the comment of kept ones when it changed.
+func (r *PolicyResource) sync(ctx context.Context, from, to *PolicyResourceModel, diags *diag.Diagnostics) {
+	groupID := to.GroupID.ValueInt64()
+	comment := r.comments.managedBy("pihole_policy", to.Comment.ValueString())
+	commentChanged := !from.Comment.Equal(to.Comment)
+
+	for _, k := range policyDomainKinds {
+		added, kept, removed := diffStringSets(ctx, *from.domainSet(k.attribute), *to.domainSet(k.attribute), diags)
+		if diags.HasError() {
+			return
+		}
+
+		var deletions []pihole.Domain
+		for _, name := range removed {
+			deletions = append(deletions, pihole.Domain{Domain: name, Type: k.domainType, Kind: k.kind})
+		}
+		if err := r.client.DeleteDomains(ctx, deletions); err != nil {
+			addAPIErrorf(diags, "Error updating policy", err, "Could not delete %s", k.attribute)
+			return
+		}
+
+		var additions []pihole.Domain
+		for _, name := range added {
+			additions = append(additions, pihole.Domain{
+				Domain:  name,
+				Type:    k.domainType,
+				Kind:    k.kind,
+				Enabled: true,
+				Comment: comment,
+				Groups:  []int64{groupID},
+			})
+		}
+		if _, err := r.client.CreateDomains(ctx, additions); err != nil {
+			addAPIErrorf(diags, "Error updating policy", err, "Could not create %s", k.attribute)
+			return
+		}
+
+		if !commentChanged {
+			continue
+		}
+		for _, name := range kept {
+			domain := &pihole.Domain{Domain: name, Type: k.domainType, Kind: k.kind, Enabled: true, Comment: comment, Groups: []int64{groupID}}
+			if _, err := r.client.UpdateDomain(ctx, k.domainType, k.kind, name, domain); err != nil {
+				addAPIErrorf(diags, "Error updating policy", err, "Could not update domain %s", name)
+				return
+			}
+		}
+	}
+
+	added, kept, removed := diffStringSets(ctx, from.Clients, to.Clients, diags)
+	if diags.HasError() {
+		return
+	}
+	if err := r.client.DeleteClients(ctx, removed); err != nil {
+		addAPIErrorf(diags, "Error updating policy", err, "Could not delete clients")
+		return
+	}
+
+	var errs []error
+	groups := []int64{pihole.DefaultGroupID, groupID}
+	for _, name := range added {
+		if _, err := r.client.CreateClient(ctx, &pihole.PiholeClient{Client: name, Comment: comment, Groups: groups}); err != nil {
+			errs = append(errs, fmt.Errorf("creating client %s: %w", name, err))
+		}
+	}
+	if commentChanged {
+		for _, name := range kept {
+			if _, err := r.client.UpdateClient(ctx, name, &pihole.PiholeClient{Client: name, Comment: comment, Groups: groups}); err != nil {
+				errs = append(errs, fmt.Errorf("updating client %s: %w", name, err))
+			}
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		addAPIError(diags, "Error updating policy", err)
+	}
+}
+
+// diffStringSets returns the elements only in to, in both, and only in from.
+// Null and unknown sets are empty.
+func diffStringSets(ctx context.Context, from, to types.Set, diags *diag.Diagnostics) (added, kept, removed []string) {
+	var before, after []string
+	if !from.IsNull() && !from.IsUnknown() {
+		diags.Append(from.ElementsAs(ctx, &before, false)...)
+	}
+	if !to.IsNull() && !to.IsUnknown() {
+		diags.Append(to.ElementsAs(ctx, &after, false)...)
+	}
+	sort.Strings(before)
+	sort.Strings(after)
+
+	for _, name := range after {
+		if slices.Contains(before, name) {
+			kept = append(kept, name)
+		} else {
+			added = append(added, name)
+		}
+	}
+	for _, name := range before {
+		if !slices.Contains(after, name) {
+			removed = append(removed, name)
+		}
+	}
+	return added, kept, removed
+}
+
+// policyStringSet returns names as a set, or keeps a null set when there are
+// no names so an omitted attribute does not show a diff.
+func policyStringSet(names []string, current types.Set) types.Set {
+	if len(names) == 0 && current.IsNull() {
+		return current
+	}
+	elements := make([]attr.Value, len(names))
+	for i, name := range names {
+		elements[i] = types.StringValue(name)
+	}
+	return types.SetValueMust(types.StringType, elements)
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccResourcePolicy_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourcePolicyConfig("Test policy", `"tf-acc-policy-deny-1.example.com", "tf-acc-policy-deny-2.example.com"`, `"192.168.1.170"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_policy.test", "id", "tf-acc-policy"),
+					resource.TestCheckResourceAttrSet("pihole_policy.test", "group_id"),
+					resource.TestCheckResourceAttr("pihole_policy.test", "deny_domains.#", "2"),
+					resource.TestCheckResourceAttr("pihole_policy.test", "deny_regexes.#", "1"),
+					resource.TestCheckResourceAttr("pihole_policy.test", "allow_domains.#", "1"),
+					resource.TestCheckResourceAttr("pihole_policy.test", "clients.#", "1"),
+				),
+			},
+			{
+				ResourceName:      "pihole_policy.test",
+				ImportState:       true,
+				ImportStateId:     "tf-acc-policy",
+				ImportStateVerify: true,
+			},
+			// Swap a domain and a client, and change the comment
+			{
+				Config: testAccResourcePolicyConfig("Updated policy", `"tf-acc-policy-deny-1.example.com", "tf-acc-policy-deny-3.example.com"`, `"192.168.1.171"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_policy.test", "comment", "Updated policy"),
+					resource.TestCheckTypeSetElemAttr("pihole_policy.test", "deny_domains.*", "tf-acc-policy-deny-3.example.com"),
+					resource.TestCheckTypeSetElemAttr("pihole_policy.test", "clients.*", "192.168.1.171"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourcePolicyConfig(comment, denyDomains, clients string) string {
+	return fmt.Sprintf(`
+resource "pihole_policy" "test" {
+  group   = "tf-acc-policy"
+  comment = %q
+
+  deny_domains  = [%s]
+  deny_regexes  = ["(\\.|^)policy-regex\\.example\\.com$"]
+  allow_domains = ["tf-acc-policy-allow.example.com"]
+  clients       = [%s]
+}
+`, comment, denyDomains, clients)
+}
@@ -0,0 +1,274 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dklesev/terraform-provider-pihole/internal/provider/convert"
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = &PTRRecordResource{}
+	_ resource.ResourceWithImportState = &PTRRecordResource{}
+	_ resource.ResourceWithModifyPlan  = &PTRRecordResource{}
+)
+
+func NewPTRRecordResource() resource.Resource {
+	return &PTRRecordResource{}
+}
+
+// PTRRecordResource manages a "ptr-record=" line in misc.dnsmasq_lines, as
+// Pi-hole has no dedicated setting for reverse records.
+type PTRRecordResource struct {
+	client *pihole.Client
+}
+
+type PTRRecordResourceModel struct {
+	ID       types.String    `tfsdk:"id"`
+	IP       types.String    `tfsdk:"ip"`
+	Hostname types.String    `tfsdk:"hostname"`
+	Verify   *dnsVerifyModel `tfsdk:"verify"`
+}
+
+func (r *PTRRecordResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ptr_record"
+}
+
+func (r *PTRRecordResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a Pi-hole PTR (reverse lookup) record.",
+		MarkdownDescription: `
+Manages a PTR record, so an IP address resolves back to a hostname. Pi-hole has
+no dedicated setting for reverse records; the resource adds a dnsmasq
+` + "`ptr-record=`" + ` line to the ` + "`misc.dnsmasq_lines`" + ` config.
+
+A ` + "`pihole_local_dns`" + ` record already answers reverse lookups for its IP.
+Use this resource for addresses that need a different or additional name.
+
+~> **Note:** ` + "`pihole_config_misc`" + ` manages ` + "`dnsmasq_lines`" + ` as a whole list and
+removes lines it does not know. When both are used, add ` + "`dnsmasq_lines`" + ` to
+the ` + "`ignore_changes`" + ` of ` + "`pihole_config_misc`" + `.
+
+## Example Usage
+
+` + "```hcl" + `
+resource "pihole_ptr_record" "gateway" {
+  ip       = "192.168.1.1"
+  hostname = "gateway.lan"
+}
+
+resource "pihole_ptr_record" "nas_v6" {
+  ip       = "fd00::10"
+  hostname = "nas.lan"
+
+  verify {}
+}
+` + "```" + `
+`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Resource identifier (`ip,hostname`).",
+			},
+			"ip": schema.StringAttribute{
+				Required:    true,
+				Description: "The IPv4 or IPv6 address.",
+			},
+			"hostname": schema.StringAttribute{
+				Required:    true,
+				Description: "The hostname the address resolves to.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"verify": dnsVerifyBlock(),
+		},
+	}
+}
+
+func (r *PTRRecordResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData))
+		return
+	}
+	r.client = data.client
+}
+
+func (r *PTRRecordResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data PTRRecordResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	line, ok := ptrRecordLine(data.IP.ValueString(), data.Hostname.ValueString())
+	if !ok {
+		resp.Diagnostics.AddAttributeError(path.Root("ip"), "Invalid IP address", fmt.Sprintf("%q is not an IP address.", data.IP.ValueString()))
+		return
+	}
+	tflog.Debug(ctx, "Creating PTR record", map[string]interface{}{"value": line})
+
+	if err := r.client.AddConfigArrayItemVerified(ctx, "misc/dnsmasq_lines", line, data.finder()); err != nil {
+		addAPIError(&resp.Diagnostics, "Error adding PTR record", err)
+		return
+	}
+
+	data.ID = types.StringValue(data.IP.ValueString() + "," + data.Hostname.ValueString())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	verifyDNS(ctx, r.client, data.Verify, data.IP.ValueString(), verifyPTR(data.IP.ValueString(), data.Hostname.ValueString()), &resp.Diagnostics)
+}
+
+func (r *PTRRecordResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data PTRRecordResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, err := r.client.GetMiscConfig(ctx)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error reading misc config", err)
+		return
+	}
+
+	if _, found := convert.FindPTRRecord(config.DnsmasqLines, data.IP.ValueString(), data.Hostname.ValueString()); !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.ID = types.StringValue(data.IP.ValueString() + "," + data.Hostname.ValueString())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PTRRecordResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state PTRRecordResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	line, ok := ptrRecordLine(data.IP.ValueString(), data.Hostname.ValueString())
+	if !ok {
+		resp.Diagnostics.AddAttributeError(path.Root("ip"), "Invalid IP address", fmt.Sprintf("%q is not an IP address.", data.IP.ValueString()))
+		return
+	}
+
+	config, err := r.client.GetMiscConfig(ctx)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error reading misc config", err)
+		return
+	}
+
+	tflog.Debug(ctx, "Updating PTR record", map[string]interface{}{"value": line})
+
+	// Swap the entry in place, like the CNAME records
+	old, found := convert.FindPTRRecord(config.DnsmasqLines, state.IP.ValueString(), state.Hostname.ValueString())
+	switch {
+	case found && old == line:
+		// Only the verify block changed.
+	case found:
+		err = r.client.ReplaceConfigArrayItem(ctx, "misc/dnsmasq_lines", old, line, state.finder(), data.finder())
+	default:
+		err = r.client.AddConfigArrayItemVerified(ctx, "misc/dnsmasq_lines", line, data.finder())
+	}
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error updating PTR record", err)
+		return
+	}
+
+	data.ID = types.StringValue(data.IP.ValueString() + "," + data.Hostname.ValueString())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	verifyDNS(ctx, r.client, data.Verify, data.IP.ValueString(), verifyPTR(data.IP.ValueString(), data.Hostname.ValueString()), &resp.Diagnostics)
+}
+
+func (r *PTRRecordResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data PTRRecordResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, err := r.client.GetMiscConfig(ctx)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error reading misc config", err)
+		return
+	}
+
+	// Delete the line as stored, which may differ in case or spacing.
+	line, found := convert.FindPTRRecord(config.DnsmasqLines, data.IP.ValueString(), data.Hostname.ValueString())
+	if !found {
+		return
+	}
+	tflog.Debug(ctx, "Deleting PTR record", map[string]interface{}{"value": line})
+
+	if err := r.client.DeleteConfigArrayItemVerified(ctx, "misc/dnsmasq_lines", line, data.finder()); err != nil && !errors.Is(err, pihole.ErrNotFound) {
+		addAPIError(&resp.Diagnostics, "Error deleting PTR record", err)
+		return
+	}
+}
+
+// ModifyPlan rejects addresses that have no reverse name and warns before
+// destroys that Pi-hole may refuse.
+func (r *PTRRecordResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if !req.Plan.Raw.IsNull() {
+		var ip types.String
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("ip"), &ip)...)
+		if !ip.IsNull() && !ip.IsUnknown() {
+			if _, ok := convert.ReverseName(ip.ValueString()); !ok {
+				resp.Diagnostics.AddAttributeError(path.Root("ip"), "Invalid IP address", fmt.Sprintf("%q is not an IP address.", ip.ValueString()))
+			}
+		}
+	}
+
+	warnDestructiveDisabled(r.client, req, resp)
+}
+
+func (r *PTRRecordResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import format: "ip,hostname"
+	parts := strings.SplitN(req.ID, ",", 2)
+	if len(parts) != 2 {
+		resp.Diagnostics.AddError("Invalid import ID", "Expected format: 'ip,hostname'")
+		return
+	}
+
+	data := PTRRecordResourceModel{
+		ID:       types.StringValue(req.ID),
+		IP:       types.StringValue(parts[0]),
+		Hostname: types.StringValue(parts[1]),
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// ptrRecordLine formats the dnsmasq line for a PTR record.
+func ptrRecordLine(ip, hostname string) (string, bool) {
+	name, ok := convert.ReverseName(ip)
+	if !ok {
+		return "", false
+	}
+	return fmt.Sprintf("ptr-record=%s,%s", name, hostname), true
+}
+
+// finder looks the record up in misc.dnsmasq_lines, which may store it in
+// another case or spacing.
+func (m PTRRecordResourceModel) finder() pihole.ItemFinder {
+	return func(lines []string) (string, bool) {
+		return convert.FindPTRRecord(lines, m.IP.ValueString(), m.Hostname.ValueString())
+	}
+}
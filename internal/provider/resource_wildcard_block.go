@@ -0,0 +1,301 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/dklesev/terraform-provider-pihole/internal/provider/convert"
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = &WildcardBlockResource{}
+	_ resource.ResourceWithImportState = &WildcardBlockResource{}
+	_ resource.ResourceWithModifyPlan  = &WildcardBlockResource{}
+)
+
+func NewWildcardBlockResource() resource.Resource {
+	return &WildcardBlockResource{}
+}
+
+// WildcardBlockResource blocks a domain and all of its subdomains with the
+// regex of the "domain" template, so the escaping is never written by hand.
+type WildcardBlockResource struct {
+	client   *pihole.Client
+	comments commentSettings
+}
+
+type WildcardBlockResourceModel struct {
+	ID           types.Int64  `tfsdk:"id"`
+	Domain       types.String `tfsdk:"domain"`
+	Enabled      types.Bool   `tfsdk:"enabled"`
+	Comment      types.String `tfsdk:"comment"`
+	Groups       types.Set    `tfsdk:"groups"`
+	Regex        types.String `tfsdk:"regex"`
+	DateAdded    types.Int64  `tfsdk:"date_added"`
+	DateModified types.Int64  `tfsdk:"date_modified"`
+}
+
+func (r *WildcardBlockResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_wildcard_block"
+}
+
+func (r *WildcardBlockResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Blocks a domain and all of its subdomains.",
+		MarkdownDescription: `
+Blocks a domain and all of its subdomains. The resource creates the deny
+regex Pi-hole's documentation recommends for wildcard blocking, e.g.
+` + "`(\\.|^)example\\.com$`" + ` for ` + "`example.com`" + `, and exports it as ` + "`regex`" + `.
+
+A leading ` + "`*.`" + ` and a trailing dot are ignored, and the domain is matched
+case-insensitively. For other rules, e.g. only the subdomains or a whole
+top-level domain, use ` + "`pihole_custom_regex`" + `.
+
+## Example Usage
+
+` + "```hcl" + `
+resource "pihole_wildcard_block" "tracker" {
+  domain  = "tracker.example.com"
+  comment = "Tracker and all of its subdomains"
+}
+` + "```" + `
+
+## Import
+
+Wildcard blocks can be imported using the domain:
+
+` + "```shell" + `
+terraform import pihole_wildcard_block.tracker tracker.example.com
+` + "```" + `
+`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Description: "The unique identifier of the domain entry in Pi-hole.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"domain": schema.StringAttribute{
+				Description: "The domain to block together with all of its subdomains, e.g. `example.com`.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Description: "Whether the block is enabled. Default: true.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"comment": schema.StringAttribute{
+				Description: "A comment describing the block. The provider's `managed_by_tag` marker is appended in Pi-hole and hidden here.",
+				Optional:    true,
+			},
+			"groups": schema.SetAttribute{
+				Description: "List of group IDs this block applies to. Default group ID is 0.",
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.Int64Type,
+			},
+			"regex": schema.StringAttribute{
+				Description: "The deny regex created for the domain.",
+				Computed:    true,
+			},
+			"date_added": schema.Int64Attribute{
+				Description: "Unix timestamp when the block was created.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"date_modified": schema.Int64Attribute{
+				Description: "Unix timestamp when the block was last modified.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *WildcardBlockResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData))
+		return
+	}
+	r.client = data.client
+	r.comments = data.comments
+}
+
+func (r *WildcardBlockResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data WildcardBlockResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domain := r.domain(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Creating wildcard block", map[string]interface{}{"domain": data.Domain.ValueString(), "regex": domain.Domain})
+
+	created, err := r.client.CreateDomain(ctx, domain)
+	if err != nil {
+		addAPIErrorf(&resp.Diagnostics, "Error creating wildcard block", err, "Could not block %s", data.Domain.ValueString())
+		return
+	}
+
+	r.mapDomainToModel(ctx, created, &data, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *WildcardBlockResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data WildcardBlockResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domain, err := r.client.GetDomain(ctx, "deny", "regex", data.Regex.ValueString())
+	if err != nil && !errors.Is(err, pihole.ErrNotFound) {
+		addAPIErrorf(&resp.Diagnostics, "Error reading wildcard block", err, "Could not read regex %s", data.Regex.ValueString())
+		return
+	}
+	if domain == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	r.mapDomainToModel(ctx, domain, &data, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *WildcardBlockResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state WildcardBlockResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domain := r.domain(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updated, err := r.client.UpdateDomain(ctx, "deny", "regex", state.Regex.ValueString(), domain)
+	if err != nil {
+		addAPIErrorf(&resp.Diagnostics, "Error updating wildcard block", err, "Could not update regex %s", state.Regex.ValueString())
+		return
+	}
+
+	r.mapDomainToModel(ctx, updated, &data, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *WildcardBlockResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data WildcardBlockResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.DeleteDomain(ctx, "deny", "regex", data.Regex.ValueString()); err != nil && !errors.Is(err, pihole.ErrNotFound) {
+		addAPIErrorf(&resp.Diagnostics, "Error deleting wildcard block", err, "Could not delete regex %s", data.Regex.ValueString())
+		return
+	}
+}
+
+// ModifyPlan shows the generated regex in the plan, rejects invalid domains
+// and warns before destroys that Pi-hole may refuse.
+func (r *WildcardBlockResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	warnDestructiveDisabled(r.client, req, resp)
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var data WildcardBlockResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.Domain.IsUnknown() {
+		return
+	}
+
+	rule, err := buildRegexRule("domain", data.Domain.ValueString(), "")
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("domain"), "Invalid wildcard block", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("regex"), rule)...)
+}
+
+func (r *WildcardBlockResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import format: domain
+	rule, err := buildRegexRule("domain", req.ID, "")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", fmt.Sprintf("Import ID must be a domain (e.g., example.com): %s", err))
+		return
+	}
+
+	data := WildcardBlockResourceModel{
+		Domain: types.StringValue(req.ID),
+		Regex:  types.StringValue(rule),
+		Groups: types.SetNull(types.Int64Type),
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// domain returns the deny regex entry described by the planned model.
+func (r *WildcardBlockResource) domain(ctx context.Context, data *WildcardBlockResourceModel, diags *diag.Diagnostics) *pihole.Domain {
+	rule, err := buildRegexRule("domain", data.Domain.ValueString(), "")
+	if err != nil {
+		diags.AddAttributeError(path.Root("domain"), "Invalid wildcard block", err.Error())
+		return nil
+	}
+
+	groups, d := convert.Int64s(ctx, data.Groups)
+	diags.Append(d...)
+
+	return &pihole.Domain{
+		Domain:  rule,
+		Type:    "deny",
+		Kind:    "regex",
+		Enabled: data.Enabled.ValueBool(),
+		Comment: r.comments.managedBy("pihole_wildcard_block", data.Comment.ValueString()),
+		Groups:  groups,
+	}
+}
+
+func (r *WildcardBlockResource) mapDomainToModel(ctx context.Context, domain *pihole.Domain, data *WildcardBlockResourceModel, diags *diag.Diagnostics) {
+	data.ID = types.Int64Value(domain.ID)
+	data.Regex = types.StringValue(domain.Domain)
+	data.Enabled = types.BoolValue(domain.Enabled)
+	data.Comment = convert.OptionalString(r.comments.unmanaged(domain.Comment))
+
+	groups, d := convert.GroupSet(ctx, domain.Groups)
+	diags.Append(d...)
+	data.Groups = groups
+
+	data.DateAdded = types.Int64Value(domain.DateAdded)
+	data.DateModified = types.Int64Value(domain.DateModified)
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccResourceWildcardBlock_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create
+			{
+				Config: testAccResourceWildcardBlockConfig("*.tf-acc-zone.example.com"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_wildcard_block.test", "regex", `(\.|^)tf-acc-zone\.example\.com$`),
+					resource.TestCheckResourceAttr("pihole_wildcard_block.test", "enabled", "true"),
+					resource.TestCheckResourceAttrSet("pihole_wildcard_block.test", "id"),
+				),
+			},
+			// Import
+			{
+				ResourceName:            "pihole_wildcard_block.test",
+				ImportState:             true,
+				ImportStateId:           "tf-acc-zone.example.com",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"domain"},
+			},
+			// Change the domain in place
+			{
+				Config: testAccResourceWildcardBlockConfig("tf-acc-tracker.tf-acc-zone.example.com"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_wildcard_block.test", "regex", `(\.|^)tf-acc-tracker\.tf-acc-zone\.example\.com$`),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceWildcardBlockConfig(domain string) string {
+	return fmt.Sprintf(`
+resource "pihole_wildcard_block" "test" {
+  domain  = %q
+  comment = "Acceptance test"
+}
+`, domain)
+}
@@ -0,0 +1,214 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	_ "time/tzdata" // schedules must work on hosts without a time zone database
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// A schedule expression lists the windows in which a schedule is active,
+// separated by semicolons. Each window is an optional day specification and a
+// time range, e.g.
+//
+//	mon-fri 21:00-07:00; sat,sun 22:30-08:00; 12:00-13:00
+//
+// Days are three-letter names, ranges of them (wrapping is allowed, so
+// fri-mon is Friday to Monday), or the words daily, weekdays and weekends.
+// A window without days applies every day. A time range whose end is before
+// its start runs past midnight and belongs to the day it starts on; 00:00-24:00
+// covers the whole day.
+
+// scheduleWindow is one window of a schedule expression.
+type scheduleWindow struct {
+	days       [7]bool // indexed by time.Weekday
+	start, end int     // minutes since midnight
+}
+
+var scheduleDayNames = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// parseSchedule parses a schedule expression.
+func parseSchedule(expr string) ([]scheduleWindow, error) {
+	var windows []scheduleWindow
+	for _, part := range strings.Split(expr, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		fields := strings.Fields(strings.ToLower(part))
+		var w scheduleWindow
+		var err error
+		switch len(fields) {
+		case 1:
+			w.days = [7]bool{true, true, true, true, true, true, true}
+			w.start, w.end, err = parseTimeRange(fields[0])
+		case 2:
+			if w.days, err = parseDays(fields[0]); err == nil {
+				w.start, w.end, err = parseTimeRange(fields[1])
+			}
+		default:
+			err = fmt.Errorf("expected \"[days] HH:MM-HH:MM\"")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("window %q: %w", part, err)
+		}
+		windows = append(windows, w)
+	}
+
+	if len(windows) == 0 {
+		return nil, fmt.Errorf("no windows in schedule")
+	}
+	return windows, nil
+}
+
+// parseDays parses a comma-separated list of days and day ranges.
+func parseDays(spec string) ([7]bool, error) {
+	var days [7]bool
+	for _, item := range strings.Split(spec, ",") {
+		switch item {
+		case "daily":
+			days = [7]bool{true, true, true, true, true, true, true}
+			continue
+		case "weekdays":
+			item = "mon-fri"
+		case "weekends":
+			item = "sat-sun"
+		}
+
+		from, to, isRange := strings.Cut(item, "-")
+		if !isRange {
+			to = from
+		}
+		first, ok := scheduleDayNames[from]
+		if !ok {
+			return days, fmt.Errorf("unknown day %q", from)
+		}
+		last, ok := scheduleDayNames[to]
+		if !ok {
+			return days, fmt.Errorf("unknown day %q", to)
+		}
+		for d := first; ; d = (d + 1) % 7 {
+			days[d] = true
+			if d == last {
+				break
+			}
+		}
+	}
+	return days, nil
+}
+
+// parseTimeRange parses "HH:MM-HH:MM" into minutes since midnight.
+func parseTimeRange(spec string) (int, int, error) {
+	from, to, ok := strings.Cut(spec, "-")
+	if !ok {
+		return 0, 0, fmt.Errorf("expected a time range HH:MM-HH:MM, got %q", spec)
+	}
+	start, err := parseClock(from, false)
+	if err != nil {
+		return 0, 0, err
+	}
+	end, err := parseClock(to, true)
+	if err != nil {
+		return 0, 0, err
+	}
+	if start == end {
+		return 0, 0, fmt.Errorf("time range %q is empty; use 00:00-24:00 for a whole day", spec)
+	}
+	return start, end, nil
+}
+
+// parseClock parses "HH:MM". 24:00 is only allowed as the end of a range.
+func parseClock(s string, end bool) (int, error) {
+	var h, m int
+	if n, err := fmt.Sscanf(s, "%d:%d", &h, &m); err != nil || n != 2 || len(s) != 5 {
+		return 0, fmt.Errorf("invalid time %q, expected HH:MM", s)
+	}
+	if end && h == 24 && m == 0 {
+		return 24 * 60, nil
+	}
+	if h < 0 || h > 23 || m < 0 || m > 59 {
+		return 0, fmt.Errorf("invalid time %q", s)
+	}
+	return h*60 + m, nil
+}
+
+// activeAt reports whether t falls into the window. t must already be in the
+// schedule's time zone.
+func (w scheduleWindow) activeAt(t time.Time) bool {
+	minute := t.Hour()*60 + t.Minute()
+	day := t.Weekday()
+	if w.start < w.end {
+		return w.days[day] && minute >= w.start && minute < w.end
+	}
+	yesterday := (day + 6) % 7
+	return (w.days[day] && minute >= w.start) || (w.days[yesterday] && minute < w.end)
+}
+
+// scheduleActiveAt reports whether t falls into any of the windows.
+func scheduleActiveAt(windows []scheduleWindow, t time.Time) bool {
+	for _, w := range windows {
+		if w.activeAt(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// scheduleExpressionValidator checks that a string is a valid schedule
+// expression.
+type scheduleExpressionValidator struct{}
+
+func (v scheduleExpressionValidator) Description(ctx context.Context) string {
+	return `value must be a schedule expression such as "mon-fri 21:00-07:00; sat,sun 22:30-08:00"`
+}
+
+func (v scheduleExpressionValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v scheduleExpressionValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if _, err := parseSchedule(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid schedule expression",
+			fmt.Sprintf("The schedule %q is invalid: %s.", req.ConfigValue.ValueString(), err),
+		)
+	}
+}
+
+// timeZoneValidator checks that a string names an IANA time zone.
+type timeZoneValidator struct{}
+
+func (v timeZoneValidator) Description(ctx context.Context) string {
+	return `value must be an IANA time zone name such as "Europe/Berlin" or "UTC"`
+}
+
+func (v timeZoneValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v timeZoneValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if _, err := time.LoadLocation(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid time zone",
+			fmt.Sprintf("The time zone %q is unknown: %s.", req.ConfigValue.ValueString(), err),
+		)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSchedule_Invalid(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		" ; ",
+		"mon-fri",
+		"mon-fri 21:00",
+		"mon-fri 21:00-07:00 extra",
+		"funday 21:00-07:00",
+		"mon-xyz 21:00-07:00",
+		"25:00-07:00",
+		"21:00-24:30",
+		"24:00-07:00",
+		"9:00-17:00",
+		"12:00-12:00",
+	} {
+		if _, err := parseSchedule(expr); err == nil {
+			t.Errorf("parseSchedule(%q) expected error", expr)
+		}
+	}
+}
+
+func TestScheduleActiveAt(t *testing.T) {
+	// 2025-06-02 is a Monday.
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2025, time.June, day, hour, minute, 0, 0, time.UTC)
+	}
+	const (
+		mon = 2
+		fri = 6
+		sat = 7
+		sun = 8
+	)
+
+	tests := []struct {
+		expr string
+		at   time.Time
+		want bool
+	}{
+		{"09:00-17:00", at(mon, 9, 0), true},
+		{"09:00-17:00", at(mon, 17, 0), false},
+		{"mon-fri 09:00-17:00", at(sat, 12, 0), false},
+		{"weekdays 09:00-17:00", at(fri, 12, 0), true},
+		{"weekends 00:00-24:00", at(sun, 23, 59), true},
+		{"daily 00:00-24:00", at(mon, 0, 0), true},
+		// Overnight windows belong to the day they start on
+		{"fri 22:00-06:00", at(fri, 23, 0), true},
+		{"fri 22:00-06:00", at(sat, 5, 59), true},
+		{"fri 22:00-06:00", at(sat, 6, 0), false},
+		{"fri 22:00-06:00", at(mon, 5, 0), false},
+		// Day ranges may wrap around the week
+		{"fri-mon 12:00-13:00", at(sun, 12, 30), true},
+		{"fri-mon 12:00-13:00", at(mon+1, 12, 30), false},
+		// Several windows
+		{"mon,wed 08:00-09:00; sat 10:00-11:00", at(sat, 10, 30), true},
+		{"mon,wed 08:00-09:00; sat 10:00-11:00", at(mon+1, 8, 30), false},
+	}
+
+	for _, tt := range tests {
+		windows, err := parseSchedule(tt.expr)
+		if err != nil {
+			t.Fatalf("parseSchedule(%q) error = %v", tt.expr, err)
+		}
+		if got := scheduleActiveAt(windows, tt.at); got != tt.want {
+			t.Errorf("scheduleActiveAt(%q, %s) = %v, want %v", tt.expr, tt.at.Format("Mon 15:04"), got, tt.want)
+		}
+	}
+}
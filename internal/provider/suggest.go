@@ -0,0 +1,79 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// maxSuggestions is the number of close matches a diagnostic lists.
+const maxSuggestions = 3
+
+// closeMatches returns up to maxSuggestions candidates that look like a typo
+// of s: at most a quarter of its characters (at least 2) apart ignoring case,
+// or containing it when it has 3 characters or more. The closest come first.
+func closeMatches(s string, candidates []string) []string {
+	want := strings.ToLower(s)
+	limit := max(2, len(want)/4)
+
+	type match struct {
+		candidate string
+		distance  int
+	}
+	var matches []match
+	for _, c := range candidates {
+		got := strings.ToLower(c)
+		d := editDistance(want, got)
+		if d > limit && (len(want) < 3 || !strings.Contains(got, want)) {
+			continue
+		}
+		matches = append(matches, match{c, d})
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].distance < matches[j].distance })
+
+	var result []string
+	for _, m := range matches {
+		if len(result) == maxSuggestions {
+			break
+		}
+		result = append(result, m.candidate)
+	}
+	return result
+}
+
+// editDistance returns the Levenshtein distance between a and b in bytes.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+// quoteList formats values as a quoted list for a diagnostic, e.g.
+// `"a", "b" or "c"`.
+func quoteList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = strconv.Quote(v)
+	}
+	if len(quoted) < 2 {
+		return strings.Join(quoted, "")
+	}
+	return strings.Join(quoted[:len(quoted)-1], ", ") + " or " + quoted[len(quoted)-1]
+}
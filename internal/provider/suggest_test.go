@@ -0,0 +1,63 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCloseMatches(t *testing.T) {
+	candidates := []string{"192.168.1.100", "192.168.1.10", "AA:BB:CC:DD:EE:FF", "laptop.lan", "10.0.0.0/24"}
+	tests := []struct {
+		s    string
+		want []string
+	}{
+		{"192.168.1.101", []string{"192.168.1.100", "192.168.1.10"}},
+		{"aa:bb:cc:dd:ee:ff", []string{"AA:BB:CC:DD:EE:FF"}},
+		{"laptop", []string{"laptop.lan"}},
+		{"10.0.0.0/42", []string{"10.0.0.0/24"}},
+		{"printer.lan", nil},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		if got := closeMatches(tt.s, candidates); !slices.Equal(got, tt.want) {
+			t.Errorf("closeMatches(%q) = %q, want %q", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"kitten", "sitting", 3},
+		{"192.168.1.10", "192.168.1.100", 1},
+	}
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestQuoteList(t *testing.T) {
+	tests := []struct {
+		values []string
+		want   string
+	}{
+		{nil, ""},
+		{[]string{"a"}, `"a"`},
+		{[]string{"a", "b"}, `"a" or "b"`},
+		{[]string{"a", "b", "c"}, `"a", "b" or "c"`},
+	}
+	for _, tt := range tests {
+		if got := quoteList(tt.values); got != tt.want {
+			t.Errorf("quoteList(%q) = %s, want %s", tt.values, got, tt.want)
+		}
+	}
+}
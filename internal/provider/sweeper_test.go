@@ -0,0 +1,157 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dklesev/terraform-provider-pihole/internal/provider/convert"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// Sweepers remove leftovers from failed or interrupted acceptance test runs.
+// Run them against the test instance only:
+//
+//	go test ./internal/provider -v -sweep=local
+//
+// The region argument is ignored; PIHOLE_URL and PIHOLE_PASSWORD select the
+// instance. Each sweeper only touches entries carrying the testSweepPrefix
+// the acceptance tests put on everything they create.
+
+// testSweepPrefix starts the names, domains and list file names of all
+// objects created by the acceptance tests.
+const testSweepPrefix = "tf-acc-"
+
+func init() {
+	resource.AddTestSweepers("pihole_client", &resource.Sweeper{
+		Name: "pihole_client",
+		F:    testSweepClients,
+	})
+
+	resource.AddTestSweepers("pihole_domain", &resource.Sweeper{
+		Name: "pihole_domain",
+		F:    testSweepDomains,
+	})
+
+	resource.AddTestSweepers("pihole_list", &resource.Sweeper{
+		Name: "pihole_list",
+		F:    testSweepLists,
+	})
+
+	// Groups go last so nothing still references them.
+	resource.AddTestSweepers("pihole_group", &resource.Sweeper{
+		Name:         "pihole_group",
+		Dependencies: []string{"pihole_client", "pihole_domain", "pihole_list"},
+		F:            testSweepGroups,
+	})
+}
+
+// testSweepClientIdentifiers are the client identifiers used by the tests.
+var testSweepClientIdentifiers = map[string]bool{
+	"192.168.1.100":     true,
+	"192.168.1.200":     true,
+	"192.168.1.250":     true,
+	"192.168.10.0/24":   true,
+	"aa:bb:cc:dd:ee:ff": true,
+}
+
+func testSweepClients(_ string) error {
+	c, err := testAccAPIClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	clients, err := c.GetClients(ctx, "")
+	if err != nil {
+		return fmt.Errorf("listing clients: %w", err)
+	}
+
+	var errs []error
+	for _, cl := range clients {
+		if !testSweepClientIdentifiers[strings.ToLower(cl.Client)] {
+			continue
+		}
+		if err := c.DeleteClient(ctx, cl.Client); err != nil {
+			errs = append(errs, fmt.Errorf("deleting client %s: %w", cl.Client, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func testSweepDomains(_ string) error {
+	c, err := testAccAPIClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	domains, err := c.GetDomains(ctx, "", "", "")
+	if err != nil {
+		return fmt.Errorf("listing domains: %w", err)
+	}
+
+	var errs []error
+	for _, d := range domains {
+		// Wildcard rules store the domain behind a regex anchor.
+		if !strings.HasPrefix(strings.TrimPrefix(d.Domain, `(\.|^)`), testSweepPrefix) {
+			continue
+		}
+		if err := c.DeleteDomain(ctx, d.Type, d.Kind, d.Domain); err != nil {
+			errs = append(errs, fmt.Errorf("deleting domain %s: %w", d.Domain, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func testSweepLists(_ string) error {
+	c, err := testAccAPIClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	lists, err := c.GetLists(ctx, "", "")
+	if err != nil {
+		return fmt.Errorf("listing lists: %w", err)
+	}
+
+	var errs []error
+	for _, l := range lists {
+		if !strings.HasPrefix(convert.NormalizeListAddress(l.Address), "https://example.com/"+testSweepPrefix) {
+			continue
+		}
+		if err := c.DeleteList(ctx, l.Type, l.Address); err != nil {
+			errs = append(errs, fmt.Errorf("deleting list %s: %w", l.Address, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func testSweepGroups(_ string) error {
+	c, err := testAccAPIClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	groups, err := c.GetGroups(ctx, "")
+	if err != nil {
+		return fmt.Errorf("listing groups: %w", err)
+	}
+
+	var errs []error
+	for _, g := range groups {
+		if g.IsDefault() || !strings.HasPrefix(g.Name, testSweepPrefix) {
+			continue
+		}
+		if err := c.DeleteGroup(ctx, g.Name); err != nil {
+			errs = append(errs, fmt.Errorf("deleting group %s: %w", g.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -6,9 +6,13 @@ package main
 import (
 	"context"
 	"flag"
+	"fmt"
 	"log"
+	"os"
 
+	"github.com/dklesev/terraform-provider-pihole/internal/importgen"
 	"github.com/dklesev/terraform-provider-pihole/internal/provider"
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 )
 
@@ -32,6 +36,10 @@ var (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "generate-imports" {
+		os.Exit(generateImports(os.Args[2:]))
+	}
+
 	var debug bool
 
 	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")
@@ -48,3 +56,39 @@ func main() {
 		log.Fatal(err.Error())
 	}
 }
+
+// generateImports runs the generate-imports command, which prints import
+// blocks for the entries of a Pi-hole, and returns the exit code.
+func generateImports(args []string) int {
+	fs := flag.NewFlagSet("generate-imports", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: terraform-provider-pihole generate-imports [flags] > imports.tf\n\n"+
+			"Prints Terraform import blocks for the groups, domains, lists, clients, local DNS records,\n"+
+			"CNAME records, upstream DNS servers and static DHCP leases of a Pi-hole.\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+	url := fs.String("url", os.Getenv("PIHOLE_URL"), "Pi-hole URL, e.g. http://pi.hole (default $PIHOLE_URL)")
+	password := fs.String("password", "", "Pi-hole password (default $PIHOLE_PASSWORD)")
+	insecure := fs.Bool("tls-insecure-skip-verify", false, "skip TLS certificate verification")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *password == "" {
+		*password = os.Getenv("PIHOLE_PASSWORD")
+	}
+
+	c, err := pihole.New(pihole.Config{
+		URL:                   *url,
+		Password:              *password,
+		TLSInsecureSkipVerify: *insecure,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "generate-imports: %s\n", err)
+		return 1
+	}
+	if err := importgen.Generate(context.Background(), c, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "generate-imports: %s\n", err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package pihole
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// batchDeleteSuffix is appended to a collection to delete several of its
+// entries in one request, e.g. "domains:batchDelete".
+const batchDeleteSuffix = ":batchDelete"
+
+// BatchDeleteItem identifies an entry in a batch delete request. Type and
+// Kind are only used by the collections that need them.
+type BatchDeleteItem struct {
+	Item string `json:"item"`
+	Type string `json:"type,omitempty"`
+	Kind string `json:"kind,omitempty"`
+}
+
+// isDeletion reports whether a request deletes entries, which Pi-hole only
+// allows with webserver.api.allow_destructive.
+func isDeletion(method, path string) bool {
+	return method == http.MethodDelete ||
+		(method == http.MethodPost && strings.HasSuffix(path, batchDeleteSuffix))
+}
+
+// batchDelete deletes items from a collection in a single request. Pi-hole
+// deletes them in one transaction, so either all or none are removed.
+func (c *Client) batchDelete(ctx context.Context, collection string, items []BatchDeleteItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+	_, err := c.Post(ctx, collection+batchDeleteSuffix, items)
+	return err
+}
+
+// DeleteDomains deletes several domain entries at once.
+func (c *Client) DeleteDomains(ctx context.Context, domains []Domain) error {
+	if c.legacy != nil {
+		// The PHP API removes one domain at a time.
+		for _, d := range domains {
+			if err := c.legacyDeleteDomain(ctx, d.Type, d.Kind, d.Domain); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	items := make([]BatchDeleteItem, len(domains))
+	for i, d := range domains {
+		items[i] = BatchDeleteItem{Item: d.Domain, Type: d.Type, Kind: d.Kind}
+	}
+	return c.batchDelete(ctx, "domains", items)
+}
+
+// DeleteLists deletes several list subscriptions at once.
+func (c *Client) DeleteLists(ctx context.Context, lists []List) error {
+	items := make([]BatchDeleteItem, len(lists))
+	for i, l := range lists {
+		items[i] = BatchDeleteItem{Item: l.Address, Type: l.Type}
+	}
+	return c.batchDelete(ctx, "lists", items)
+}
+
+// DeleteClients deletes several clients, identified by their client string,
+// at once.
+func (c *Client) DeleteClients(ctx context.Context, clients []string) error {
+	items := make([]BatchDeleteItem, len(clients))
+	for i, client := range clients {
+		items[i] = BatchDeleteItem{Item: client}
+	}
+	return c.batchDelete(ctx, "clients", items)
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package pihole
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestClient_BatchDelete(t *testing.T) {
+	var gotPath string
+	var gotItems []BatchDeleteItem
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/auth":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"session": map[string]interface{}{"valid": true, "sid": "test-sid"},
+			})
+		case "/api/domains:batchDelete", "/api/lists:batchDelete", "/api/clients:batchDelete":
+			if r.Method != http.MethodPost {
+				t.Errorf("Expected POST, got %s", r.Method)
+			}
+			gotPath = r.URL.Path
+			gotItems = nil
+			if err := json.NewDecoder(r.Body).Decode(&gotItems); err != nil {
+				t.Errorf("Failed to decode body: %v", err)
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(Config{URL: server.URL, Password: "test"})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		call      func() error
+		wantPath  string
+		wantItems []BatchDeleteItem
+	}{
+		{
+			name: "domains",
+			call: func() error {
+				return client.DeleteDomains(ctx, []Domain{
+					{Domain: "ads.example.com", Type: "deny", Kind: "exact"},
+					{Domain: `^ads\.`, Type: "deny", Kind: "regex"},
+				})
+			},
+			wantPath: "/api/domains:batchDelete",
+			wantItems: []BatchDeleteItem{
+				{Item: "ads.example.com", Type: "deny", Kind: "exact"},
+				{Item: `^ads\.`, Type: "deny", Kind: "regex"},
+			},
+		},
+		{
+			name: "lists",
+			call: func() error {
+				return client.DeleteLists(ctx, []List{{Address: "https://example.com/list.txt", Type: "block"}})
+			},
+			wantPath:  "/api/lists:batchDelete",
+			wantItems: []BatchDeleteItem{{Item: "https://example.com/list.txt", Type: "block"}},
+		},
+		{
+			name: "clients",
+			call: func() error {
+				return client.DeleteClients(ctx, []string{"192.168.1.10", "laptop"})
+			},
+			wantPath:  "/api/clients:batchDelete",
+			wantItems: []BatchDeleteItem{{Item: "192.168.1.10"}, {Item: "laptop"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); err != nil {
+				t.Fatalf("batch delete error = %v", err)
+			}
+			if gotPath != tt.wantPath {
+				t.Errorf("Path = %s, want %s", gotPath, tt.wantPath)
+			}
+			if !reflect.DeepEqual(gotItems, tt.wantItems) {
+				t.Errorf("Items = %+v, want %+v", gotItems, tt.wantItems)
+			}
+		})
+	}
+
+	// Nothing to delete sends no request
+	gotPath = ""
+	if err := client.DeleteDomains(ctx, nil); err != nil || gotPath != "" {
+		t.Errorf("Expected no request for an empty batch, got path %q, error %v", gotPath, err)
+	}
+}
+
+func TestClient_BatchDelete_DestructiveDisabled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/auth":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"session": map[string]interface{}{"valid": true, "sid": "test-sid"},
+			})
+		default:
+			w.WriteHeader(http.StatusForbidden)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"error": map[string]interface{}{"key": "forbidden", "message": "Destructive API actions are disabled"},
+			})
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(Config{URL: server.URL, Password: "test", RetryMax: -1})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	client.SetAllowDestructive(false)
+
+	err = client.DeleteClients(context.Background(), []string{"laptop"})
+	if !errors.Is(err, ErrDestructiveDisabled) {
+		t.Errorf("Expected ErrDestructiveDisabled, got %v", err)
+	}
+}
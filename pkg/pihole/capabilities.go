@@ -0,0 +1,150 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package pihole
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// ErrUnsupported is matched by errors for requests to endpoints the Pi-hole
+// does not have, e.g. ones added in a later v6 release.
+var ErrUnsupported = errors.New("not supported by this Pi-hole version")
+
+// UnsupportedError is returned for requests to an endpoint the Pi-hole does
+// not have.
+type UnsupportedError struct {
+	Endpoint string
+	// Version is the FTL version of the Pi-hole, empty when it could not
+	// be read.
+	Version string
+	// Response is the 404 error Pi-hole answered the request with. It is
+	// not unwrapped, so the error does not match ErrNotFound.
+	Response *APIError
+}
+
+func (e *UnsupportedError) Error() string {
+	version := "unknown version"
+	if e.Version != "" {
+		version = "FTL " + e.Version
+	}
+	return fmt.Sprintf("the %s API endpoint is %s (%s, %s); update Pi-hole to use it", e.Endpoint, ErrUnsupported, version, e.Response)
+}
+
+// Is reports whether the error matches ErrUnsupported.
+func (e *UnsupportedError) Is(target error) bool {
+	return target == ErrUnsupported
+}
+
+// capabilityCache remembers which endpoints were probed and the FTL version,
+// so each is looked up at most once per client.
+type capabilityCache struct {
+	mu        sync.Mutex
+	endpoints map[string]bool
+	version   *string
+}
+
+// GetVersion retrieves the versions of the Pi-hole components.
+func (c *Client) GetVersion(ctx context.Context) (*VersionInfo, error) {
+	resp, err := c.Get(ctx, "info/version")
+	if err != nil {
+		return nil, err
+	}
+
+	var result VersionResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse version response: %w", err)
+	}
+
+	return &result.Version, nil
+}
+
+// FTLVersion returns the FTL version of the Pi-hole, e.g. "v6.0.4", reading
+// it on first use. It returns an empty string when the version cannot be
+// read.
+func (c *Client) FTLVersion(ctx context.Context) string {
+	c.capabilities.mu.Lock()
+	cached := c.capabilities.version
+	c.capabilities.mu.Unlock()
+	if cached != nil {
+		return *cached
+	}
+
+	version := ""
+	if info, err := c.GetVersion(ctx); err == nil {
+		version = info.FTL.Local.Version
+	}
+
+	c.capabilities.mu.Lock()
+	c.capabilities.version = &version
+	c.capabilities.mu.Unlock()
+	return version
+}
+
+// unsupportedEndpoint is called for requests Pi-hole answered with 404. It
+// reads the root of the endpoint, e.g. "groups" for "groups/kids", which
+// lists entries without changing anything; when Pi-hole answers that with
+// 404 as well, the endpoint itself is missing and an UnsupportedError
+// replaces err. Results are cached per endpoint.
+func (c *Client) unsupportedEndpoint(ctx context.Context, method, path string, err error) error {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Key == "" {
+		// Only Pi-hole itself can tell a missing endpoint apart from a
+		// wrong URL.
+		return err
+	}
+
+	endpoint := endpointName(path)
+	root, _, _ := strings.Cut(path, "?")
+	if method != http.MethodGet || strings.Trim(root, "/") != endpoint {
+		if c.endpointSupported(ctx, endpoint) {
+			return err
+		}
+	} else {
+		c.capabilities.mu.Lock()
+		c.setEndpointSupported(endpoint, false)
+		c.capabilities.mu.Unlock()
+	}
+
+	unsupported := &UnsupportedError{Endpoint: endpoint, Response: apiErr}
+	if endpoint != "info/version" {
+		unsupported.Version = c.FTLVersion(ctx)
+	}
+	return unsupported
+}
+
+// endpointSupported reports whether the Pi-hole has the endpoint, probing
+// it on first use. Endpoints that cannot be probed count as supported, so
+// the original error is kept.
+func (c *Client) endpointSupported(ctx context.Context, endpoint string) bool {
+	c.capabilities.mu.Lock()
+	supported, ok := c.capabilities.endpoints[endpoint]
+	c.capabilities.mu.Unlock()
+	if ok {
+		return supported
+	}
+
+	_, status, err := c.doRequest(ctx, http.MethodGet, endpoint, nil)
+	var apiErr *APIError
+	supported = status != http.StatusNotFound || !errors.As(err, &apiErr) || apiErr.Key == ""
+
+	c.capabilities.mu.Lock()
+	c.setEndpointSupported(endpoint, supported)
+	c.capabilities.mu.Unlock()
+	return supported
+}
+
+// setEndpointSupported caches a probe result. The caller holds
+// c.capabilities.mu.
+func (c *Client) setEndpointSupported(endpoint string, supported bool) {
+	if c.capabilities.endpoints == nil {
+		c.capabilities.endpoints = make(map[string]bool)
+	}
+	c.capabilities.endpoints[endpoint] = supported
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package pihole
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestClient_UnsupportedEndpoint(t *testing.T) {
+	var probes atomic.Int32
+	notFound := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error": {"key": "not_found", "message": "Not found", "hint": "` + r.URL.Path + `"}}`))
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/auth":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"session": map[string]interface{}{"valid": true, "sid": "test-sid"},
+			})
+		case r.URL.Path == "/api/info/version":
+			w.Write([]byte(`{"version": {"ftl": {"local": {"version": "v6.0.1", "branch": "master"}}}, "took": 0.001}`))
+		case r.URL.Path == "/api/groups":
+			w.Write([]byte(`{"groups": [], "took": 0.001}`))
+		case r.URL.Path == "/api/groups/kids":
+			notFound(w, r)
+		case r.URL.Path == "/api/auth/sessions":
+			notFound(w, r)
+		case r.URL.Path == "/api/dhcp/leases":
+			probes.Add(1)
+			notFound(w, r)
+		case strings.HasPrefix(r.URL.Path, "/api/dhcp/leases/"):
+			notFound(w, r)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte("404 page not found"))
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(Config{URL: server.URL, Password: "test", RetryMax: -1})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	ctx := context.Background()
+
+	// A missing item of an existing endpoint stays a not-found error.
+	_, err = client.Delete(ctx, "groups/kids")
+	if !errors.Is(err, ErrNotFound) || errors.Is(err, ErrUnsupported) {
+		t.Errorf("Expected ErrNotFound for missing group, got %v", err)
+	}
+
+	// A missing endpoint is reported as such, with the FTL version, and is
+	// not mistaken for a missing item.
+	_, err = client.GetSessions(ctx)
+	if !errors.Is(err, ErrUnsupported) || errors.Is(err, ErrNotFound) {
+		t.Fatalf("Expected ErrUnsupported, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "auth/sessions") || !strings.Contains(err.Error(), "FTL v6.0.1") {
+		t.Errorf("Unexpected error message: %v", err)
+	}
+
+	// The endpoint of items below it is probed once.
+	for i := 0; i < 2; i++ {
+		if _, err := client.Delete(ctx, "dhcp/leases/192.168.1.50"); !errors.Is(err, ErrUnsupported) {
+			t.Errorf("Expected ErrUnsupported for item below missing endpoint, got %v", err)
+		}
+	}
+	if n := probes.Load(); n != 1 {
+		t.Errorf("Expected one probe of dhcp/leases, got %d", n)
+	}
+
+	// 404s that do not come from Pi-hole are kept.
+	if _, err := client.Get(ctx, "stats/history"); errors.Is(err, ErrUnsupported) {
+		t.Errorf("Expected plain 404 for non-Pi-hole response, got %v", err)
+	}
+}
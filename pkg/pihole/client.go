@@ -0,0 +1,809 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package pihole
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+const (
+	// DefaultTimeout is the default HTTP timeout for API requests.
+	DefaultTimeout = 30 * time.Second
+
+	// SessionRefreshBuffer is how long before expiry we should refresh the session.
+	SessionRefreshBuffer = 5 * time.Minute
+
+	// DefaultRetryMax is the default number of retries for transient errors.
+	DefaultRetryMax = 3
+
+	// DefaultRetryWaitMin is the minimum wait time between retries.
+	DefaultRetryWaitMin = 2 * time.Second
+
+	// DefaultRetryWaitMax is the maximum wait time between retries.
+	DefaultRetryWaitMax = 10 * time.Second
+
+	// DefaultMaxConcurrentRequests is the default number of API requests a
+	// client has in flight at once.
+	DefaultMaxConcurrentRequests = 8
+)
+
+// Client is a Pi-hole FTL API client.
+type Client struct {
+	baseURL    *url.URL
+	httpClient *retryablehttp.Client
+	password   string
+
+	// Session management
+	mu        sync.RWMutex
+	sid       string
+	sidExpiry time.Time
+	// loginSlot serializes logins. Unlike mu, which only guards the
+	// session fields, it is held during the login requests, and waiting for
+	// it ends when the context is canceled.
+	loginSlot chan struct{}
+
+	// destructiveDisabled mirrors webserver.api.allow_destructive = false.
+	destructiveDisabled bool
+
+	readOnly bool
+
+	waitForRestart time.Duration
+
+	requestObserver func(context.Context, RequestMetric)
+
+	// capabilities caches which endpoints the Pi-hole has, see
+	// endpointSupported.
+	capabilities capabilityCache
+	// groupIDs caches group IDs by name, see GroupIDsByName.
+	groupIDs groupIDCache
+	// listGroupMu serializes SetListGroup.
+	listGroupMu sync.Mutex
+
+	// requestSlots bounds the requests in flight; nil means unbounded.
+	requestSlots chan struct{}
+	// inFlight counts the requests in flight or waiting for a slot.
+	inFlight atomic.Int64
+
+	// legacy is the PHP API of Pi-hole v5 when Config.APIVersion selects it.
+	legacy *legacyAPI
+}
+
+// Config holds the configuration for creating a new Client.
+type Config struct {
+	// URL is the base URL of the Pi-hole instance (e.g., "http://pi.hole").
+	URL string
+
+	// Password is the Pi-hole web interface password.
+	Password string
+
+	// TLSInsecureSkipVerify skips TLS certificate verification.
+	TLSInsecureSkipVerify bool
+
+	// Timeout is the HTTP timeout for API requests.
+	Timeout time.Duration
+
+	// RetryMax is the maximum number of retries for transient errors.
+	RetryMax int
+
+	// RetryWaitMin is the minimum wait time between retries.
+	RetryWaitMin time.Duration
+
+	// RetryWaitMax is the maximum wait time between retries.
+	RetryWaitMax time.Duration
+
+	// WaitForRestart is how long to wait for Pi-hole to come back when FTL
+	// restarts, e.g. after a DNS configuration change. When set, refused
+	// connections are retried once the API answers again, and configuration
+	// writes wait for the API before returning. Zero disables waiting.
+	WaitForRestart time.Duration
+
+	// UserAgent is sent with every request. Defaults to DefaultUserAgent.
+	UserAgent string
+
+	// Headers are added to every request, e.g. credentials for a reverse
+	// proxy in front of Pi-hole. They cannot replace the session ID header.
+	Headers map[string]string
+
+	// RequestObserver, when set, is called after every API request with its
+	// latency and the processing time reported by FTL.
+	RequestObserver func(context.Context, RequestMetric)
+
+	// ReadOnly makes the client refuse every request that is not a GET with
+	// ErrReadOnly, before it is sent. Logging in still works.
+	ReadOnly bool
+
+	// MaxConcurrentRequests bounds the API requests in flight at once;
+	// further requests wait for a free slot. Zero means
+	// DefaultMaxConcurrentRequests, a negative value no bound.
+	MaxConcurrentRequests int
+
+	// APIVersion is the Pi-hole API to use: APIVersionFTL, the default, or
+	// APIVersionLegacy for the PHP API of Pi-hole v5. The PHP API only
+	// covers domains and blocking, and takes Password or the API token
+	// derived from it.
+	APIVersion int
+}
+
+// New creates a new Pi-hole API client with automatic retry support.
+func New(cfg Config) (*Client, error) {
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("Pi-hole URL is required")
+	}
+
+	baseURL, err := apiBaseURL(cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Pi-hole URL: %w", err)
+	}
+
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+
+	retryMax := cfg.RetryMax
+	if retryMax < 0 {
+		// Negative value means explicitly disable retries
+		retryMax = 0
+	} else if retryMax == 0 {
+		retryMax = DefaultRetryMax
+	}
+
+	retryWaitMin := cfg.RetryWaitMin
+	if retryWaitMin == 0 {
+		retryWaitMin = DefaultRetryWaitMin
+	}
+
+	retryWaitMax := cfg.RetryWaitMax
+	if retryWaitMax == 0 {
+		retryWaitMax = DefaultRetryWaitMax
+	}
+
+	transport := &http.Transport{
+		TLSClientConfig: &tls.Config{
+			InsecureSkipVerify: cfg.TLSInsecureSkipVerify,
+		},
+		// headerTransport decompresses responses itself.
+		DisableCompression: true,
+	}
+
+	userAgent := cfg.UserAgent
+	if userAgent == "" {
+		userAgent = DefaultUserAgent
+	}
+
+	// Create retryable HTTP client
+	retryClient := retryablehttp.NewClient()
+	retryClient.HTTPClient = &http.Client{
+		Timeout: timeout,
+		Transport: &headerTransport{
+			base:      transport,
+			userAgent: userAgent,
+			headers:   cfg.Headers,
+		},
+	}
+	retryClient.RetryMax = retryMax
+	retryClient.RetryWaitMin = retryWaitMin
+	retryClient.RetryWaitMax = retryWaitMax
+	retryClient.Logger = nil // Disable default noisy logging
+
+	// Custom retry policy: retry on connection errors and 5xx
+	retryClient.CheckRetry = retryablehttp.DefaultRetryPolicy
+	retryClient.Backoff = jitterBackoff
+	retryClient.RequestLogHook = recordRetryAttempt
+	retryClient.ResponseLogHook = recordRetryResponse
+	retryClient.ErrorHandler = retryErrorHandler
+
+	var legacy *legacyAPI
+	switch cfg.APIVersion {
+	case 0, APIVersionFTL:
+	case APIVersionLegacy:
+		legacy, err = newLegacyAPI(cfg.URL, cfg.Password)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Pi-hole URL: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported Pi-hole API version %d", cfg.APIVersion)
+	}
+
+	var requestSlots chan struct{}
+	switch {
+	case cfg.MaxConcurrentRequests == 0:
+		requestSlots = make(chan struct{}, DefaultMaxConcurrentRequests)
+	case cfg.MaxConcurrentRequests > 0:
+		requestSlots = make(chan struct{}, cfg.MaxConcurrentRequests)
+	}
+
+	return &Client{
+		baseURL:         baseURL,
+		password:        cfg.Password,
+		httpClient:      retryClient,
+		waitForRestart:  cfg.WaitForRestart,
+		requestObserver: cfg.RequestObserver,
+		readOnly:        cfg.ReadOnly,
+		requestSlots:    requestSlots,
+		loginSlot:       make(chan struct{}, 1),
+		legacy:          legacy,
+	}, nil
+}
+
+// apiBaseURL returns the API root for a Pi-hole URL. Any path is kept as a
+// prefix, for Pi-holes served below a subpath by a reverse proxy, and "/api"
+// is appended unless the path already ends with it.
+func apiBaseURL(raw string) (*url.URL, error) {
+	u, err := ParseURL(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := strings.Trim(u.EscapedPath(), "/")
+	if prefix != "api" && !strings.HasSuffix(prefix, "/api") {
+		prefix = strings.TrimPrefix(prefix+"/api", "/")
+	}
+
+	// Resolving the absolute path keeps scheme, credentials and host and
+	// drops any query or fragment.
+	return u.Parse("/" + prefix)
+}
+
+// ReadOnly reports whether the client refuses to change anything, see
+// Config.ReadOnly.
+func (c *Client) ReadOnly() bool {
+	return c.readOnly
+}
+
+// Host returns the host name or IP address of the Pi-hole from Config.URL.
+func (c *Client) Host() string {
+	return c.baseURL.Hostname()
+}
+
+// URL returns the Pi-hole URL from Config.URL, without the API path.
+func (c *Client) URL() *url.URL {
+	u := *c.baseURL
+	u.Path = strings.TrimSuffix(u.Path, "api")
+	u.RawPath = ""
+	return &u
+}
+
+// AuthResponse represents the response from the authentication endpoint.
+type AuthResponse struct {
+	Session struct {
+		Valid    bool   `json:"valid"`
+		TOTP     bool   `json:"totp"`
+		SID      string `json:"sid"`
+		CSRF     string `json:"csrf"`
+		Validity int    `json:"validity"` // seconds until expiry
+		Message  string `json:"message"`
+	} `json:"session"`
+	Took float64 `json:"took"`
+}
+
+// ErrorResponse represents an error response from the API.
+type ErrorResponse struct {
+	Error struct {
+		Key     string  `json:"key"`
+		Message string  `json:"message"`
+		Hint    *string `json:"hint"`
+	} `json:"error"`
+	Took float64 `json:"took"`
+}
+
+// ErrNotFound is matched by errors for requests Pi-hole answered with 404
+// Not Found, e.g. an item that was deleted outside Terraform.
+var ErrNotFound = errors.New("not found")
+
+// ErrExists is matched by errors for creations Pi-hole rejected because the
+// item is already there.
+var ErrExists = errors.New("already exists")
+
+// APIError is returned for requests Pi-hole answered with an error status.
+type APIError struct {
+	StatusCode int
+	Key        string // Pi-hole's error key; empty when the body was not a Pi-hole error
+	Message    string
+	Hint       string
+	Body       string // raw response body when it was not a Pi-hole error
+
+	// Method and Path identify the failed request. Path has no query
+	// string, which may hold credentials.
+	Method string
+	Path   string
+
+	// Took is the processing time FTL reported in the error response; zero
+	// when it did not report one.
+	Took time.Duration
+}
+
+// Endpoint is the method and path of the failed request, e.g.
+// "POST /api/groups".
+func (e *APIError) Endpoint() string {
+	return e.Method + " " + e.Path
+}
+
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("API request failed with status %d: %s", e.StatusCode, e.Body)
+	}
+	hint := ""
+	if e.Hint != "" {
+		hint = fmt.Sprintf(" (hint: %s)", e.Hint)
+	}
+	return fmt.Sprintf("API error [%s]: %s%s", e.Key, e.Message, hint)
+}
+
+// Is reports whether the error matches ErrNotFound or ErrExists. Only 404s
+// that Pi-hole itself describes count: a proxy answering 404 for a wrong URL
+// must not make resources disappear from state.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound && e.Key != ""
+	case ErrExists:
+		// Adding an item a config array already holds
+		return e.StatusCode == http.StatusBadRequest && e.Message == "Item already present"
+	}
+	return false
+}
+
+// ErrDestructiveDisabled is wrapped by errors from deletions that Pi-hole
+// rejected while webserver.api.allow_destructive is turned off.
+var ErrDestructiveDisabled = errors.New("destructive API actions are disabled on this Pi-hole (webserver.api.allow_destructive = false)")
+
+// ErrReadOnly is wrapped by errors from requests refused because the client
+// is read-only.
+var ErrReadOnly = errors.New("read-only mode is enabled")
+
+// DestructiveRemediation explains how to allow destructive API actions.
+const DestructiveRemediation = "Enable webserver.api.allow_destructive in the Pi-hole web interface (Settings > All settings > Webserver and API) " +
+	"or run `pihole-FTL --config webserver.api.allow_destructive true`."
+
+// SetAllowDestructive records the instance's webserver.api.allow_destructive
+// setting so rejected DELETE requests can explain why they failed.
+func (c *Client) SetAllowDestructive(allowed bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.destructiveDisabled = !allowed
+}
+
+// AllowDestructive reports whether destructive API actions are allowed. It
+// returns true unless SetAllowDestructive recorded otherwise.
+func (c *Client) AllowDestructive() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return !c.destructiveDisabled
+}
+
+// Authenticate obtains a new session ID from Pi-hole. With the PHP API of
+// Pi-hole v5, which has no sessions, it checks the API token instead.
+//
+// Canceling ctx aborts the login, including waiting for a login in progress
+// on another goroutine and the backoff between retries.
+func (c *Client) Authenticate(ctx context.Context) error {
+	if c.legacy != nil {
+		return c.legacyAuthenticate(ctx)
+	}
+
+	unlock, err := c.lockLogin(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	return c.login(ctx)
+}
+
+// lockLogin waits for the login slot and returns the function releasing it,
+// or the context's error when ctx is canceled first.
+func (c *Client) lockLogin(ctx context.Context) (func(), error) {
+	select {
+	case c.loginSlot <- struct{}{}:
+		return func() { <-c.loginSlot }, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf("login aborted while waiting for another login: %w", ctx.Err())
+	}
+}
+
+// login logs in and stores the new session. The caller holds the login
+// slot.
+func (c *Client) login(ctx context.Context) error {
+	c.mu.RLock()
+	password := c.password
+	c.mu.RUnlock()
+
+	// First, check if authentication is required
+	authURL := c.baseURL.JoinPath("auth")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, authURL.String(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create auth check request: %w", err)
+	}
+
+	retryReq, err := retryablehttp.FromRequest(req)
+	if err != nil {
+		return fmt.Errorf("failed to create retryable request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(retryReq)
+	if err != nil {
+		return fmt.Errorf("failed to check auth status: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read auth response: %w", err)
+	}
+
+	var authResp AuthResponse
+	if err := json.Unmarshal(body, &authResp); err != nil {
+		return fmt.Errorf("failed to parse auth response: %w", err)
+	}
+
+	// If session is already valid (no password set on Pi-hole), we're done
+	if authResp.Session.Valid {
+		c.setSession(authResp, password)
+		return nil
+	}
+
+	// Need to authenticate with password
+	if password == "" {
+		return fmt.Errorf("authentication required but no password provided")
+	}
+
+	loginPayload := map[string]string{
+		"password": password,
+	}
+
+	payloadBytes, err := json.Marshal(loginPayload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal login payload: %w", err)
+	}
+
+	req, err = http.NewRequestWithContext(ctx, http.MethodPost, authURL.String(), bytes.NewReader(payloadBytes))
+	if err != nil {
+		return fmt.Errorf("failed to create login request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	retryReq, err = retryablehttp.FromRequest(req)
+	if err != nil {
+		return fmt.Errorf("failed to create retryable request: %w", err)
+	}
+
+	resp, err = c.httpClient.Do(retryReq)
+	if err != nil {
+		return fmt.Errorf("failed to authenticate: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err = io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read login response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err == nil && errResp.Error.Message != "" {
+			return fmt.Errorf("authentication failed: %s", errResp.Error.Message)
+		}
+		return fmt.Errorf("authentication failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	if err := json.Unmarshal(body, &authResp); err != nil {
+		return fmt.Errorf("failed to parse login response: %w", err)
+	}
+
+	if !authResp.Session.Valid {
+		return fmt.Errorf("authentication failed: invalid session")
+	}
+
+	c.setSession(authResp, password)
+
+	return nil
+}
+
+// setSession stores the session of a successful login with password. When
+// SetPassword changed the password meanwhile, Pi-hole ended the session, so
+// it is dropped and the next request logs in again.
+func (c *Client) setSession(authResp AuthResponse, password string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.password != password {
+		return
+	}
+	c.sid = authResp.Session.SID
+	c.sidExpiry = time.Now().Add(time.Duration(authResp.Session.Validity) * time.Second)
+}
+
+// ensureAuthenticated ensures we have a valid session, refreshing if needed.
+func (c *Client) ensureAuthenticated(ctx context.Context) error {
+	c.mu.RLock()
+	valid := c.sid != "" && time.Now().Add(SessionRefreshBuffer).Before(c.sidExpiry)
+	c.mu.RUnlock()
+
+	if valid {
+		return nil
+	}
+
+	unlock, err := c.lockLogin(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	// Double-check after acquiring the login slot
+	c.mu.RLock()
+	valid = c.sid != "" && time.Now().Add(SessionRefreshBuffer).Before(c.sidExpiry)
+	c.mu.RUnlock()
+	if valid {
+		return nil
+	}
+
+	return c.login(ctx)
+}
+
+// Request makes an authenticated API request.
+//
+// When Config.WaitForRestart is set, a request that finds FTL restarting is
+// retried once the API answers again, a session lost in the restart is
+// replaced, and configuration writes wait for FTL to be back before returning
+// unless ctx comes from WithoutRestartWait.
+//
+// A request that Pi-hole answers with 404 because it does not have the
+// endpoint at all, as happens with endpoints added in later v6 releases,
+// fails with an UnsupportedError instead of an APIError. With the PHP API of
+// Pi-hole v5 every request fails with an error matching ErrUnsupported.
+func (c *Client) Request(ctx context.Context, method, path string, body interface{}) ([]byte, error) {
+	if c.legacy != nil {
+		return nil, legacyUnsupported(fmt.Sprintf("the %s API endpoint", endpointName(path)))
+	}
+	if c.readOnly && method != http.MethodGet {
+		return nil, fmt.Errorf("%w, refusing %s %s", ErrReadOnly, method, path)
+	}
+
+	respBody, status, err := c.request(ctx, method, path, body)
+	if status == http.StatusNotFound && err != nil {
+		err = c.unsupportedEndpoint(ctx, method, path, err)
+	}
+	return respBody, err
+}
+
+// request makes an API request, waiting for FTL restarts as described for
+// Request, and returns the response body and the HTTP status code of the
+// last attempt.
+func (c *Client) request(ctx context.Context, method, path string, body interface{}) ([]byte, int, error) {
+	respBody, status, err := c.doRequest(ctx, method, path, body)
+	if c.waitForRestart <= 0 {
+		return respBody, status, err
+	}
+
+	if err != nil && isConnectionRefused(err) {
+		if waitErr := c.WaitForReady(ctx); waitErr != nil {
+			return nil, status, fmt.Errorf("%w; %s", err, waitErr)
+		}
+		respBody, status, err = c.doRequest(ctx, method, path, body)
+	}
+
+	if status == http.StatusUnauthorized {
+		// Sessions only survive a restart with webserver.session.restore;
+		// doRequest dropped the rejected one.
+		respBody, status, err = c.doRequest(ctx, method, path, body)
+	}
+
+	// A poll that still reaches FTL before it shuts down is harmless: the
+	// next request then finds the port closed and waits above.
+	if err == nil && isConfigWrite(method, path) && waitsForRestart(ctx) {
+		if waitErr := c.WaitForReady(ctx); waitErr != nil {
+			return nil, status, fmt.Errorf("configuration saved, but %w", waitErr)
+		}
+	}
+
+	return respBody, status, err
+}
+
+// acquireRequestSlot waits for one of the Config.MaxConcurrentRequests
+// request slots and counts the request as in flight until the returned
+// function releases it.
+func (c *Client) acquireRequestSlot(ctx context.Context) (func(), error) {
+	c.inFlight.Add(1)
+	if c.requestSlots == nil {
+		return func() { c.inFlight.Add(-1) }, nil
+	}
+	select {
+	case c.requestSlots <- struct{}{}:
+		return func() {
+			<-c.requestSlots
+			c.inFlight.Add(-1)
+		}, nil
+	case <-ctx.Done():
+		c.inFlight.Add(-1)
+		return nil, fmt.Errorf("request failed while waiting for a free request slot: %w", ctx.Err())
+	}
+}
+
+// Busy reports whether any request of c is in flight or waiting for a
+// request slot.
+func (c *Client) Busy() bool {
+	return c.inFlight.Load() > 0
+}
+
+// doRequest makes a single authenticated API request and returns the
+// response body and HTTP status code. The status is zero when no response
+// was received.
+func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}) ([]byte, int, error) {
+	if err := c.ensureAuthenticated(ctx); err != nil {
+		return nil, 0, fmt.Errorf("authentication failed: %w", err)
+	}
+
+	// Parse the path to separate query string from path component
+	var reqURL *url.URL
+	if pathPart, queryPart, found := strings.Cut(path, "?"); found {
+		reqURL = c.baseURL.JoinPath(pathPart)
+		reqURL.RawQuery = queryPart
+	} else {
+		reqURL = c.baseURL.JoinPath(path)
+	}
+
+	var bodyReader io.Reader
+	var bodyBytes []byte
+	if body != nil {
+		var err error
+		bodyBytes, err = json.Marshal(body)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		bodyReader = bytes.NewReader(bodyBytes)
+	}
+
+	reqCtx, retries := withRetryStats(ctx)
+	req, err := http.NewRequestWithContext(reqCtx, method, reqURL.String(), bodyReader)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Wait for a slot before reading the session, which may be replaced
+	// meanwhile.
+	release, err := c.acquireRequestSlot(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer release()
+
+	c.mu.RLock()
+	sid := c.sid
+	c.mu.RUnlock()
+
+	req.Header.Set("sid", sid)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	retryReq, err := retryablehttp.FromRequest(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create retryable request: %w", err)
+	}
+
+	start := time.Now()
+	resp, err := c.httpClient.Do(retryReq)
+	if err != nil {
+		c.observe(ctx, method, path, bodyBytes, start, 0, nil, err)
+		return nil, 0, retried(fmt.Errorf("request failed: %w", err), retries, start)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	c.observe(ctx, method, path, bodyBytes, start, resp.StatusCode, respBody, err)
+	if err != nil {
+		return nil, resp.StatusCode, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	// Handle error responses
+	if resp.StatusCode >= 400 {
+		// A 401 is a session Pi-hole dropped, not a refused deletion; it is
+		// renewed below like for any other request.
+		if isDeletion(method, path) && resp.StatusCode == http.StatusForbidden && !c.AllowDestructive() {
+			return nil, resp.StatusCode, fmt.Errorf("%w. %s", ErrDestructiveDisabled, DestructiveRemediation)
+		}
+		if resp.StatusCode == http.StatusUnauthorized {
+			c.invalidateSession(sid)
+		}
+
+		return nil, resp.StatusCode, retried(newAPIError(resp, respBody), retries, start)
+	}
+
+	return respBody, resp.StatusCode, nil
+}
+
+// newAPIError describes the error response resp with the given body, using
+// Pi-hole's error object when the body holds one.
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Body:       string(body),
+		Method:     resp.Request.Method,
+		Path:       resp.Request.URL.Path,
+		Took:       responseTook(body),
+	}
+	var errResp ErrorResponse
+	if err := json.Unmarshal(body, &errResp); err == nil && errResp.Error.Message != "" {
+		apiErr.Key = errResp.Error.Key
+		apiErr.Message = errResp.Error.Message
+		if errResp.Error.Hint != nil {
+			apiErr.Hint = *errResp.Error.Hint
+		}
+	}
+	return apiErr
+}
+
+// retried wraps err in a RetryError when the request was retried.
+func retried(err error, stats *retryStats, start time.Time) error {
+	if stats.retries == 0 {
+		return err
+	}
+	return &RetryError{
+		Retries:    stats.retries,
+		Elapsed:    time.Since(start),
+		LastStatus: stats.lastStatus,
+		Err:        err,
+	}
+}
+
+// observe reports a finished request to the RequestObserver, if any.
+func (c *Client) observe(ctx context.Context, method, path string, reqBody []byte, start time.Time, status int, body []byte, err error) {
+	if c.requestObserver == nil {
+		return
+	}
+	if err == nil && status >= 400 {
+		err = fmt.Errorf("status %d", status)
+	}
+	path, _, _ = strings.Cut(path, "?")
+	c.requestObserver(ctx, RequestMetric{
+		Method:   method,
+		Path:     path,
+		Endpoint: endpointName(path),
+		Body:     reqBody,
+		Status:   status,
+		Duration: time.Since(start),
+		Took:     responseTook(body),
+		Err:      err,
+	})
+}
+
+// Get performs an authenticated GET request.
+func (c *Client) Get(ctx context.Context, path string) ([]byte, error) {
+	return c.Request(ctx, http.MethodGet, path, nil)
+}
+
+// Post performs an authenticated POST request.
+func (c *Client) Post(ctx context.Context, path string, body interface{}) ([]byte, error) {
+	return c.Request(ctx, http.MethodPost, path, body)
+}
+
+// Put performs an authenticated PUT request.
+func (c *Client) Put(ctx context.Context, path string, body interface{}) ([]byte, error) {
+	return c.Request(ctx, http.MethodPut, path, body)
+}
+
+// Delete performs an authenticated DELETE request.
+func (c *Client) Delete(ctx context.Context, path string) ([]byte, error) {
+	return c.Request(ctx, http.MethodDelete, path, nil)
+}
+
+// Patch performs an authenticated PATCH request.
+func (c *Client) Patch(ctx context.Context, path string, body interface{}) ([]byte, error) {
+	return c.Request(ctx, http.MethodPatch, path, body)
+}
@@ -0,0 +1,869 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package pihole
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{
+			name: "valid config",
+			cfg: Config{
+				URL:      "http://pi.hole",
+				Password: "test",
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid config with port",
+			cfg: Config{
+				URL:      "http://pi.hole:8080",
+				Password: "test",
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid config with path",
+			cfg: Config{
+				URL:      "http://pi.hole/admin",
+				Password: "test",
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid config already has /api",
+			cfg: Config{
+				URL:      "http://pi.hole/api",
+				Password: "test",
+			},
+			wantErr: false,
+		},
+		{
+			name: "empty URL",
+			cfg: Config{
+				URL:      "",
+				Password: "test",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid URL",
+			cfg: Config{
+				URL:      "://invalid",
+				Password: "test",
+			},
+			wantErr: true,
+		},
+		{
+			name: "URL without scheme",
+			cfg: Config{
+				URL:      "pi.hole",
+				Password: "test",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := New(tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("New() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && client == nil {
+				t.Error("New() returned nil client without error")
+			}
+		})
+	}
+}
+
+func TestNew_BaseURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"http://pi.hole", "http://pi.hole/api"},
+		{"http://pi.hole/", "http://pi.hole/api"},
+		{"http://pi.hole:8080", "http://pi.hole:8080/api"},
+		{"http://pi.hole/api", "http://pi.hole/api"},
+		{"http://pi.hole/api/", "http://pi.hole/api"},
+		{"https://example.com/pihole", "https://example.com/pihole/api"},
+		{"https://example.com/pihole/", "https://example.com/pihole/api"},
+		{"https://example.com/pihole/api/", "https://example.com/pihole/api"},
+		{"https://example.com/home/dns/pihole//", "https://example.com/home/dns/pihole/api"},
+		{"https://example.com/pi%20hole/", "https://example.com/pi%20hole/api"},
+		{"https://example.com/pihole/?foo=bar#frag", "https://example.com/pihole/api"},
+		{"http://[fd00::53]:8080", "http://[fd00::53]:8080/api"},
+		{"http://[fd00::53]/pihole/", "http://[fd00::53]/pihole/api"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			client, err := New(Config{URL: tt.url})
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			if got := client.baseURL.String(); got != tt.want {
+				t.Errorf("baseURL = %q, want %q", got, tt.want)
+			}
+			if got := client.baseURL.JoinPath("auth").String(); got != tt.want+"/auth" {
+				t.Errorf("auth URL = %q, want %q", got, tt.want+"/auth")
+			}
+			if got, want := client.URL().String(), strings.TrimSuffix(tt.want, "api"); got != want {
+				t.Errorf("URL() = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestClient_Authenticate(t *testing.T) {
+	tests := []struct {
+		name           string
+		password       string
+		serverResponse func(w http.ResponseWriter, r *http.Request)
+		wantErr        bool
+	}{
+		{
+			name:     "successful auth",
+			password: "test123",
+			serverResponse: func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/api/auth" {
+					if r.Method == http.MethodGet {
+						// Check auth status - return unauthenticated
+						json.NewEncoder(w).Encode(map[string]interface{}{
+							"session": map[string]interface{}{
+								"valid": false,
+							},
+						})
+					} else if r.Method == http.MethodPost {
+						// Login
+						json.NewEncoder(w).Encode(map[string]interface{}{
+							"session": map[string]interface{}{
+								"valid":    true,
+								"sid":      "test-session-id",
+								"validity": 300,
+							},
+						})
+					}
+				}
+			},
+			wantErr: false,
+		},
+		{
+			name:     "no password required",
+			password: "",
+			serverResponse: func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/api/auth" && r.Method == http.MethodGet {
+					json.NewEncoder(w).Encode(map[string]interface{}{
+						"session": map[string]interface{}{
+							"valid": true,
+							"sid":   "no-auth-session",
+						},
+					})
+				}
+			},
+			wantErr: false,
+		},
+		{
+			name:     "wrong password",
+			password: "wrongpassword",
+			serverResponse: func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/api/auth" {
+					if r.Method == http.MethodGet {
+						json.NewEncoder(w).Encode(map[string]interface{}{
+							"session": map[string]interface{}{
+								"valid": false,
+							},
+						})
+					} else if r.Method == http.MethodPost {
+						w.WriteHeader(http.StatusUnauthorized)
+						json.NewEncoder(w).Encode(map[string]interface{}{
+							"error": map[string]interface{}{
+								"key":     "unauthorized",
+								"message": "Invalid password",
+							},
+						})
+					}
+				}
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(tt.serverResponse))
+			defer server.Close()
+
+			client, err := New(Config{
+				URL:      server.URL,
+				Password: tt.password,
+			})
+			if err != nil {
+				t.Fatalf("Failed to create client: %v", err)
+			}
+
+			ctx := context.Background()
+			err = client.Authenticate(ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Authenticate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestClient_Authenticate_Canceled(t *testing.T) {
+	arrived := make(chan struct{}, 10)
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Pi-hole accepts the connection but does not answer.
+		arrived <- struct{}{}
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client, err := New(Config{URL: server.URL, Password: "test"})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	// Canceling aborts the login instead of waiting for the HTTP timeout.
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-arrived
+		cancel()
+	}()
+	start := time.Now()
+	err = client.Authenticate(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Authenticate() returned %s after the context was canceled", elapsed)
+	}
+
+	// A login waiting for another one in progress is aborted as well.
+	other, stop := context.WithCancel(context.Background())
+	defer stop()
+	go client.Authenticate(other)
+	<-arrived
+
+	ctx, cancel = context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start = time.Now()
+	_, err = client.Get(ctx, "groups")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Get() returned %s after the context expired", elapsed)
+	}
+}
+
+func TestClient_SessionRefresh(t *testing.T) {
+	callCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/auth" {
+			if r.Method == http.MethodGet {
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"session": map[string]interface{}{
+						"valid": false,
+					},
+				})
+			} else if r.Method == http.MethodPost {
+				callCount++
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"session": map[string]interface{}{
+						"valid":    true,
+						"sid":      "session-" + string(rune('0'+callCount)),
+						"validity": 1, // 1 second validity
+					},
+				})
+			}
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(Config{
+		URL:      server.URL,
+		Password: "test",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx := context.Background()
+
+	// First auth
+	err = client.Authenticate(ctx)
+	if err != nil {
+		t.Fatalf("First auth failed: %v", err)
+	}
+	if callCount != 1 {
+		t.Errorf("Expected 1 auth call, got %d", callCount)
+	}
+
+	// Wait for session to expire
+	time.Sleep(2 * time.Second)
+
+	// Second auth should trigger refresh
+	err = client.Authenticate(ctx)
+	if err != nil {
+		t.Fatalf("Second auth failed: %v", err)
+	}
+	if callCount != 2 {
+		t.Errorf("Expected 2 auth calls after expiry, got %d", callCount)
+	}
+}
+
+func TestClient_Request_Errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		serverResponse func(w http.ResponseWriter, r *http.Request)
+		wantErr        bool
+		errContains    string
+	}{
+		{
+			name: "400 bad request",
+			serverResponse: func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/api/auth" {
+					json.NewEncoder(w).Encode(map[string]interface{}{
+						"session": map[string]interface{}{
+							"valid": true,
+							"sid":   "test-sid",
+						},
+					})
+				} else {
+					w.WriteHeader(http.StatusBadRequest)
+					json.NewEncoder(w).Encode(map[string]interface{}{
+						"error": map[string]interface{}{
+							"key":     "bad_request",
+							"message": "Invalid input",
+						},
+					})
+				}
+			},
+			wantErr:     true,
+			errContains: "Invalid input",
+		},
+		{
+			name: "404 not found",
+			serverResponse: func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/api/auth" {
+					json.NewEncoder(w).Encode(map[string]interface{}{
+						"session": map[string]interface{}{
+							"valid": true,
+							"sid":   "test-sid",
+						},
+					})
+				} else {
+					w.WriteHeader(http.StatusNotFound)
+					json.NewEncoder(w).Encode(map[string]interface{}{
+						"error": map[string]interface{}{
+							"key":     "not_found",
+							"message": "Resource not found",
+						},
+					})
+				}
+			},
+			wantErr:     true,
+			errContains: "not_found",
+		},
+		{
+			name: "500 server error",
+			serverResponse: func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/api/auth" {
+					json.NewEncoder(w).Encode(map[string]interface{}{
+						"session": map[string]interface{}{
+							"valid": true,
+							"sid":   "test-sid",
+						},
+					})
+				} else {
+					w.WriteHeader(http.StatusInternalServerError)
+					json.NewEncoder(w).Encode(map[string]interface{}{
+						"error": map[string]interface{}{
+							"key":     "internal_error",
+							"message": "Something went wrong",
+						},
+					})
+				}
+			},
+			wantErr:     true,
+			errContains: "Something went wrong",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(tt.serverResponse))
+			defer server.Close()
+
+			// Disable retries for error tests to get consistent error messages
+			client, err := New(Config{
+				URL:      server.URL,
+				Password: "test",
+				RetryMax: -1, // Explicitly disable retries
+			})
+			if err != nil {
+				t.Fatalf("Failed to create client: %v", err)
+			}
+
+			ctx := context.Background()
+			_, err = client.Get(ctx, "groups")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Get() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && tt.errContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+					t.Errorf("Error should contain %q, got %v", tt.errContains, err)
+				}
+			}
+		})
+	}
+}
+
+func TestClient_Delete_DestructiveDisabled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/auth" {
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"session": map[string]interface{}{
+					"valid": true,
+					"sid":   "test-sid",
+				},
+			})
+			return
+		}
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"error": map[string]interface{}{
+				"key":     "forbidden",
+				"message": "Destructive API calls are not allowed",
+			},
+		})
+	}))
+	defer server.Close()
+
+	client, err := New(Config{URL: server.URL, Password: "test", RetryMax: -1})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx := context.Background()
+
+	// Without the recorded setting the API error is passed through
+	if _, err := client.Delete(ctx, "groups/test"); err == nil || errors.Is(err, ErrDestructiveDisabled) {
+		t.Errorf("Expected plain API error, got %v", err)
+	}
+
+	client.SetAllowDestructive(false)
+	if client.AllowDestructive() {
+		t.Error("AllowDestructive() = true after SetAllowDestructive(false)")
+	}
+	if _, err := client.Delete(ctx, "groups/test"); !errors.Is(err, ErrDestructiveDisabled) {
+		t.Errorf("Expected ErrDestructiveDisabled, got %v", err)
+	}
+
+	// Other methods keep the API error
+	if _, err := client.Get(ctx, "groups/test"); errors.Is(err, ErrDestructiveDisabled) {
+		t.Errorf("Expected plain API error for GET, got %v", err)
+	}
+}
+
+func TestClient_Delete_SessionExpired(t *testing.T) {
+	var logins, deletes atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/auth" {
+			n := logins.Add(1)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"session": map[string]interface{}{"valid": true, "sid": fmt.Sprintf("sid-%d", n), "validity": 1800},
+			})
+			return
+		}
+		deletes.Add(1)
+		// Pi-hole dropped the first session, e.g. in a restart
+		if r.Header.Get("sid") == "sid-1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"error": map[string]interface{}{"key": "unauthorized", "message": "Unauthorized"},
+			})
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	// A 401 is not reported as a refused deletion
+	client, err := New(Config{URL: server.URL, Password: "test", RetryMax: -1})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	client.SetAllowDestructive(false)
+	_, err = client.Delete(context.Background(), "groups/test")
+	var apiErr *APIError
+	if errors.Is(err, ErrDestructiveDisabled) || !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Expected the 401 API error, got %v", err)
+	}
+
+	// and the session is renewed for the retry
+	logins.Store(0)
+	deletes.Store(0)
+	client, err = New(Config{URL: server.URL, Password: "test", RetryMax: -1, WaitForRestart: time.Second})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	client.SetAllowDestructive(false)
+	if _, err := client.Delete(context.Background(), "groups/test"); err != nil {
+		t.Errorf("Delete() error = %v", err)
+	}
+	if logins.Load() != 2 || deletes.Load() != 2 {
+		t.Errorf("Expected 2 logins and 2 deletions, got %d and %d", logins.Load(), deletes.Load())
+	}
+}
+
+func TestClient_ReadOnly(t *testing.T) {
+	var writes []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/auth" {
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"session": map[string]interface{}{
+					"valid": true,
+					"sid":   "test-sid",
+				},
+			})
+			return
+		}
+		if r.Method != http.MethodGet {
+			writes = append(writes, r.Method+" "+r.URL.Path)
+		}
+		w.Write([]byte(`{"groups":[]}`))
+	}))
+	defer server.Close()
+
+	client, err := New(Config{URL: server.URL, Password: "test", ReadOnly: true})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	if !client.ReadOnly() {
+		t.Error("ReadOnly() = false")
+	}
+
+	ctx := context.Background()
+
+	if _, err := client.GetGroups(ctx, ""); err != nil {
+		t.Errorf("GET failed: %v", err)
+	}
+	if _, err := client.Post(ctx, "groups", map[string]string{"name": "test"}); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly for POST, got %v", err)
+	}
+	if _, err := client.Patch(ctx, "config", map[string]interface{}{}); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly for PATCH, got %v", err)
+	}
+	if _, err := client.Delete(ctx, "groups/test"); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly for DELETE, got %v", err)
+	}
+	if len(writes) > 0 {
+		t.Errorf("Read-only client sent %v", writes)
+	}
+}
+
+func TestClient_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/auth":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"session": map[string]interface{}{
+					"valid": true,
+					"sid":   "test-sid",
+				},
+			})
+		case "/api/groups/gone":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":{"key":"not_found","message":"Item not found","hint":null},"took":0.001}`))
+		case "/api/groups/proxy":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`<html>404 Not Found</html>`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":{"key":"bad_request","message":"Invalid request","hint":"groups"},"took":0.001}`))
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(Config{URL: server.URL, Password: "test", RetryMax: -1})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx := context.Background()
+
+	_, err = client.Get(ctx, "groups/gone")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound || apiErr.Key != "not_found" {
+		t.Errorf("Expected *APIError with status 404, got %#v", err)
+	}
+	if want := "API error [not_found]: Item not found"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+	if apiErr != nil {
+		if got, want := apiErr.Endpoint(), "GET /api/groups/gone"; got != want {
+			t.Errorf("Endpoint() = %q, want %q", got, want)
+		}
+		if apiErr.Took != time.Millisecond {
+			t.Errorf("Took = %s, want 1ms", apiErr.Took)
+		}
+	}
+
+	// A 404 that Pi-hole did not describe may come from a proxy
+	if _, err := client.Get(ctx, "groups/proxy"); err == nil || errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected a plain error for a proxy 404, got %v", err)
+	}
+
+	_, err = client.Get(ctx, "groups/broken")
+	if errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected no ErrNotFound for status 400, got %v", err)
+	}
+	if want := "API error [bad_request]: Invalid request (hint: groups)"; err == nil || err.Error() != want {
+		t.Errorf("Error() = %v, want %q", err, want)
+	}
+}
+
+func TestClient_Headers(t *testing.T) {
+	var requests []http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Header.Clone())
+		if r.URL.Path == "/api/auth" {
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"session": map[string]interface{}{"valid": true, "sid": "test-sid", "validity": 1800},
+			})
+			return
+		}
+		json.NewEncoder(w).Encode(GroupsResponse{})
+	}))
+	defer server.Close()
+
+	client, err := New(Config{
+		URL:       server.URL,
+		Password:  "test",
+		UserAgent: "terraform-provider-pihole/1.2.3",
+		Headers: map[string]string{
+			"CF-Access-Client-Id": "client-id",
+			"sid":                 "must-not-win",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	if _, err := client.GetGroups(context.Background(), ""); err != nil {
+		t.Fatalf("GetGroups() error = %v", err)
+	}
+
+	if len(requests) != 2 {
+		t.Fatalf("Expected 2 requests, got %d", len(requests))
+	}
+	for i, h := range requests {
+		if got := h.Get("User-Agent"); got != "terraform-provider-pihole/1.2.3" {
+			t.Errorf("Request %d: User-Agent = %q", i, got)
+		}
+		if got := h.Get("CF-Access-Client-Id"); got != "client-id" {
+			t.Errorf("Request %d: CF-Access-Client-Id = %q", i, got)
+		}
+	}
+	if got := requests[1].Get("sid"); got != "test-sid" {
+		t.Errorf("sid = %q, want the session ID", got)
+	}
+}
+
+// TestClient_ConcurrentRequests is meant to run with the race detector, as
+// the CI workflow does.
+func TestClient_ConcurrentRequests(t *testing.T) {
+	var inFlight, maxInFlight, logins atomic.Int32
+	var mu sync.Mutex
+	sid := ""
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/auth" {
+			if r.Method == http.MethodGet {
+				json.NewEncoder(w).Encode(map[string]interface{}{"session": map[string]interface{}{"valid": false}})
+				return
+			}
+			mu.Lock()
+			sid = fmt.Sprintf("session-%d", logins.Add(1))
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"session": map[string]interface{}{"valid": true, "sid": sid, "validity": 1800},
+			})
+			mu.Unlock()
+			return
+		}
+
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		mu.Lock()
+		current := sid
+		mu.Unlock()
+		if r.Header.Get("sid") != current {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error": {"key": "unauthorized", "message": "Unauthorized", "hint": null}}`))
+			return
+		}
+		w.Write([]byte(`{"groups": [], "took": 0.001}`))
+	}))
+	defer server.Close()
+
+	client, err := New(Config{
+		URL:                   server.URL,
+		Password:              "test",
+		WaitForRestart:        time.Second,
+		MaxConcurrentRequests: 3,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	ctx := context.Background()
+
+	run := func() {
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := client.Get(ctx, "groups"); err != nil {
+					t.Errorf("Get() error = %v", err)
+				}
+			}()
+		}
+		wg.Wait()
+	}
+
+	run()
+	if n := logins.Load(); n != 1 {
+		t.Errorf("Expected 1 login for parallel requests, got %d", n)
+	}
+	if n := maxInFlight.Load(); n > 3 {
+		t.Errorf("Expected at most 3 requests in flight, got %d", n)
+	}
+
+	// Pi-hole drops the session, e.g. in an FTL restart: the parallel
+	// requests rejected with it log in once, not once each.
+	mu.Lock()
+	sid = "expired"
+	mu.Unlock()
+	run()
+	if n := logins.Load(); n != 2 {
+		t.Errorf("Expected 1 more login after the session was dropped, got %d", n-1)
+	}
+}
+
+func TestClient_Compression(t *testing.T) {
+	domains := make([]Domain, 20000)
+	for i := range domains {
+		domains[i] = Domain{ID: int64(i + 1), Domain: fmt.Sprintf("ads%d.example.com", i), Type: "deny", Kind: "exact", Enabled: true, Groups: []int64{0}}
+	}
+	payload, err := json.Marshal(DomainsResponse{Domains: domains})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encoders := map[string]func(io.Writer) io.WriteCloser{
+		"gzip":    func(w io.Writer) io.WriteCloser { return gzip.NewWriter(w) },
+		"deflate": func(w io.Writer) io.WriteCloser { return zlib.NewWriter(w) },
+		"raw deflate": func(w io.Writer) io.WriteCloser {
+			fw, _ := flate.NewWriter(w, flate.DefaultCompression)
+			return fw
+		},
+		"identity": nil,
+	}
+	for name, encoder := range encoders {
+		t.Run(name, func(t *testing.T) {
+			var sent int
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if got := r.Header.Get("Accept-Encoding"); got != "gzip, deflate" {
+					t.Errorf("Accept-Encoding = %q", got)
+				}
+				if r.URL.Path == "/api/auth" {
+					json.NewEncoder(w).Encode(map[string]interface{}{
+						"session": map[string]interface{}{"valid": true, "sid": "test-sid", "validity": 1800},
+					})
+					return
+				}
+				if r.Method == http.MethodDelete {
+					// An empty body declared as compressed.
+					w.Header().Set("Content-Encoding", "gzip")
+					w.WriteHeader(http.StatusNoContent)
+					return
+				}
+				if encoder == nil {
+					sent = len(payload)
+					w.Write(payload)
+					return
+				}
+				var buf bytes.Buffer
+				enc := encoder(&buf)
+				enc.Write(payload)
+				enc.Close()
+				sent = buf.Len()
+				w.Header().Set("Content-Encoding", strings.Fields(name)[len(strings.Fields(name))-1])
+				w.Write(buf.Bytes())
+			}))
+			defer server.Close()
+
+			client, err := New(Config{URL: server.URL, Password: "test"})
+			if err != nil {
+				t.Fatalf("Failed to create client: %v", err)
+			}
+
+			got, err := client.GetDomains(context.Background(), "", "", "")
+			if err != nil {
+				t.Fatalf("GetDomains() error = %v", err)
+			}
+			if len(got) != len(domains) || got[len(got)-1].Domain != domains[len(domains)-1].Domain {
+				t.Errorf("Got %d domains, want %d", len(got), len(domains))
+			}
+			if encoder != nil && sent*10 > len(payload) {
+				t.Errorf("Compressed response of %d bytes for %d bytes of JSON", sent, len(payload))
+			}
+
+			if err := client.DeleteGroup(context.Background(), "test"); err != nil {
+				t.Errorf("DeleteGroup() error = %v", err)
+			}
+		})
+	}
+}
@@ -1,20 +1,20 @@
 // Copyright (c) 2025 dklesev
 // SPDX-License-Identifier: MIT
 
-package client
+package pihole
 
 import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"net/url"
+	"strings"
 )
 
 // GetClients retrieves all clients or a specific client.
 func (c *Client) GetClients(ctx context.Context, client string) ([]PiholeClient, error) {
 	path := "clients"
 	if client != "" {
-		path = fmt.Sprintf("clients/%s", url.PathEscape(client))
+		path = fmt.Sprintf("clients/%s", pathSegment(client))
 	}
 
 	resp, err := c.Get(ctx, path)
@@ -44,6 +44,44 @@ func (c *Client) GetClient(ctx context.Context, client string) (*PiholeClient, e
 	return &clients[0], nil
 }
 
+// GetClientSuggestions retrieves the devices in Pi-hole's network table that
+// can be configured as clients.
+func (c *Client) GetClientSuggestions(ctx context.Context) ([]ClientSuggestion, error) {
+	resp, err := c.Get(ctx, "clients/_suggestions")
+	if err != nil {
+		return nil, err
+	}
+
+	var result ClientSuggestionsResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse client suggestions response: %w", err)
+	}
+
+	return result.Clients, nil
+}
+
+// AddressList returns the IP addresses the device was seen with.
+func (s ClientSuggestion) AddressList() []string {
+	return splitCommaList(s.Addresses)
+}
+
+// NameList returns the host names the device was seen with.
+func (s ClientSuggestion) NameList() []string {
+	return splitCommaList(s.Names)
+}
+
+// splitCommaList splits a comma-separated list, dropping empty entries. It
+// returns an empty, non-nil slice for an empty list.
+func splitCommaList(s string) []string {
+	items := []string{}
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 // CreateClient creates a new client.
 func (c *Client) CreateClient(ctx context.Context, client *PiholeClient) (*PiholeClient, error) {
 	payload := map[string]interface{}{
@@ -81,7 +119,7 @@ func (c *Client) UpdateClient(ctx context.Context, originalClient string, client
 		"groups":  client.Groups,
 	}
 
-	path := fmt.Sprintf("clients/%s", url.PathEscape(originalClient))
+	path := fmt.Sprintf("clients/%s", pathSegment(originalClient))
 	resp, err := c.Put(ctx, path, payload)
 	if err != nil {
 		return nil, err
@@ -109,7 +147,7 @@ func (c *Client) UpdateClient(ctx context.Context, originalClient string, client
 
 // DeleteClient deletes a client.
 func (c *Client) DeleteClient(ctx context.Context, client string) error {
-	path := fmt.Sprintf("clients/%s", url.PathEscape(client))
+	path := fmt.Sprintf("clients/%s", pathSegment(client))
 	_, err := c.Delete(ctx, path)
 	return err
 }
@@ -1,7 +1,7 @@
 // Copyright (c) 2025 dklesev
 // SPDX-License-Identifier: MIT
 
-package client
+package pihole
 
 import (
 	"context"
@@ -71,6 +71,57 @@ func TestClient_GetClients(t *testing.T) {
 	}
 }
 
+func TestClient_GetClientSuggestions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/auth":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"session": map[string]interface{}{
+					"valid": true,
+					"sid":   "test-sid",
+				},
+			})
+		case "/api/clients/_suggestions":
+			w.Write([]byte(`{"clients":[` +
+				`{"hwaddr":"aa:bb:cc:dd:ee:ff","macVendor":"Raspberry Pi Trading Ltd","lastQuery":1700000000,"addresses":"192.168.1.10,fe80::1","names":"pi.lan"},` +
+				`{"hwaddr":"ip-192.168.1.20","macVendor":null,"lastQuery":0,"addresses":"192.168.1.20","names":null}` +
+				`],"took":0.001}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(Config{URL: server.URL, Password: "test"})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	suggestions, err := client.GetClientSuggestions(context.Background())
+	if err != nil {
+		t.Fatalf("GetClientSuggestions() error = %v", err)
+	}
+	if len(suggestions) != 2 {
+		t.Fatalf("Expected 2 suggestions, got %d", len(suggestions))
+	}
+
+	s := suggestions[0]
+	if s.HWAddr != "aa:bb:cc:dd:ee:ff" || s.MACVendor != "Raspberry Pi Trading Ltd" || s.LastQuery != 1700000000 {
+		t.Errorf("Unexpected suggestion %+v", s)
+	}
+	if got := s.AddressList(); len(got) != 2 || got[0] != "192.168.1.10" || got[1] != "fe80::1" {
+		t.Errorf("AddressList() = %q", got)
+	}
+	if got := s.NameList(); len(got) != 1 || got[0] != "pi.lan" {
+		t.Errorf("NameList() = %q", got)
+	}
+
+	s = suggestions[1]
+	if s.MACVendor != "" || len(s.NameList()) != 0 {
+		t.Errorf("Expected no vendor and names for %+v", s)
+	}
+}
+
 func TestClient_CreateClient(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
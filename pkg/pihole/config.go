@@ -1,13 +1,18 @@
 // Copyright (c) 2025 dklesev
 // SPDX-License-Identifier: MIT
 
-package client
+package pihole
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"net/url"
+	"io"
+	"slices"
+	"sort"
+	"strings"
 )
 
 // ========================================================================
@@ -240,12 +245,13 @@ type WebserverAPIConfig struct {
 	MaxClients             int                     `json:"maxClients,omitempty"`
 	ClientHistoryGlobalMax bool                    `json:"client_history_global_max"`
 	AllowDestructive       bool                    `json:"allow_destructive"`
+	AppPwhash              string                  `json:"app_pwhash,omitempty"`
 	Temp                   *WebserverAPITempConfig `json:"temp,omitempty"`
 }
 
 type WebserverAPITempConfig struct {
-	Limit int    `json:"limit,omitempty"`
-	Unit  string `json:"unit,omitempty"`
+	Limit float64 `json:"limit,omitempty"`
+	Unit  string  `json:"unit,omitempty"`
 }
 
 // ========================================================================
@@ -333,6 +339,186 @@ type DebugConfig struct {
 	All          bool `json:"all"`
 }
 
+// ========================================================================
+// Config Option Details
+// ========================================================================
+
+// ConfigOption describes a single configuration option as reported by
+// GET /api/config?detailed=true.
+type ConfigOption struct {
+	Description string            `json:"description"`
+	Type        string            `json:"type"`
+	Allowed     json.RawMessage   `json:"allowed"`
+	Value       json.RawMessage   `json:"value"`
+	Default     json.RawMessage   `json:"default"`
+	Modified    bool              `json:"modified"`
+	Flags       ConfigOptionFlags `json:"flags"`
+}
+
+// ConfigOptionFlags describes how FTL treats changes to an option.
+type ConfigOptionFlags struct {
+	RestartDnsmasq bool `json:"restart_dnsmasq"`
+	SessionReset   bool `json:"session_reset"`
+	EnvVar         bool `json:"env_var"`
+	Pseudo         bool `json:"pseudo"`
+}
+
+// ConfigOptionChoice is one of the allowed values of an enum option.
+type ConfigOptionChoice struct {
+	Item        json.RawMessage `json:"item"`
+	Description string          `json:"description"`
+}
+
+// Choices returns the allowed values of an enum option, or nil when the
+// option is not an enum and Allowed only describes the value in prose.
+func (o ConfigOption) Choices() []ConfigOptionChoice {
+	var choices []ConfigOptionChoice
+	if err := json.Unmarshal(o.Allowed, &choices); err != nil {
+		return nil
+	}
+	return choices
+}
+
+// GetConfigOptions retrieves the detailed description of every option in a
+// config section, keyed by the option's dotted path below the section
+// (e.g. "cache.size" for dns.cache.size).
+func (c *Client) GetConfigOptions(ctx context.Context, section string) (map[string]ConfigOption, error) {
+	resp, err := c.Get(ctx, fmt.Sprintf("config/%s?detailed=true", pathSegment(section)))
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Config map[string]json.RawMessage `json:"config"`
+	}
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse config details response: %w", err)
+	}
+
+	raw, ok := result.Config[section]
+	if !ok {
+		return nil, fmt.Errorf("config section %q not found", section)
+	}
+
+	options := make(map[string]ConfigOption)
+	if err := flattenConfigOptions(raw, "", options); err != nil {
+		return nil, fmt.Errorf("failed to parse config details response: %w", err)
+	}
+	return options, nil
+}
+
+// flattenConfigOptions walks a detailed config tree. Objects that carry both
+// "type" and "value" are options; any other object is a nested section.
+func flattenConfigOptions(raw json.RawMessage, prefix string, options map[string]ConfigOption) error {
+	var node map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &node); err != nil {
+		return err
+	}
+
+	_, hasType := node["type"]
+	_, hasValue := node["value"]
+	if hasType && hasValue && prefix != "" {
+		var option ConfigOption
+		if err := json.Unmarshal(raw, &option); err != nil {
+			return fmt.Errorf("%s: %w", prefix, err)
+		}
+		options[prefix] = option
+		return nil
+	}
+
+	for key, child := range node {
+		name := key
+		if prefix != "" {
+			name = prefix + "." + key
+		}
+		if err := flattenConfigOptions(child, name, options); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetConfigDefaults returns the default value of every option in a config
+// section, keyed like GetConfigOptions.
+func (c *Client) GetConfigDefaults(ctx context.Context, section string) (map[string]json.RawMessage, error) {
+	options, err := c.GetConfigOptions(ctx, section)
+	if err != nil {
+		return nil, err
+	}
+
+	defaults := make(map[string]json.RawMessage, len(options))
+	for key, option := range options {
+		defaults[key] = option.Default
+	}
+	return defaults, nil
+}
+
+// explicitResetKeys are the options ResetConfigSection only resets when they
+// are listed: resetting them removes the passwords or moves the API, which
+// locks out the client that asked for the reset.
+var explicitResetKeys = map[string][]string{
+	"webserver": {"api.pwhash", "api.app_pwhash", "port"},
+}
+
+// ResetConfigSection sets options of a config section back to their
+// defaults with a single PATCH. keys are dotted paths below the section as
+// returned by GetConfigOptions; when keys is empty, every option is reset
+// except the passwords and port of the webserver section. Options already
+// at their default, options set by environment variables (which the API
+// cannot change) and pseudo options are skipped. It returns the keys that
+// were reset, sorted.
+func (c *Client) ResetConfigSection(ctx context.Context, section string, keys []string) ([]string, error) {
+	options, err := c.GetConfigOptions(ctx, section)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(keys) == 0 {
+		for key := range options {
+			if !slices.Contains(explicitResetKeys[section], key) {
+				keys = append(keys, key)
+			}
+		}
+	}
+
+	var reset []string
+	values := make(map[string]interface{})
+	for _, key := range keys {
+		option, ok := options[key]
+		if !ok {
+			return nil, fmt.Errorf("config section %q has no option %q", section, key)
+		}
+		if !option.Modified || option.Flags.EnvVar || option.Flags.Pseudo || len(option.Default) == 0 {
+			continue
+		}
+		setConfigPath(values, key, option.Default)
+		reset = append(reset, key)
+	}
+	sort.Strings(reset)
+
+	if len(reset) == 0 {
+		return nil, nil
+	}
+	if err := c.UpdateConfig(ctx, section, values); err != nil {
+		return nil, err
+	}
+	return reset, nil
+}
+
+// setConfigPath stores value in a nested config tree under a dotted path.
+func setConfigPath(tree map[string]interface{}, key string, value interface{}) {
+	parts := strings.Split(key, ".")
+	for _, part := range parts[:len(parts)-1] {
+		child, ok := tree[part].(map[string]interface{})
+		if !ok {
+			child = make(map[string]interface{})
+			tree[part] = child
+		}
+		tree = child
+	}
+	tree[parts[len(parts)-1]] = value
+}
+
 // ========================================================================
 // API Methods
 // ========================================================================
@@ -352,10 +538,80 @@ func (c *Client) GetConfig(ctx context.Context) (*PiholeConfig, error) {
 	return &result.Config, nil
 }
 
+// getConfigSection reads one section of the configuration into v. Only
+// that section is requested, e.g. config/dns, so the others, like large
+// dnsmasq_lines or hosts arrays, are neither sent nor decoded.
+func (c *Client) getConfigSection(ctx context.Context, section string, v interface{}) error {
+	resp, err := c.Get(ctx, "config/"+section)
+	if err != nil {
+		return err
+	}
+
+	if err := decodeConfigSection(bytes.NewReader(resp), section, v); err != nil {
+		return fmt.Errorf("failed to parse config response: %w", err)
+	}
+	return nil
+}
+
+// decodeConfigSection streams a {"config": {...}} response and decodes the
+// named section into v, stopping once it is read. v is left untouched when
+// the section is missing.
+func decodeConfigSection(r io.Reader, section string, v interface{}) error {
+	dec := json.NewDecoder(r)
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		if key, _ := tok.(string); key != "config" {
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return err
+			}
+			continue
+		}
+
+		tok, err = dec.Token()
+		if err != nil {
+			return err
+		}
+		if tok == nil {
+			return nil // "config": null
+		}
+		if d, ok := tok.(json.Delim); !ok || d != '{' {
+			return fmt.Errorf("expected config object, got %v", tok)
+		}
+		for dec.More() {
+			tok, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			if key, _ := tok.(string); key == section {
+				return dec.Decode(v)
+			}
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return nil
+}
+
 // UpdateConfig updates specific configuration options using PATCH.
 // The body must be wrapped in {"config": {...}} format.
-// Path should be the section name (e.g., "misc").
+// Path should be the section name (e.g., "misc"). Empty values send no
+// request.
 func (c *Client) UpdateConfig(ctx context.Context, section string, values map[string]interface{}) error {
+	if len(values) == 0 {
+		return nil
+	}
+
 	// Pi-hole v6 requires PATCH to /api/config with body {"config": {"section": {...}}}
 	body := map[string]interface{}{
 		"config": map[string]interface{}{
@@ -373,90 +629,90 @@ func (c *Client) UpdateConfigValue(ctx context.Context, section, key string, val
 
 // GetDNSConfig retrieves only the DNS configuration.
 func (c *Client) GetDNSConfig(ctx context.Context) (*DNSConfig, error) {
-	config, err := c.GetConfig(ctx)
-	if err != nil {
+	var section *DNSConfig
+	if err := c.getConfigSection(ctx, "dns", &section); err != nil {
 		return nil, err
 	}
-	return config.DNS, nil
+	return section, nil
 }
 
 // GetDHCPConfig retrieves only the DHCP configuration.
 func (c *Client) GetDHCPConfig(ctx context.Context) (*DHCPConfig, error) {
-	piholeConfig, err := c.GetConfig(ctx)
-	if err != nil {
+	var section *DHCPConfig
+	if err := c.getConfigSection(ctx, "dhcp", &section); err != nil {
 		return nil, err
 	}
-	return piholeConfig.DHCP, nil
+	return section, nil
 }
 
 // GetMiscConfig retrieves only the miscellaneous configuration.
 func (c *Client) GetMiscConfig(ctx context.Context) (*MiscConfig, error) {
-	piholeConfig, err := c.GetConfig(ctx)
-	if err != nil {
+	var section *MiscConfig
+	if err := c.getConfigSection(ctx, "misc", &section); err != nil {
 		return nil, err
 	}
-	return piholeConfig.Misc, nil
+	return section, nil
 }
 
 // GetNTPConfig retrieves only the NTP configuration.
 func (c *Client) GetNTPConfig(ctx context.Context) (*NTPConfig, error) {
-	piholeConfig, err := c.GetConfig(ctx)
-	if err != nil {
+	var section *NTPConfig
+	if err := c.getConfigSection(ctx, "ntp", &section); err != nil {
 		return nil, err
 	}
-	return piholeConfig.NTP, nil
+	return section, nil
 }
 
 // GetResolverConfig retrieves only the resolver configuration.
 func (c *Client) GetResolverConfig(ctx context.Context) (*ResolverConfig, error) {
-	piholeConfig, err := c.GetConfig(ctx)
-	if err != nil {
+	var section *ResolverConfig
+	if err := c.getConfigSection(ctx, "resolver", &section); err != nil {
 		return nil, err
 	}
-	return piholeConfig.Resolver, nil
+	return section, nil
 }
 
 // GetDatabaseConfig retrieves only the database configuration.
 func (c *Client) GetDatabaseConfig(ctx context.Context) (*DatabaseConfig, error) {
-	piholeConfig, err := c.GetConfig(ctx)
-	if err != nil {
+	var section *DatabaseConfig
+	if err := c.getConfigSection(ctx, "database", &section); err != nil {
 		return nil, err
 	}
-	return piholeConfig.Database, nil
+	return section, nil
 }
 
 // GetWebserverConfig retrieves only the webserver configuration.
 func (c *Client) GetWebserverConfig(ctx context.Context) (*WebserverConfig, error) {
-	piholeConfig, err := c.GetConfig(ctx)
-	if err != nil {
+	var section *WebserverConfig
+	if err := c.getConfigSection(ctx, "webserver", &section); err != nil {
 		return nil, err
 	}
-	return piholeConfig.Webserver, nil
+	return section, nil
 }
 
 // GetFilesConfig retrieves only the files configuration.
 func (c *Client) GetFilesConfig(ctx context.Context) (*FilesConfig, error) {
-	piholeConfig, err := c.GetConfig(ctx)
-	if err != nil {
+	var section *FilesConfig
+	if err := c.getConfigSection(ctx, "files", &section); err != nil {
 		return nil, err
 	}
-	return piholeConfig.Files, nil
+	return section, nil
 }
 
 // GetDebugConfig retrieves only the debug configuration.
 func (c *Client) GetDebugConfig(ctx context.Context) (*DebugConfig, error) {
-	piholeConfig, err := c.GetConfig(ctx)
-	if err != nil {
+	var section *DebugConfig
+	if err := c.getConfigSection(ctx, "debug", &section); err != nil {
 		return nil, err
 	}
-	return piholeConfig.Debug, nil
+	return section, nil
 }
 
 // AddConfigArrayItem adds an item to a config array using PUT.
 // Path should be like "dns/upstreams" and value is the item to add.
 func (c *Client) AddConfigArrayItem(ctx context.Context, path, value string) error {
 	// URL encode the value for the path
-	encoded := url.PathEscape(value)
+	encoded := pathSegment(value)
 	endpoint := fmt.Sprintf("config/%s/%s", path, encoded)
 	_, err := c.Put(ctx, endpoint, nil)
 	return err
@@ -464,8 +720,135 @@ func (c *Client) AddConfigArrayItem(ctx context.Context, path, value string) err
 
 // DeleteConfigArrayItem removes an item from a config array using DELETE.
 func (c *Client) DeleteConfigArrayItem(ctx context.Context, path, value string) error {
-	encoded := url.PathEscape(value)
+	encoded := pathSegment(value)
 	endpoint := fmt.Sprintf("config/%s/%s", path, encoded)
 	_, err := c.Delete(ctx, endpoint)
 	return err
 }
+
+// configArrayAttempts is how often a config array item is written before
+// giving up on concurrent changes that keep undoing the write.
+const configArrayAttempts = 2
+
+// GetConfigArray reads the config array at path, e.g. "dns/hosts".
+func (c *Client) GetConfigArray(ctx context.Context, path string) ([]string, error) {
+	keys := strings.Split(path, "/")
+	var raw json.RawMessage
+	if err := c.getConfigSection(ctx, keys[0], &raw); err != nil {
+		return nil, err
+	}
+	for _, key := range keys[1:] {
+		var object map[string]json.RawMessage
+		if len(raw) > 0 {
+			if err := json.Unmarshal(raw, &object); err != nil {
+				return nil, fmt.Errorf("failed to parse config response: %w", err)
+			}
+		}
+		raw = object[key]
+	}
+
+	var items []string
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &items); err != nil {
+			return nil, fmt.Errorf("failed to parse config array %s: %w", path, err)
+		}
+	}
+	return items, nil
+}
+
+// ItemFinder returns the item of a config array that stands for a value
+// written to it, as Pi-hole stores it. FTL may normalize items, e.g. the case
+// of a MAC address or the spacing of a hosts line, so callers that know the
+// format of the array pass a finder that compares canonical values. A nil
+// ItemFinder compares items exactly.
+type ItemFinder func(items []string) (string, bool)
+
+// find returns the item standing for value using f, or an exact match when
+// f is nil.
+func (f ItemFinder) find(items []string, value string) (string, bool) {
+	if f == nil {
+		return value, slices.Contains(items, value)
+	}
+	return f(items)
+}
+
+// AddConfigArrayItemVerified adds an item to a config array like
+// AddConfigArrayItem, then reads the array back and looks the item up with
+// find to confirm it is in it. Pi-hole rewrites the whole array for every
+// change, so a concurrent change by another client can drop the item again;
+// it is then added once more, and an error wrapping ErrConflict returned
+// when it is still missing.
+func (c *Client) AddConfigArrayItemVerified(ctx context.Context, path, value string, find ItemFinder) error {
+	return c.writeConfigArrayItem(ctx, path, value, find, true)
+}
+
+// DeleteConfigArrayItemVerified removes an item from a config array like
+// DeleteConfigArrayItem, then reads the array back and looks the item up
+// with find to confirm it is gone, deleting it once more, as stored, when a
+// concurrent change brought it back.
+func (c *Client) DeleteConfigArrayItemVerified(ctx context.Context, path, value string, find ItemFinder) error {
+	return c.writeConfigArrayItem(ctx, path, value, find, false)
+}
+
+// writeConfigArrayItem adds value to, or with present false removes it
+// from, the config array at path until a read of the array confirms the
+// change, at most configArrayAttempts times. The error of the first write
+// is returned as is, e.g. ErrExists; a retry that finds the item already
+// added or removed counts as written.
+func (c *Client) writeConfigArrayItem(ctx context.Context, path, value string, find ItemFinder, present bool) error {
+	item := value
+	for attempt := 1; ; attempt++ {
+		var err error
+		if present {
+			err = c.AddConfigArrayItem(ctx, path, item)
+		} else {
+			err = c.DeleteConfigArrayItem(ctx, path, item)
+		}
+		if attempt > 1 && (errors.Is(err, ErrExists) || errors.Is(err, ErrNotFound)) {
+			err = nil
+		}
+		if err != nil {
+			return err
+		}
+
+		items, err := c.GetConfigArray(ctx, path)
+		if err != nil {
+			return fmt.Errorf("verifying %s: %w", path, err)
+		}
+		stored, found := find.find(items, value)
+		if found == present {
+			return nil
+		}
+		if attempt == configArrayAttempts {
+			if present {
+				return fmt.Errorf("adding %q to %s: %w", value, path, ErrConflict)
+			}
+			return fmt.Errorf("removing %q from %s: %w", value, path, ErrConflict)
+		}
+		if !present {
+			// The API deletes items by their stored form.
+			item = stored
+		}
+	}
+}
+
+// ReplaceConfigArrayItem swaps oldValue for newValue in a config array. The
+// API has no update for array items, so the old item is deleted and the new
+// one added, each verified like DeleteConfigArrayItemVerified and
+// AddConfigArrayItemVerified with findOld and findNew; if adding fails the
+// old item is put back.
+func (c *Client) ReplaceConfigArrayItem(ctx context.Context, path, oldValue, newValue string, findOld, findNew ItemFinder) error {
+	if oldValue == newValue {
+		return nil
+	}
+	if err := c.DeleteConfigArrayItemVerified(ctx, path, oldValue, findOld); err != nil {
+		return fmt.Errorf("removing %q: %w", oldValue, err)
+	}
+	if err := c.AddConfigArrayItemVerified(ctx, path, newValue, findNew); err != nil {
+		if restoreErr := c.AddConfigArrayItem(ctx, path, oldValue); restoreErr != nil {
+			return fmt.Errorf("adding %q: %w (restoring %q also failed: %v)", newValue, err, oldValue, restoreErr)
+		}
+		return fmt.Errorf("adding %q: %w", newValue, err)
+	}
+	return nil
+}
@@ -0,0 +1,748 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package pihole
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestClient_ReplaceConfigArrayItem(t *testing.T) {
+	tests := []struct {
+		name      string
+		failAdd   string
+		wantErr   bool
+		wantCalls []string
+	}{
+		{
+			name: "replaces item",
+			wantCalls: []string{
+				"DELETE /api/config/dns/cnameRecords/old.lan,target.lan",
+				"GET /api/config/dns",
+				"PUT /api/config/dns/cnameRecords/new.lan,target.lan",
+				"GET /api/config/dns",
+			},
+		},
+		{
+			name:    "restores old item when add fails",
+			failAdd: "/api/config/dns/cnameRecords/new.lan,target.lan",
+			wantErr: true,
+			wantCalls: []string{
+				"DELETE /api/config/dns/cnameRecords/old.lan,target.lan",
+				"GET /api/config/dns",
+				"PUT /api/config/dns/cnameRecords/new.lan,target.lan",
+				"PUT /api/config/dns/cnameRecords/old.lan,target.lan",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []string
+			records := []string{"old.lan,target.lan"}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/api/auth" {
+					json.NewEncoder(w).Encode(map[string]interface{}{
+						"session": map[string]interface{}{
+							"valid": true,
+							"sid":   "test-sid",
+						},
+					})
+					return
+				}
+				calls = append(calls, r.Method+" "+r.URL.Path)
+				item := strings.TrimPrefix(r.URL.Path, "/api/config/dns/cnameRecords/")
+				switch {
+				case r.Method == http.MethodGet:
+					json.NewEncoder(w).Encode(map[string]interface{}{
+						"config": map[string]interface{}{"dns": map[string]interface{}{"cnameRecords": records}},
+					})
+					return
+				case r.Method == http.MethodDelete:
+					records = slices.DeleteFunc(records, func(r string) bool { return r == item })
+				case r.Method == http.MethodPut && r.URL.Path != tt.failAdd:
+					records = append(records, item)
+				}
+				if r.Method == http.MethodPut && r.URL.Path == tt.failAdd {
+					w.WriteHeader(http.StatusBadRequest)
+					json.NewEncoder(w).Encode(map[string]interface{}{
+						"error": map[string]interface{}{
+							"key":     "bad_request",
+							"message": "Invalid value",
+						},
+					})
+					return
+				}
+				json.NewEncoder(w).Encode(map[string]interface{}{"took": 0.001})
+			}))
+			defer server.Close()
+
+			client, err := New(Config{URL: server.URL, Password: "test"})
+			if err != nil {
+				t.Fatalf("Failed to create client: %v", err)
+			}
+
+			err = client.ReplaceConfigArrayItem(context.Background(), "dns/cnameRecords", "old.lan,target.lan", "new.lan,target.lan", nil, nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReplaceConfigArrayItem() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(calls) != len(tt.wantCalls) {
+				t.Fatalf("Expected calls %v, got %v", tt.wantCalls, calls)
+			}
+			for i := range calls {
+				if calls[i] != tt.wantCalls[i] {
+					t.Errorf("Call %d: expected %q, got %q", i, tt.wantCalls[i], calls[i])
+				}
+			}
+		})
+	}
+}
+
+func TestClient_AddConfigArrayItemVerified(t *testing.T) {
+	tests := []struct {
+		name      string
+		dropAdds  int // how many adds a concurrent writer undoes
+		wantErr   error
+		wantCalls int
+	}{
+		{name: "added", wantCalls: 2},
+		{name: "added again after a concurrent change dropped it", dropAdds: 1, wantCalls: 4},
+		{name: "conflict", dropAdds: 2, wantErr: ErrConflict, wantCalls: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			dropped := 0
+			var upstreams []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/api/auth" {
+					json.NewEncoder(w).Encode(map[string]interface{}{
+						"session": map[string]interface{}{
+							"valid": true,
+							"sid":   "test-sid",
+						},
+					})
+					return
+				}
+				calls++
+				switch r.Method {
+				case http.MethodPut:
+					if dropped < tt.dropAdds {
+						dropped++
+					} else {
+						upstreams = append(upstreams, strings.TrimPrefix(r.URL.Path, "/api/config/dns/upstreams/"))
+					}
+					json.NewEncoder(w).Encode(map[string]interface{}{"took": 0.001})
+				case http.MethodGet:
+					json.NewEncoder(w).Encode(map[string]interface{}{
+						"config": map[string]interface{}{"dns": map[string]interface{}{"upstreams": upstreams}},
+					})
+				}
+			}))
+			defer server.Close()
+
+			client, err := New(Config{URL: server.URL, Password: "test"})
+			if err != nil {
+				t.Fatalf("Failed to create client: %v", err)
+			}
+
+			err = client.AddConfigArrayItemVerified(context.Background(), "dns/upstreams", "9.9.9.9", nil)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("AddConfigArrayItemVerified() error = %v, want %v", err, tt.wantErr)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("Expected %d requests, got %d", tt.wantCalls, calls)
+			}
+		})
+	}
+}
+
+func TestClient_DeleteConfigArrayItemVerified(t *testing.T) {
+	calls := 0
+	hosts := []string{"192.168.1.10 nas.lan", "192.168.1.11 tv.lan"}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/auth" {
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"session": map[string]interface{}{
+					"valid": true,
+					"sid":   "test-sid",
+				},
+			})
+			return
+		}
+		calls++
+		switch r.Method {
+		case http.MethodDelete:
+			// The first delete is undone by a concurrent writer
+			if calls > 1 {
+				hosts = hosts[1:]
+			}
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodGet:
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"config": map[string]interface{}{"dns": map[string]interface{}{"hosts": hosts}},
+			})
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(Config{URL: server.URL, Password: "test"})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	if err := client.DeleteConfigArrayItemVerified(context.Background(), "dns/hosts", "192.168.1.10 nas.lan", nil); err != nil {
+		t.Fatalf("DeleteConfigArrayItemVerified() error = %v", err)
+	}
+	if calls != 4 {
+		t.Errorf("Expected 4 requests, got %d", calls)
+	}
+}
+
+func TestClient_AddConfigArrayItemVerified_normalized(t *testing.T) {
+	var hosts []string
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/auth" {
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"session": map[string]interface{}{
+					"valid": true,
+					"sid":   "test-sid",
+				},
+			})
+			return
+		}
+		calls++
+		switch r.Method {
+		case http.MethodPut:
+			// FTL stores MAC addresses in lower case
+			item, _ := url.PathUnescape(strings.TrimPrefix(r.URL.EscapedPath(), "/api/config/dhcp/hosts/"))
+			if slices.Contains(hosts, strings.ToLower(item)) {
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"error": map[string]interface{}{"key": "bad_request", "message": "Item already present"},
+				})
+				return
+			}
+			hosts = append(hosts, strings.ToLower(item))
+			json.NewEncoder(w).Encode(map[string]interface{}{"took": 0.001})
+		case http.MethodGet:
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"config": map[string]interface{}{"dhcp": map[string]interface{}{"hosts": hosts}},
+			})
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(Config{URL: server.URL, Password: "test"})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	const lease = "AA:BB:CC:DD:EE:FF,192.168.1.50,nas"
+	sameLease := func(items []string) (string, bool) {
+		for _, item := range items {
+			if strings.EqualFold(item, lease) {
+				return item, true
+			}
+		}
+		return "", false
+	}
+
+	if err := client.AddConfigArrayItemVerified(context.Background(), "dhcp/hosts", lease, sameLease); err != nil {
+		t.Fatalf("AddConfigArrayItemVerified() error = %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("Expected 2 requests, got %d", calls)
+	}
+
+	// An exact comparison misses the stored item and reports a conflict
+	hosts, calls = nil, 0
+	if err := client.AddConfigArrayItemVerified(context.Background(), "dhcp/hosts", lease, nil); !errors.Is(err, ErrConflict) {
+		t.Errorf("AddConfigArrayItemVerified() without finder error = %v, want %v", err, ErrConflict)
+	}
+}
+
+func TestClient_GetConfigArray(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/auth":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"session": map[string]interface{}{
+					"valid": true,
+					"sid":   "test-sid",
+				},
+			})
+		case "/api/config/webserver":
+			w.Write([]byte(`{"config":{"webserver":{"api":{"excludeClients":["10.0.0.1"]}}},"took":0.001}`))
+		case "/api/config/misc":
+			w.Write([]byte(`{"config":{"misc":{}},"took":0.001}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(Config{URL: server.URL, Password: "test"})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	ctx := context.Background()
+
+	items, err := client.GetConfigArray(ctx, "webserver/api/excludeClients")
+	if err != nil {
+		t.Fatalf("GetConfigArray() error = %v", err)
+	}
+	if !reflect.DeepEqual(items, []string{"10.0.0.1"}) {
+		t.Errorf("GetConfigArray() = %q, want [10.0.0.1]", items)
+	}
+
+	items, err = client.GetConfigArray(ctx, "misc/dnsmasq_lines")
+	if err != nil {
+		t.Fatalf("GetConfigArray() error = %v", err)
+	}
+	if len(items) != 0 {
+		t.Errorf("GetConfigArray() = %q, want none", items)
+	}
+}
+
+func TestClient_AddConfigArrayItem_Exists(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/auth" {
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"session": map[string]interface{}{
+					"valid": true,
+					"sid":   "test-sid",
+				},
+			})
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"error": map[string]interface{}{
+				"key":     "bad_request",
+				"message": "Item already present",
+				"hint":    "Uniqueness of items is enforced",
+			},
+		})
+	}))
+	defer server.Close()
+
+	client, err := New(Config{URL: server.URL, Password: "test", RetryMax: -1})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	err = client.AddConfigArrayItem(context.Background(), "dns/hosts", "192.168.1.10 nas.lan")
+	if !errors.Is(err, ErrExists) {
+		t.Fatalf("AddConfigArrayItem() error = %v, want ErrExists", err)
+	}
+	if errors.Is(err, ErrNotFound) {
+		t.Errorf("AddConfigArrayItem() error = %v, should not match ErrNotFound", err)
+	}
+}
+
+func TestClient_GetConfigOptions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/auth":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"session": map[string]interface{}{
+					"valid": true,
+					"sid":   "test-sid",
+				},
+			})
+		case "/api/config/dns":
+			if r.URL.Query().Get("detailed") != "true" {
+				t.Errorf("Expected detailed=true, got %q", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"config":{"dns":{
+				"port":{"description":"Port","allowed":"any valid port","type":"unsigned integer (16 bit)","value":53,"default":53,"modified":false,"flags":{"restart_dnsmasq":true,"env_var":false}},
+				"cache":{"size":{"description":"Cache size","allowed":"any","type":"unsigned integer","value":10000,"default":10000,"modified":false,"flags":{}}},
+				"blocking":{"mode":{"description":"Mode","allowed":[{"item":"NULL","description":"null"},{"item":"NXDOMAIN","description":"nx"}],"type":"enum (string)","value":"NULL","default":"NULL","modified":false,"flags":{"env_var":true}}}
+			}},"took":0.001}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(Config{URL: server.URL, Password: "test"})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	options, err := client.GetConfigOptions(context.Background(), "dns")
+	if err != nil {
+		t.Fatalf("GetConfigOptions() error = %v", err)
+	}
+
+	if len(options) != 3 {
+		t.Fatalf("Expected 3 options, got %d: %v", len(options), options)
+	}
+	if got := options["port"].Type; got != "unsigned integer (16 bit)" {
+		t.Errorf("Unexpected port type %q", got)
+	}
+	if options["port"].Choices() != nil {
+		t.Errorf("Expected no choices for port")
+	}
+	if _, ok := options["cache.size"]; !ok {
+		t.Errorf("Expected nested option cache.size")
+	}
+	mode := options["blocking.mode"]
+	if !mode.Flags.EnvVar {
+		t.Errorf("Expected blocking.mode to be set by an environment variable")
+	}
+	if choices := mode.Choices(); len(choices) != 2 || string(choices[1].Item) != `"NXDOMAIN"` {
+		t.Errorf("Unexpected blocking.mode choices: %+v", choices)
+	}
+}
+
+// detailedDNSConfig is a GET /api/config/dns?detailed=true response with one
+// modified option, one at its default and one set by an environment variable.
+const detailedDNSConfig = `{"config":{"dns":{
+	"port":{"type":"unsigned integer (16 bit)","value":5353,"default":53,"modified":true,"flags":{}},
+	"cache":{"size":{"type":"unsigned integer","value":10000,"default":10000,"modified":false,"flags":{}},
+		"optimizer":{"type":"integer","value":0,"default":3600,"modified":true,"flags":{}}},
+	"upstreams":{"type":"string array","value":["9.9.9.9"],"default":[],"modified":true,"flags":{"env_var":true}}
+}},"took":0.001}`
+
+func TestClient_GetConfigDefaults(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/auth":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"session": map[string]interface{}{"valid": true, "sid": "test-sid"},
+			})
+		case "/api/config/dns":
+			w.Write([]byte(detailedDNSConfig))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(Config{URL: server.URL, Password: "test"})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	defaults, err := client.GetConfigDefaults(context.Background(), "dns")
+	if err != nil {
+		t.Fatalf("GetConfigDefaults() error = %v", err)
+	}
+
+	want := map[string]string{"port": `53`, "cache.size": `10000`, "cache.optimizer": `3600`, "upstreams": `[]`}
+	if len(defaults) != len(want) {
+		t.Fatalf("Expected %d defaults, got %v", len(want), defaults)
+	}
+	for key, value := range want {
+		if got := string(defaults[key]); got != value {
+			t.Errorf("Default of %s = %s, want %s", key, got, value)
+		}
+	}
+}
+
+func TestClient_ResetConfigSection(t *testing.T) {
+	tests := []struct {
+		name      string
+		keys      []string
+		wantReset []string
+		wantBody  string
+		wantErr   bool
+	}{
+		{
+			name:      "whole section",
+			wantReset: []string{"cache.optimizer", "port"},
+			wantBody:  `{"config":{"dns":{"cache":{"optimizer":3600},"port":53}}}`,
+		},
+		{
+			name:      "selected keys",
+			keys:      []string{"port", "cache.size", "upstreams"},
+			wantReset: []string{"port"},
+			wantBody:  `{"config":{"dns":{"port":53}}}`,
+		},
+		{
+			name: "nothing to reset",
+			keys: []string{"cache.size"},
+		},
+		{
+			name:    "unknown key",
+			keys:    []string{"nope"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.URL.Path == "/api/auth":
+					json.NewEncoder(w).Encode(map[string]interface{}{
+						"session": map[string]interface{}{"valid": true, "sid": "test-sid"},
+					})
+				case r.URL.Path == "/api/config/dns" && r.Method == http.MethodGet:
+					w.Write([]byte(detailedDNSConfig))
+				case r.URL.Path == "/api/config" && r.Method == http.MethodPatch:
+					raw, _ := io.ReadAll(r.Body)
+					body = string(raw)
+					w.Write([]byte(`{"config":{},"took":0.001}`))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			client, err := New(Config{URL: server.URL, Password: "test"})
+			if err != nil {
+				t.Fatalf("Failed to create client: %v", err)
+			}
+
+			reset, err := client.ResetConfigSection(context.Background(), "dns", tt.keys)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ResetConfigSection() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(reset, tt.wantReset) {
+				t.Errorf("ResetConfigSection() = %v, want %v", reset, tt.wantReset)
+			}
+			if body != tt.wantBody {
+				t.Errorf("PATCH body = %s, want %s", body, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestClient_ResetConfigSection_webserver(t *testing.T) {
+	const webserverConfig = `{"config":{"webserver":{
+	"port":{"type":"string","value":"8080o","default":"80o,443os,[::]:80o,[::]:443os","modified":true,"flags":{"restart_dnsmasq":true}},
+	"session":{"timeout":{"type":"unsigned integer","value":300,"default":1800,"modified":true,"flags":{}}},
+	"api":{"pwhash":{"type":"string","value":"$BALLOON-SHA256$v=1$s=1024,t=32$abc","default":"","modified":true,"flags":{}},
+		"app_pwhash":{"type":"string","value":"$BALLOON-SHA256$v=1$s=1024,t=32$def","default":"","modified":true,"flags":{}}}
+}},"took":0.001}`
+
+	tests := []struct {
+		name      string
+		keys      []string
+		wantReset []string
+		wantBody  string
+	}{
+		{
+			name:      "whole section keeps the passwords and port",
+			wantReset: []string{"session.timeout"},
+			wantBody:  `{"config":{"webserver":{"session":{"timeout":1800}}}}`,
+		},
+		{
+			name:      "listed explicitly",
+			keys:      []string{"port"},
+			wantReset: []string{"port"},
+			wantBody:  `{"config":{"webserver":{"port":"80o,443os,[::]:80o,[::]:443os"}}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.URL.Path == "/api/auth":
+					json.NewEncoder(w).Encode(map[string]interface{}{
+						"session": map[string]interface{}{"valid": true, "sid": "test-sid"},
+					})
+				case r.URL.Path == "/api/config/webserver" && r.Method == http.MethodGet:
+					w.Write([]byte(webserverConfig))
+				case r.URL.Path == "/api/config" && r.Method == http.MethodPatch:
+					raw, _ := io.ReadAll(r.Body)
+					body = string(raw)
+					w.Write([]byte(`{"config":{},"took":0.001}`))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			client, err := New(Config{URL: server.URL, Password: "test"})
+			if err != nil {
+				t.Fatalf("Failed to create client: %v", err)
+			}
+
+			reset, err := client.ResetConfigSection(context.Background(), "webserver", tt.keys)
+			if err != nil {
+				t.Fatalf("ResetConfigSection() error = %v", err)
+			}
+			if !reflect.DeepEqual(reset, tt.wantReset) {
+				t.Errorf("ResetConfigSection() = %v, want %v", reset, tt.wantReset)
+			}
+			if body != tt.wantBody {
+				t.Errorf("PATCH body = %s, want %s", body, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestClient_UpdateConfig(t *testing.T) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/auth" {
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"session": map[string]interface{}{
+					"valid": true,
+					"sid":   "test-sid",
+				},
+			})
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, r.Method+" "+r.URL.Path+" "+string(body))
+		w.Write([]byte(`{"config":{},"took":0.001}`))
+	}))
+	defer server.Close()
+
+	client, err := New(Config{URL: server.URL, Password: "test", RetryMax: -1})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx := context.Background()
+	if err := client.UpdateConfig(ctx, "dns", map[string]interface{}{"port": 5353}); err != nil {
+		t.Fatalf("UpdateConfig() error = %v", err)
+	}
+	// Nothing to change sends no request
+	if err := client.UpdateConfig(ctx, "dns", map[string]interface{}{}); err != nil {
+		t.Fatalf("UpdateConfig() without values error = %v", err)
+	}
+
+	want := []string{`PATCH /api/config {"config":{"dns":{"port":5353}}}`}
+	if !reflect.DeepEqual(bodies, want) {
+		t.Errorf("requests = %v, want %v", bodies, want)
+	}
+}
+
+func TestDecodeConfigSection(t *testing.T) {
+	body := `{"config":{"dns":{"upstreams":["1.1.1.1"],"hosts":["192.168.1.10 nas.lan"]},` +
+		`"misc":{"dnsmasq_lines":["address=/lan/192.168.1.1"],"check":{"load":true}}},"took":0.001}`
+
+	var misc *MiscConfig
+	if err := decodeConfigSection(strings.NewReader(body), "misc", &misc); err != nil {
+		t.Fatalf("decodeConfigSection() error = %v", err)
+	}
+	want := &MiscConfig{DnsmasqLines: []string{"address=/lan/192.168.1.1"}, Check: &MiscCheckConfig{Load: true}}
+	if !reflect.DeepEqual(misc, want) {
+		t.Errorf("misc = %+v, want %+v", misc, want)
+	}
+
+	// A missing section leaves the value untouched.
+	var ntp *NTPConfig
+	if err := decodeConfigSection(strings.NewReader(body), "ntp", &ntp); err != nil || ntp != nil {
+		t.Errorf("missing section = %+v, %v, want nil", ntp, err)
+	}
+
+	if err := decodeConfigSection(strings.NewReader(`{"config":[]}`), "dns", &misc); err == nil {
+		t.Error("expected error for malformed config")
+	}
+}
+
+func TestClient_GetDNSConfig(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/auth" {
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"session": map[string]interface{}{"valid": true, "sid": "test-sid"},
+			})
+			return
+		}
+		if r.URL.Path != "/api/config/dns" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`{"config":{"dns":{"upstreams":["1.1.1.1"],"hosts":["192.168.1.10 nas.lan"]}},"took":0.001}`))
+	}))
+	defer server.Close()
+
+	client, err := New(Config{URL: server.URL, Password: "test", RetryMax: -1})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	dns, err := client.GetDNSConfig(context.Background())
+	if err != nil {
+		t.Fatalf("GetDNSConfig() error = %v", err)
+	}
+	want := &DNSConfig{Upstreams: []string{"1.1.1.1"}, Hosts: []string{"192.168.1.10 nas.lan"}}
+	if !reflect.DeepEqual(dns, want) {
+		t.Errorf("GetDNSConfig() = %+v, want %+v", dns, want)
+	}
+}
+
+// largeConfigResponse returns a config response with n custom DNS records
+// and n dnsmasq lines.
+func largeConfigResponse(n int) []byte {
+	resp := PiholeConfigResponse{
+		Config: PiholeConfig{
+			DNS:       &DNSConfig{Upstreams: []string{"1.1.1.1", "9.9.9.9"}, Hosts: make([]string, n)},
+			DHCP:      &DHCPConfig{Hosts: make([]string, n)},
+			NTP:       &NTPConfig{},
+			Resolver:  &ResolverConfig{},
+			Database:  &DatabaseConfig{},
+			Webserver: &WebserverConfig{},
+			Files:     &FilesConfig{},
+			Misc:      &MiscConfig{DnsmasqLines: make([]string, n)},
+			Debug:     &DebugConfig{},
+		},
+		Took: 0.01,
+	}
+	for i := 0; i < n; i++ {
+		resp.Config.DNS.Hosts[i] = fmt.Sprintf("10.0.%d.%d host%d.lan", i/256%256, i%256, i)
+		resp.Config.DHCP.Hosts[i] = fmt.Sprintf("aa:bb:cc:%02x:%02x:%02x,10.1.%d.%d,client%d", i>>16&0xff, i>>8&0xff, i&0xff, i/256%256, i%256, i)
+		resp.Config.Misc.DnsmasqLines[i] = fmt.Sprintf("address=/blocked%d.example.com/0.0.0.0", i)
+	}
+	body, _ := json.Marshal(resp)
+	return body
+}
+
+func BenchmarkDecodeConfig_Unmarshal(b *testing.B) {
+	body := largeConfigResponse(20000)
+	b.SetBytes(int64(len(body)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var resp PiholeConfigResponse
+		if err := json.Unmarshal(body, &resp); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeConfig_SectionDNS(b *testing.B) {
+	body := largeConfigResponse(20000)
+	b.SetBytes(int64(len(body)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var dns *DNSConfig
+		if err := decodeConfigSection(bytes.NewReader(body), "dns", &dns); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeConfig_SectionNTP(b *testing.B) {
+	body := largeConfigResponse(20000)
+	b.SetBytes(int64(len(body)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var ntp *NTPConfig
+		if err := decodeConfigSection(bytes.NewReader(body), "ntp", &ntp); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
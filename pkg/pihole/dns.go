@@ -1,16 +1,21 @@
 // Copyright (c) 2025 dklesev
 // SPDX-License-Identifier: MIT
 
-package client
+package pihole
 
 import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
 )
 
 // GetDNSBlocking gets the current DNS blocking status.
 func (c *Client) GetDNSBlocking(ctx context.Context) (*DNSBlocking, error) {
+	if c.legacy != nil {
+		return c.legacyBlocking(ctx, url.Values{"status": {""}})
+	}
+
 	resp, err := c.Get(ctx, "dns/blocking")
 	if err != nil {
 		return nil, err
@@ -29,6 +34,10 @@ func (c *Client) GetDNSBlocking(ctx context.Context) (*DNSBlocking, error) {
 
 // SetDNSBlocking sets the DNS blocking status.
 func (c *Client) SetDNSBlocking(ctx context.Context, enabled bool, timer *float64) (*DNSBlocking, error) {
+	if c.legacy != nil {
+		return c.legacySetBlocking(ctx, enabled, timer)
+	}
+
 	payload := DNSBlockingRequest{
 		Blocking: enabled,
 		Timer:    timer,
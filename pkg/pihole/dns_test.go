@@ -1,7 +1,7 @@
 // Copyright (c) 2025 dklesev
 // SPDX-License-Identifier: MIT
 
-package client
+package pihole
 
 import (
 	"context"
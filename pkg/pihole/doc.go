@@ -0,0 +1,41 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+// Package pihole is a Go client for the Pi-hole v6 FTL API, with a subset
+// of it (domains and blocking) for the PHP API of Pi-hole v5. The Terraform
+// provider is built on it, and other tools such as CLIs or operators can use
+// it the same way.
+//
+// A Client logs in with the web interface password on the first request
+// and keeps the session, logging in again when it expires.
+// Transient failures are retried with backoff and all methods are safe for
+// concurrent use:
+//
+//	c, err := pihole.New(pihole.Config{
+//		URL:      "http://pi.hole",
+//		Password: os.Getenv("PIHOLE_PASSWORD"),
+//	})
+//	if err != nil {
+//		return err
+//	}
+//
+//	domain, err := c.CreateDomain(ctx, &pihole.Domain{
+//		Domain:  "ads.example.com",
+//		Type:    "deny",
+//		Kind:    "exact",
+//		Enabled: true,
+//	})
+//
+// Errors wrap sentinels to check with errors.Is: ErrNotFound for entries
+// that do not exist, ErrExists for entries created twice, ErrUnsupported for
+// endpoints the Pi-hole version lacks, ErrReadOnly and ErrDestructiveDisabled
+// for refused changes. Pi-hole's own error responses are *APIError.
+//
+// The package is versioned with the provider: releases are tagged vX.Y.Z
+// in this module, and
+//
+//	go get github.com/dklesev/terraform-provider-pihole/pkg/pihole@latest
+//
+// fetches the latest one. Exported identifiers only change in a
+// backwards-incompatible way in a new major version.
+package pihole
@@ -0,0 +1,357 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package pihole
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// GetDomains retrieves domains with optional filters.
+func (c *Client) GetDomains(ctx context.Context, domainType, kind, domain string) ([]Domain, error) {
+	var domains []Domain
+	err := c.ForEachDomain(ctx, domainType, kind, domain, func(d Domain) bool {
+		domains = append(domains, d)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return domains, nil
+}
+
+// ForEachDomain calls fn for each domain matching the filters, in the order
+// Pi-hole returns them, until fn returns false. The response body is still
+// read in full, but it is decoded one entry at a time, so callers that filter
+// or stop early never hold the whole list as decoded Domains.
+func (c *Client) ForEachDomain(ctx context.Context, domainType, kind, domain string, fn func(Domain) bool) error {
+	if c.legacy != nil {
+		return c.legacyForEachDomain(ctx, domainType, kind, domain, fn)
+	}
+
+	segments := []string{"domains"}
+	if domainType != "" {
+		segments = append(segments, domainType)
+	}
+	if kind != "" {
+		segments = append(segments, kind)
+	}
+	if domain != "" {
+		segments = append(segments, pathSegment(domain))
+	}
+	path := strings.Join(segments, "/")
+
+	resp, err := c.Get(ctx, path)
+	if err != nil {
+		return err
+	}
+
+	if err := decodeDomains(bytes.NewReader(resp), fn); err != nil {
+		return fmt.Errorf("failed to parse domains response: %w", err)
+	}
+	return nil
+}
+
+// decodeDomains streams the "domains" array of a DomainsResponse into fn,
+// skipping the other fields.
+func decodeDomains(r io.Reader, fn func(Domain) bool) error {
+	dec := json.NewDecoder(r)
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		if key, _ := tok.(string); key != "domains" {
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return err
+			}
+			continue
+		}
+
+		tok, err = dec.Token()
+		if err != nil {
+			return err
+		}
+		if tok == nil {
+			continue // "domains": null
+		}
+		if d, ok := tok.(json.Delim); !ok || d != '[' {
+			return fmt.Errorf("expected domains array, got %v", tok)
+		}
+		for dec.More() {
+			var d Domain
+			if err := dec.Decode(&d); err != nil {
+				return err
+			}
+			if !fn(d) {
+				return nil
+			}
+		}
+		if err := expectDelim(dec, ']'); err != nil {
+			return err
+		}
+	}
+
+	return expectDelim(dec, '}')
+}
+
+// expectDelim reads the next token and checks that it is the delimiter want.
+func expectDelim(dec *json.Decoder, want json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if d, ok := tok.(json.Delim); !ok || d != want {
+		return fmt.Errorf("expected %q, got %v", want, tok)
+	}
+	return nil
+}
+
+// GetDomain retrieves a specific domain. Pi-hole looks the domain up by its
+// path, so instances with large regex sets do not send every entry of the
+// type and kind. Lookups Pi-hole rejects as a bad request fall back to
+// filtering those entries. It returns nil when the domain does not exist.
+func (c *Client) GetDomain(ctx context.Context, domainType, kind, domain string) (*Domain, error) {
+	var found *Domain
+	match := func(d Domain) bool {
+		if d.Domain == domain && d.Type == domainType && d.Kind == kind {
+			found = &d
+			return false
+		}
+		return true
+	}
+
+	err := c.ForEachDomain(ctx, domainType, kind, domain, match)
+	var apiErr *APIError
+	if domain != "" && errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusBadRequest {
+		err = c.ForEachDomain(ctx, domainType, kind, "", match)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return found, nil
+}
+
+// CreateDomain creates a new domain entry.
+func (c *Client) CreateDomain(ctx context.Context, domain *Domain) (*Domain, error) {
+	if domain.Type == "" || domain.Kind == "" {
+		return nil, fmt.Errorf("domain type and kind are required")
+	}
+	if c.legacy != nil {
+		return c.legacyCreateDomain(ctx, domain)
+	}
+
+	payload := map[string]interface{}{
+		"domain":  domain.Domain,
+		"enabled": domain.Enabled,
+	}
+	if domain.Comment != "" {
+		payload["comment"] = domain.Comment
+	}
+	if len(domain.Groups) > 0 {
+		payload["groups"] = domain.Groups
+	}
+
+	path := fmt.Sprintf("domains/%s/%s", domain.Type, domain.Kind)
+	resp, err := c.Post(ctx, path, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var result DomainsResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse create domain response: %w", err)
+	}
+	if err := result.Processed.err(); err != nil {
+		return nil, err
+	}
+
+	if len(result.Domains) == 0 {
+		return nil, fmt.Errorf("no domain returned in response")
+	}
+
+	return &result.Domains[0], nil
+}
+
+// maxBulkDomains limits how many domains CreateDomains sends per request.
+const maxBulkDomains = 500
+
+// BulkError lists the items of a bulk write that Pi-hole rejected.
+type BulkError struct {
+	Errors []ProcessedError
+}
+
+func (e *BulkError) Error() string {
+	items := make([]string, len(e.Errors))
+	for i, pe := range e.Errors {
+		items[i] = fmt.Sprintf("%s: %s", pe.Item, pe.Error)
+	}
+	return fmt.Sprintf("%d items failed: %s", len(e.Errors), strings.Join(items, "; "))
+}
+
+// CreateDomains creates many domain entries with few requests. Pi-hole
+// accepts an array of domains that share type, kind, enabled, comment and
+// groups, so the entries are grouped by those and sent in chunks of
+// maxBulkDomains. It returns the entries Pi-hole created; when some entries
+// are rejected the error is a *BulkError and the others are still created.
+// Like the other client methods it is safe for concurrent use.
+func (c *Client) CreateDomains(ctx context.Context, domains []Domain) ([]Domain, error) {
+	type batchKey struct {
+		domainType, kind, comment, groups string
+		enabled                           bool
+	}
+
+	var keys []batchKey
+	batches := make(map[batchKey][]string)
+	groups := make(map[batchKey][]int64)
+	for _, d := range domains {
+		if d.Type == "" || d.Kind == "" {
+			return nil, fmt.Errorf("domain type and kind are required (%s)", d.Domain)
+		}
+		key := batchKey{d.Type, d.Kind, d.Comment, fmt.Sprint(d.Groups), d.Enabled}
+		if _, ok := batches[key]; !ok {
+			keys = append(keys, key)
+			groups[key] = d.Groups
+		}
+		batches[key] = append(batches[key], d.Domain)
+	}
+
+	created := make([]Domain, 0, len(domains))
+	if c.legacy != nil {
+		// The PHP API adds one domain at a time.
+		for _, d := range domains {
+			entry, err := c.legacyCreateDomain(ctx, &d)
+			if err != nil {
+				return created, err
+			}
+			created = append(created, *entry)
+		}
+		return created, nil
+	}
+
+	bulkErr := &BulkError{}
+	for _, key := range keys {
+		names := batches[key]
+		for start := 0; start < len(names); start += maxBulkDomains {
+			end := min(start+maxBulkDomains, len(names))
+
+			payload := map[string]interface{}{
+				"domain":  names[start:end],
+				"enabled": key.enabled,
+			}
+			if key.comment != "" {
+				payload["comment"] = key.comment
+			}
+			if len(groups[key]) > 0 {
+				payload["groups"] = groups[key]
+			}
+
+			path := fmt.Sprintf("domains/%s/%s", key.domainType, key.kind)
+			resp, err := c.Post(ctx, path, payload)
+			if err != nil {
+				return created, err
+			}
+
+			var result DomainsResponse
+			if err := json.Unmarshal(resp, &result); err != nil {
+				return created, fmt.Errorf("failed to parse create domains response: %w", err)
+			}
+			created = append(created, result.Domains...)
+			if result.Processed != nil {
+				bulkErr.Errors = append(bulkErr.Errors, result.Processed.Errors...)
+			}
+		}
+	}
+
+	if len(bulkErr.Errors) > 0 {
+		return created, bulkErr
+	}
+	return created, nil
+}
+
+// err returns an error for the first item the write failed for, wrapping
+// ErrExists when the item was already there, or nil when none failed.
+func (p *Processed) err() error {
+	if p == nil || len(p.Errors) == 0 {
+		return nil
+	}
+	pe := p.Errors[0]
+	if isUniqueViolation(pe.Error) {
+		return fmt.Errorf("%s: %s: %w", pe.Item, pe.Error, ErrExists)
+	}
+	return fmt.Errorf("%s: %s", pe.Item, pe.Error)
+}
+
+// isUniqueViolation reports whether a database error message is about an
+// item that is already there.
+func isUniqueViolation(message string) bool {
+	return strings.Contains(message, "UNIQUE constraint failed")
+}
+
+// UpdateDomain updates an existing domain entry.
+func (c *Client) UpdateDomain(ctx context.Context, originalType, originalKind, originalDomain string, domain *Domain) (*Domain, error) {
+	if c.legacy != nil {
+		return c.legacyUpdateDomain(ctx, originalType, originalKind, originalDomain, domain)
+	}
+
+	payload := map[string]interface{}{
+		"domain":  domain.Domain,
+		"enabled": domain.Enabled,
+		"comment": domain.Comment,
+		"groups":  domain.Groups,
+	}
+
+	// If type/kind changed, we need to specify the new values
+	if domain.Type != originalType || domain.Kind != originalKind {
+		payload["type"] = domain.Type
+		payload["kind"] = domain.Kind
+	}
+
+	path := fmt.Sprintf("domains/%s/%s/%s", originalType, originalKind, pathSegment(originalDomain))
+	resp, err := c.Put(ctx, path, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var result DomainsResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse update domain response: %w", err)
+	}
+
+	// If domains array is empty (may happen on domain/type/kind change), fetch by new values
+	if len(result.Domains) == 0 {
+		updatedDomain, err := c.GetDomain(ctx, domain.Type, domain.Kind, domain.Domain)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch updated domain: %w", err)
+		}
+		if updatedDomain == nil {
+			return nil, fmt.Errorf("no domain returned in response and updated domain not found")
+		}
+		return updatedDomain, nil
+	}
+
+	return &result.Domains[0], nil
+}
+
+// DeleteDomain deletes a domain entry.
+func (c *Client) DeleteDomain(ctx context.Context, domainType, kind, domain string) error {
+	if c.legacy != nil {
+		return c.legacyDeleteDomain(ctx, domainType, kind, domain)
+	}
+
+	path := fmt.Sprintf("domains/%s/%s/%s", domainType, kind, pathSegment(domain))
+	_, err := c.Delete(ctx, path)
+	return err
+}
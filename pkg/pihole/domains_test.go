@@ -0,0 +1,511 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package pihole
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestClient_GetDomains(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/auth":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"session": map[string]interface{}{
+					"valid": true,
+					"sid":   "test-sid",
+				},
+			})
+		case "/api/domains":
+			json.NewEncoder(w).Encode(DomainsResponse{
+				Domains: []Domain{
+					{ID: 1, Domain: "ads.example.com", Type: "deny", Kind: "exact", Enabled: true},
+					{ID: 2, Domain: "^ads\\..*", Type: "deny", Kind: "regex", Enabled: true},
+				},
+				Took: 0.001,
+			})
+		case "/api/domains/deny":
+			json.NewEncoder(w).Encode(DomainsResponse{
+				Domains: []Domain{
+					{ID: 1, Domain: "ads.example.com", Type: "deny", Kind: "exact", Enabled: true},
+					{ID: 2, Domain: "^ads\\..*", Type: "deny", Kind: "regex", Enabled: true},
+				},
+				Took: 0.001,
+			})
+		case "/api/domains/deny/exact":
+			json.NewEncoder(w).Encode(DomainsResponse{
+				Domains: []Domain{
+					{ID: 1, Domain: "ads.example.com", Type: "deny", Kind: "exact", Enabled: true},
+				},
+				Took: 0.001,
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(Config{URL: server.URL, Password: "test"})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx := context.Background()
+
+	// Test get all domains
+	domains, err := client.GetDomains(ctx, "", "", "")
+	if err != nil {
+		t.Fatalf("GetDomains() error = %v", err)
+	}
+	if len(domains) != 2 {
+		t.Errorf("Expected 2 domains, got %d", len(domains))
+	}
+
+	// Test filter by type
+	domains, err = client.GetDomains(ctx, "deny", "", "")
+	if err != nil {
+		t.Fatalf("GetDomains(deny) error = %v", err)
+	}
+	if len(domains) != 2 {
+		t.Errorf("Expected 2 deny domains, got %d", len(domains))
+	}
+
+	// Test filter by type and kind
+	domains, err = client.GetDomains(ctx, "deny", "exact", "")
+	if err != nil {
+		t.Fatalf("GetDomains(deny, exact) error = %v", err)
+	}
+	if len(domains) != 1 {
+		t.Errorf("Expected 1 exact deny domain, got %d", len(domains))
+	}
+}
+
+func TestClient_GetDomain(t *testing.T) {
+	regexes := []string{
+		`(\.|^)ads\.example\.com$`,
+		`^ad[sx]?[0-9]*\.tracker\.(com|net)$`,
+		`^a/b\?c#d%20e\+f$`,
+		`^[^.]+\.example\.org;querytype=A`,
+		`.`,
+	}
+	entries := make([]Domain, len(regexes))
+	for i, regex := range regexes {
+		entries[i] = Domain{ID: int64(i + 1), Domain: regex, Type: "deny", Kind: "regex", Enabled: true}
+	}
+
+	tests := []struct {
+		name       string
+		rejectItem bool
+	}{
+		{name: "path lookup"},
+		{name: "falls back when the lookup is rejected", rejectItem: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var lookups []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/api/auth" {
+					json.NewEncoder(w).Encode(map[string]interface{}{
+						"session": map[string]interface{}{
+							"valid": true,
+							"sid":   "test-sid",
+						},
+					})
+					return
+				}
+				mu.Lock()
+				lookups = append(lookups, r.URL.Path)
+				mu.Unlock()
+
+				const prefix = "/api/domains/deny/regex"
+				switch {
+				case r.URL.Path == prefix:
+					json.NewEncoder(w).Encode(DomainsResponse{Domains: entries})
+				case strings.HasPrefix(r.URL.Path, prefix+"/"):
+					if tt.rejectItem {
+						w.WriteHeader(http.StatusBadRequest)
+						json.NewEncoder(w).Encode(map[string]interface{}{
+							"error": map[string]interface{}{"key": "bad_request", "message": "Invalid request"},
+						})
+						return
+					}
+					// The server decodes the path segment back into the regex
+					resp := DomainsResponse{Domains: []Domain{}}
+					for _, e := range entries {
+						if e.Domain == strings.TrimPrefix(r.URL.Path, prefix+"/") {
+							resp.Domains = append(resp.Domains, e)
+						}
+					}
+					json.NewEncoder(w).Encode(resp)
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			client, err := New(Config{URL: server.URL, Password: "test", RetryMax: -1})
+			if err != nil {
+				t.Fatalf("Failed to create client: %v", err)
+			}
+
+			for _, regex := range regexes {
+				lookups = nil
+				got, err := client.GetDomain(context.Background(), "deny", "regex", regex)
+				if err != nil {
+					t.Fatalf("GetDomain(%q) error = %v", regex, err)
+				}
+				if got == nil || got.Domain != regex {
+					t.Fatalf("GetDomain(%q) = %+v, lookups %v", regex, got, lookups)
+				}
+				wantLookups := 1
+				if tt.rejectItem {
+					wantLookups = 2
+				}
+				if len(lookups) != wantLookups {
+					t.Errorf("GetDomain(%q) made lookups %v, want %d", regex, lookups, wantLookups)
+				}
+			}
+
+			if got, err := client.GetDomain(context.Background(), "deny", "regex", `^missing$`); err != nil || got != nil {
+				t.Errorf("GetDomain(missing) = %+v, %v, want nil", got, err)
+			}
+		})
+	}
+}
+
+func TestClient_CreateDomain(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/auth":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"session": map[string]interface{}{
+					"valid": true,
+					"sid":   "test-sid",
+				},
+			})
+		case "/api/domains/deny/exact":
+			if r.Method == http.MethodPost {
+				var req map[string]interface{}
+				json.NewDecoder(r.Body).Decode(&req)
+
+				domain, _ := req["domain"].(string)
+				enabled, _ := req["enabled"].(bool)
+
+				json.NewEncoder(w).Encode(DomainsResponse{
+					Domains: []Domain{
+						{
+							ID:      1,
+							Domain:  domain,
+							Type:    "deny",
+							Kind:    "exact",
+							Enabled: enabled,
+						},
+					},
+					Took: 0.001,
+				})
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(Config{URL: server.URL, Password: "test"})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx := context.Background()
+	domain := &Domain{
+		Domain:  "test.example.com",
+		Type:    "deny",
+		Kind:    "exact",
+		Enabled: true,
+	}
+
+	created, err := client.CreateDomain(ctx, domain)
+	if err != nil {
+		t.Fatalf("CreateDomain() error = %v", err)
+	}
+	if created.Domain != "test.example.com" {
+		t.Errorf("Expected domain 'test.example.com', got %q", created.Domain)
+	}
+	if created.Type != "deny" {
+		t.Errorf("Expected type 'deny', got %q", created.Type)
+	}
+}
+
+func TestClient_CreateDomain_Exists(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/auth":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"session": map[string]interface{}{
+					"valid": true,
+					"sid":   "test-sid",
+				},
+			})
+		case "/api/domains/deny/exact":
+			// Pi-hole answers 201 with the existing entry and the failure in processed
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(DomainsResponse{
+				Domains: []Domain{{ID: 7, Domain: "test.example.com", Type: "deny", Kind: "exact", Enabled: false}},
+				Processed: &Processed{Errors: []ProcessedError{
+					{Item: "test.example.com", Error: "UNIQUE constraint failed: domainlist.domain, domainlist.type"},
+				}},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(Config{URL: server.URL, Password: "test"})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	_, err = client.CreateDomain(context.Background(), &Domain{Domain: "test.example.com", Type: "deny", Kind: "exact", Enabled: true})
+	if !errors.Is(err, ErrExists) {
+		t.Fatalf("CreateDomain() error = %v, want ErrExists", err)
+	}
+}
+
+func TestClient_CreateDomain_ValidationErrors(t *testing.T) {
+	client, err := New(Config{URL: "http://localhost", Password: "test"})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx := context.Background()
+
+	// Missing type
+	_, err = client.CreateDomain(ctx, &Domain{
+		Domain: "test.com",
+		Kind:   "exact",
+	})
+	if err == nil {
+		t.Error("Expected error for missing type")
+	}
+
+	// Missing kind
+	_, err = client.CreateDomain(ctx, &Domain{
+		Domain: "test.com",
+		Type:   "deny",
+	})
+	if err == nil {
+		t.Error("Expected error for missing kind")
+	}
+}
+
+func TestClient_CreateDomains(t *testing.T) {
+	var mu sync.Mutex
+	var requests []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/auth":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"session": map[string]interface{}{"valid": true, "sid": "test-sid"},
+			})
+		case "/api/domains/deny/exact", "/api/domains/deny/regex":
+			var body struct {
+				Domain  []string `json:"domain"`
+				Comment string   `json:"comment"`
+				Groups  []int64  `json:"groups"`
+				Enabled bool     `json:"enabled"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("Expected an array of domains: %v", err)
+			}
+			mu.Lock()
+			requests = append(requests, fmt.Sprintf("%s %d %q %v", r.URL.Path, len(body.Domain), body.Comment, body.Groups))
+			mu.Unlock()
+
+			resp := DomainsResponse{Processed: &Processed{}}
+			for _, d := range body.Domain {
+				if d == "taken.example.com" {
+					resp.Processed.Errors = append(resp.Processed.Errors, ProcessedError{Item: d, Error: "UNIQUE constraint failed: domainlist.domain, domainlist.type"})
+					continue
+				}
+				resp.Domains = append(resp.Domains, Domain{Domain: d, Comment: body.Comment, Groups: body.Groups, Enabled: body.Enabled})
+				resp.Processed.Success = append(resp.Processed.Success, ProcessedItem{Item: d})
+			}
+			json.NewEncoder(w).Encode(resp)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(Config{URL: server.URL, Password: "test"})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	var domains []Domain
+	for i := 0; i < maxBulkDomains+1; i++ {
+		domains = append(domains, Domain{Domain: fmt.Sprintf("ads%d.example.com", i), Type: "deny", Kind: "exact", Enabled: true, Comment: "ads"})
+	}
+	domains = append(domains,
+		Domain{Domain: "taken.example.com", Type: "deny", Kind: "exact", Enabled: true, Comment: "ads"},
+		Domain{Domain: "tracker.example.com", Type: "deny", Kind: "exact", Enabled: true, Comment: "trackers", Groups: []int64{0, 2}},
+		Domain{Domain: `^ads\.`, Type: "deny", Kind: "regex", Enabled: true},
+	)
+
+	created, err := client.CreateDomains(context.Background(), domains)
+	var bulkErr *BulkError
+	if !errors.As(err, &bulkErr) {
+		t.Fatalf("Expected *BulkError, got %v", err)
+	}
+	if len(bulkErr.Errors) != 1 || bulkErr.Errors[0].Item != "taken.example.com" {
+		t.Errorf("Expected taken.example.com to fail, got %v", bulkErr.Errors)
+	}
+	if len(created) != len(domains)-1 {
+		t.Errorf("Expected %d created domains, got %d", len(domains)-1, len(created))
+	}
+
+	want := []string{
+		fmt.Sprintf(`/api/domains/deny/exact %d "ads" []`, maxBulkDomains),
+		`/api/domains/deny/exact 2 "ads" []`,
+		`/api/domains/deny/exact 1 "trackers" [0 2]`,
+		`/api/domains/deny/regex 1 "" []`,
+	}
+	if !reflect.DeepEqual(requests, want) {
+		t.Errorf("Requests = %v, want %v", requests, want)
+	}
+
+	if _, err := client.CreateDomains(context.Background(), []Domain{{Domain: "x.example.com"}}); err == nil {
+		t.Error("Expected error for missing type and kind")
+	}
+}
+
+func TestDecodeDomains(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		stop    int
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "all entries",
+			body: `{"domains":[{"domain":"a.example.com"},{"domain":"b.example.com"}],"took":0.001}`,
+			want: []string{"a.example.com", "b.example.com"},
+		},
+		{
+			name: "domains after other fields",
+			body: `{"took":0.001,"extra":{"nested":[1,2]},"domains":[{"domain":"a.example.com"}]}`,
+			want: []string{"a.example.com"},
+		},
+		{
+			name: "stop early",
+			body: `{"domains":[{"domain":"a.example.com"},{"domain":"b.example.com"},{"domain":"c.example.com"}]}`,
+			stop: 2,
+			want: []string{"a.example.com", "b.example.com"},
+		},
+		{
+			name: "null domains",
+			body: `{"domains":null,"took":0.001}`,
+		},
+		{
+			name:    "not an object",
+			body:    `[]`,
+			wantErr: true,
+		},
+		{
+			name:    "truncated",
+			body:    `{"domains":[{"domain":"a.example.com"},`,
+			want:    []string{"a.example.com"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			err := decodeDomains(strings.NewReader(tt.body), func(d Domain) bool {
+				got = append(got, d.Domain)
+				return tt.stop == 0 || len(got) < tt.stop
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("decodeDomains() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("decodeDomains() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// largeDomainsResponse returns a domains response with n entries, the size
+// of a regex-heavy instance.
+func largeDomainsResponse(n int) []byte {
+	resp := DomainsResponse{Domains: make([]Domain, n), Took: 0.5}
+	for i := range resp.Domains {
+		resp.Domains[i] = Domain{
+			ID:        int64(i + 1),
+			Domain:    fmt.Sprintf(`(\.|^)ads%d\.example\.com$`, i),
+			Type:      "deny",
+			Kind:      "regex",
+			Enabled:   true,
+			Comment:   "imported from community list",
+			Groups:    []int64{0},
+			DateAdded: 1700000000,
+		}
+	}
+	body, _ := json.Marshal(resp)
+	return body
+}
+
+func BenchmarkDecodeDomains_Unmarshal(b *testing.B) {
+	body := largeDomainsResponse(50000)
+	b.SetBytes(int64(len(body)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var resp DomainsResponse
+		if err := json.Unmarshal(body, &resp); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeDomains_Stream(b *testing.B) {
+	body := largeDomainsResponse(50000)
+	b.SetBytes(int64(len(body)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		err := decodeDomains(bytes.NewReader(body), func(Domain) bool { return true })
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeDomains_StreamFirst100(b *testing.B) {
+	body := largeDomainsResponse(50000)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		n := 0
+		err := decodeDomains(bytes.NewReader(body), func(Domain) bool {
+			n++
+			return n < 100
+		})
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
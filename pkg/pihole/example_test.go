@@ -0,0 +1,73 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package pihole_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/dklesev/terraform-provider-pihole/pkg/pihole"
+)
+
+func ExampleNew() {
+	c, err := pihole.New(pihole.Config{
+		URL:      "https://pi.hole",
+		Password: os.Getenv("PIHOLE_PASSWORD"),
+		Timeout:  10 * time.Second,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	blocking, err := c.GetDNSBlocking(context.Background())
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("Blocking:", blocking.Blocking)
+}
+
+func ExampleClient_CreateDomain() {
+	c, err := pihole.New(pihole.Config{URL: "http://pi.hole", Password: os.Getenv("PIHOLE_PASSWORD")})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	domain, err := c.CreateDomain(context.Background(), &pihole.Domain{
+		Domain:  "ads.example.com",
+		Type:    "deny",
+		Kind:    "exact",
+		Enabled: true,
+		Comment: "Blocked by the example",
+	})
+	switch {
+	case errors.Is(err, pihole.ErrExists):
+		fmt.Println("ads.example.com is blocked already")
+	case err != nil:
+		log.Fatal(err)
+	default:
+		fmt.Println("Created domain", domain.ID)
+	}
+}
+
+func ExampleClient_ForEachDomain() {
+	c, err := pihole.New(pihole.Config{URL: "http://pi.hole", Password: os.Getenv("PIHOLE_PASSWORD")})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Print the disabled deny regexes without loading all of them at once
+	err = c.ForEachDomain(context.Background(), "deny", "regex", "", func(d pihole.Domain) bool {
+		if !d.Enabled {
+			fmt.Println(d.Domain)
+		}
+		return true
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package pihole
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// DefaultGravityTimeout bounds a gravity update when the context passed to
+// UpdateGravity has no deadline. Downloading large lists on a Raspberry Pi
+// takes minutes, far longer than Config.Timeout allows a request.
+const DefaultGravityTimeout = 15 * time.Minute
+
+// UpdateGravity runs `pihole -g` on the Pi-hole, downloading the subscribed
+// lists and rebuilding the gravity database, and returns its output once it
+// finishes. Lists and domain rules only take effect for blocking after it ran.
+//
+// The request is sent once, without retries, since a retry would start
+// another update, and it is not bound by Config.Timeout. It holds one of
+// the Config.MaxConcurrentRequests request slots while the update runs.
+func (c *Client) UpdateGravity(ctx context.Context) (string, error) {
+	const path = "action/gravity"
+	if c.readOnly {
+		return "", fmt.Errorf("%w, refusing %s %s", ErrReadOnly, http.MethodPost, path)
+	}
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, DefaultGravityTimeout)
+		defer cancel()
+	}
+
+	if err := c.ensureAuthenticated(ctx); err != nil {
+		return "", fmt.Errorf("authentication failed: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL.JoinPath(path).String(), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	release, err := c.acquireRequestSlot(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer release()
+
+	c.mu.RLock()
+	sid := c.sid
+	c.mu.RUnlock()
+	req.Header.Set("sid", sid)
+
+	// Bypass the retrying client and its timeout; the context bounds the
+	// update instead.
+	httpClient := *c.httpClient.HTTPClient
+	httpClient.Timeout = 0
+
+	start := time.Now()
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		c.observe(ctx, http.MethodPost, path, nil, start, 0, nil, err)
+		return "", fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	c.observe(ctx, http.MethodPost, path, nil, start, resp.StatusCode, nil, err)
+	if err != nil {
+		return string(body), fmt.Errorf("failed to read gravity output: %w", err)
+	}
+	if resp.StatusCode >= 400 {
+		return "", newAPIError(resp, body)
+	}
+
+	return string(body), nil
+}
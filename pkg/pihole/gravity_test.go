@@ -0,0 +1,165 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package pihole
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_UpdateGravity(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/auth":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"session": map[string]interface{}{
+					"valid": true,
+					"sid":   "test-sid",
+				},
+			})
+		case "/api/action/gravity":
+			if r.Method != http.MethodPost || r.Header.Get("sid") != "test-sid" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			if calls.Add(1) > 1 {
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(`{"error": {"key": "server_error", "message": "gravity failed", "hint": null}}`))
+				return
+			}
+			// Outlast the client timeout, as gravity runs on real lists do.
+			w.Write([]byte("  [i] Neutrino emissions detected...\n"))
+			w.(http.Flusher).Flush()
+			time.Sleep(300 * time.Millisecond)
+			w.Write([]byte("  [✓] Done.\n"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(Config{URL: server.URL, Password: "test", Timeout: 100 * time.Millisecond, RetryMax: -1})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	output, err := client.UpdateGravity(context.Background())
+	if err != nil {
+		t.Fatalf("UpdateGravity() error = %v", err)
+	}
+	if !strings.Contains(output, "Neutrino emissions") || !strings.Contains(output, "Done.") {
+		t.Errorf("Unexpected gravity output: %q", output)
+	}
+
+	_, err = client.UpdateGravity(context.Background())
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Message != "gravity failed" {
+		t.Errorf("Expected APIError for failed update, got %v", err)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("Expected 2 gravity requests without retries, got %d", calls.Load())
+	}
+
+	readOnly, err := New(Config{URL: server.URL, Password: "test", ReadOnly: true})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	if _, err := readOnly.UpdateGravity(context.Background()); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly, got %v", err)
+	}
+}
+
+func TestClient_UpdateGravity_Canceled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/auth":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"session": map[string]interface{}{
+					"valid": true,
+					"sid":   "test-sid",
+				},
+			})
+		case "/api/action/gravity":
+			w.Write([]byte("  [i] Neutrino emissions detected...\n"))
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(Config{URL: server.URL, Password: "test"})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err = client.UpdateGravity(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("UpdateGravity() returned %s after the context expired", elapsed)
+	}
+}
+
+func TestClient_UpdateGravity_RequestSlot(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/auth":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"session": map[string]interface{}{
+					"valid": true,
+					"sid":   "test-sid",
+				},
+			})
+		case "/api/action/gravity":
+			w.Write([]byte("  [✓] Done.\n"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(Config{URL: server.URL, Password: "test", MaxConcurrentRequests: 1})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	if err := client.Authenticate(context.Background()); err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+
+	// The only slot is taken by another request.
+	release, err := client.acquireRequestSlot(context.Background())
+	if err != nil {
+		t.Fatalf("acquireRequestSlot() error = %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if _, err := client.UpdateGravity(ctx); err == nil || !strings.Contains(err.Error(), "free request slot") {
+		t.Errorf("Expected UpdateGravity() to wait for a request slot, got %v", err)
+	}
+	if !client.Busy() {
+		t.Error("Busy() = false while a request held a slot")
+	}
+	release()
+
+	if _, err := client.UpdateGravity(context.Background()); err != nil {
+		t.Errorf("UpdateGravity() error = %v", err)
+	}
+	if client.Busy() {
+		t.Error("Busy() = true after the requests ended")
+	}
+}
@@ -1,20 +1,20 @@
 // Copyright (c) 2025 dklesev
 // SPDX-License-Identifier: MIT
 
-package client
+package pihole
 
 import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"net/url"
+	"sync"
 )
 
 // GetGroups retrieves all groups or a specific group by name.
 func (c *Client) GetGroups(ctx context.Context, name string) ([]Group, error) {
 	path := "groups"
 	if name != "" {
-		path = fmt.Sprintf("groups/%s", url.PathEscape(name))
+		path = fmt.Sprintf("groups/%s", pathSegment(name))
 	}
 
 	resp, err := c.Get(ctx, path)
@@ -44,6 +44,79 @@ func (c *Client) GetGroup(ctx context.Context, name string) (*Group, error) {
 	return &groups[0], nil
 }
 
+// GetGroupByID retrieves the group with the given ID. The API looks groups up
+// by name only, so all groups are read; a group renamed since it was last
+// seen is still found.
+func (c *Client) GetGroupByID(ctx context.Context, id int64) (*Group, error) {
+	groups, err := c.GetGroups(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range groups {
+		if groups[i].ID == id {
+			return &groups[i], nil
+		}
+	}
+	return nil, nil // Not found
+}
+
+// groupIDCache maps group names to IDs for GroupIDsByName. It is dropped
+// whenever a group is created, updated or deleted through the client.
+type groupIDCache struct {
+	mu  sync.Mutex
+	ids map[string]int64
+}
+
+// GroupIDsByName returns the IDs of the groups with the given names, in the
+// same order, and the names no group has. The groups are read on first use
+// and cached, so resolving the groups of many entries reads them once; a
+// name missing from the cache reads them again, in case the group was
+// created outside this client.
+func (c *Client) GroupIDsByName(ctx context.Context, names []string) ([]int64, []string, error) {
+	c.groupIDs.mu.Lock()
+	defer c.groupIDs.mu.Unlock()
+
+	ids, missing := c.groupIDs.lookup(names)
+	if c.groupIDs.ids != nil && len(missing) == 0 {
+		return ids, nil, nil
+	}
+
+	groups, err := c.GetGroups(ctx, "")
+	if err != nil {
+		return nil, nil, err
+	}
+	c.groupIDs.ids = make(map[string]int64, len(groups))
+	for _, g := range groups {
+		c.groupIDs.ids[g.Name] = g.ID
+	}
+
+	ids, missing = c.groupIDs.lookup(names)
+	return ids, missing, nil
+}
+
+// lookup returns the cached IDs of names and the names not in the cache.
+func (g *groupIDCache) lookup(names []string) ([]int64, []string) {
+	ids := make([]int64, 0, len(names))
+	var missing []string
+	for _, name := range names {
+		id, ok := g.ids[name]
+		if !ok {
+			missing = append(missing, name)
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return ids, missing
+}
+
+// invalidateGroupIDs drops the cache of GroupIDsByName.
+func (c *Client) invalidateGroupIDs() {
+	c.groupIDs.mu.Lock()
+	c.groupIDs.ids = nil
+	c.groupIDs.mu.Unlock()
+}
+
 // CreateGroup creates a new group.
 func (c *Client) CreateGroup(ctx context.Context, group *Group) (*Group, error) {
 	payload := map[string]interface{}{
@@ -54,6 +127,7 @@ func (c *Client) CreateGroup(ctx context.Context, group *Group) (*Group, error)
 		payload["comment"] = group.Description
 	}
 
+	defer c.invalidateGroupIDs()
 	resp, err := c.Post(ctx, "groups", payload)
 	if err != nil {
 		return nil, err
@@ -79,7 +153,8 @@ func (c *Client) UpdateGroup(ctx context.Context, name string, group *Group) (*G
 		"comment": group.Description,
 	}
 
-	path := fmt.Sprintf("groups/%s", url.PathEscape(name))
+	defer c.invalidateGroupIDs()
+	path := fmt.Sprintf("groups/%s", pathSegment(name))
 	resp, err := c.Put(ctx, path, payload)
 	if err != nil {
 		return nil, err
@@ -109,7 +184,8 @@ func (c *Client) UpdateGroup(ctx context.Context, name string, group *Group) (*G
 
 // DeleteGroup deletes a group by name.
 func (c *Client) DeleteGroup(ctx context.Context, name string) error {
-	path := fmt.Sprintf("groups/%s", url.PathEscape(name))
+	defer c.invalidateGroupIDs()
+	path := fmt.Sprintf("groups/%s", pathSegment(name))
 	_, err := c.Delete(ctx, path)
 	return err
 }
@@ -1,7 +1,7 @@
 // Copyright (c) 2025 dklesev
 // SPDX-License-Identifier: MIT
 
-package client
+package pihole
 
 import (
 	"context"
@@ -69,6 +69,22 @@ func TestClient_GetGroups(t *testing.T) {
 	if groups[0].Name != "Custom" {
 		t.Errorf("Expected group name 'Custom', got %q", groups[0].Name)
 	}
+
+	// Test get by ID
+	group, err := client.GetGroupByID(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetGroupByID(1) error = %v", err)
+	}
+	if group == nil || group.Name != "Custom" {
+		t.Errorf("Expected group 'Custom', got %+v", group)
+	}
+	group, err = client.GetGroupByID(ctx, 7)
+	if err != nil {
+		t.Fatalf("GetGroupByID(7) error = %v", err)
+	}
+	if group != nil {
+		t.Errorf("Expected no group for ID 7, got %+v", group)
+	}
 }
 
 func TestClient_CreateGroup(t *testing.T) {
@@ -228,3 +244,101 @@ func TestClient_DeleteGroup(t *testing.T) {
 		t.Error("Expected DELETE request to be made")
 	}
 }
+
+func TestClient_GroupIDsByName(t *testing.T) {
+	reads := 0
+	groups := []Group{
+		{ID: 0, Name: "Default"},
+		{ID: 3, Name: "kids"},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/auth":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"session": map[string]interface{}{
+					"valid": true,
+					"sid":   "test-sid",
+				},
+			})
+		case "/api/groups":
+			reads++
+			json.NewEncoder(w).Encode(GroupsResponse{Groups: groups})
+		case "/api/groups/iot":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(Config{URL: server.URL, Password: "test"})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	ctx := context.Background()
+
+	ids, missing, err := client.GroupIDsByName(ctx, []string{"kids", "Default"})
+	if err != nil {
+		t.Fatalf("GroupIDsByName() error = %v", err)
+	}
+	if len(ids) != 2 || ids[0] != 3 || ids[1] != 0 || len(missing) != 0 {
+		t.Errorf("GroupIDsByName() = %v, %v, want [3 0], []", ids, missing)
+	}
+
+	// Cached
+	if _, _, err := client.GroupIDsByName(ctx, []string{"kids"}); err != nil {
+		t.Fatalf("GroupIDsByName() error = %v", err)
+	}
+	if reads != 1 {
+		t.Errorf("Expected the groups to be read once, got %d reads", reads)
+	}
+
+	// A missing name reads the groups again
+	groups = append(groups, Group{ID: 4, Name: "iot"})
+	ids, missing, err = client.GroupIDsByName(ctx, []string{"iot", "guests"})
+	if err != nil {
+		t.Fatalf("GroupIDsByName() error = %v", err)
+	}
+	if len(ids) != 1 || ids[0] != 4 || len(missing) != 1 || missing[0] != "guests" {
+		t.Errorf("GroupIDsByName() = %v, %v, want [4], [guests]", ids, missing)
+	}
+	if reads != 2 {
+		t.Errorf("Expected 2 reads, got %d", reads)
+	}
+
+	// Deleting a group drops the cache
+	if err := client.DeleteGroup(ctx, "iot"); err != nil {
+		t.Fatalf("DeleteGroup() error = %v", err)
+	}
+	groups = groups[:2]
+	ids, missing, err = client.GroupIDsByName(ctx, []string{"iot"})
+	if err != nil {
+		t.Fatalf("GroupIDsByName() error = %v", err)
+	}
+	if len(ids) != 0 || len(missing) != 1 {
+		t.Errorf("GroupIDsByName() = %v, %v, want [], [iot]", ids, missing)
+	}
+	if reads != 3 {
+		t.Errorf("Expected 3 reads, got %d", reads)
+	}
+}
+
+func TestGroup_IsDefault(t *testing.T) {
+	tests := []struct {
+		name  string
+		group Group
+		want  bool
+	}{
+		{name: "built-in group", group: Group{ID: 0, Name: "Default"}, want: true},
+		{name: "renamed built-in group", group: Group{ID: 0, Name: "Everyone"}, want: true},
+		{name: "custom group named Default", group: Group{ID: 3, Name: "Default"}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.group.IsDefault(); got != tt.want {
+				t.Errorf("IsDefault() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
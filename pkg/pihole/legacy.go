@@ -0,0 +1,370 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package pihole
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+const (
+	// APIVersionLegacy selects the PHP API of Pi-hole v5 in
+	// Config.APIVersion.
+	APIVersionLegacy = 5
+
+	// APIVersionFTL selects the FTL API of Pi-hole v6 in Config.APIVersion.
+	// It is the default.
+	APIVersionFTL = 6
+)
+
+// legacyAPI is the PHP API of a Pi-hole v5 web interface, admin/api.php.
+// It only covers the domain lists and blocking; the client fails every
+// other request with an error matching ErrUnsupported.
+type legacyAPI struct {
+	url   *url.URL
+	token string
+}
+
+// legacyLists maps the type and kind of a domain to the name of its list in
+// the PHP API, and legacyTypes the type numbers of the gravity database.
+var (
+	legacyLists = map[[2]string]string{
+		{"allow", "exact"}: "white",
+		{"deny", "exact"}:  "black",
+		{"allow", "regex"}: "regex_white",
+		{"deny", "regex"}:  "regex_black",
+	}
+	legacyTypes = [][2]string{
+		{"allow", "exact"},
+		{"deny", "exact"},
+		{"allow", "regex"},
+		{"deny", "regex"},
+	}
+)
+
+// legacyTokenPattern matches the API token of Pi-hole v5, the WEBPASSWORD
+// hash in setupVars.conf.
+var legacyTokenPattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// newLegacyAPI returns the PHP API of the Pi-hole at rawURL. password is the
+// web interface password or the API token derived from it.
+func newLegacyAPI(rawURL, password string) (*legacyAPI, error) {
+	u, err := ParseURL(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := strings.Trim(u.EscapedPath(), "/")
+	prefix = strings.TrimSuffix(strings.TrimSuffix(prefix, "api.php"), "/")
+	if prefix != "admin" && !strings.HasSuffix(prefix, "/admin") {
+		prefix = strings.TrimPrefix(prefix+"/admin", "/")
+	}
+	apiURL, err := u.Parse("/" + prefix + "/api.php")
+	if err != nil {
+		return nil, err
+	}
+
+	return &legacyAPI{url: apiURL, token: legacyToken(password)}, nil
+}
+
+// legacyToken returns the API token for a Pi-hole v5 password: its double
+// SHA-256 hash, as the web interface stores it. A password that already is
+// such a hash is taken to be the token itself.
+func legacyToken(password string) string {
+	if password == "" || legacyTokenPattern.MatchString(password) {
+		return password
+	}
+	first := sha256.Sum256([]byte(password))
+	second := sha256.Sum256([]byte(hex.EncodeToString(first[:])))
+	return hex.EncodeToString(second[:])
+}
+
+// errLegacyUnauthorized is returned when the PHP API rejects the token.
+var errLegacyUnauthorized = errors.New("the Pi-hole v5 API rejected the password or API token")
+
+// legacyUnsupported returns the error for something the PHP API of Pi-hole v5
+// cannot do.
+func legacyUnsupported(what string) error {
+	return fmt.Errorf("%s is %w: with api_version 5 only domains and blocking can be managed", what, ErrUnsupported)
+}
+
+// legacyCall makes a request to the PHP API and decodes its JSON response
+// into v. The API has no methods; write tells the calls that change
+// something apart for read-only mode.
+func (c *Client) legacyCall(ctx context.Context, write bool, params url.Values, v interface{}) error {
+	if c.readOnly && write {
+		return fmt.Errorf("%w, refusing api.php?%s", ErrReadOnly, params.Encode())
+	}
+
+	query := url.Values{}
+	for k, vs := range params {
+		query[k] = vs
+	}
+	if c.legacy.token != "" {
+		query.Set("auth", c.legacy.token)
+	}
+	reqURL := *c.legacy.url
+	reqURL.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL.String(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	retryReq, err := retryablehttp.FromRequest(req)
+	if err != nil {
+		return fmt.Errorf("failed to create retryable request: %w", err)
+	}
+
+	release, err := c.acquireRequestSlot(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	path := "api.php?" + params.Encode()
+	start := time.Now()
+	resp, err := c.httpClient.Do(retryReq)
+	if err != nil {
+		// The URL in the error carries the token.
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			redacted := *c.legacy.url
+			redacted.RawQuery = params.Encode()
+			urlErr.URL = redacted.String()
+		}
+		c.observe(ctx, http.MethodGet, path, nil, start, 0, nil, err)
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	c.observe(ctx, http.MethodGet, path, nil, start, resp.StatusCode, body, err)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode >= 400 {
+		return newAPIError(resp, body)
+	}
+
+	// Without a valid token the API answers with this text, or an empty
+	// array for the status calls.
+	trimmed := bytes.TrimSpace(body)
+	if string(trimmed) == "Not authorized!" || string(trimmed) == "[]" {
+		return errLegacyUnauthorized
+	}
+
+	if err := json.Unmarshal(trimmed, v); err != nil {
+		return fmt.Errorf("failed to parse Pi-hole v5 API response %q: %w", truncate(string(trimmed), 200), err)
+	}
+	return nil
+}
+
+// truncate shortens s to at most n bytes for error messages.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
+
+// legacyAuthenticate checks the API token by reading the blocking status,
+// which the PHP API only reports to authorized callers.
+func (c *Client) legacyAuthenticate(ctx context.Context) error {
+	if _, err := c.legacyBlocking(ctx, url.Values{"status": {""}}); err != nil {
+		return fmt.Errorf("authentication failed: %w", err)
+	}
+	return nil
+}
+
+// legacyBlocking makes a status, enable or disable call.
+func (c *Client) legacyBlocking(ctx context.Context, params url.Values) (*DNSBlocking, error) {
+	var result struct {
+		Status string `json:"status"`
+	}
+	_, status := params["status"]
+	if err := c.legacyCall(ctx, !status, params, &result); err != nil {
+		return nil, err
+	}
+	if result.Status == "" {
+		return nil, fmt.Errorf("no blocking status in Pi-hole v5 API response")
+	}
+	return &DNSBlocking{Blocking: result.Status}, nil
+}
+
+// legacySetBlocking enables blocking or disables it, for timer seconds when
+// set. Pi-hole v5 does not report the time left, so the result has no timer.
+func (c *Client) legacySetBlocking(ctx context.Context, enabled bool, timer *float64) (*DNSBlocking, error) {
+	if enabled {
+		return c.legacyBlocking(ctx, url.Values{"enable": {""}})
+	}
+	seconds := 0
+	if timer != nil {
+		seconds = int(*timer)
+	}
+	return c.legacyBlocking(ctx, url.Values{"disable": {strconv.Itoa(seconds)}})
+}
+
+// legacyDomain is a domain entry as the PHP API lists it.
+type legacyDomain struct {
+	ID           int64      `json:"id"`
+	Type         int        `json:"type"`
+	Domain       string     `json:"domain"`
+	Enabled      legacyBool `json:"enabled"`
+	DateAdded    int64      `json:"date_added"`
+	DateModified int64      `json:"date_modified"`
+	Comment      string     `json:"comment"`
+	Groups       []int64    `json:"groups"`
+}
+
+// legacyBool decodes the 0 and 1 SQLite returns for booleans.
+type legacyBool bool
+
+func (b *legacyBool) UnmarshalJSON(data []byte) error {
+	switch strings.Trim(string(data), `"`) {
+	case "1", "true":
+		*b = true
+	case "0", "false", "null":
+		*b = false
+	default:
+		return fmt.Errorf("invalid boolean %s", data)
+	}
+	return nil
+}
+
+// legacyResult is the response of the add and sub calls.
+type legacyResult struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// legacyForEachDomain lists the domains of the PHP API like ForEachDomain.
+// The PHP API has a list per type and kind, which are read in turn.
+func (c *Client) legacyForEachDomain(ctx context.Context, domainType, kind, domain string, fn func(Domain) bool) error {
+	for _, tk := range legacyTypes {
+		if (domainType != "" && domainType != tk[0]) || (kind != "" && kind != tk[1]) {
+			continue
+		}
+
+		var result struct {
+			Data []legacyDomain `json:"data"`
+		}
+		if err := c.legacyCall(ctx, false, url.Values{"list": {legacyLists[tk]}}, &result); err != nil {
+			return err
+		}
+
+		for _, d := range result.Data {
+			if d.Type < 0 || d.Type >= len(legacyTypes) || (domain != "" && d.Domain != domain) {
+				continue
+			}
+			entry := Domain{
+				ID:           d.ID,
+				Domain:       d.Domain,
+				Type:         legacyTypes[d.Type][0],
+				Kind:         legacyTypes[d.Type][1],
+				Enabled:      bool(d.Enabled),
+				Comment:      d.Comment,
+				Groups:       d.Groups,
+				DateAdded:    d.DateAdded,
+				DateModified: d.DateModified,
+			}
+			if !fn(entry) {
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+// legacyCreateDomain adds a domain with the PHP API, which always creates
+// enabled entries in the Default group.
+func (c *Client) legacyCreateDomain(ctx context.Context, domain *Domain) (*Domain, error) {
+	list, err := legacyList(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{"list": {list}, "add": {domain.Domain}}
+	if domain.Comment != "" {
+		params.Set("comment", domain.Comment)
+	}
+	if err := c.legacyWrite(ctx, params); err != nil {
+		return nil, err
+	}
+
+	created, err := c.GetDomain(ctx, domain.Type, domain.Kind, domain.Domain)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch created domain: %w", err)
+	}
+	if created == nil {
+		return nil, fmt.Errorf("domain %s not found after adding it", domain.Domain)
+	}
+	return created, nil
+}
+
+// legacyUpdateDomain replaces a domain entry, as the PHP API cannot change
+// one in place.
+func (c *Client) legacyUpdateDomain(ctx context.Context, originalType, originalKind, originalDomain string, domain *Domain) (*Domain, error) {
+	if _, err := legacyList(domain); err != nil {
+		return nil, err
+	}
+	if err := c.legacyDeleteDomain(ctx, originalType, originalKind, originalDomain); err != nil {
+		return nil, err
+	}
+	return c.legacyCreateDomain(ctx, domain)
+}
+
+// legacyDeleteDomain removes a domain with the PHP API.
+func (c *Client) legacyDeleteDomain(ctx context.Context, domainType, kind, domain string) error {
+	list, ok := legacyLists[[2]string{domainType, kind}]
+	if !ok {
+		return fmt.Errorf("invalid domain type %q and kind %q", domainType, kind)
+	}
+	return c.legacyWrite(ctx, url.Values{"list": {list}, "sub": {domain}})
+}
+
+// legacyWrite makes an add or sub call and checks its result.
+func (c *Client) legacyWrite(ctx context.Context, params url.Values) error {
+	var result legacyResult
+	if err := c.legacyCall(ctx, true, params, &result); err != nil {
+		return err
+	}
+	if !result.Success {
+		if isUniqueViolation(result.Message) {
+			return fmt.Errorf("Pi-hole v5 API error: %s: %w", result.Message, ErrExists)
+		}
+		return fmt.Errorf("Pi-hole v5 API error: %s", result.Message)
+	}
+	return nil
+}
+
+// legacyList returns the PHP API list for a new domain entry, or an error
+// when the entry has settings the PHP API cannot make.
+func legacyList(domain *Domain) (string, error) {
+	list, ok := legacyLists[[2]string{domain.Type, domain.Kind}]
+	if !ok {
+		return "", fmt.Errorf("invalid domain type %q and kind %q", domain.Type, domain.Kind)
+	}
+	if !domain.Enabled {
+		return "", legacyUnsupported("adding a disabled domain")
+	}
+	if len(domain.Groups) > 0 && !slices.Equal(domain.Groups, []int64{DefaultGroupID}) {
+		return "", legacyUnsupported("adding a domain to groups other than Default")
+	}
+	return list, nil
+}
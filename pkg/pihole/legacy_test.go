@@ -0,0 +1,275 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package pihole
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// legacyServer fakes the PHP API of Pi-hole v5 with domain lists kept in
+// memory.
+type legacyServer struct {
+	mu       sync.Mutex
+	token    string
+	status   string
+	disabled string
+	domains  []legacyDomain
+	nextID   int64
+}
+
+func (s *legacyServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/admin/api.php" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	q := r.URL.Query()
+	authorized := q.Get("auth") == s.token
+	switch {
+	case q.Has("status"), q.Has("enable"), q.Has("disable"):
+		if !authorized {
+			fmt.Fprint(w, "[]")
+			return
+		}
+		if q.Has("enable") {
+			s.status = "enabled"
+		}
+		if q.Has("disable") {
+			s.status, s.disabled = "disabled", q.Get("disable")
+		}
+		json.NewEncoder(w).Encode(map[string]string{"status": s.status})
+	case q.Has("list"):
+		if !authorized {
+			fmt.Fprint(w, "Not authorized!")
+			return
+		}
+		listType := map[string]int{"white": 0, "black": 1, "regex_white": 2, "regex_black": 3}[q.Get("list")]
+		switch {
+		case q.Has("add"):
+			for _, d := range s.domains {
+				if d.Domain == q.Get("add") && d.Type == listType {
+					json.NewEncoder(w).Encode(legacyResult{Message: "UNIQUE constraint failed"})
+					return
+				}
+			}
+			s.nextID++
+			s.domains = append(s.domains, legacyDomain{
+				ID: s.nextID, Type: listType, Domain: q.Get("add"), Enabled: true,
+				Comment: q.Get("comment"), Groups: []int64{0}, DateAdded: 1700000000,
+			})
+			json.NewEncoder(w).Encode(legacyResult{Success: true})
+		case q.Has("sub"):
+			kept := s.domains[:0]
+			for _, d := range s.domains {
+				if d.Domain != q.Get("sub") || d.Type != listType {
+					kept = append(kept, d)
+				}
+			}
+			s.domains = kept
+			json.NewEncoder(w).Encode(legacyResult{Success: true})
+		default:
+			data := []map[string]interface{}{}
+			for _, d := range s.domains {
+				if d.Type == listType {
+					enabled := 0
+					if d.Enabled {
+						enabled = 1
+					}
+					data = append(data, map[string]interface{}{
+						"id": d.ID, "type": d.Type, "domain": d.Domain, "enabled": enabled,
+						"date_added": d.DateAdded, "date_modified": d.DateAdded, "comment": d.Comment, "groups": d.Groups,
+					})
+				}
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+		}
+	default:
+		fmt.Fprint(w, "[]")
+	}
+}
+
+func newLegacyTestClient(t *testing.T, password string) (*Client, *legacyServer) {
+	t.Helper()
+	fake := &legacyServer{token: legacyToken("secret"), status: "enabled"}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	c, err := New(Config{URL: server.URL, Password: password, APIVersion: APIVersionLegacy, RetryMax: -1})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	return c, fake
+}
+
+func TestLegacyToken(t *testing.T) {
+	// The WEBPASSWORD hash Pi-hole v5 stores for the password "secret".
+	const token = "3d91b58504a6cc3a159005ee7b16c7ae503ca6ac2a6a3c893837083c236b864a"
+	if got := legacyToken("secret"); got != token {
+		t.Errorf("legacyToken(secret) = %q, want %q", got, token)
+	}
+	if got := legacyToken(token); got != token {
+		t.Errorf("legacyToken(token) = %q, want the token unchanged", got)
+	}
+	if got := legacyToken(""); got != "" {
+		t.Errorf("legacyToken(\"\") = %q, want empty", got)
+	}
+}
+
+func TestNewLegacyAPI_URL(t *testing.T) {
+	tests := map[string]string{
+		"http://pi.hole":                         "http://pi.hole/admin/api.php",
+		"http://pi.hole/":                        "http://pi.hole/admin/api.php",
+		"http://pi.hole/admin":                   "http://pi.hole/admin/api.php",
+		"http://pi.hole/admin/api.php":           "http://pi.hole/admin/api.php",
+		"https://proxy.example.com/pihole/":      "https://proxy.example.com/pihole/admin/api.php",
+		"https://proxy.example.com/pihole/admin": "https://proxy.example.com/pihole/admin/api.php",
+	}
+	for raw, want := range tests {
+		api, err := newLegacyAPI(raw, "")
+		if err != nil {
+			t.Fatalf("newLegacyAPI(%q) error = %v", raw, err)
+		}
+		if got := api.url.String(); got != want {
+			t.Errorf("newLegacyAPI(%q) URL = %q, want %q", raw, got, want)
+		}
+	}
+}
+
+func TestClient_Legacy_Authenticate(t *testing.T) {
+	c, _ := newLegacyTestClient(t, "secret")
+	if err := c.Authenticate(context.Background()); err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+
+	c, _ = newLegacyTestClient(t, "wrong")
+	err := c.Authenticate(context.Background())
+	if !errors.Is(err, errLegacyUnauthorized) {
+		t.Fatalf("Authenticate() error = %v, want errLegacyUnauthorized", err)
+	}
+	if strings.Contains(err.Error(), legacyToken("wrong")) {
+		t.Errorf("Authenticate() error leaks the token: %v", err)
+	}
+}
+
+func TestClient_Legacy_Domains(t *testing.T) {
+	ctx := context.Background()
+	c, fake := newLegacyTestClient(t, "secret")
+
+	created, err := c.CreateDomain(ctx, &Domain{Domain: "ads.example.com", Type: "deny", Kind: "exact", Enabled: true, Comment: "ads"})
+	if err != nil {
+		t.Fatalf("CreateDomain() error = %v", err)
+	}
+	if created.ID != 1 || created.Type != "deny" || created.Kind != "exact" || !created.Enabled || created.Comment != "ads" {
+		t.Errorf("CreateDomain() = %+v", created)
+	}
+	if _, err := c.CreateDomain(ctx, &Domain{Domain: "ads.example.com", Type: "deny", Kind: "exact", Enabled: true}); !errors.Is(err, ErrExists) {
+		t.Errorf("CreateDomain() of an existing domain error = %v, want ErrExists", err)
+	}
+	if _, err := c.CreateDomain(ctx, &Domain{Domain: `(\.|^)example\.org$`, Type: "allow", Kind: "regex", Enabled: true}); err != nil {
+		t.Fatalf("CreateDomain() error = %v", err)
+	}
+
+	domains, err := c.GetDomains(ctx, "allow", "", "")
+	if err != nil {
+		t.Fatalf("GetDomains() error = %v", err)
+	}
+	if len(domains) != 1 || domains[0].Domain != `(\.|^)example\.org$` || domains[0].Kind != "regex" {
+		t.Errorf("GetDomains(allow) = %+v", domains)
+	}
+
+	updated, err := c.UpdateDomain(ctx, "deny", "exact", "ads.example.com", &Domain{Domain: "ads.example.com", Type: "deny", Kind: "exact", Enabled: true, Comment: "more ads"})
+	if err != nil {
+		t.Fatalf("UpdateDomain() error = %v", err)
+	}
+	if updated.Comment != "more ads" {
+		t.Errorf("UpdateDomain() comment = %q, want %q", updated.Comment, "more ads")
+	}
+
+	if err := c.DeleteDomain(ctx, "deny", "exact", "ads.example.com"); err != nil {
+		t.Fatalf("DeleteDomain() error = %v", err)
+	}
+	if d, err := c.GetDomain(ctx, "deny", "exact", "ads.example.com"); err != nil || d != nil {
+		t.Errorf("GetDomain() after delete = %+v, %v", d, err)
+	}
+	if len(fake.domains) != 1 {
+		t.Errorf("Expected 1 domain left, got %+v", fake.domains)
+	}
+}
+
+func TestClient_Legacy_DomainsUnsupported(t *testing.T) {
+	ctx := context.Background()
+	c, fake := newLegacyTestClient(t, "secret")
+
+	for name, d := range map[string]*Domain{
+		"disabled": {Domain: "a.example.com", Type: "deny", Kind: "exact"},
+		"groups":   {Domain: "b.example.com", Type: "deny", Kind: "exact", Enabled: true, Groups: []int64{0, 2}},
+	} {
+		if _, err := c.CreateDomain(ctx, d); !errors.Is(err, ErrUnsupported) {
+			t.Errorf("CreateDomain(%s) error = %v, want ErrUnsupported", name, err)
+		}
+	}
+	if len(fake.domains) != 0 {
+		t.Errorf("Expected no domains to be added, got %+v", fake.domains)
+	}
+
+	if _, err := c.GetLists(ctx, "", ""); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("GetLists() error = %v, want ErrUnsupported", err)
+	}
+}
+
+func TestClient_Legacy_Blocking(t *testing.T) {
+	ctx := context.Background()
+	c, fake := newLegacyTestClient(t, "secret")
+
+	timer := 300.0
+	blocking, err := c.SetDNSBlocking(ctx, false, &timer)
+	if err != nil {
+		t.Fatalf("SetDNSBlocking() error = %v", err)
+	}
+	if blocking.Blocking != "disabled" || fake.disabled != "300" {
+		t.Errorf("SetDNSBlocking(false, 300) = %+v, disable=%q", blocking, fake.disabled)
+	}
+
+	if _, err := c.SetDNSBlocking(ctx, true, nil); err != nil {
+		t.Fatalf("SetDNSBlocking() error = %v", err)
+	}
+	blocking, err = c.GetDNSBlocking(ctx)
+	if err != nil {
+		t.Fatalf("GetDNSBlocking() error = %v", err)
+	}
+	if blocking.Blocking != "enabled" || blocking.Timer != nil {
+		t.Errorf("GetDNSBlocking() = %+v", blocking)
+	}
+}
+
+func TestClient_Legacy_ReadOnly(t *testing.T) {
+	fake := &legacyServer{token: legacyToken("secret"), status: "enabled"}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	c, err := New(Config{URL: server.URL, Password: "secret", APIVersion: APIVersionLegacy, ReadOnly: true})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	ctx := context.Background()
+	if _, err := c.GetDNSBlocking(ctx); err != nil {
+		t.Fatalf("GetDNSBlocking() error = %v", err)
+	}
+	if _, err := c.SetDNSBlocking(ctx, false, nil); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("SetDNSBlocking() error = %v, want ErrReadOnly", err)
+	}
+	if fake.status != "enabled" {
+		t.Errorf("Expected blocking to stay enabled, got %q", fake.status)
+	}
+}
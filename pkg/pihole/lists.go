@@ -1,25 +1,28 @@
 // Copyright (c) 2025 dklesev
 // SPDX-License-Identifier: MIT
 
-package client
+package pihole
 
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/url"
+	"slices"
+	"time"
 )
 
 // GetLists retrieves all lists or a specific list.
 func (c *Client) GetLists(ctx context.Context, listType, address string) ([]List, error) {
 	path := "lists"
 	if address != "" {
-		path = fmt.Sprintf("lists/%s", url.PathEscape(address))
+		path = fmt.Sprintf("lists/%s", pathSegment(address))
 	}
 
 	// Add type query parameter if specified
 	if listType != "" {
-		path = fmt.Sprintf("%s?type=%s", path, listType)
+		path = fmt.Sprintf("%s?type=%s", path, url.QueryEscape(listType))
 	}
 
 	resp, err := c.Get(ctx, path)
@@ -52,6 +55,24 @@ func (c *Client) GetList(ctx context.Context, listType, address string) (*List,
 	return nil, nil // Not found
 }
 
+// GetListByID retrieves the list with the given ID. The API looks lists up
+// by address only, so all lists are read; this also finds lists whose
+// address shares with a list of the other type or does not survive path
+// encoding.
+func (c *Client) GetListByID(ctx context.Context, id int64) (*List, error) {
+	lists, err := c.GetLists(ctx, "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range lists {
+		if lists[i].ID == id {
+			return &lists[i], nil
+		}
+	}
+	return nil, nil // Not found
+}
+
 // CreateList creates a new list.
 func (c *Client) CreateList(ctx context.Context, list *List) (*List, error) {
 	if list.Type == "" {
@@ -69,7 +90,7 @@ func (c *Client) CreateList(ctx context.Context, list *List) (*List, error) {
 		payload["groups"] = list.Groups
 	}
 
-	path := fmt.Sprintf("lists?type=%s", list.Type)
+	path := fmt.Sprintf("lists?type=%s", url.QueryEscape(list.Type))
 	resp, err := c.Post(ctx, path, payload)
 	if err != nil {
 		return nil, err
@@ -79,6 +100,9 @@ func (c *Client) CreateList(ctx context.Context, list *List) (*List, error) {
 	if err := json.Unmarshal(resp, &result); err != nil {
 		return nil, fmt.Errorf("failed to parse create list response: %w", err)
 	}
+	if err := result.Processed.err(); err != nil {
+		return nil, err
+	}
 
 	if len(result.Lists) == 0 {
 		return nil, fmt.Errorf("no list returned in response")
@@ -96,7 +120,7 @@ func (c *Client) UpdateList(ctx context.Context, originalType, originalAddress s
 		"groups":  list.Groups,
 	}
 
-	path := fmt.Sprintf("lists/%s?type=%s", url.PathEscape(originalAddress), originalType)
+	path := fmt.Sprintf("lists/%s?type=%s", pathSegment(originalAddress), url.QueryEscape(originalType))
 	resp, err := c.Put(ctx, path, payload)
 	if err != nil {
 		return nil, err
@@ -124,7 +148,67 @@ func (c *Client) UpdateList(ctx context.Context, originalType, originalAddress s
 
 // DeleteList deletes a list.
 func (c *Client) DeleteList(ctx context.Context, listType, address string) error {
-	path := fmt.Sprintf("lists/%s?type=%s", url.PathEscape(address), listType)
+	path := fmt.Sprintf("lists/%s?type=%s", pathSegment(address), url.QueryEscape(listType))
 	_, err := c.Delete(ctx, path)
 	return err
 }
+
+// listGroupAttempts is how often SetListGroup writes a list's groups before
+// giving up on concurrent changes that keep overwriting them.
+const listGroupAttempts = 5
+
+// ErrConflict is wrapped by errors from read-modify-write changes that kept
+// being overwritten by concurrent changes.
+var ErrConflict = errors.New("concurrent changes kept overwriting the update")
+
+// SetListGroup adds the group to, or with member false removes it from, the
+// groups of an existing list, keeping its other groups and settings. The
+// list is read, changed and written back, then read again; when another
+// writer replaced the groups in between, the change is retried. Changes
+// through the same client are serialized.
+func (c *Client) SetListGroup(ctx context.Context, listType, address string, groupID int64, member bool) (*List, error) {
+	c.listGroupMu.Lock()
+	defer c.listGroupMu.Unlock()
+
+	wait := 100 * time.Millisecond
+	for attempt := 0; ; attempt++ {
+		if attempt > 1 {
+			// The last write was overwritten again; back off before
+			// reading the list for the next one.
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(wait):
+			}
+			wait *= 2
+		}
+
+		list, err := c.GetList(ctx, listType, address)
+		if err != nil {
+			return nil, err
+		}
+		if list == nil {
+			return nil, fmt.Errorf("%s list %s: %w", listType, address, ErrNotFound)
+		}
+		if slices.Contains(list.Groups, groupID) == member {
+			return list, nil
+		}
+		if attempt == listGroupAttempts {
+			return nil, fmt.Errorf("changing the groups of %s list %s: %w", listType, address, ErrConflict)
+		}
+
+		groups := make([]int64, 0, len(list.Groups)+1)
+		for _, g := range list.Groups {
+			if g != groupID {
+				groups = append(groups, g)
+			}
+		}
+		if member {
+			groups = append(groups, groupID)
+		}
+		list.Groups = groups
+		if _, err := c.UpdateList(ctx, listType, address, list); err != nil {
+			return nil, err
+		}
+	}
+}
@@ -1,13 +1,16 @@
 // Copyright (c) 2025 dklesev
 // SPDX-License-Identifier: MIT
 
-package client
+package pihole
 
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"slices"
+	"sync"
 	"testing"
 )
 
@@ -73,6 +76,22 @@ func TestClient_GetLists(t *testing.T) {
 	if lists[0].Type != "block" {
 		t.Errorf("Expected type 'block', got %q", lists[0].Type)
 	}
+
+	// Test get by ID
+	list, err := client.GetListByID(ctx, 2)
+	if err != nil {
+		t.Fatalf("GetListByID(2) error = %v", err)
+	}
+	if list == nil || list.Type != "allow" {
+		t.Errorf("Expected allowlist with ID 2, got %+v", list)
+	}
+	list, err = client.GetListByID(ctx, 3)
+	if err != nil {
+		t.Fatalf("GetListByID(3) error = %v", err)
+	}
+	if list != nil {
+		t.Errorf("Expected no list for ID 3, got %+v", list)
+	}
 }
 
 func TestClient_CreateList(t *testing.T) {
@@ -257,3 +276,84 @@ func TestClient_DeleteList(t *testing.T) {
 		t.Error("Expected DELETE request to be made")
 	}
 }
+
+func TestClient_SetListGroup(t *testing.T) {
+	var mu sync.Mutex
+	list := List{ID: 1, Address: "https://example.com/blocklist.txt", Type: "block", Enabled: true, Comment: "ads", Groups: []int64{0}}
+	// overwrites makes the next writes lose to a concurrent writer that
+	// puts back the groups it read earlier.
+	overwrites := 0
+	puts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case r.URL.Path == "/api/auth":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"session": map[string]interface{}{
+					"valid": true,
+					"sid":   "test-sid",
+				},
+			})
+		case r.URL.Path == "/api/lists/"+list.Address && r.Method == http.MethodGet:
+			json.NewEncoder(w).Encode(ListsResponse{Lists: []List{list}})
+		case r.URL.Path == "/api/lists/"+list.Address && r.Method == http.MethodPut:
+			puts++
+			var payload List
+			json.NewDecoder(r.Body).Decode(&payload)
+			if payload.Comment != "ads" || !payload.Enabled {
+				t.Errorf("Update did not keep the list settings: %+v", payload)
+			}
+			if overwrites > 0 {
+				overwrites--
+			} else {
+				list.Groups = payload.Groups
+			}
+			json.NewEncoder(w).Encode(ListsResponse{Lists: []List{list}})
+		case r.Method == http.MethodGet:
+			json.NewEncoder(w).Encode(ListsResponse{Lists: []List{}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(Config{URL: server.URL, Password: "test"})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	ctx := context.Background()
+
+	// A lost write is retried.
+	overwrites = 1
+	updated, err := client.SetListGroup(ctx, "block", list.Address, 3, true)
+	if err != nil {
+		t.Fatalf("SetListGroup() error = %v", err)
+	}
+	if !slices.Equal(updated.Groups, []int64{0, 3}) || puts != 2 {
+		t.Errorf("Groups = %v after %d writes, want [0 3] after 2", updated.Groups, puts)
+	}
+
+	// Nothing is written when the group is already assigned.
+	if _, err := client.SetListGroup(ctx, "block", list.Address, 3, true); err != nil || puts != 2 {
+		t.Errorf("SetListGroup() for assigned group = %v after %d writes", err, puts)
+	}
+
+	updated, err = client.SetListGroup(ctx, "block", list.Address, 0, false)
+	if err != nil {
+		t.Fatalf("SetListGroup() error = %v", err)
+	}
+	if !slices.Equal(updated.Groups, []int64{3}) {
+		t.Errorf("Groups = %v, want [3]", updated.Groups)
+	}
+
+	// Writes that keep being lost fail.
+	overwrites = listGroupAttempts
+	if _, err := client.SetListGroup(ctx, "block", list.Address, 5, true); !errors.Is(err, ErrConflict) {
+		t.Errorf("Expected ErrConflict, got %v", err)
+	}
+
+	if _, err := client.SetListGroup(ctx, "block", "https://example.com/missing.txt", 3, true); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for missing list, got %v", err)
+	}
+}
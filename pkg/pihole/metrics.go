@@ -0,0 +1,133 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package pihole
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RequestMetric describes a completed API request. It is passed to
+// Config.RequestObserver.
+type RequestMetric struct {
+	Method string
+	// Path is the request path below /api, without the query string.
+	Path string
+	// Endpoint groups requests to the same endpoint, e.g. "domains/deny" or
+	// "config/dns", leaving out names and values.
+	Endpoint string
+	// Body is the JSON request body, nil for requests without one.
+	Body []byte
+	// Status is the HTTP status code, or zero when no response was received.
+	Status int
+	// Duration is the wall time of the request including retries.
+	Duration time.Duration
+	// Took is the processing time reported by FTL in the response.
+	Took time.Duration
+	Err  error
+}
+
+// endpointName returns the endpoint a request path belongs to. Collections
+// whose second segment is user data are grouped under their first segment.
+func endpointName(path string) string {
+	path, _, _ = strings.Cut(path, "?")
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	switch {
+	case len(segments) == 1:
+		return segments[0]
+	case segments[0] == "lists", segments[0] == "clients", segments[0] == "groups":
+		return segments[0]
+	default:
+		return segments[0] + "/" + segments[1]
+	}
+}
+
+// responseTook extracts the "took" field (seconds) from an API response.
+func responseTook(body []byte) time.Duration {
+	var resp struct {
+		Took float64 `json:"took"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return 0
+	}
+	return time.Duration(resp.Took * float64(time.Second))
+}
+
+// EndpointMetrics aggregates the requests made to one endpoint.
+type EndpointMetrics struct {
+	Method    string
+	Endpoint  string
+	Requests  int
+	Errors    int
+	Total     time.Duration
+	Max       time.Duration
+	TotalTook time.Duration
+}
+
+// String formats the aggregate for logs.
+func (e EndpointMetrics) String() string {
+	return fmt.Sprintf("%s %s: %d requests, %d errors, total %s, avg %s, max %s, avg took %s",
+		e.Method, e.Endpoint, e.Requests, e.Errors,
+		e.Total.Round(time.Millisecond),
+		(e.Total / time.Duration(e.Requests)).Round(time.Millisecond),
+		e.Max.Round(time.Millisecond),
+		(e.TotalTook / time.Duration(e.Requests)).Round(time.Microsecond))
+}
+
+// Metrics aggregates request metrics per endpoint. It is safe for concurrent
+// use; the zero value is ready to use.
+type Metrics struct {
+	mu        sync.Mutex
+	endpoints map[string]*EndpointMetrics
+}
+
+// Record adds a request to the aggregate and returns the updated aggregate of
+// its endpoint.
+func (m *Metrics) Record(r RequestMetric) EndpointMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.endpoints == nil {
+		m.endpoints = make(map[string]*EndpointMetrics)
+	}
+	key := r.Method + " " + r.Endpoint
+	e, ok := m.endpoints[key]
+	if !ok {
+		e = &EndpointMetrics{Method: r.Method, Endpoint: r.Endpoint}
+		m.endpoints[key] = e
+	}
+
+	e.Requests++
+	if r.Err != nil {
+		e.Errors++
+	}
+	e.Total += r.Duration
+	if r.Duration > e.Max {
+		e.Max = r.Duration
+	}
+	e.TotalTook += r.Took
+	return *e
+}
+
+// Summary returns the aggregates, slowest endpoint (by total time) first.
+func (m *Metrics) Summary() []EndpointMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	summary := make([]EndpointMetrics, 0, len(m.endpoints))
+	for _, e := range m.endpoints {
+		summary = append(summary, *e)
+	}
+	sort.Slice(summary, func(i, j int) bool {
+		if summary[i].Total != summary[j].Total {
+			return summary[i].Total > summary[j].Total
+		}
+		return summary[i].Method+summary[i].Endpoint < summary[j].Method+summary[j].Endpoint
+	})
+	return summary
+}
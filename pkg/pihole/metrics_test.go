@@ -0,0 +1,89 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package pihole
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClient_RequestObserver(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/auth":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"session": map[string]interface{}{"valid": true, "sid": "test-sid", "validity": 1800},
+			})
+		case "/api/domains/deny/exact/ads.example.com":
+			json.NewEncoder(w).Encode(DomainsResponse{Took: 0.002})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	var metrics Metrics
+	var observed []RequestMetric
+	client, err := New(Config{
+		URL:      server.URL,
+		Password: "test",
+		RetryMax: -1,
+		RequestObserver: func(_ context.Context, m RequestMetric) {
+			observed = append(observed, m)
+			if e := metrics.Record(m); e.Endpoint != m.Endpoint || e.Requests == 0 {
+				t.Errorf("Unexpected aggregate for %s: %+v", m.Endpoint, e)
+			}
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx := context.Background()
+	client.Get(ctx, "domains/deny/exact/ads.example.com")
+	client.Get(ctx, "domains/deny/exact/ads.example.com")
+	client.Get(ctx, "lists/https%3A%2F%2Fexample.com%2Flist.txt?type=block")
+
+	if len(observed) != 3 {
+		t.Fatalf("Expected 3 observed requests, got %d", len(observed))
+	}
+	if got := observed[0]; got.Endpoint != "domains/deny" || got.Status != http.StatusOK || got.Took != 2*time.Millisecond || got.Err != nil {
+		t.Errorf("Unexpected metric: %+v", got)
+	}
+	if got := observed[2]; got.Endpoint != "lists" || got.Status != http.StatusNotFound || got.Err == nil {
+		t.Errorf("Unexpected metric: %+v", got)
+	}
+
+	summary := metrics.Summary()
+	if len(summary) != 2 {
+		t.Fatalf("Expected 2 endpoints, got %d: %v", len(summary), summary)
+	}
+	for _, e := range summary {
+		switch e.Endpoint {
+		case "domains/deny":
+			if e.Requests != 2 || e.Errors != 0 || e.TotalTook != 4*time.Millisecond {
+				t.Errorf("Unexpected domains aggregate: %+v", e)
+			}
+		case "lists":
+			if e.Requests != 1 || e.Errors != 1 {
+				t.Errorf("Unexpected lists aggregate: %+v", e)
+			}
+		default:
+			t.Errorf("Unexpected endpoint %q", e.Endpoint)
+		}
+	}
+
+	// The request body is passed on
+	client.Patch(ctx, "config", map[string]interface{}{"config": map[string]interface{}{}})
+	if got := observed[len(observed)-1]; got.Method != http.MethodPatch || string(got.Body) != `{"config":{}}` {
+		t.Errorf("Unexpected metric: %+v", got)
+	}
+	if observed[0].Body != nil {
+		t.Errorf("Expected no body for GET, got %q", observed[0].Body)
+	}
+}
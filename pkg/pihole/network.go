@@ -0,0 +1,40 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package pihole
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// GetNetworkDevices retrieves the devices in Pi-hole's network table.
+func (c *Client) GetNetworkDevices(ctx context.Context) ([]NetworkDevice, error) {
+	resp, err := c.Get(ctx, "network/devices")
+	if err != nil {
+		return nil, err
+	}
+
+	var result NetworkDevicesResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse network devices response: %w", err)
+	}
+
+	return result.Devices, nil
+}
+
+// GetNetworkInterfaces retrieves the network interfaces of the Pi-hole host.
+func (c *Client) GetNetworkInterfaces(ctx context.Context) ([]NetworkInterface, error) {
+	resp, err := c.Get(ctx, "network/interfaces")
+	if err != nil {
+		return nil, err
+	}
+
+	var result NetworkInterfacesResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse network interfaces response: %w", err)
+	}
+
+	return result.Interfaces, nil
+}
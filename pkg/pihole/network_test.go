@@ -0,0 +1,99 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package pihole
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_GetNetworkDevices(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/auth":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"session": map[string]interface{}{
+					"valid": true,
+					"sid":   "test-sid",
+				},
+			})
+		case "/api/network/devices":
+			json.NewEncoder(w).Encode(NetworkDevicesResponse{
+				Devices: []NetworkDevice{
+					{ID: 1, HWAddr: "aa:bb:cc:dd:ee:ff", Interface: "eth0", MACVendor: "Raspberry Pi Trading Ltd", IPs: []NetworkDeviceIP{
+						{IP: "192.168.1.10", Name: "pi.lan"},
+					}},
+					{ID: 2, HWAddr: "11:22:33:44:55:66", Interface: "eth0"},
+				},
+				Took: 0.001,
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(Config{URL: server.URL, Password: "test"})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	devices, err := client.GetNetworkDevices(context.Background())
+	if err != nil {
+		t.Fatalf("GetNetworkDevices() error = %v", err)
+	}
+	if len(devices) != 2 {
+		t.Fatalf("Expected 2 devices, got %d", len(devices))
+	}
+	if devices[0].MACVendor != "Raspberry Pi Trading Ltd" {
+		t.Errorf("Expected vendor 'Raspberry Pi Trading Ltd', got %q", devices[0].MACVendor)
+	}
+	if len(devices[0].IPs) != 1 || devices[0].IPs[0].Name != "pi.lan" {
+		t.Errorf("Unexpected IPs: %+v", devices[0].IPs)
+	}
+}
+
+func TestClient_GetNetworkInterfaces(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/auth":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"session": map[string]interface{}{
+					"valid": true,
+					"sid":   "test-sid",
+				},
+			})
+		case "/api/network/interfaces":
+			w.Write([]byte(`{"interfaces":[
+				{"name":"eth0","up":true,"type":"ether","speed":1000,"addresses":[{"address":"192.168.1.2"}]},
+				{"name":"wlan0","up":false,"type":"ether","speed":0}
+			],"took":0.001}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(Config{URL: server.URL, Password: "test"})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	interfaces, err := client.GetNetworkInterfaces(context.Background())
+	if err != nil {
+		t.Fatalf("GetNetworkInterfaces() error = %v", err)
+	}
+	if len(interfaces) != 2 {
+		t.Fatalf("Expected 2 interfaces, got %d", len(interfaces))
+	}
+	if interfaces[0].Name != "eth0" || !interfaces[0].Up || interfaces[0].Speed != 1000 {
+		t.Errorf("Unexpected interface: %+v", interfaces[0])
+	}
+	if interfaces[1].Up {
+		t.Errorf("Expected wlan0 to be down")
+	}
+}
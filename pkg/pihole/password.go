@@ -0,0 +1,72 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package pihole
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// AppPassword is an application password generated by Pi-hole. Only the
+// hash is stored on the server; the password itself cannot be read back.
+type AppPassword struct {
+	Password string `json:"password"`
+	Hash     string `json:"hash"`
+}
+
+// AppPasswordResponse represents the response from the auth/app endpoint.
+type AppPasswordResponse struct {
+	App  AppPassword `json:"app"`
+	Took float64     `json:"took"`
+}
+
+// GenerateAppPassword asks Pi-hole for a new application password. It does
+// not take effect until its hash is stored with SetAppPasswordHash.
+func (c *Client) GenerateAppPassword(ctx context.Context) (*AppPassword, error) {
+	resp, err := c.Get(ctx, "auth/app")
+	if err != nil {
+		return nil, err
+	}
+
+	var result AppPasswordResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse app password response: %w", err)
+	}
+
+	if result.App.Password == "" || result.App.Hash == "" {
+		return nil, fmt.Errorf("no app password returned in response")
+	}
+
+	return &result.App, nil
+}
+
+// SetAppPasswordHash activates an application password. An empty hash
+// removes the application password.
+func (c *Client) SetAppPasswordHash(ctx context.Context, hash string) error {
+	return c.UpdateConfig(ctx, "webserver", map[string]interface{}{
+		"api": map[string]interface{}{"app_pwhash": hash},
+	})
+}
+
+// SetPassword changes the web interface password. Pi-hole ends existing
+// sessions when the password changes, so the client switches to the new
+// password and logs in again on the next request.
+func (c *Client) SetPassword(ctx context.Context, password string) error {
+	err := c.UpdateConfig(ctx, "webserver", map[string]interface{}{
+		"api": map[string]interface{}{"password": password},
+	})
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.password = password
+	c.sid = ""
+	c.sidExpiry = time.Time{}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package pihole
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestClient_GenerateAppPassword(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/auth":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"session": map[string]interface{}{
+					"valid": true,
+					"sid":   "test-sid",
+				},
+			})
+		case "/api/auth/app":
+			json.NewEncoder(w).Encode(AppPasswordResponse{
+				App:  AppPassword{Password: "app-secret", Hash: "$BALLOON-SHA256$v=1$s=1024,t=32$hash"},
+				Took: 0.001,
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(Config{URL: server.URL, Password: "test"})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	app, err := client.GenerateAppPassword(context.Background())
+	if err != nil {
+		t.Fatalf("GenerateAppPassword() error = %v", err)
+	}
+	if app.Password != "app-secret" || app.Hash == "" {
+		t.Errorf("Unexpected app password: %+v", app)
+	}
+}
+
+func TestClient_SetPassword(t *testing.T) {
+	var mu sync.Mutex
+	current := "old"
+	var logins []string
+	var patch map[string]interface{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch {
+		case r.URL.Path == "/api/auth" && r.Method == http.MethodGet:
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"session": map[string]interface{}{"valid": false},
+			})
+		case r.URL.Path == "/api/auth" && r.Method == http.MethodPost:
+			var body map[string]string
+			json.NewDecoder(r.Body).Decode(&body)
+			logins = append(logins, body["password"])
+			if body["password"] != current {
+				w.WriteHeader(http.StatusUnauthorized)
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"error": map[string]interface{}{"key": "unauthorized", "message": "Wrong password"},
+				})
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"session": map[string]interface{}{"valid": true, "sid": "sid-" + current, "validity": 1800},
+			})
+		case r.URL.Path == "/api/config" && r.Method == http.MethodPatch:
+			json.NewDecoder(r.Body).Decode(&patch)
+			current = "new"
+			json.NewEncoder(w).Encode(map[string]interface{}{"took": 0.001})
+		case r.URL.Path == "/api/groups":
+			json.NewEncoder(w).Encode(GroupsResponse{})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(Config{URL: server.URL, Password: "old", RetryMax: -1})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx := context.Background()
+	if err := client.Authenticate(ctx); err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+
+	if err := client.SetPassword(ctx, "new"); err != nil {
+		t.Fatalf("SetPassword() error = %v", err)
+	}
+
+	api := patch["config"].(map[string]interface{})["webserver"].(map[string]interface{})["api"].(map[string]interface{})
+	if api["password"] != "new" {
+		t.Errorf("Expected webserver.api.password 'new' in PATCH, got %v", api["password"])
+	}
+
+	// The next request must log in again with the new password
+	if _, err := client.GetGroups(ctx, ""); err != nil {
+		t.Fatalf("GetGroups() after password change error = %v", err)
+	}
+	if len(logins) != 2 || logins[1] != "new" {
+		t.Errorf("Expected re-login with new password, got logins %v", logins)
+	}
+}
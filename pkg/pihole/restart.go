@@ -0,0 +1,117 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package pihole
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	// restartPollMin and restartPollMax bound the exponential backoff
+	// between readiness polls.
+	restartPollMin = 250 * time.Millisecond
+	restartPollMax = 5 * time.Second
+)
+
+// ErrNotReady is returned when the Pi-hole API does not answer again within
+// Config.WaitForRestart.
+var ErrNotReady = errors.New("Pi-hole API not ready")
+
+// isConnectionRefused reports whether err means nothing is listening on the
+// Pi-hole port, as happens while FTL restarts.
+func isConnectionRefused(err error) bool {
+	return errors.Is(err, syscall.ECONNREFUSED)
+}
+
+// isConfigWrite reports whether a request changes the FTL configuration and
+// may therefore make FTL restart.
+func isConfigWrite(method, path string) bool {
+	if method == http.MethodGet {
+		return false
+	}
+	return path == "config" || strings.HasPrefix(path, "config/") || strings.HasPrefix(path, "config?")
+}
+
+// noRestartWaitKey is the context key set by WithoutRestartWait.
+type noRestartWaitKey struct{}
+
+// WithoutRestartWait returns a context whose configuration writes return as
+// soon as Pi-hole accepted them, without waiting for FTL to restart. Use it
+// for writes after which the client's URL no longer reaches Pi-hole, such as
+// moving the webserver to another port.
+func WithoutRestartWait(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noRestartWaitKey{}, true)
+}
+
+// waitsForRestart reports whether configuration writes made with ctx wait
+// for FTL to restart.
+func waitsForRestart(ctx context.Context) bool {
+	skip, _ := ctx.Value(noRestartWaitKey{}).(bool)
+	return !skip
+}
+
+// WaitForReady polls the unauthenticated auth endpoint until Pi-hole answers,
+// backing off exponentially, for at most Config.WaitForRestart. It returns
+// immediately when waiting for restarts is disabled, and with the context's
+// error when ctx is canceled first.
+func (c *Client) WaitForReady(ctx context.Context) error {
+	if c.waitForRestart <= 0 {
+		return nil
+	}
+
+	parent := ctx
+	ctx, cancel := context.WithTimeout(ctx, c.waitForRestart)
+	defer cancel()
+
+	authURL := c.baseURL.JoinPath("auth").String()
+	wait := restartPollMin
+	for {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, authURL, nil)
+		if err != nil {
+			return fmt.Errorf("failed to create readiness request: %w", err)
+		}
+
+		// Bypass the retrying client; the loop does its own backoff.
+		resp, err := c.httpClient.HTTPClient.Do(req)
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode < http.StatusInternalServerError {
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			if err := parent.Err(); err != nil {
+				return fmt.Errorf("stopped waiting for the Pi-hole API: %w", err)
+			}
+			return fmt.Errorf("%w after %s", ErrNotReady, c.waitForRestart)
+		case <-time.After(wait):
+		}
+
+		wait *= 2
+		if wait > restartPollMax {
+			wait = restartPollMax
+		}
+	}
+}
+
+// invalidateSession drops the session sid, which Pi-hole rejected, so the
+// next request logs in again. When a parallel request that was rejected as
+// well already replaced it, the new session stays.
+func (c *Client) invalidateSession(sid string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.sid != sid {
+		return
+	}
+	c.sid = ""
+	c.sidExpiry = time.Time{}
+}
@@ -0,0 +1,252 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package pihole
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestClient_Request_WaitsForRestart(t *testing.T) {
+	// Reserve an address and close it, as if FTL were restarting.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/auth":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"session": map[string]interface{}{"valid": true, "sid": "test-sid", "validity": 1800},
+			})
+		case "/api/groups":
+			json.NewEncoder(w).Encode(GroupsResponse{Groups: []Group{{ID: 0, Name: "Default"}}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	started := make(chan error, 1)
+	go func() {
+		time.Sleep(500 * time.Millisecond)
+		l, err := net.Listen("tcp", addr)
+		if err != nil {
+			started <- err
+			return
+		}
+		server.Listener = l
+		server.Start()
+		started <- nil
+	}()
+
+	client, err := New(Config{URL: "http://" + addr, Password: "test", RetryMax: -1, WaitForRestart: 10 * time.Second})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	groups, err := client.GetGroups(context.Background(), "")
+	if startErr := <-started; startErr != nil {
+		t.Skipf("Could not reopen %s: %v", addr, startErr)
+	}
+	if err != nil {
+		t.Fatalf("GetGroups() error = %v", err)
+	}
+	if len(groups) != 1 {
+		t.Errorf("Expected 1 group, got %d", len(groups))
+	}
+}
+
+func TestClient_Request_NoWaitByDefault(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+
+	client, err := New(Config{URL: "http://" + addr, Password: "test", RetryMax: -1})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	if _, err := client.GetGroups(context.Background(), ""); err == nil || !isConnectionRefused(err) {
+		t.Errorf("Expected connection refused error, got %v", err)
+	}
+}
+
+func TestClient_WaitForReady_Canceled(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+
+	client, err := New(Config{URL: "http://" + addr, Password: "test", WaitForRestart: time.Minute})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err = client.WaitForReady(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) || errors.Is(err, ErrNotReady) {
+		t.Errorf("Expected context.DeadlineExceeded rather than ErrNotReady, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("WaitForReady() returned %s after the context expired", elapsed)
+	}
+}
+
+func TestClient_Request_SessionLostInRestart(t *testing.T) {
+	var mu sync.Mutex
+	logins := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch {
+		case r.URL.Path == "/api/auth" && r.Method == http.MethodGet:
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"session": map[string]interface{}{"valid": false},
+			})
+		case r.URL.Path == "/api/auth" && r.Method == http.MethodPost:
+			logins++
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"session": map[string]interface{}{"valid": true, "sid": "sid-" + string(rune('0'+logins)), "validity": 1800},
+			})
+		case r.URL.Path == "/api/groups":
+			// Only the session from after the restart is known
+			if r.Header.Get("sid") != "sid-2" {
+				w.WriteHeader(http.StatusUnauthorized)
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"error": map[string]interface{}{"key": "unauthorized", "message": "Unauthorized"},
+				})
+				return
+			}
+			json.NewEncoder(w).Encode(GroupsResponse{})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(Config{URL: server.URL, Password: "test", RetryMax: -1, WaitForRestart: 5 * time.Second})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	if _, err := client.GetGroups(context.Background(), ""); err != nil {
+		t.Fatalf("GetGroups() error = %v", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if logins != 2 {
+		t.Errorf("Expected 2 logins, got %d", logins)
+	}
+}
+
+func TestClient_ConfigWrite_WaitsForReady(t *testing.T) {
+	var mu sync.Mutex
+	patched := false
+	polls := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch {
+		case r.URL.Path == "/api/auth":
+			// FTL answers 503 while it is coming back up
+			if patched {
+				polls++
+				if polls < 3 {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"session": map[string]interface{}{"valid": true, "sid": "test-sid", "validity": 1800},
+			})
+		case r.URL.Path == "/api/config" && r.Method == http.MethodPatch:
+			patched = true
+			json.NewEncoder(w).Encode(map[string]interface{}{"took": 0.001})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(Config{URL: server.URL, Password: "test", RetryMax: -1, WaitForRestart: 5 * time.Second})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	if _, err := client.Patch(context.Background(), "config", map[string]interface{}{"config": map[string]interface{}{}}); err != nil {
+		t.Fatalf("Patch() error = %v", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if polls != 3 {
+		t.Errorf("Expected 3 readiness polls, got %d", polls)
+	}
+}
+
+func TestClient_ConfigWrite_WithoutRestartWait(t *testing.T) {
+	var mu sync.Mutex
+	patched := false
+	polls := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch {
+		case r.URL.Path == "/api/auth":
+			// FTL moved to another port, so nothing answers here any more
+			if patched {
+				polls++
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"session": map[string]interface{}{"valid": true, "sid": "test-sid", "validity": 1800},
+			})
+		case r.URL.Path == "/api/config" && r.Method == http.MethodPatch:
+			patched = true
+			json.NewEncoder(w).Encode(map[string]interface{}{"took": 0.001})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(Config{URL: server.URL, Password: "test", RetryMax: -1, WaitForRestart: 5 * time.Second})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx := WithoutRestartWait(context.Background())
+	if err := client.UpdateConfig(ctx, "webserver", map[string]interface{}{"port": "8080o"}); err != nil {
+		t.Fatalf("UpdateConfig() error = %v", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if polls != 0 {
+		t.Errorf("Expected no readiness polls, got %d", polls)
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package pihole
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+// RetryError wraps the final error of a request that was retried, so the
+// diagnostic tells how hard the client tried.
+type RetryError struct {
+	Retries    int
+	Elapsed    time.Duration
+	LastStatus int // zero when the last attempt got no response
+	Err        error
+}
+
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("%s (%s)", e.Err, e.Summary())
+}
+
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
+
+// Summary describes the retries, e.g. "3 retries over 12s, last status 503".
+func (e *RetryError) Summary() string {
+	retries := "retries"
+	if e.Retries == 1 {
+		retries = "retry"
+	}
+	summary := fmt.Sprintf("%d %s over %s", e.Retries, retries, e.Elapsed.Round(100*time.Millisecond))
+	if e.LastStatus != 0 {
+		summary += fmt.Sprintf(", last status %d", e.LastStatus)
+	}
+	return summary
+}
+
+// retryStatsKey is the context key of the retryStats of a request.
+type retryStatsKey struct{}
+
+// retryStats records the attempts of one request through the retry hooks.
+type retryStats struct {
+	retries    int
+	lastStatus int
+}
+
+// withRetryStats returns a context that collects the retries of a request.
+func withRetryStats(ctx context.Context) (context.Context, *retryStats) {
+	stats := &retryStats{}
+	return context.WithValue(ctx, retryStatsKey{}, stats), stats
+}
+
+// recordRetryAttempt is a RequestLogHook that counts retries.
+func recordRetryAttempt(_ retryablehttp.Logger, req *http.Request, attempt int) {
+	if stats, ok := req.Context().Value(retryStatsKey{}).(*retryStats); ok {
+		stats.retries = attempt
+		stats.lastStatus = 0
+	}
+}
+
+// recordRetryResponse is a ResponseLogHook that keeps the last status.
+func recordRetryResponse(_ retryablehttp.Logger, resp *http.Response) {
+	if stats, ok := resp.Request.Context().Value(retryStatsKey{}).(*retryStats); ok {
+		stats.lastStatus = resp.StatusCode
+	}
+}
+
+// retryErrorHandler hands the last response to the caller once retries are
+// exhausted, so Pi-hole's error message is kept instead of a generic
+// "giving up" error.
+func retryErrorHandler(resp *http.Response, err error, _ int) (*http.Response, error) {
+	if err != nil && resp != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	return resp, err
+}
+
+// jitterBackoff is DefaultBackoff with each wait drawn from its upper half,
+// so clients that failed together do not retry in lockstep. A Retry-After
+// header is honoured as is.
+func jitterBackoff(min, max time.Duration, attempt int, resp *http.Response) time.Duration {
+	wait := retryablehttp.DefaultBackoff(min, max, attempt, resp)
+	if resp != nil && resp.Header.Get("Retry-After") != "" {
+		return wait
+	}
+	if wait <= 1 {
+		return wait
+	}
+	return wait/2 + rand.N(wait/2+1)
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package pihole
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_Request_RetrySummary(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/auth" {
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"session": map[string]interface{}{"valid": true, "sid": "test-sid", "validity": 1800},
+			})
+			return
+		}
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"error": map[string]interface{}{"key": "busy", "message": "Database busy"},
+		})
+	}))
+	defer server.Close()
+
+	client, err := New(Config{
+		URL:          server.URL,
+		Password:     "test",
+		RetryMax:     2,
+		RetryWaitMin: time.Millisecond,
+		RetryWaitMax: 2 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	_, err = client.Get(context.Background(), "groups")
+	if err == nil {
+		t.Fatal("Get() should fail")
+	}
+	if got := calls.Load(); got != 3 {
+		t.Errorf("Expected 3 attempts, got %d", got)
+	}
+
+	var retryErr *RetryError
+	if !errors.As(err, &retryErr) {
+		t.Fatalf("Expected a RetryError, got %T: %v", err, err)
+	}
+	if retryErr.Retries != 2 || retryErr.LastStatus != http.StatusServiceUnavailable {
+		t.Errorf("RetryError = %+v, want 2 retries and last status 503", retryErr)
+	}
+	if !strings.Contains(err.Error(), "Database busy") || !strings.Contains(err.Error(), "2 retries over") ||
+		!strings.Contains(err.Error(), "last status 503") {
+		t.Errorf("Error should carry Pi-hole's message and the retry summary, got %v", err)
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Expected the APIError to be wrapped, got %v", err)
+	}
+}
+
+func TestClient_Request_RetrySucceeds(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/auth" {
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"session": map[string]interface{}{"valid": true, "sid": "test-sid", "validity": 1800},
+			})
+			return
+		}
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		json.NewEncoder(w).Encode(GroupsResponse{Groups: []Group{{ID: 0, Name: "Default"}}})
+	}))
+	defer server.Close()
+
+	client, err := New(Config{URL: server.URL, Password: "test", RetryWaitMin: time.Millisecond, RetryWaitMax: time.Millisecond})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	if _, err := client.GetGroups(context.Background(), ""); err != nil {
+		t.Fatalf("GetGroups() error = %v", err)
+	}
+}
+
+func TestClient_Request_CanceledDuringRetry(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/auth" {
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"session": map[string]interface{}{"valid": true, "sid": "test-sid", "validity": 1800},
+			})
+			return
+		}
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client, err := New(Config{URL: server.URL, Password: "test"})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err = client.Get(ctx, "groups")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Get() waited %s for the retry instead of returning once the context expired", elapsed)
+	}
+}
+
+func TestRetryError_Summary(t *testing.T) {
+	err := &RetryError{Retries: 1, Elapsed: 2340 * time.Millisecond, Err: errors.New("request failed")}
+	if got, want := err.Error(), "request failed (1 retry over 2.3s)"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestJitterBackoff(t *testing.T) {
+	for attempt := 0; attempt < 5; attempt++ {
+		max := time.Second << attempt
+		if max > 10*time.Second {
+			max = 10 * time.Second
+		}
+		for i := 0; i < 50; i++ {
+			wait := jitterBackoff(time.Second, 10*time.Second, attempt, nil)
+			if wait < max/2 || wait > max {
+				t.Fatalf("attempt %d: wait %s outside [%s, %s]", attempt, wait, max/2, max)
+			}
+		}
+	}
+
+	resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": {"7"}}}
+	if wait := jitterBackoff(time.Second, 10*time.Second, 0, resp); wait != 7*time.Second {
+		t.Errorf("Retry-After should be honoured, got %s", wait)
+	}
+}
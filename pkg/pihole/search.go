@@ -0,0 +1,32 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package pihole
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// MaxSearchResults is the number of matches Search requests per kind of
+// entry, enough for every rule that can match one domain.
+const MaxSearchResults = 10000
+
+// Search finds the domain entries, including matching regex rules, and the
+// gravity lists that contain domain. With partial, entries that contain
+// domain as a substring match as well.
+func (c *Client) Search(ctx context.Context, domain string, partial bool) (*SearchResult, error) {
+	path := fmt.Sprintf("search/%s?partial=%t&N=%d", pathSegment(domain), partial, MaxSearchResults)
+	resp, err := c.Get(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	var result SearchResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse search response: %w", err)
+	}
+
+	return &result.Search, nil
+}
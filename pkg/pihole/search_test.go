@@ -0,0 +1,59 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package pihole
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_Search(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/auth":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"session": map[string]interface{}{"valid": true, "sid": "test-sid"},
+			})
+		case "/api/search/ads.example.com":
+			if got := r.URL.RawQuery; got != "partial=false&N=10000" {
+				t.Errorf("query = %q", got)
+			}
+			w.Write([]byte(`{
+				"search": {
+					"domains": [
+						{"id": 4, "domain": "(\\.|^)example\\.com$", "type": "deny", "kind": "regex", "enabled": true, "groups": [0, 2]}
+					],
+					"gravity": [
+						{"id": 1, "domain": "ads.example.com", "address": "https://example.com/hosts.txt", "type": "block", "enabled": true, "groups": [0]}
+					],
+					"results": {"domains": {"exact": 0, "regex": 1}, "gravity": {"allow": 0, "block": 1}, "total": 2},
+					"parameters": {"N": 10000, "partial": false, "domain": "ads.example.com", "debug": false}
+				},
+				"took": 0.002
+			}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(Config{URL: server.URL, Password: "test"})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	result, err := client.Search(context.Background(), "ads.example.com", false)
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if len(result.Domains) != 1 || result.Domains[0].Kind != "regex" || len(result.Domains[0].Groups) != 2 {
+		t.Errorf("Unexpected domains: %+v", result.Domains)
+	}
+	if len(result.Gravity) != 1 || result.Gravity[0].Address != "https://example.com/hosts.txt" || result.Gravity[0].Type != "block" {
+		t.Errorf("Unexpected gravity matches: %+v", result.Gravity)
+	}
+}
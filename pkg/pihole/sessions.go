@@ -0,0 +1,26 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package pihole
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// GetSessions retrieves the login sessions Pi-hole currently holds,
+// including the provider's own.
+func (c *Client) GetSessions(ctx context.Context) ([]Session, error) {
+	resp, err := c.Get(ctx, "auth/sessions")
+	if err != nil {
+		return nil, err
+	}
+
+	var result SessionsResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse sessions response: %w", err)
+	}
+
+	return result.Sessions, nil
+}
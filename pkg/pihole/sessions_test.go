@@ -0,0 +1,62 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package pihole
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_GetSessions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/auth":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"session": map[string]interface{}{
+					"valid": true,
+					"sid":   "test-sid",
+				},
+			})
+		case "/api/auth/sessions":
+			w.Write([]byte(`{
+				"sessions": [
+					{"id": 0, "current_session": true, "valid": true, "tls": {"login": true, "mixed": false},
+					 "app": false, "cli": false, "login_at": 1700000000, "last_active": 1700000100,
+					 "valid_until": 1700001900, "remote_addr": "192.168.1.20", "user_agent": "terraform-provider-pihole",
+					 "x_forwarded_for": null},
+					{"id": 1, "current_session": false, "valid": true, "tls": {"login": false, "mixed": false},
+					 "app": true, "cli": false, "login_at": 1700000000, "last_active": 1700000000,
+					 "valid_until": 1700001800, "remote_addr": "10.0.0.5", "user_agent": "curl/8.5.0",
+					 "x_forwarded_for": "203.0.113.7"}
+				],
+				"took": 0.001
+			}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(Config{URL: server.URL, Password: "test"})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	sessions, err := client.GetSessions(context.Background())
+	if err != nil {
+		t.Fatalf("GetSessions() error = %v", err)
+	}
+	if len(sessions) != 2 {
+		t.Fatalf("Expected 2 sessions, got %d", len(sessions))
+	}
+	if !sessions[0].CurrentSession || !sessions[0].TLS.Login || sessions[0].XForwardedFor != nil {
+		t.Errorf("Unexpected first session: %+v", sessions[0])
+	}
+	if !sessions[1].App || sessions[1].RemoteAddr != "10.0.0.5" || sessions[1].XForwardedFor == nil || *sessions[1].XForwardedFor != "203.0.113.7" {
+		t.Errorf("Unexpected second session: %+v", sessions[1])
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package pihole
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// GetUpstreamStats retrieves the per-upstream query statistics FTL keeps
+// for the last 24 hours.
+func (c *Client) GetUpstreamStats(ctx context.Context) (*UpstreamStatsResponse, error) {
+	resp, err := c.Get(ctx, "stats/upstreams")
+	if err != nil {
+		return nil, err
+	}
+
+	var result UpstreamStatsResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse upstream stats response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// GetQueryTypes retrieves the number of queries per DNS record type FTL
+// keeps for the last 24 hours.
+func (c *Client) GetQueryTypes(ctx context.Context) (*QueryTypesResponse, error) {
+	resp, err := c.Get(ctx, "stats/query_types")
+	if err != nil {
+		return nil, err
+	}
+
+	var result QueryTypesResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse query types response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// GetStatsSummary retrieves the query, client and gravity counters FTL
+// keeps for the last 24 hours.
+func (c *Client) GetStatsSummary(ctx context.Context) (*StatsSummaryResponse, error) {
+	resp, err := c.Get(ctx, "stats/summary")
+	if err != nil {
+		return nil, err
+	}
+
+	var result StatsSummaryResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse stats summary response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// GetFTLInfo retrieves FTL's runtime information, including the gravity
+// database counters.
+func (c *Client) GetFTLInfo(ctx context.Context) (*FTLInfoResponse, error) {
+	resp, err := c.Get(ctx, "info/ftl")
+	if err != nil {
+		return nil, err
+	}
+
+	var result FTLInfoResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse FTL info response: %w", err)
+	}
+
+	return &result, nil
+}
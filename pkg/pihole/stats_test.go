@@ -0,0 +1,191 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package pihole
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_GetUpstreamStats(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/auth":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"session": map[string]interface{}{
+					"valid": true,
+					"sid":   "test-sid",
+				},
+			})
+		case "/api/stats/upstreams":
+			w.Write([]byte(`{
+				"upstreams": [
+					{"ip": "blocklist", "name": "blocklist", "port": -1, "count": 10, "statistics": {"response": 0, "variance": 0}},
+					{"ip": "8.8.8.8", "name": "dns.google", "port": 53, "count": 42, "statistics": {"response": 0.012, "variance": 0.001}}
+				],
+				"forwarded_queries": 42,
+				"total_queries": 60,
+				"took": 0.001
+			}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(Config{URL: server.URL, Password: "test"})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	stats, err := client.GetUpstreamStats(context.Background())
+	if err != nil {
+		t.Fatalf("GetUpstreamStats() error = %v", err)
+	}
+	if stats.ForwardedQueries != 42 {
+		t.Errorf("Expected 42 forwarded queries, got %d", stats.ForwardedQueries)
+	}
+	if len(stats.Upstreams) != 2 {
+		t.Fatalf("Expected 2 upstreams, got %d", len(stats.Upstreams))
+	}
+	if u := stats.Upstreams[1]; u.IP != "8.8.8.8" || u.Port != 53 || u.Statistics.Response != 0.012 {
+		t.Errorf("Unexpected upstream: %+v", u)
+	}
+}
+
+func TestClient_GetQueryTypes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/auth":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"session": map[string]interface{}{
+					"valid": true,
+					"sid":   "test-sid",
+				},
+			})
+		case "/api/stats/query_types":
+			w.Write([]byte(`{
+				"types": {"A": 700, "AAAA": 200, "HTTPS": 80, "PTR": 20, "OTHER": 0},
+				"took": 0.001
+			}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(Config{URL: server.URL, Password: "test"})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	stats, err := client.GetQueryTypes(context.Background())
+	if err != nil {
+		t.Fatalf("GetQueryTypes() error = %v", err)
+	}
+	if len(stats.Types) != 5 {
+		t.Errorf("Expected 5 query types, got %d", len(stats.Types))
+	}
+	if stats.Types["HTTPS"] != 80 {
+		t.Errorf("Expected 80 HTTPS queries, got %d", stats.Types["HTTPS"])
+	}
+}
+
+func TestClient_GetStatsSummary(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/auth":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"session": map[string]interface{}{
+					"valid": true,
+					"sid":   "test-sid",
+				},
+			})
+		case "/api/stats/summary":
+			w.Write([]byte(`{
+				"queries": {
+					"total": 7497, "blocked": 3465, "percent_blocked": 46.22, "unique_domains": 445,
+					"forwarded": 4021, "cached": 11, "frequency": 1.1,
+					"types": {"A": 3643}, "status": {"GRAVITY": 3465}, "replies": {"IP": 3700}
+				},
+				"clients": {"active": 10, "total": 22},
+				"gravity": {"domains_being_blocked": 104873, "last_update": 1725194639},
+				"took": 0.003
+			}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(Config{URL: server.URL, Password: "test"})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	summary, err := client.GetStatsSummary(context.Background())
+	if err != nil {
+		t.Fatalf("GetStatsSummary() error = %v", err)
+	}
+	if q := summary.Queries; q.Total != 7497 || q.Blocked != 3465 || q.PercentBlocked != 46.22 || q.Cached != 11 {
+		t.Errorf("Unexpected query counters: %+v", q)
+	}
+	if summary.Clients.Active != 10 || summary.Clients.Total != 22 {
+		t.Errorf("Unexpected client counters: %+v", summary.Clients)
+	}
+	if g := summary.Gravity; g.DomainsBeingBlocked != 104873 || g.LastUpdate != 1725194639 {
+		t.Errorf("Unexpected gravity counters: %+v", g)
+	}
+}
+
+func TestClient_GetFTLInfo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/auth":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"session": map[string]interface{}{
+					"valid": true,
+					"sid":   "test-sid",
+				},
+			})
+		case "/api/info/ftl":
+			w.Write([]byte(`{"ftl": {
+				"database": {
+					"gravity": 123456, "groups": 3, "lists": 4, "clients": 7,
+					"domains": {"allowed": {"total": 5, "enabled": 4}, "denied": {"total": 9, "enabled": 9}},
+					"regex": {"allowed": {"total": 1, "enabled": 0}, "denied": {"total": 2, "enabled": 2}}
+				},
+				"privacy_level": 0,
+				"uptime": 3600500,
+				"allow_destructive": true
+			}, "took": 0.001}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(Config{URL: server.URL, Password: "test"})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	info, err := client.GetFTLInfo(context.Background())
+	if err != nil {
+		t.Fatalf("GetFTLInfo() error = %v", err)
+	}
+	db := info.FTL.Database
+	if db.Gravity != 123456 || db.Lists != 4 || db.Clients != 7 {
+		t.Errorf("Unexpected database counters: %+v", db)
+	}
+	if info.FTL.Uptime != 3600500 {
+		t.Errorf("Expected uptime 3600500, got %d", info.FTL.Uptime)
+	}
+	if db.Domains.Allowed.Enabled != 4 || db.Regex.Denied.Total != 2 {
+		t.Errorf("Unexpected domain counters: %+v", db)
+	}
+}
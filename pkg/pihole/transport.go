@@ -0,0 +1,109 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package pihole
+
+import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// DefaultUserAgent is sent when Config.UserAgent is empty.
+const DefaultUserAgent = "terraform-provider-pihole"
+
+// acceptEncoding lists the content codings the client decodes. FTL itself
+// answers uncompressed, but reverse proxies in front of it commonly compress
+// the large domain and list responses.
+const acceptEncoding = "gzip, deflate"
+
+// headerTransport adds the User-Agent and any extra headers to every request,
+// including logins and readiness polls, asks for compressed responses and
+// decompresses them. The base transport's own gzip handling is disabled, as
+// it neither covers deflate nor works once Accept-Encoding is set.
+type headerTransport struct {
+	base      http.RoundTripper
+	userAgent string
+	headers   map[string]string
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// A RoundTripper must not modify the caller's request.
+	req = req.Clone(req.Context())
+	req.Header.Set("User-Agent", t.userAgent)
+	req.Header.Set("Accept-Encoding", acceptEncoding)
+	for name, value := range t.headers {
+		// Headers set by the client itself, like the session ID, win.
+		if req.Header.Get(name) == "" || http.CanonicalHeaderKey(name) == "User-Agent" || http.CanonicalHeaderKey(name) == "Accept-Encoding" {
+			req.Header.Set(name, value)
+		}
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	decompress(resp)
+	return resp, nil
+}
+
+// decompress replaces a gzip or deflate encoded response body with its
+// decoded content. Other codings are left alone.
+func decompress(resp *http.Response) {
+	var open func(io.Reader) (io.Reader, error)
+	switch strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding"))) {
+	case "gzip", "x-gzip":
+		open = func(r io.Reader) (io.Reader, error) { return gzip.NewReader(r) }
+	case "deflate":
+		open = openDeflate
+	default:
+		return
+	}
+
+	resp.Body = &decodedBody{body: resp.Body, open: open}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+}
+
+// openDeflate decodes "deflate" content, which should be zlib wrapped but
+// is sent as raw deflate data by some servers.
+func openDeflate(r io.Reader) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	header, err := br.Peek(2)
+	if err != nil {
+		return nil, err
+	}
+	if header[0]&0x0f == 8 && (uint16(header[0])<<8|uint16(header[1]))%31 == 0 {
+		return zlib.NewReader(br)
+	}
+	return flate.NewReader(br), nil
+}
+
+// decodedBody decodes a response body on first read, so empty bodies of
+// e.g. 204 responses need no valid compressed stream.
+type decodedBody struct {
+	body   io.ReadCloser
+	open   func(io.Reader) (io.Reader, error)
+	reader io.Reader
+	err    error
+}
+
+func (b *decodedBody) Read(p []byte) (int, error) {
+	if b.reader == nil && b.err == nil {
+		b.reader, b.err = b.open(b.body)
+	}
+	if b.err != nil {
+		return 0, b.err
+	}
+	return b.reader.Read(p)
+}
+
+func (b *decodedBody) Close() error {
+	return b.body.Close()
+}
@@ -0,0 +1,392 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package pihole
+
+// DefaultGroupID is the ID of Pi-hole's built-in Default group. It always
+// exists and must never be deleted; every client, domain and list falls back
+// to it when no other group is assigned.
+const DefaultGroupID int64 = 0
+
+// DefaultGroupName is the name Pi-hole gives the built-in Default group.
+const DefaultGroupName = "Default"
+
+// Group represents a Pi-hole group.
+type Group struct {
+	ID           int64  `json:"id,omitempty"`
+	Name         string `json:"name"`
+	Enabled      bool   `json:"enabled"`
+	Description  string `json:"comment,omitempty"`
+	DateAdded    int64  `json:"date_added,omitempty"`
+	DateModified int64  `json:"date_modified,omitempty"`
+}
+
+// IsDefault reports whether the group is Pi-hole's built-in Default group.
+// It is only meaningful for groups returned by the API.
+func (g *Group) IsDefault() bool {
+	return g.ID == DefaultGroupID
+}
+
+// GroupsResponse represents the response from the groups endpoint.
+type GroupsResponse struct {
+	Groups []Group `json:"groups"`
+	Took   float64 `json:"took"`
+}
+
+// Domain represents a Pi-hole domain entry (allow/deny, exact/regex).
+type Domain struct {
+	ID           int64   `json:"id,omitempty"`
+	Domain       string  `json:"domain"`
+	Type         string  `json:"type"` // "allow" or "deny"
+	Kind         string  `json:"kind"` // "exact" or "regex"
+	Enabled      bool    `json:"enabled"`
+	Comment      string  `json:"comment,omitempty"`
+	Groups       []int64 `json:"groups,omitempty"`
+	DateAdded    int64   `json:"date_added,omitempty"`
+	DateModified int64   `json:"date_modified,omitempty"`
+}
+
+// DomainsResponse represents the response from the domains endpoint.
+type DomainsResponse struct {
+	Domains   []Domain   `json:"domains"`
+	Processed *Processed `json:"processed,omitempty"`
+	Took      float64    `json:"took"`
+}
+
+// Processed reports the outcome per item of a write that accepts several
+// items at once.
+type Processed struct {
+	Success []ProcessedItem  `json:"success"`
+	Errors  []ProcessedError `json:"errors"`
+}
+
+// ProcessedItem is an item a write succeeded for.
+type ProcessedItem struct {
+	Item string `json:"item"`
+}
+
+// ProcessedError is an item a write failed for.
+type ProcessedError struct {
+	Item  string `json:"item"`
+	Error string `json:"error"`
+}
+
+// Client represents a Pi-hole client configuration.
+type PiholeClient struct {
+	ID           int64   `json:"id,omitempty"`
+	Client       string  `json:"client"`
+	Comment      string  `json:"comment,omitempty"`
+	Groups       []int64 `json:"groups,omitempty"`
+	DateAdded    int64   `json:"date_added,omitempty"`
+	DateModified int64   `json:"date_modified,omitempty"`
+}
+
+// ClientsResponse represents the response from the clients endpoint.
+type ClientsResponse struct {
+	Clients []PiholeClient `json:"clients"`
+	Took    float64        `json:"took"`
+}
+
+// ClientSuggestion is a device from Pi-hole's network table offered as a
+// client to configure.
+type ClientSuggestion struct {
+	HWAddr    string `json:"hwaddr"`
+	MACVendor string `json:"macVendor"`
+	LastQuery int64  `json:"lastQuery"`
+	Addresses string `json:"addresses"` // comma-separated
+	Names     string `json:"names"`     // comma-separated
+}
+
+// ClientSuggestionsResponse represents the response from the
+// clients/_suggestions endpoint.
+type ClientSuggestionsResponse struct {
+	Clients []ClientSuggestion `json:"clients"`
+	Took    float64            `json:"took"`
+}
+
+// List represents a Pi-hole blocklist/allowlist.
+type List struct {
+	ID             int64   `json:"id,omitempty"`
+	Address        string  `json:"address"`
+	Type           string  `json:"type"` // "block" or "allow"
+	Enabled        bool    `json:"enabled"`
+	Comment        string  `json:"comment,omitempty"`
+	Groups         []int64 `json:"groups,omitempty"`
+	DateAdded      int64   `json:"date_added,omitempty"`
+	DateModified   int64   `json:"date_modified,omitempty"`
+	DateUpdated    int64   `json:"date_updated,omitempty"` // Last time gravity downloaded the list
+	Number         int64   `json:"number,omitempty"`       // Number of domains in the list
+	InvalidDomains int64   `json:"invalid_domains,omitempty"`
+	Status         int     `json:"status,omitempty"`
+	ABPEntries     int64   `json:"abp_entries,omitempty"`
+}
+
+// ListsResponse represents the response from the lists endpoint.
+type ListsResponse struct {
+	Lists     []List     `json:"lists"`
+	Processed *Processed `json:"processed,omitempty"`
+	Took      float64    `json:"took"`
+}
+
+// DNSBlocking represents the DNS blocking status.
+type DNSBlocking struct {
+	Blocking string   `json:"blocking"` // "enabled", "disabled", "failed", "unknown"
+	Timer    *float64 `json:"timer"`    // nil if no timer, otherwise seconds remaining
+}
+
+// DNSBlockingResponse represents the response from the dns/blocking endpoint.
+type DNSBlockingResponse struct {
+	Blocking string   `json:"blocking"`
+	Timer    *float64 `json:"timer"`
+	Took     float64  `json:"took"`
+}
+
+// DNSBlockingRequest represents a request to change blocking status.
+type DNSBlockingRequest struct {
+	Blocking bool     `json:"blocking"`
+	Timer    *float64 `json:"timer,omitempty"`
+}
+
+// CNAMERecord represents a local CNAME record.
+type CNAMERecord struct {
+	Domain string `json:"domain"`
+	Target string `json:"target"`
+	TTL    int    `json:"ttl,omitempty"`
+}
+
+// LocalDNS represents a local DNS record (A/AAAA).
+type LocalDNS struct {
+	Domain string `json:"domain"`
+	IP     string `json:"ip"`
+}
+
+// FTLInfo represents Pi-hole FTL information.
+type FTLInfo struct {
+	Version  string          `json:"version"`
+	Branch   string          `json:"branch"`
+	Tag      string          `json:"tag"`
+	Hash     string          `json:"hash"`
+	Date     string          `json:"date"`
+	Uptime   int64           `json:"uptime"` // Milliseconds since FTL started
+	Database FTLDatabaseInfo `json:"database"`
+}
+
+// FTLDatabaseInfo holds the gravity database counters FTL reports.
+type FTLDatabaseInfo struct {
+	Gravity int64 `json:"gravity"` // Unique domains on enabled lists
+	Groups  int64 `json:"groups"`
+	Lists   int64 `json:"lists"`
+	Clients int64 `json:"clients"`
+	Domains struct {
+		Allowed FTLCount `json:"allowed"`
+		Denied  FTLCount `json:"denied"`
+	} `json:"domains"` // Exact domains
+	Regex struct {
+		Allowed FTLCount `json:"allowed"`
+		Denied  FTLCount `json:"denied"`
+	} `json:"regex"`
+}
+
+// FTLCount counts entries of one kind and how many of them are enabled.
+type FTLCount struct {
+	Total   int64 `json:"total"`
+	Enabled int64 `json:"enabled"`
+}
+
+// FTLInfoResponse represents the response from the info/ftl endpoint.
+type FTLInfoResponse struct {
+	FTL  FTLInfo `json:"ftl"`
+	Took float64 `json:"took"`
+}
+
+// SystemInfo represents system information.
+type SystemInfo struct {
+	Uptime int64 `json:"uptime"`
+	Memory struct {
+		RAM struct {
+			Total       int64   `json:"total"`
+			PercentUsed float64 `json:"perc_used"`
+		} `json:"ram"`
+	} `json:"memory"`
+	Load    []float64 `json:"load"`
+	Sensors struct {
+		CPUTemp float64 `json:"cpu_temp"`
+	} `json:"sensors"`
+}
+
+// InfoResponse represents the combined info response.
+type InfoResponse struct {
+	FTL    FTLInfo    `json:"ftl"`
+	System SystemInfo `json:"system"`
+	Took   float64    `json:"took"`
+}
+
+// VersionInfo represents version information.
+type VersionInfo struct {
+	Core struct {
+		Local struct {
+			Version string `json:"version"`
+			Branch  string `json:"branch"`
+			Hash    string `json:"hash"`
+		} `json:"local"`
+	} `json:"core"`
+	Web struct {
+		Local struct {
+			Version string `json:"version"`
+			Branch  string `json:"branch"`
+			Hash    string `json:"hash"`
+		} `json:"local"`
+	} `json:"web"`
+	FTL struct {
+		Local struct {
+			Version string `json:"version"`
+			Branch  string `json:"branch"`
+			Hash    string `json:"hash"`
+		} `json:"local"`
+	} `json:"ftl"`
+}
+
+// VersionResponse represents the response from the info/version endpoint.
+type VersionResponse struct {
+	Version VersionInfo `json:"version"`
+	Took    float64     `json:"took"`
+}
+
+// SearchResult holds the domain entries and gravity lists that match a
+// searched domain. Domains include the regex entries matching it.
+type SearchResult struct {
+	Domains []Domain       `json:"domains"`
+	Gravity []GravityMatch `json:"gravity"`
+}
+
+// GravityMatch is a list whose downloaded domains contain the searched
+// domain. Type is "block" for blocklists and "allow" for allowlists
+// (antigravity).
+type GravityMatch struct {
+	List
+	Domain string `json:"domain"`
+}
+
+// SearchResponse represents the response from the search endpoint.
+type SearchResponse struct {
+	Search SearchResult `json:"search"`
+	Took   float64      `json:"took"`
+}
+
+// NetworkDevice represents a device in Pi-hole's network table.
+type NetworkDevice struct {
+	ID         int64             `json:"id"`
+	HWAddr     string            `json:"hwaddr"`
+	Interface  string            `json:"interface"`
+	FirstSeen  int64             `json:"firstSeen"`
+	LastQuery  int64             `json:"lastQuery"`
+	NumQueries int64             `json:"numQueries"`
+	MACVendor  string            `json:"macVendor"`
+	IPs        []NetworkDeviceIP `json:"ips"`
+}
+
+// NetworkDeviceIP represents an address seen for a network device.
+type NetworkDeviceIP struct {
+	IP          string `json:"ip"`
+	Name        string `json:"name"`
+	LastSeen    int64  `json:"lastSeen"`
+	NameUpdated int64  `json:"nameUpdated"`
+}
+
+// NetworkDevicesResponse represents the response from the network/devices endpoint.
+type NetworkDevicesResponse struct {
+	Devices []NetworkDevice `json:"devices"`
+	Took    float64         `json:"took"`
+}
+
+// NetworkInterface represents a network interface of the Pi-hole host.
+type NetworkInterface struct {
+	Name  string `json:"name"`
+	Up    bool   `json:"up"`
+	Type  string `json:"type"`
+	Speed int64  `json:"speed"`
+}
+
+// NetworkInterfacesResponse represents the response from the network/interfaces endpoint.
+type NetworkInterfacesResponse struct {
+	Interfaces []NetworkInterface `json:"interfaces"`
+	Took       float64            `json:"took"`
+}
+
+// UpstreamStats represents query statistics for a single upstream server.
+// FTL also reports the pseudo-upstreams "blocklist" and "cache" with port -1.
+type UpstreamStats struct {
+	IP         string `json:"ip"`
+	Name       string `json:"name"`
+	Port       int    `json:"port"`
+	Count      int64  `json:"count"`
+	Statistics struct {
+		Response float64 `json:"response"`
+		Variance float64 `json:"variance"`
+	} `json:"statistics"`
+}
+
+// QueryTypesResponse represents the response from the stats/query_types
+// endpoint: the number of queries per DNS record type, e.g. "A" or "HTTPS",
+// over the last 24 hours.
+type QueryTypesResponse struct {
+	Types map[string]int64 `json:"types"`
+	Took  float64          `json:"took"`
+}
+
+// StatsSummaryResponse represents the response from the stats/summary
+// endpoint: the query, client and gravity counters of the dashboard, over
+// the last 24 hours.
+type StatsSummaryResponse struct {
+	Queries struct {
+		Total          int64   `json:"total"`
+		Blocked        int64   `json:"blocked"`
+		PercentBlocked float64 `json:"percent_blocked"`
+		UniqueDomains  int64   `json:"unique_domains"`
+		Forwarded      int64   `json:"forwarded"`
+		Cached         int64   `json:"cached"`
+		Frequency      float64 `json:"frequency"` // Queries per second
+	} `json:"queries"`
+	Clients struct {
+		Active int64 `json:"active"`
+		Total  int64 `json:"total"`
+	} `json:"clients"`
+	Gravity struct {
+		DomainsBeingBlocked int64 `json:"domains_being_blocked"`
+		LastUpdate          int64 `json:"last_update"` // Unix time
+	} `json:"gravity"`
+	Took float64 `json:"took"`
+}
+
+// UpstreamStatsResponse represents the response from the stats/upstreams endpoint.
+type UpstreamStatsResponse struct {
+	Upstreams        []UpstreamStats `json:"upstreams"`
+	ForwardedQueries int64           `json:"forwarded_queries"`
+	TotalQueries     int64           `json:"total_queries"`
+	Took             float64         `json:"took"`
+}
+
+// Session represents a login session from the auth/sessions endpoint.
+type Session struct {
+	ID             int64 `json:"id"`
+	CurrentSession bool  `json:"current_session"`
+	Valid          bool  `json:"valid"`
+	TLS            struct {
+		Login bool `json:"login"`
+		Mixed bool `json:"mixed"`
+	} `json:"tls"`
+	App           bool    `json:"app"`
+	CLI           bool    `json:"cli"`
+	LoginAt       int64   `json:"login_at"`
+	LastActive    int64   `json:"last_active"`
+	ValidUntil    int64   `json:"valid_until"`
+	RemoteAddr    string  `json:"remote_addr"`
+	UserAgent     string  `json:"user_agent"`
+	XForwardedFor *string `json:"x_forwarded_for"`
+}
+
+// SessionsResponse represents the response from the auth/sessions endpoint.
+type SessionsResponse struct {
+	Sessions []Session `json:"sessions"`
+	Took     float64   `json:"took"`
+}
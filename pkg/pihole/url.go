@@ -0,0 +1,83 @@
+// Copyright (c) 2025 dklesev
+// SPDX-License-Identifier: MIT
+
+package pihole
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ParseURL parses a Pi-hole URL such as "http://pi.hole",
+// "https://example.com/pihole/" or "http://[fd00::53]:8080". Its errors say
+// how to fix common mistakes: a missing scheme, an IPv6 address without
+// brackets, unbalanced brackets or an invalid port.
+func ParseURL(raw string) (*url.URL, error) {
+	scheme, rest, found := strings.Cut(raw, "://")
+	if !found {
+		return nil, fmt.Errorf("missing scheme, use %q", "http://"+bracketIPv6(strings.TrimRight(raw, "/")))
+	}
+	if s := strings.ToLower(scheme); s != "http" && s != "https" {
+		return nil, fmt.Errorf("unsupported scheme %q, use http or https", scheme)
+	}
+
+	authority := rest
+	if i := strings.IndexAny(authority, "/?#"); i >= 0 {
+		authority = authority[:i]
+	}
+	if i := strings.LastIndex(authority, "@"); i >= 0 {
+		authority = authority[i+1:]
+	}
+	if authority == "" {
+		return nil, fmt.Errorf("missing host")
+	}
+
+	open, closing := strings.Count(authority, "["), strings.Count(authority, "]")
+	switch {
+	case open != closing || open > 1:
+		return nil, fmt.Errorf("unbalanced brackets in host %q, enclose IPv6 addresses in one pair of brackets, e.g. http://[fd00::53]:8080", authority)
+	case open == 1 && (!strings.HasPrefix(authority, "[") || strings.Index(authority, "]") < strings.Index(authority, "[")):
+		return nil, fmt.Errorf("misplaced brackets in host %q, e.g. http://[fd00::53]:8080", authority)
+	case open == 0 && strings.Count(authority, ":") > 1:
+		return nil, fmt.Errorf("IPv6 address %q must be enclosed in brackets, with any port after them, e.g. %s://%s", authority, scheme, bracketIPv6(authority))
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, err
+	}
+	if u.Hostname() == "" {
+		return nil, fmt.Errorf("missing host")
+	}
+	if port := u.Port(); port != "" {
+		if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+			return nil, fmt.Errorf("invalid port %q, must be between 1 and 65535", port)
+		}
+	}
+	return u, nil
+}
+
+// bracketIPv6 puts a bare IPv6 address in brackets for use in a URL.
+func bracketIPv6(host string) string {
+	if ip := net.ParseIP(host); ip != nil && ip.To4() == nil {
+		return "[" + host + "]"
+	}
+	return host
+}
+
+// pathSegment escapes s for use as one segment of an API path, e.g. a list
+// address, regex or client identifier. Besides what url.PathEscape escapes,
+// it encodes the segments "." and "..", which joining the path would remove
+// together with the segment before.
+func pathSegment(s string) string {
+	switch s {
+	case ".":
+		return "%2E"
+	case "..":
+		return "%2E%2E"
+	}
+	return url.PathEscape(s)
+}